@@ -1,6 +1,9 @@
 package config
 
 import (
+	"cscan/pkg/blob"
+	"cscan/pkg/rpcauth"
+
 	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/rest"
 	"github.com/zeromicro/go-zero/zrpc"
@@ -12,10 +15,29 @@ type Config struct {
 		AccessSecret string
 		AccessExpire int64
 	}
+	// 登录安全策略，未配置时使用默认值
+	Login struct {
+		MaxUserFailures int  `json:",optional"` // 单用户失败次数上限，默认5
+		MaxIpFailures   int  `json:",optional"` // 单IP失败次数上限，默认20
+		FailureWindow   int  `json:",optional"` // 失败计数窗口(秒)，默认900
+		LockDuration    int  `json:",optional"` // 锁定时长(秒)，默认900
+		TrustProxy      bool `json:",optional"` // 部署在反向代理后时从X-Forwarded-For获取客户端IP
+	} `json:",optional"`
+	// 审计日志
+	Audit struct {
+		RetentionDays int `json:",optional"` // 保留天数，默认180，小于0表示永久保留
+	} `json:",optional"`
 	Mongo struct {
 		Uri    string
 		DbName string
 	}
 	Redis   redis.RedisConf
 	TaskRpc zrpc.RpcClientConf
+	// 任务服务开启Worker认证时，API服务调用使用的服务令牌和TLS配置，需与RPC服务配置一致
+	TaskRpcAuth struct {
+		ServiceToken string          `json:",optional"`
+		TLS          rpcauth.TLSConf `json:",optional"`
+	} `json:",optional"`
+	// 截图、图标和超长响应体的存储后端，需与RPC服务配置一致
+	Blob blob.Config `json:",optional"`
 }
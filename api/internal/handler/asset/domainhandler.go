@@ -32,6 +32,26 @@ func DomainListHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 	}
 }
 
+// SubdomainListHandler 子域名监控列表
+func SubdomainListHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.SubdomainListReq
+		if err := httpx.Parse(r, &req); err != nil {
+			response.ParamError(w, err.Error())
+			return
+		}
+
+		workspaceId := middleware.GetWorkspaceId(r.Context())
+		l := logic.NewDomainLogic(r.Context(), svcCtx)
+		resp, err := l.SubdomainList(&req, workspaceId)
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		httpx.OkJson(w, resp)
+	}
+}
+
 // DomainStatHandler 域名统计
 func DomainStatHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,31 @@
+package audit
+
+import (
+	"net/http"
+
+	"cscan/api/internal/logic"
+	"cscan/api/internal/svc"
+	"cscan/api/internal/types"
+	"cscan/pkg/response"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// AuditLogListHandler 审计日志列表
+func AuditLogListHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.AuditLogListReq
+		if err := httpx.Parse(r, &req); err != nil {
+			response.ParamError(w, err.Error())
+			return
+		}
+
+		l := logic.NewAuditLogListLogic(r.Context(), svcCtx)
+		resp, err := l.AuditLogList(&req)
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		httpx.OkJson(w, resp)
+	}
+}
@@ -0,0 +1,48 @@
+package blob
+
+import (
+	"net/http"
+	"strings"
+
+	"cscan/api/internal/logic"
+	"cscan/api/internal/svc"
+	"cscan/api/internal/types"
+	"cscan/pkg/blob"
+	"cscan/pkg/response"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// BlobGetHandler 读取截图、图标和完整响应体
+func BlobGetHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.BlobGetReq
+		if err := httpx.Parse(r, &req); err != nil {
+			response.ParamError(w, err.Error())
+			return
+		}
+
+		l := logic.NewBlobGetLogic(r.Context(), svcCtx)
+		data, err := l.BlobGet(req.Hash)
+		if err == blob.ErrNotFound {
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		// 响应体来自扫描目标，不可信，图片以外的内容一律按纯文本返回并禁止脚本执行
+		contentType := http.DetectContentType(data)
+		if !strings.HasPrefix(contentType, "image/") {
+			contentType = "text/plain; charset=utf-8"
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Content-Security-Policy", "default-src 'none'; sandbox")
+		// 内容按哈希寻址，不会变化
+		w.Header().Set("Cache-Control", "private, max-age=31536000, immutable")
+		w.Write(data)
+	}
+}
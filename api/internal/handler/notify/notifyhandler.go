@@ -0,0 +1,71 @@
+package notify
+
+import (
+	"net/http"
+
+	"cscan/api/internal/logic"
+	"cscan/api/internal/svc"
+	"cscan/api/internal/types"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// NotifyConfigListHandler 通知渠道列表
+func NotifyConfigListHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.PageReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.OkJson(w, &types.BaseResp{Code: 400, Msg: err.Error()})
+			return
+		}
+
+		l := logic.NewNotifyConfigListLogic(r.Context(), svcCtx)
+		resp, _ := l.NotifyConfigList(&req)
+		httpx.OkJson(w, resp)
+	}
+}
+
+// NotifyConfigSaveHandler 保存通知渠道
+func NotifyConfigSaveHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.NotifyConfigSaveReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.OkJson(w, &types.BaseResp{Code: 400, Msg: err.Error()})
+			return
+		}
+
+		l := logic.NewNotifyConfigSaveLogic(r.Context(), svcCtx)
+		resp, _ := l.NotifyConfigSave(&req)
+		httpx.OkJson(w, resp)
+	}
+}
+
+// NotifyConfigDeleteHandler 删除通知渠道
+func NotifyConfigDeleteHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.NotifyConfigDeleteReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.OkJson(w, &types.BaseResp{Code: 400, Msg: err.Error()})
+			return
+		}
+
+		l := logic.NewNotifyConfigDeleteLogic(r.Context(), svcCtx)
+		resp, _ := l.NotifyConfigDelete(&req)
+		httpx.OkJson(w, resp)
+	}
+}
+
+// NotifyConfigTestHandler 发送测试通知
+func NotifyConfigTestHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.NotifyConfigTestReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.OkJson(w, &types.BaseResp{Code: 400, Msg: err.Error()})
+			return
+		}
+
+		l := logic.NewNotifyConfigTestLogic(r.Context(), svcCtx)
+		resp, _ := l.NotifyConfigTest(&req)
+		httpx.OkJson(w, resp)
+	}
+}
@@ -1,18 +1,24 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 
 	"cscan/api/internal/handler/asset"
+	"cscan/api/internal/handler/audit"
+	"cscan/api/internal/handler/blob"
 	"cscan/api/internal/handler/fingerprint"
+	"cscan/api/internal/handler/notify"
 	"cscan/api/internal/handler/onlineapi"
 	"cscan/api/internal/handler/organization"
 	"cscan/api/internal/handler/poc"
 	"cscan/api/internal/handler/report"
+	"cscan/api/internal/handler/secret"
 	"cscan/api/internal/handler/subfinder"
 	"cscan/api/internal/handler/task"
 	"cscan/api/internal/handler/user"
 	"cscan/api/internal/handler/vul"
+	"cscan/api/internal/handler/wordlist"
 	"cscan/api/internal/handler/worker"
 	"cscan/api/internal/handler/workspace"
 	"cscan/api/internal/middleware"
@@ -30,7 +36,7 @@ func RegisterHandlers(server *rest.Server, svcCtx *svc.ServiceContext) {
 	)
 
 	// 需要认证的路由
-	authMiddleware := middleware.NewAuthMiddleware(svcCtx.Config.Auth.AccessSecret)
+	authMiddleware := middleware.NewAuthMiddleware(svcCtx.Config.Auth.AccessSecret, svcCtx.ApiTokenModel)
 	authRoutes := []rest.Route{
 		// 用户管理
 		{Method: http.MethodPost, Path: "/api/v1/user/list", Handler: user.UserListHandler(svcCtx)},
@@ -38,9 +44,16 @@ func RegisterHandlers(server *rest.Server, svcCtx *svc.ServiceContext) {
 		{Method: http.MethodPost, Path: "/api/v1/user/update", Handler: user.UserUpdateHandler(svcCtx)},
 		{Method: http.MethodPost, Path: "/api/v1/user/delete", Handler: user.UserDeleteHandler(svcCtx)},
 		{Method: http.MethodPost, Path: "/api/v1/user/resetPassword", Handler: user.UserResetPasswordHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/user/setRoles", Handler: user.UserSetRolesHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/user/loginLog", Handler: user.LoginLogHandler(svcCtx)},
 		{Method: http.MethodPost, Path: "/api/v1/user/scanConfig/save", Handler: user.SaveScanConfigHandler(svcCtx)},
 		{Method: http.MethodPost, Path: "/api/v1/user/scanConfig/get", Handler: user.GetScanConfigHandler(svcCtx)},
 
+		// API令牌
+		{Method: http.MethodPost, Path: "/api/v1/user/token/list", Handler: user.ApiTokenListHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/user/token/create", Handler: user.ApiTokenCreateHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/user/token/revoke", Handler: user.ApiTokenRevokeHandler(svcCtx)},
+
 		// Worker日志（需要认证）
 		{Method: http.MethodGet, Path: "/api/v1/worker/logs/stream", Handler: worker.WorkerLogsHandler(svcCtx)},
 		{Method: http.MethodPost, Path: "/api/v1/worker/logs/history", Handler: worker.WorkerLogsHistoryHandler(svcCtx)},
@@ -64,6 +77,14 @@ func RegisterHandlers(server *rest.Server, svcCtx *svc.ServiceContext) {
 		{Method: http.MethodPost, Path: "/api/v1/asset/batchDelete", Handler: asset.AssetBatchDeleteHandler(svcCtx)},
 		{Method: http.MethodPost, Path: "/api/v1/asset/clear", Handler: asset.AssetClearHandler(svcCtx)},
 		{Method: http.MethodPost, Path: "/api/v1/asset/history", Handler: asset.AssetHistoryHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/asset/changes", Handler: asset.AssetChangeHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/asset/screenshot/cluster", Handler: asset.ScreenshotClusterHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/asset/url/list", Handler: asset.UrlListHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/asset/dirscan/list", Handler: asset.DirScanListHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/asset/secret/list", Handler: asset.SecretListHandler(svcCtx)},
+
+		// 证书管理
+		{Method: http.MethodPost, Path: "/api/v1/asset/cert/list", Handler: asset.CertListHandler(svcCtx)},
 
 		// 站点管理
 		{Method: http.MethodPost, Path: "/api/v1/asset/site/list", Handler: asset.SiteListHandler(svcCtx)},
@@ -74,6 +95,7 @@ func RegisterHandlers(server *rest.Server, svcCtx *svc.ServiceContext) {
 		{Method: http.MethodPost, Path: "/api/v1/asset/domain/stat", Handler: asset.DomainStatHandler(svcCtx)},
 		{Method: http.MethodPost, Path: "/api/v1/asset/domain/delete", Handler: asset.DomainDeleteHandler(svcCtx)},
 		{Method: http.MethodPost, Path: "/api/v1/asset/domain/batchDelete", Handler: asset.DomainBatchDeleteHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/asset/subdomain/list", Handler: asset.SubdomainListHandler(svcCtx)},
 
 		// IP管理
 		{Method: http.MethodPost, Path: "/api/v1/asset/ip/list", Handler: asset.IPListHandler(svcCtx)},
@@ -98,6 +120,7 @@ func RegisterHandlers(server *rest.Server, svcCtx *svc.ServiceContext) {
 		{Method: http.MethodPost, Path: "/api/v1/task/profile/delete", Handler: task.TaskProfileDeleteHandler(svcCtx)},
 		{Method: http.MethodPost, Path: "/api/v1/task/logs", Handler: task.GetTaskLogsHandler(svcCtx)},
 		{Method: http.MethodGet, Path: "/api/v1/task/logs/stream", Handler: task.TaskLogsStreamHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/task/lease/list", Handler: task.TaskLeaseListHandler(svcCtx)},
 
 		// 漏洞管理
 		{Method: http.MethodPost, Path: "/api/v1/vul/list", Handler: vul.VulListHandler(svcCtx)},
@@ -113,6 +136,11 @@ func RegisterHandlers(server *rest.Server, svcCtx *svc.ServiceContext) {
 		{Method: http.MethodPost, Path: "/api/v1/worker/rename", Handler: worker.WorkerRenameHandler(svcCtx)},
 		{Method: http.MethodPost, Path: "/api/v1/worker/restart", Handler: worker.WorkerRestartHandler(svcCtx)},
 		{Method: http.MethodPost, Path: "/api/v1/worker/concurrency", Handler: worker.WorkerSetConcurrencyHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/worker/enroll/list", Handler: worker.WorkerEnrollTokenListHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/worker/enroll/create", Handler: worker.WorkerEnrollTokenCreateHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/worker/enroll/revoke", Handler: worker.WorkerEnrollTokenRevokeHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/worker/credential/list", Handler: worker.WorkerCredentialListHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/worker/credential/revoke", Handler: worker.WorkerCredentialRevokeHandler(svcCtx)},
 
 		// 在线API搜索
 		{Method: http.MethodPost, Path: "/api/v1/onlineapi/search", Handler: onlineapi.OnlineSearchHandler(svcCtx)},
@@ -173,13 +201,46 @@ func RegisterHandlers(server *rest.Server, svcCtx *svc.ServiceContext) {
 		{Method: http.MethodPost, Path: "/api/v1/subfinder/provider/list", Handler: subfinder.SubfinderProviderListHandler(svcCtx)},
 		{Method: http.MethodPost, Path: "/api/v1/subfinder/provider/save", Handler: subfinder.SubfinderProviderSaveHandler(svcCtx)},
 		{Method: http.MethodPost, Path: "/api/v1/subfinder/provider/info", Handler: subfinder.SubfinderProviderInfoHandler(svcCtx)},
+
+		// 敏感信息规则
+		{Method: http.MethodPost, Path: "/api/v1/secret/rule/list", Handler: secret.SecretRuleListHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/secret/rule/save", Handler: secret.SecretRuleSaveHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/secret/rule/delete", Handler: secret.SecretRuleDeleteHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/secret/rule/updateEnabled", Handler: secret.SecretRuleUpdateEnabledHandler(svcCtx)},
+
+		// 爆破字典
+		{Method: http.MethodPost, Path: "/api/v1/wordlist/list", Handler: wordlist.WordlistListHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/wordlist/save", Handler: wordlist.WordlistSaveHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/wordlist/delete", Handler: wordlist.WordlistDeleteHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/wordlist/detail", Handler: wordlist.WordlistDetailHandler(svcCtx)},
+
+		// 通知配置
+		{Method: http.MethodPost, Path: "/api/v1/notify/list", Handler: notify.NotifyConfigListHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/notify/save", Handler: notify.NotifyConfigSaveHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/notify/delete", Handler: notify.NotifyConfigDeleteHandler(svcCtx)},
+		{Method: http.MethodPost, Path: "/api/v1/notify/test", Handler: notify.NotifyConfigTestHandler(svcCtx)},
+
+		// 审计日志
+		{Method: http.MethodPost, Path: "/api/v1/audit/list", Handler: audit.AuditLogListHandler(svcCtx)},
+
+		// 截图、图标和完整响应体，图片标签无法携带请求头时可通过token参数认证
+		{Method: http.MethodGet, Path: "/api/v1/blob/:hash", Handler: blob.BlobGetHandler(svcCtx)},
 	}
 
-	// 为每个路由包装认证中间件
+	// 为每个路由包装认证和权限中间件，权限矩阵见 middleware.RoutePermissions；修改类接口记录审计日志
+	permissionMiddleware := middleware.NewPermissionMiddleware(svcCtx.UserModel)
+	auditMiddleware := middleware.NewAuditMiddleware(svcCtx.AuditLogModel)
 	for i := range authRoutes {
-		originalHandler := authRoutes[i].Handler
+		perm, ok := middleware.RoutePermissions[authRoutes[i].Path]
+		if !ok {
+			panic(fmt.Sprintf("route %s is missing from the permission matrix", authRoutes[i].Path))
+		}
+		originalHandler := permissionMiddleware.Handle(perm, authRoutes[i].Handler)
+		if middleware.NeedAudit(authRoutes[i].Path, perm) {
+			originalHandler = auditMiddleware.Handle(originalHandler)
+		}
 		authRoutes[i].Handler = func(w http.ResponseWriter, r *http.Request) {
-			authMiddleware.Handle(http.HandlerFunc(originalHandler)).ServeHTTP(w, r)
+			authMiddleware.Handle(originalHandler).ServeHTTP(w, r)
 		}
 	}
 
@@ -0,0 +1,88 @@
+package secret
+
+import (
+	"net/http"
+
+	"cscan/api/internal/logic"
+	"cscan/api/internal/svc"
+	"cscan/api/internal/types"
+	"cscan/pkg/response"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// SecretRuleListHandler 敏感信息规则列表
+func SecretRuleListHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.SecretRuleListReq
+		if err := httpx.Parse(r, &req); err != nil {
+			response.ParamError(w, err.Error())
+			return
+		}
+
+		l := logic.NewSecretRuleLogic(r.Context(), svcCtx)
+		resp, err := l.SecretRuleList(&req)
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		httpx.OkJson(w, resp)
+	}
+}
+
+// SecretRuleSaveHandler 保存敏感信息规则
+func SecretRuleSaveHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.SecretRuleSaveReq
+		if err := httpx.Parse(r, &req); err != nil {
+			response.ParamError(w, err.Error())
+			return
+		}
+
+		l := logic.NewSecretRuleLogic(r.Context(), svcCtx)
+		resp, err := l.SecretRuleSave(&req)
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		httpx.OkJson(w, resp)
+	}
+}
+
+// SecretRuleDeleteHandler 删除敏感信息规则
+func SecretRuleDeleteHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.SecretRuleDeleteReq
+		if err := httpx.Parse(r, &req); err != nil {
+			response.ParamError(w, err.Error())
+			return
+		}
+
+		l := logic.NewSecretRuleLogic(r.Context(), svcCtx)
+		resp, err := l.SecretRuleDelete(&req)
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		httpx.OkJson(w, resp)
+	}
+}
+
+// SecretRuleUpdateEnabledHandler 启用或禁用敏感信息规则
+func SecretRuleUpdateEnabledHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.SecretRuleUpdateEnabledReq
+		if err := httpx.Parse(r, &req); err != nil {
+			response.ParamError(w, err.Error())
+			return
+		}
+
+		l := logic.NewSecretRuleLogic(r.Context(), svcCtx)
+		resp, err := l.SecretRuleUpdateEnabled(&req)
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		httpx.OkJson(w, resp)
+	}
+}
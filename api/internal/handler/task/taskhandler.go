@@ -298,6 +298,25 @@ func GetTaskLogsHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 	}
 }
 
+// TaskLeaseListHandler 查看子任务租约和死信
+func TaskLeaseListHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.TaskLeaseListReq
+		if err := httpx.Parse(r, &req); err != nil {
+			response.ParamError(w, err.Error())
+			return
+		}
+
+		l := logic.NewTaskLeaseListLogic(r.Context(), svcCtx)
+		resp, err := l.TaskLeaseList(&req)
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		httpx.OkJson(w, resp)
+	}
+}
+
 // TaskLogsStreamHandler SSE实时任务日志推送 
 func TaskLogsStreamHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -1,7 +1,9 @@
 package user
 
 import (
+	"net"
 	"net/http"
+	"strings"
 
 	"cscan/api/internal/logic"
 	"cscan/api/internal/svc"
@@ -21,7 +23,40 @@ func LoginHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 		}
 
 		l := logic.NewLoginLogic(r.Context(), svcCtx)
-		resp, err := l.Login(&req)
+		resp, err := l.Login(&req, clientIp(r, svcCtx.Config.Login.TrustProxy), r.UserAgent())
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		httpx.OkJson(w, resp)
+	}
+}
+
+// clientIp 获取客户端IP，仅在部署于反向代理之后时信任 X-Forwarded-For
+func clientIp(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if ip := strings.TrimSpace(strings.Split(r.Header.Get("X-Forwarded-For"), ",")[0]); ip != "" {
+			return ip
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// LoginLogHandler 登录审计日志
+func LoginLogHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.LoginLogReq
+		if err := httpx.Parse(r, &req); err != nil {
+			response.ParamError(w, err.Error())
+			return
+		}
+
+		l := logic.NewLoginLogLogic(r.Context(), svcCtx)
+		resp, err := l.LoginLog(&req)
 		if err != nil {
 			response.Error(w, err)
 			return
@@ -106,6 +141,25 @@ func UserDeleteHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 	}
 }
 
+// UserSetRolesHandler 设置用户角色
+func UserSetRolesHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.UserSetRolesReq
+		if err := httpx.Parse(r, &req); err != nil {
+			response.ParamError(w, err.Error())
+			return
+		}
+
+		l := logic.NewUserSetRolesLogic(r.Context(), svcCtx)
+		resp, err := l.UserSetRoles(&req)
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		httpx.OkJson(w, resp)
+	}
+}
+
 // UserResetPasswordHandler 重置用户密码
 func UserResetPasswordHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -156,3 +210,54 @@ func GetScanConfigHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 		httpx.OkJson(w, resp)
 	}
 }
+
+// ApiTokenListHandler API令牌列表
+func ApiTokenListHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logic.NewApiTokenLogic(r.Context(), svcCtx)
+		resp, err := l.ApiTokenList()
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		httpx.OkJson(w, resp)
+	}
+}
+
+// ApiTokenCreateHandler 创建API令牌
+func ApiTokenCreateHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ApiTokenCreateReq
+		if err := httpx.Parse(r, &req); err != nil {
+			response.ParamError(w, err.Error())
+			return
+		}
+
+		l := logic.NewApiTokenLogic(r.Context(), svcCtx)
+		resp, err := l.ApiTokenCreate(&req)
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		httpx.OkJson(w, resp)
+	}
+}
+
+// ApiTokenRevokeHandler 吊销API令牌
+func ApiTokenRevokeHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ApiTokenRevokeReq
+		if err := httpx.Parse(r, &req); err != nil {
+			response.ParamError(w, err.Error())
+			return
+		}
+
+		l := logic.NewApiTokenLogic(r.Context(), svcCtx)
+		resp, err := l.ApiTokenRevoke(&req)
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		httpx.OkJson(w, resp)
+	}
+}
@@ -0,0 +1,88 @@
+package wordlist
+
+import (
+	"net/http"
+
+	"cscan/api/internal/logic"
+	"cscan/api/internal/svc"
+	"cscan/api/internal/types"
+	"cscan/pkg/response"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// WordlistListHandler 字典列表
+func WordlistListHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.WordlistListReq
+		if err := httpx.Parse(r, &req); err != nil {
+			response.ParamError(w, err.Error())
+			return
+		}
+
+		l := logic.NewWordlistLogic(r.Context(), svcCtx)
+		resp, err := l.WordlistList(&req)
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		httpx.OkJson(w, resp)
+	}
+}
+
+// WordlistSaveHandler 保存字典
+func WordlistSaveHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.WordlistSaveReq
+		if err := httpx.Parse(r, &req); err != nil {
+			response.ParamError(w, err.Error())
+			return
+		}
+
+		l := logic.NewWordlistLogic(r.Context(), svcCtx)
+		resp, err := l.WordlistSave(&req)
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		httpx.OkJson(w, resp)
+	}
+}
+
+// WordlistDeleteHandler 删除字典
+func WordlistDeleteHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.WordlistDeleteReq
+		if err := httpx.Parse(r, &req); err != nil {
+			response.ParamError(w, err.Error())
+			return
+		}
+
+		l := logic.NewWordlistLogic(r.Context(), svcCtx)
+		resp, err := l.WordlistDelete(&req)
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		httpx.OkJson(w, resp)
+	}
+}
+
+// WordlistDetailHandler 字典详情，包含字典内容
+func WordlistDetailHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.WordlistDetailReq
+		if err := httpx.Parse(r, &req); err != nil {
+			response.ParamError(w, err.Error())
+			return
+		}
+
+		l := logic.NewWordlistLogic(r.Context(), svcCtx)
+		resp, err := l.WordlistDetail(&req)
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		httpx.OkJson(w, resp)
+	}
+}
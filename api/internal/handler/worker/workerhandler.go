@@ -236,3 +236,86 @@ func WorkerLogsHistoryHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 		})
 	}
 }
+
+// WorkerEnrollTokenListHandler 注册令牌列表
+func WorkerEnrollTokenListHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logic.NewWorkerAuthLogic(r.Context(), svcCtx)
+		resp, err := l.EnrollTokenList()
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		httpx.OkJson(w, resp)
+	}
+}
+
+// WorkerEnrollTokenCreateHandler 创建注册令牌
+func WorkerEnrollTokenCreateHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.WorkerEnrollTokenCreateReq
+		if err := httpx.Parse(r, &req); err != nil {
+			response.ParamError(w, err.Error())
+			return
+		}
+
+		l := logic.NewWorkerAuthLogic(r.Context(), svcCtx)
+		resp, err := l.EnrollTokenCreate(&req)
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		httpx.OkJson(w, resp)
+	}
+}
+
+// WorkerEnrollTokenRevokeHandler 吊销注册令牌
+func WorkerEnrollTokenRevokeHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.WorkerAuthRevokeReq
+		if err := httpx.Parse(r, &req); err != nil {
+			response.ParamError(w, err.Error())
+			return
+		}
+
+		l := logic.NewWorkerAuthLogic(r.Context(), svcCtx)
+		resp, err := l.EnrollTokenRevoke(&req)
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		httpx.OkJson(w, resp)
+	}
+}
+
+// WorkerCredentialListHandler Worker凭证列表
+func WorkerCredentialListHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logic.NewWorkerAuthLogic(r.Context(), svcCtx)
+		resp, err := l.CredentialList()
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		httpx.OkJson(w, resp)
+	}
+}
+
+// WorkerCredentialRevokeHandler 吊销Worker凭证
+func WorkerCredentialRevokeHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.WorkerAuthRevokeReq
+		if err := httpx.Parse(r, &req); err != nil {
+			response.ParamError(w, err.Error())
+			return
+		}
+
+		l := logic.NewWorkerAuthLogic(r.Context(), svcCtx)
+		resp, err := l.CredentialRevoke(&req)
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		httpx.OkJson(w, resp)
+	}
+}
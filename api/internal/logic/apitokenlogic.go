@@ -0,0 +1,126 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"cscan/api/internal/middleware"
+	"cscan/api/internal/svc"
+	"cscan/api/internal/types"
+	"cscan/model"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ApiTokenLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewApiTokenLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ApiTokenLogic {
+	return &ApiTokenLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// ApiTokenList 当前用户的API令牌列表
+func (l *ApiTokenLogic) ApiTokenList() (*types.ApiTokenListResp, error) {
+	tokens, err := l.svcCtx.ApiTokenModel.FindByUser(l.ctx, middleware.GetUserId(l.ctx))
+	if err != nil {
+		return &types.ApiTokenListResp{Code: 500, Msg: "查询失败"}, nil
+	}
+
+	list := make([]types.ApiTokenItem, 0, len(tokens))
+	for _, t := range tokens {
+		item := types.ApiTokenItem{
+			Id:         t.Id.Hex(),
+			Name:       t.Name,
+			Prefix:     t.Prefix,
+			Scopes:     t.Scopes,
+			LastUsedIp: t.LastUsedIp,
+			Status:     "active",
+			CreateTime: t.CreateTime.Local().Format("2006-01-02 15:04:05"),
+		}
+		if t.ExpireTime != nil {
+			item.ExpireTime = t.ExpireTime.Local().Format("2006-01-02 15:04:05")
+		}
+		if t.LastUsedTime != nil {
+			item.LastUsedTime = t.LastUsedTime.Local().Format("2006-01-02 15:04:05")
+		}
+		if t.Revoked {
+			item.Status = "revoked"
+		} else if t.Expired() {
+			item.Status = "expired"
+		}
+		list = append(list, item)
+	}
+
+	return &types.ApiTokenListResp{Code: 0, Msg: "success", List: list}, nil
+}
+
+// ApiTokenCreate 创建API令牌，令牌明文只在此时返回
+func (l *ApiTokenLogic) ApiTokenCreate(req *types.ApiTokenCreateReq) (*types.ApiTokenCreateResp, error) {
+	// 令牌不能再创建令牌，避免泄露的令牌自我续期
+	if middleware.IsApiTokenAuth(l.ctx) {
+		return &types.ApiTokenCreateResp{Code: 403, Msg: "不能使用API令牌创建令牌"}, nil
+	}
+	if req.Name == "" {
+		return &types.ApiTokenCreateResp{Code: 400, Msg: "令牌名称不能为空"}, nil
+	}
+	if len(req.Scopes) == 0 {
+		return &types.ApiTokenCreateResp{Code: 400, Msg: "至少选择一个权限范围"}, nil
+	}
+	for _, scope := range req.Scopes {
+		if !middleware.IsValidTokenScope(scope) {
+			return &types.ApiTokenCreateResp{Code: 400, Msg: "无效的权限范围: " + scope}, nil
+		}
+	}
+	if req.ExpireDays < 0 {
+		return &types.ApiTokenCreateResp{Code: 400, Msg: "有效天数不能为负数"}, nil
+	}
+
+	plain, hash, err := model.GenerateApiToken()
+	if err != nil {
+		l.Errorf("Generate api token failed: %v", err)
+		return &types.ApiTokenCreateResp{Code: 500, Msg: "生成令牌失败"}, nil
+	}
+
+	token := &model.ApiToken{
+		UserId:    middleware.GetUserId(l.ctx),
+		Username:  middleware.GetUsername(l.ctx),
+		Name:      req.Name,
+		TokenHash: hash,
+		Prefix:    plain[:len(model.ApiTokenPrefix)+6],
+		Scopes:    req.Scopes,
+	}
+	if req.ExpireDays > 0 {
+		expire := time.Now().AddDate(0, 0, req.ExpireDays)
+		token.ExpireTime = &expire
+	}
+	if err := l.svcCtx.ApiTokenModel.Insert(l.ctx, token); err != nil {
+		l.Errorf("Insert api token failed: %v", err)
+		return &types.ApiTokenCreateResp{Code: 500, Msg: "创建令牌失败"}, nil
+	}
+
+	return &types.ApiTokenCreateResp{
+		Code:  0,
+		Msg:   "创建成功，请妥善保存令牌，关闭后将无法再次查看",
+		Id:    token.Id.Hex(),
+		Token: plain,
+	}, nil
+}
+
+// ApiTokenRevoke 吊销当前用户的API令牌
+func (l *ApiTokenLogic) ApiTokenRevoke(req *types.ApiTokenRevokeReq) (*types.BaseResp, error) {
+	found, err := l.svcCtx.ApiTokenModel.Revoke(l.ctx, req.Id, middleware.GetUserId(l.ctx))
+	if err != nil {
+		return &types.BaseResp{Code: 500, Msg: "吊销失败"}, nil
+	}
+	if !found {
+		return &types.BaseResp{Code: 404, Msg: "令牌不存在"}, nil
+	}
+	return &types.BaseResp{Code: 0, Msg: "已吊销"}, nil
+}
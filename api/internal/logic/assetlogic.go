@@ -11,6 +11,7 @@ import (
 	"cscan/api/internal/svc"
 	"cscan/api/internal/types"
 	"cscan/model"
+	"cscan/pkg/query"
 
 	"github.com/zeromicro/go-zero/core/logx"
 	"go.mongodb.org/mongo-driver/bson"
@@ -101,58 +102,6 @@ func sortIconHashMap(m map[string]*types.IconHashStatItem, limit int) []types.Ic
 	return result
 }
 
-// parseQuerySyntax 解析查询语法
-// 支持格式: port=80 && service=http || title="test"
-func parseQuerySyntax(query string, filter bson.M) {
-	query = strings.TrimSpace(query)
-	if query == "" {
-		return
-	}
-
-	// 简单解析：支持 field=value 格式，多个条件用 && 连接
-	// 例如: port=80 && service=http && title=test
-	conditions := strings.Split(query, "&&")
-	for _, cond := range conditions {
-		cond = strings.TrimSpace(cond)
-		if cond == "" {
-			continue
-		}
-
-		// 解析 field=value 或 field="value"
-		parts := strings.SplitN(cond, "=", 2)
-		if len(parts) != 2 {
-			continue
-		}
-
-		field := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
-		// 去除引号
-		value = strings.Trim(value, "\"'")
-
-		// 映射字段名
-		switch strings.ToLower(field) {
-		case "port":
-			if port, err := strconv.Atoi(value); err == nil {
-				filter["port"] = port
-			}
-		case "host", "ip":
-			filter["host"] = bson.M{"$regex": value, "$options": "i"}
-		case "service", "protocol":
-			filter["service"] = bson.M{"$regex": value, "$options": "i"}
-		case "title":
-			filter["title"] = bson.M{"$regex": value, "$options": "i"}
-		case "app", "finger", "fingerprint":
-			filter["app"] = bson.M{"$regex": cleanAppName(value), "$options": "i"}
-		case "status", "httpstatus":
-			filter["status"] = value
-		case "domain":
-			filter["domain"] = bson.M{"$regex": value, "$options": "i"}
-		case "banner":
-			filter["banner"] = bson.M{"$regex": value, "$options": "i"}
-		}
-	}
-}
-
 type AssetListLogic struct {
 	logx.Logger
 	ctx    context.Context
@@ -173,7 +122,13 @@ func (l *AssetListLogic) AssetList(req *types.AssetListReq, workspaceId string)
 
 	// 如果有语法查询，解析语法
 	if req.Query != "" {
-		parseQuerySyntax(req.Query, filter)
+		queryFilter, err := query.CompileWith(req.Query, newScreenshotResolver(l.ctx, l.svcCtx, workspaceId))
+		if err != nil {
+			return &types.AssetListResp{Code: 400, Msg: "查询语法错误: " + err.Error()}, nil
+		}
+		if len(queryFilter) > 0 {
+			filter["$and"] = []bson.M{queryFilter}
+		}
 	} else {
 		// 快捷查询
 		if req.Host != "" {
@@ -309,28 +264,32 @@ func (l *AssetListLogic) AssetList(req *types.AssetListReq, workspaceId string)
 		}
 
 		list = append(list, types.Asset{
-			Id:         a.Id.Hex(),
-			Authority:  a.Authority,
-			Host:       a.Host,
-			Port:       a.Port,
-			Category:   a.Category,
-			Service:    a.Service,
-			Title:      a.Title,
-			App:        a.App,
-			HttpStatus: a.HttpStatus,
-			HttpHeader: a.HttpHeader,
-			HttpBody:   a.HttpBody,
-			Banner:     a.Banner,
-			IconHash:   a.IconHash,
-			IconData:   iconData,
-			Screenshot: a.Screenshot,
-			Location:   location,
-			IsCDN:      a.IsCDN,
-			IsCloud:    a.IsCloud,
-			IsNew:      a.IsNewAsset,
-			IsUpdated:  a.IsUpdated,
-			CreateTime: a.CreateTime.Local().Format("2006-01-02 15:04:05"),
-			UpdateTime: a.UpdateTime.Local().Format("2006-01-02 15:04:05"),
+			Id:             a.Id.Hex(),
+			Authority:      a.Authority,
+			Host:           a.Host,
+			Port:           a.Port,
+			Category:       a.Category,
+			Service:        a.Service,
+			Title:          a.Title,
+			App:            a.App,
+			HttpStatus:     a.HttpStatus,
+			HttpHeader:     a.HttpHeader,
+			HttpBody:       a.HttpBody,
+			BodyUrl:        blobUrl(a.BodyRef),
+			Banner:         a.Banner,
+			IconHash:       a.IconHash,
+			IconData:       iconData,
+			IconUrl:        blobUrl(a.IconRef),
+			Screenshot:     a.Screenshot,
+			ScreenshotUrl:  blobUrl(a.ScreenshotRef),
+			ScreenshotHash: a.ScreenshotHash,
+			Location:       location,
+			IsCDN:          a.IsCDN,
+			IsCloud:        a.IsCloud,
+			IsNew:          a.IsNewAsset,
+			IsUpdated:      a.IsUpdated,
+			CreateTime:     a.CreateTime.Local().Format("2006-01-02 15:04:05"),
+			UpdateTime:     a.UpdateTime.Local().Format("2006-01-02 15:04:05"),
 			// 组织信息
 			OrgId:   a.OrgId,
 			OrgName: orgName,
@@ -431,6 +390,7 @@ func (l *AssetStatLogic) AssetStat(workspaceId string) (resp *types.AssetStatRes
 					iconHashMap[s.IconHash] = &types.IconHashStatItem{
 						IconHash: s.IconHash,
 						IconData: iconData,
+						IconUrl:  blobUrl(s.IconRef),
 						Count:    s.Count,
 					}
 				}
@@ -516,6 +476,7 @@ func (l *AssetStatLogic) AssetStat(workspaceId string) (resp *types.AssetStatRes
 			topIconHash = append(topIconHash, types.IconHashStatItem{
 				IconHash: s.IconHash,
 				IconData: iconData,
+				IconUrl:  blobUrl(s.IconRef),
 				Count:    s.Count,
 			})
 		}
@@ -619,6 +580,24 @@ func (l *AssetClearLogic) AssetClear(workspaceId string) (resp *types.BaseResp,
 	// 清空资产历史表
 	historyModel := l.svcCtx.GetAssetHistoryModel(workspaceId)
 	historyModel.Clear(l.ctx)
+
+	// 清空资产变更表
+	l.svcCtx.GetAssetChangeModel(workspaceId).Clear(l.ctx)
+
+	// 清空爬虫URL表
+	l.svcCtx.GetUrlModel(workspaceId).Clear(l.ctx)
+
+	// 清空目录扫描结果表
+	l.svcCtx.GetDirScanModel(workspaceId).Clear(l.ctx)
+
+	// 清空敏感信息检测结果表
+	l.svcCtx.GetSecretFindingModel(workspaceId).Clear(l.ctx)
+
+	// 清空DNS记录表
+	l.svcCtx.GetDNSRecordModel(workspaceId).Clear(l.ctx)
+
+	// 清空子域名监控表，下次监控扫描时所有子域名重新作为新增
+	l.svcCtx.GetSubdomainModel(workspaceId).Clear(l.ctx)
 	
 	return &types.BaseResp{Code: 0, Msg: "成功清空 " + strconv.FormatInt(deleted, 10) + " 条资产"}, nil
 }
@@ -655,21 +634,23 @@ func (l *AssetHistoryLogic) AssetHistory(req *types.AssetHistoryReq, workspaceId
 	list := make([]types.AssetHistoryItem, 0, len(histories))
 	for _, h := range histories {
 		list = append(list, types.AssetHistoryItem{
-			Id:         h.Id.Hex(),
-			Authority:  h.Authority,
-			Host:       h.Host,
-			Port:       h.Port,
-			Service:    h.Service,
-			Title:      h.Title,
-			App:        h.App,
-			HttpStatus: h.HttpStatus,
-			HttpHeader: h.HttpHeader,
-			HttpBody:   h.HttpBody,
-			Banner:     h.Banner,
-			IconHash:   h.IconHash,
-			Screenshot: h.Screenshot,
-			TaskId:     h.TaskId,
-			CreateTime: h.CreateTime.Local().Format("2006-01-02 15:04:05"),
+			Id:            h.Id.Hex(),
+			Authority:     h.Authority,
+			Host:          h.Host,
+			Port:          h.Port,
+			Service:       h.Service,
+			Title:         h.Title,
+			App:           h.App,
+			HttpStatus:    h.HttpStatus,
+			HttpHeader:    h.HttpHeader,
+			HttpBody:      h.HttpBody,
+			BodyUrl:       blobUrl(h.BodyRef),
+			Banner:        h.Banner,
+			IconHash:      h.IconHash,
+			Screenshot:    h.Screenshot,
+			ScreenshotUrl: blobUrl(h.ScreenshotRef),
+			TaskId:        h.TaskId,
+			CreateTime:    h.CreateTime.Local().Format("2006-01-02 15:04:05"),
 		})
 	}
 
@@ -679,3 +660,74 @@ func (l *AssetHistoryLogic) AssetHistory(req *types.AssetHistoryReq, workspaceId
 		List: list,
 	}, nil
 }
+
+// AssetChangeLogic 资产变更记录
+type AssetChangeLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewAssetChangeLogic(ctx context.Context, svcCtx *svc.ServiceContext) *AssetChangeLogic {
+	return &AssetChangeLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *AssetChangeLogic) AssetChange(req *types.AssetChangeReq, workspaceId string) (resp *types.AssetChangeResp, err error) {
+	changeModel := l.svcCtx.GetAssetChangeModel(workspaceId)
+
+	filter := bson.M{}
+	if req.ChangeType != "" {
+		filter["change_type"] = req.ChangeType
+	}
+	if req.Field != "" {
+		filter["field"] = req.Field
+	}
+	if req.TaskId != "" {
+		filter["taskId"] = req.TaskId
+	}
+	if req.AssetId != "" {
+		filter["assetId"] = req.AssetId
+	}
+	if req.Host != "" {
+		filter["host"] = bson.M{"$regex": regexp.QuoteMeta(req.Host), "$options": "i"}
+	}
+
+	total, err := changeModel.Count(l.ctx, filter)
+	if err != nil {
+		return &types.AssetChangeResp{Code: 500, Msg: "查询失败"}, nil
+	}
+
+	changes, err := changeModel.Find(l.ctx, filter, req.Page, req.PageSize)
+	if err != nil {
+		return &types.AssetChangeResp{Code: 500, Msg: "查询失败"}, nil
+	}
+
+	list := make([]types.AssetChangeItem, 0, len(changes))
+	for _, c := range changes {
+		list = append(list, types.AssetChangeItem{
+			Id:         c.Id.Hex(),
+			AssetId:    c.AssetId,
+			Authority:  c.Authority,
+			Host:       c.Host,
+			Port:       c.Port,
+			ChangeType: c.ChangeType,
+			Field:      c.Field,
+			OldValue:   c.OldValue,
+			NewValue:   c.NewValue,
+			TaskId:     c.TaskId,
+			PrevTaskId: c.PrevTaskId,
+			CreateTime: c.CreateTime.Local().Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return &types.AssetChangeResp{
+		Code:  0,
+		Msg:   "success",
+		Total: int(total),
+		List:  list,
+	}, nil
+}
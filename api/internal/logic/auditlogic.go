@@ -0,0 +1,96 @@
+package logic
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"cscan/api/internal/svc"
+	"cscan/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type AuditLogListLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewAuditLogListLogic(ctx context.Context, svcCtx *svc.ServiceContext) *AuditLogListLogic {
+	return &AuditLogListLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *AuditLogListLogic) AuditLogList(req *types.AuditLogListReq) (resp *types.AuditLogListResp, err error) {
+	filter := bson.M{}
+	if req.Username != "" {
+		filter["username"] = req.Username
+	}
+	if req.WorkspaceId != "" {
+		filter["workspace_id"] = req.WorkspaceId
+	}
+	if req.Path != "" {
+		filter["path"] = bson.M{"$regex": regexp.QuoteMeta(req.Path), "$options": "i"}
+	}
+	switch req.Status {
+	case "success":
+		filter["code"] = 0
+	case "failed":
+		filter["code"] = bson.M{"$ne": 0}
+	}
+
+	timeRange := bson.M{}
+	for key, value := range map[string]string{"$gte": req.StartTime, "$lte": req.EndTime} {
+		if value == "" {
+			continue
+		}
+		t, err := time.ParseInLocation("2006-01-02 15:04:05", value, time.Local)
+		if err != nil {
+			return &types.AuditLogListResp{Code: 400, Msg: "时间格式错误，应为 2006-01-02 15:04:05"}, nil
+		}
+		timeRange[key] = t
+	}
+	if len(timeRange) > 0 {
+		filter["create_time"] = timeRange
+	}
+
+	total, err := l.svcCtx.AuditLogModel.Count(l.ctx, filter)
+	if err != nil {
+		return &types.AuditLogListResp{Code: 500, Msg: "查询失败"}, nil
+	}
+	logs, err := l.svcCtx.AuditLogModel.Find(l.ctx, filter, req.Page, req.PageSize)
+	if err != nil {
+		return &types.AuditLogListResp{Code: 500, Msg: "查询失败"}, nil
+	}
+
+	list := make([]types.AuditLogItem, 0, len(logs))
+	for _, log := range logs {
+		list = append(list, types.AuditLogItem{
+			Id:          log.Id.Hex(),
+			Username:    log.Username,
+			AuthType:    log.AuthType,
+			WorkspaceId: log.WorkspaceId,
+			Method:      log.Method,
+			Path:        log.Path,
+			Summary:     log.Summary,
+			Ip:          log.Ip,
+			Status:      log.Status,
+			Code:        log.Code,
+			Msg:         log.Msg,
+			Duration:    log.Duration,
+			CreateTime:  log.CreateTime.Local().Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return &types.AuditLogListResp{
+		Code:  0,
+		Msg:   "success",
+		Total: int(total),
+		List:  list,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package logic
+
+import (
+	"context"
+	"slices"
+
+	"cscan/api/internal/logic/common"
+	"cscan/api/internal/middleware"
+	"cscan/api/internal/svc"
+	"cscan/pkg/blob"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// blobUrlPrefix 对象下载地址前缀，与路由 /api/v1/blob/:hash 对应
+const blobUrlPrefix = "/api/v1/blob/"
+
+// blobUrl 返回对象的下载地址，未转存的数据返回空
+func blobUrl(hash string) string {
+	if hash == "" {
+		return ""
+	}
+	return blobUrlPrefix + hash
+}
+
+// fullBody 返回完整响应体，超长响应体转存到对象存储时Mongo中只有开头部分
+func fullBody(ctx context.Context, svcCtx *svc.ServiceContext, body, ref string) string {
+	if ref == "" || svcCtx.Blob == nil {
+		return body
+	}
+	data, err := svcCtx.Blob.Get(ctx, ref)
+	if err != nil {
+		logx.WithContext(ctx).Errorf("Get body %s failed: %v", ref, err)
+		return body
+	}
+	return string(data)
+}
+
+type BlobGetLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewBlobGetLogic(ctx context.Context, svcCtx *svc.ServiceContext) *BlobGetLogic {
+	return &BlobGetLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// BlobGet 读取对象内容，对象按内容哈希寻址，只返回用户可访问的工作空间中资产引用的对象
+func (l *BlobGetLogic) BlobGet(hash string) ([]byte, error) {
+	if l.svcCtx.Blob == nil || !blob.IsValidHash(hash) {
+		return nil, blob.ErrNotFound
+	}
+	if !l.referenced(hash) {
+		return nil, blob.ErrNotFound
+	}
+	data, err := l.svcCtx.Blob.Get(l.ctx, hash)
+	if err != nil && err != blob.ErrNotFound {
+		l.Logger.Errorf("Get blob %s failed: %v", hash, err)
+	}
+	return data, err
+}
+
+// referenced 对象是否被用户可访问的工作空间中的资产或资产历史引用
+func (l *BlobGetLogic) referenced(hash string) bool {
+	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, "all", middleware.PermView)
+	// 未指定工作空间的任务结果保存在 default 工作空间
+	if !slices.Contains(workspaceIds, "default") && middleware.CanAccessWorkspace(l.ctx, "default") &&
+		middleware.HasWorkspacePermission(l.ctx, middleware.PermView, "default") {
+		workspaceIds = append(workspaceIds, "default")
+	}
+	for _, workspaceId := range workspaceIds {
+		if ok, err := l.svcCtx.GetAssetModel(workspaceId).ReferencesBlob(l.ctx, hash); err != nil {
+			l.Logger.Errorf("Check blob %s in workspace %s failed: %v", hash, workspaceId, err)
+		} else if ok {
+			return true
+		}
+		if ok, err := l.svcCtx.GetAssetHistoryModel(workspaceId).ReferencesBlob(l.ctx, hash); err != nil {
+			l.Logger.Errorf("Check blob %s history in workspace %s failed: %v", hash, workspaceId, err)
+		} else if ok {
+			return true
+		}
+	}
+	return false
+}
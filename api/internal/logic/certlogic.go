@@ -0,0 +1,153 @@
+package logic
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"cscan/api/internal/logic/common"
+	"cscan/api/internal/middleware"
+	"cscan/api/internal/svc"
+	"cscan/api/internal/types"
+	"cscan/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type CertLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCertLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CertLogic {
+	return &CertLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// CertList 证书清单 - 按证书指纹聚合资产，标记过期和即将过期的证书
+func (l *CertLogic) CertList(req *types.CertListReq, workspaceId string) (*types.CertListResp, error) {
+	resp := &types.CertListResp{Code: 0, List: []types.CertItem{}}
+
+	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, workspaceId, middleware.PermView)
+	if len(workspaceIds) == 0 {
+		return resp, nil
+	}
+
+	expireDays := req.ExpireDays
+	if expireDays <= 0 {
+		expireDays = 30
+	}
+
+	filter := bson.M{}
+	if req.Keyword != "" {
+		keyword := regexp.QuoteMeta(req.Keyword)
+		filter["$or"] = []bson.M{
+			{"tls.subject": bson.M{"$regex": keyword, "$options": "i"}},
+			{"tls.issuer": bson.M{"$regex": keyword, "$options": "i"}},
+			{"tls.sans": bson.M{"$regex": keyword, "$options": "i"}},
+			{"tls.sha256": strings.ToLower(req.Keyword)},
+		}
+	}
+	if req.SelfSigned {
+		filter["tls.self_signed"] = true
+	}
+	if req.OrgId != "" {
+		filter["org_id"] = req.OrgId
+	}
+
+	// 多个工作空间中的相同证书合并
+	certMap := make(map[string]*types.CertItem)
+	for _, wsId := range workspaceIds {
+		assetModel := model.NewAssetModel(l.svcCtx.MongoDB, wsId)
+		results, err := assetModel.AggregateCert(l.ctx, filter)
+		if err != nil {
+			continue
+		}
+		for _, r := range results {
+			if existing, ok := certMap[r.SHA256]; ok {
+				existing.Assets = append(existing.Assets, r.Assets...)
+				existing.AssetCount += r.Count
+				continue
+			}
+			certMap[r.SHA256] = buildCertItem(&r, expireDays)
+		}
+	}
+
+	list := make([]types.CertItem, 0, len(certMap))
+	for _, item := range certMap {
+		switch item.Status {
+		case "expired":
+			resp.Expired++
+		case "expiring":
+			resp.Expiring++
+		}
+		if req.Status != "" && item.Status != req.Status {
+			continue
+		}
+		list = append(list, *item)
+	}
+
+	// 按剩余天数升序，最先过期的在前
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].DaysLeft != list[j].DaysLeft {
+			return list[i].DaysLeft < list[j].DaysLeft
+		}
+		return list[i].Sha256 < list[j].Sha256
+	})
+
+	// 分页
+	total := len(list)
+	start := (req.Page - 1) * req.PageSize
+	end := start + req.PageSize
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+
+	resp.Total = total
+	if start < total {
+		resp.List = list[start:end]
+	}
+	return resp, nil
+}
+
+func buildCertItem(r *model.CertStatResult, expireDays int) *types.CertItem {
+	cert := r.TLS
+	daysLeft := int(time.Until(cert.NotAfter).Hours() / 24)
+	status := "valid"
+	if time.Now().After(cert.NotAfter) {
+		status = "expired"
+	} else if daysLeft <= expireDays {
+		status = "expiring"
+	}
+
+	return &types.CertItem{
+		Sha256:      r.SHA256,
+		Subject:     cert.Subject,
+		SubjectDN:   cert.SubjectDN,
+		Issuer:      cert.Issuer,
+		IssuerDN:    cert.IssuerDN,
+		Sans:        cert.SANs,
+		NotBefore:   cert.NotBefore.Local().Format("2006-01-02 15:04:05"),
+		NotAfter:    cert.NotAfter.Local().Format("2006-01-02 15:04:05"),
+		DaysLeft:    daysLeft,
+		Status:      status,
+		SelfSigned:  cert.SelfSigned,
+		Trusted:     cert.Trusted,
+		SigAlg:      cert.SigAlg,
+		KeyAlg:      cert.KeyAlg,
+		KeyBits:     cert.KeyBits,
+		TlsVersion:  cert.TLSVersion,
+		Jarm:        cert.Jarm,
+		ChainLength: len(cert.Chain) + 1,
+		Assets:      r.Assets,
+		AssetCount:  r.Count,
+		LastSeen:    r.LastSeen.Local().Format("2006-01-02 15:04:05"),
+	}
+}
@@ -3,14 +3,15 @@ package common
 import (
 	"context"
 
+	"cscan/api/internal/middleware"
 	"cscan/api/internal/svc"
 
 	"go.mongodb.org/mongo-driver/bson"
 )
 
 // GetWorkspaceIds 获取工作空间ID列表
-// 当 workspaceId 为空或 "all" 时，返回所有工作空间ID
-func GetWorkspaceIds(ctx context.Context, svcCtx *svc.ServiceContext, workspaceId string) []string {
+// 当 workspaceId 为空或 "all" 时，返回用户在其中拥有 perm 权限的所有工作空间ID
+func GetWorkspaceIds(ctx context.Context, svcCtx *svc.ServiceContext, workspaceId string, perm middleware.Permission) []string {
 	// 处理 "all" 值 - 前端传递 "all" 表示查询所有工作空间
 	if workspaceId != "" && workspaceId != "all" {
 		return []string{workspaceId}
@@ -24,6 +25,10 @@ func GetWorkspaceIds(ctx context.Context, svcCtx *svc.ServiceContext, workspaceI
 
 	ids := make([]string, 0, len(workspaces))
 	for _, ws := range workspaces {
+		// 只返回当前用户有角色且角色拥有所需权限的工作空间
+		if !middleware.CanAccessWorkspace(ctx, ws.Id.Hex()) || !middleware.HasWorkspacePermission(ctx, perm, ws.Id.Hex()) {
+			continue
+		}
 		ids = append(ids, ws.Id.Hex())
 	}
 	return ids
@@ -0,0 +1,83 @@
+package logic
+
+import (
+	"context"
+	"regexp"
+
+	"cscan/api/internal/svc"
+	"cscan/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// DirScanListLogic 目录扫描结果列表
+type DirScanListLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDirScanListLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DirScanListLogic {
+	return &DirScanListLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DirScanListLogic) DirScanList(req *types.DirScanListReq, workspaceId string) (resp *types.DirScanListResp, err error) {
+	dirScanModel := l.svcCtx.GetDirScanModel(workspaceId)
+
+	filter := bson.M{}
+	if req.Keyword != "" {
+		keyword := bson.M{"$regex": regexp.QuoteMeta(req.Keyword), "$options": "i"}
+		filter["$or"] = []bson.M{{"path": keyword}, {"title": keyword}}
+	}
+	if req.Authority != "" {
+		filter["authority"] = req.Authority
+	}
+	if req.Status > 0 {
+		filter["status"] = req.Status
+	}
+	if req.TaskId != "" {
+		filter["taskId"] = req.TaskId
+	}
+
+	total, err := dirScanModel.Count(l.ctx, filter)
+	if err != nil {
+		return &types.DirScanListResp{Code: 500, Msg: "查询失败"}, nil
+	}
+
+	docs, err := dirScanModel.Find(l.ctx, filter, req.Page, req.PageSize)
+	if err != nil {
+		return &types.DirScanListResp{Code: 500, Msg: "查询失败"}, nil
+	}
+
+	list := make([]types.DirScanItem, 0, len(docs))
+	for _, doc := range docs {
+		list = append(list, types.DirScanItem{
+			Id:          doc.Id.Hex(),
+			Authority:   doc.Authority,
+			Host:        doc.Host,
+			Port:        doc.Port,
+			Url:         doc.Url,
+			Path:        doc.Path,
+			Status:      doc.Status,
+			Length:      doc.Length,
+			Title:       doc.Title,
+			ContentType: doc.ContentType,
+			Location:    doc.Location,
+			TaskId:      doc.TaskId,
+			CreateTime:  doc.CreateTime.Local().Format("2006-01-02 15:04:05"),
+			UpdateTime:  doc.UpdateTime.Local().Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return &types.DirScanListResp{
+		Code:  0,
+		Msg:   "success",
+		Total: int(total),
+		List:  list,
+	}, nil
+}
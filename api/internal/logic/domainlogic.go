@@ -2,12 +2,15 @@ package logic
 
 import (
 	"context"
+	"regexp"
 	"strconv"
 
 	"cscan/api/internal/logic/common"
+	"cscan/api/internal/middleware"
 	"cscan/api/internal/svc"
 	"cscan/api/internal/types"
 	"cscan/model"
+	"cscan/pkg/query"
 
 	"go.mongodb.org/mongo-driver/bson"
 )
@@ -28,11 +31,17 @@ func NewDomainLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DomainLogi
 func (l *DomainLogic) DomainList(req *types.DomainListReq, workspaceId string) (*types.DomainListResp, error) {
 	resp := &types.DomainListResp{Code: 0, List: []types.Domain{}}
 
-	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, workspaceId)
+	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, workspaceId, middleware.PermView)
 	if len(workspaceIds) == 0 {
 		return resp, nil
 	}
 
+	// 语法查询
+	queryFilter, err := query.Compile(req.Query)
+	if err != nil {
+		return &types.DomainListResp{Code: 400, Msg: "查询语法错误: " + err.Error()}, nil
+	}
+
 	orgMap := common.LoadOrgMap(l.ctx, l.svcCtx)
 
 	// 用于去重和聚合域名
@@ -86,6 +95,11 @@ func (l *DomainLogic) DomainList(req *types.DomainListReq, workspaceId string) (
 			filter["org_id"] = req.OrgId
 		}
 
+		// 语法查询，与上面的条件组合
+		if len(queryFilter) > 0 {
+			filter = bson.M{"$and": []bson.M{filter, queryFilter}}
+		}
+
 		// 查询所有匹配的资产
 		assets, err := assetModel.Find(l.ctx, filter, 0, 0)
 		if err != nil {
@@ -175,15 +189,109 @@ func (l *DomainLogic) DomainList(req *types.DomainListReq, workspaceId string) (
 	resp.Total = total
 	if start < total {
 		resp.List = allDomains[start:end]
+		l.fillDNSRecords(resp.List, workspaceIds)
 	}
 	return resp, nil
 }
 
+// fillDNSRecords 补充当前页域名的DNS记录
+func (l *DomainLogic) fillDNSRecords(list []types.Domain, workspaceIds []string) {
+	index := make(map[string]int, len(list))
+	names := make([]string, 0, len(list))
+	for i, d := range list {
+		index[d.Domain] = i
+		names = append(names, d.Domain)
+	}
+
+	for _, wsId := range workspaceIds {
+		docs, err := l.svcCtx.GetDNSRecordModel(wsId).FindByDomains(l.ctx, names)
+		if err != nil {
+			continue
+		}
+		for _, doc := range docs {
+			i, ok := index[doc.Domain]
+			if !ok || list[i].DNSTime != "" {
+				continue
+			}
+			d := &list[i]
+			for _, r := range doc.Records {
+				d.DNSRecords = append(d.DNSRecords, types.DomainDNSRecord{Type: r.Type, Name: r.Name, Value: r.Value, TTL: r.TTL})
+			}
+			for _, issue := range doc.Issues {
+				d.DNSIssues = append(d.DNSIssues, types.DomainDNSIssue{Type: issue.Type, Severity: issue.Severity, Detail: issue.Detail})
+			}
+			d.SPF = doc.SPF
+			d.DMARC = doc.DMARC
+			d.ZoneTransfer = doc.ZoneTransfer
+			d.DNSTime = doc.UpdateTime.Local().Format("2006-01-02 15:04:05")
+		}
+	}
+}
+
+// SubdomainList 子域名监控列表，指定任务时返回该任务新发现的子域名
+func (l *DomainLogic) SubdomainList(req *types.SubdomainListReq, workspaceId string) (*types.SubdomainListResp, error) {
+	subdomainModel := l.svcCtx.GetSubdomainModel(workspaceId)
+
+	filter := bson.M{}
+	if req.Domain != "" {
+		filter["domain"] = bson.M{"$regex": regexp.QuoteMeta(req.Domain), "$options": "i"}
+	}
+	if req.RootDomain != "" {
+		filter["root_domain"] = req.RootDomain
+	}
+	if req.TaskId != "" {
+		// 匹配主任务ID或子任务ID（子任务格式: {mainTaskId}-{index}）
+		filter["$or"] = []bson.M{
+			{"first_task_id": req.TaskId},
+			{"first_task_id": bson.M{"$regex": "^" + regexp.QuoteMeta(req.TaskId) + "-\\d+$"}},
+		}
+		// 待确认的新增子域名尚未扫描完成，不算作该任务的新增
+		filter["pending"] = bson.M{"$ne": model.SubdomainStatusNew}
+	}
+
+	total, err := subdomainModel.Count(l.ctx, filter)
+	if err != nil {
+		return &types.SubdomainListResp{Code: 500, Msg: "查询失败"}, nil
+	}
+	docs, err := subdomainModel.Find(l.ctx, filter, req.Page, req.PageSize)
+	if err != nil {
+		return &types.SubdomainListResp{Code: 500, Msg: "查询失败"}, nil
+	}
+
+	list := make([]types.SubdomainItem, 0, len(docs))
+	for _, doc := range docs {
+		lastSeen := ""
+		if !doc.LastSeen.IsZero() {
+			lastSeen = doc.LastSeen.Local().Format("2006-01-02 15:04:05")
+		}
+		list = append(list, types.SubdomainItem{
+			Id:          doc.Id.Hex(),
+			Domain:      doc.Domain,
+			RootDomain:  doc.RootDomain,
+			IPs:         doc.IPs,
+			CName:       doc.CName,
+			FirstTaskId: doc.FirstTaskId,
+			LastTaskId:  doc.LastTaskId,
+			FirstSeen:   doc.FirstSeen.Local().Format("2006-01-02 15:04:05"),
+			LastSeen:    lastSeen,
+			ChangeTime:  doc.ChangeTime.Local().Format("2006-01-02 15:04:05"),
+			Pending:     doc.Pending,
+		})
+	}
+
+	return &types.SubdomainListResp{
+		Code:  0,
+		Msg:   "success",
+		Total: int(total),
+		List:  list,
+	}, nil
+}
+
 // DomainStat 域名统计
 func (l *DomainLogic) DomainStat(workspaceId string) (*types.DomainStatResp, error) {
 	resp := &types.DomainStatResp{Code: 0}
 
-	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, workspaceId)
+	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, workspaceId, middleware.PermView)
 	if len(workspaceIds) == 0 {
 		return resp, nil
 	}
@@ -246,7 +354,7 @@ func (l *DomainLogic) DomainStat(workspaceId string) (*types.DomainStatResp, err
 
 // DomainDelete 删除域名（实际删除对应的资产）
 func (l *DomainLogic) DomainDelete(req *types.DomainDeleteReq, workspaceId string) (*types.BaseResp, error) {
-	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, workspaceId)
+	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, workspaceId, middleware.PermAssetEdit)
 
 	for _, wsId := range workspaceIds {
 		assetModel := model.NewAssetModel(l.svcCtx.MongoDB, wsId)
@@ -265,7 +373,7 @@ func (l *DomainLogic) DomainBatchDelete(req *types.DomainBatchDeleteReq, workspa
 		return &types.BaseResp{Code: 400, Msg: "请选择要删除的域名"}, nil
 	}
 
-	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, workspaceId)
+	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, workspaceId, middleware.PermAssetEdit)
 	var totalDeleted int64
 
 	for _, wsId := range workspaceIds {
@@ -1757,7 +1757,7 @@ func (l *FingerprintMatchAssetsLogic) FingerprintMatchAssets(req *types.Fingerpr
 		// 构建指纹数据
 		data := &FingerprintData{
 			Title:        asset.Title,
-			Body:         asset.HttpBody,
+			Body:         fullBody(l.ctx, l.svcCtx, asset.HttpBody, asset.BodyRef),
 			HeaderString: asset.HttpHeader,
 			Server:       asset.Server,
 			FaviconHash:  asset.IconHash,
@@ -2,13 +2,16 @@ package logic
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"strconv"
 
 	"cscan/api/internal/logic/common"
+	"cscan/api/internal/middleware"
 	"cscan/api/internal/svc"
 	"cscan/api/internal/types"
 	"cscan/model"
+	"cscan/pkg/query"
 
 	"go.mongodb.org/mongo-driver/bson"
 )
@@ -30,11 +33,17 @@ func NewIPLogic(ctx context.Context, svcCtx *svc.ServiceContext) *IPLogic {
 func (l *IPLogic) IPList(req *types.IPListReq, workspaceId string) (*types.IPListResp, error) {
 	resp := &types.IPListResp{Code: 0, List: []types.IPAsset{}}
 
-	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, workspaceId)
+	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, workspaceId, middleware.PermView)
 	if len(workspaceIds) == 0 {
 		return resp, nil
 	}
 
+	// 语法查询
+	queryFilter, err := query.Compile(req.Query)
+	if err != nil {
+		return &types.IPListResp{Code: 400, Msg: "查询语法错误: " + err.Error()}, nil
+	}
+
 	orgMap := common.LoadOrgMap(l.ctx, l.svcCtx)
 
 	// 用于聚合IP信息
@@ -78,6 +87,10 @@ func (l *IPLogic) IPList(req *types.IPListReq, workspaceId string) (*types.IPLis
 		if req.OrgId != "" {
 			conditions = append(conditions, bson.M{"org_id": req.OrgId})
 		}
+		// 语法查询
+		if len(queryFilter) > 0 {
+			conditions = append(conditions, queryFilter)
+		}
 
 		if len(conditions) > 0 {
 			filter["$and"] = conditions
@@ -94,11 +107,13 @@ func (l *IPLogic) IPList(req *types.IPListReq, workspaceId string) (*types.IPLis
 			// 收集所有IP地址
 			var ips []string
 			var location string
+			geoMap := make(map[string]model.IPV4)
 
 			// 从ip.ipv4字段获取IP
 			for _, ipv4 := range asset.Ip.IpV4 {
 				if ipv4.IPName != "" {
 					ips = append(ips, ipv4.IPName)
+					geoMap[ipv4.IPName] = ipv4
 					if location == "" && ipv4.Location != "" {
 						location = ipv4.Location
 					}
@@ -168,6 +183,14 @@ func (l *IPLogic) IPList(req *types.IPListReq, workspaceId string) (*types.IPLis
 					if existing.Location == "" && location != "" {
 						existing.Location = location
 					}
+					if geo, ok := geoMap[ip]; ok {
+						if existing.ASN == "" && geo.ASN > 0 {
+							existing.ASN = fmt.Sprintf("AS%d", geo.ASN)
+						}
+						if existing.ISP == "" && geo.ISP != "" {
+							existing.ISP = geo.ISP
+						}
+					}
 				} else {
 					// 创建新的IP记录
 					ports := []types.PortInfo{}
@@ -199,6 +222,12 @@ func (l *IPLogic) IPList(req *types.IPListReq, workspaceId string) (*types.IPLis
 						UpdateTime:  asset.UpdateTime.Local().Format("2006-01-02 15:04:05"),
 						IsNew:       asset.IsNewAsset,
 					}
+					if geo, ok := geoMap[ip]; ok {
+						if geo.ASN > 0 {
+							ipMap[ip].ASN = fmt.Sprintf("AS%d", geo.ASN)
+						}
+						ipMap[ip].ISP = geo.ISP
+					}
 				}
 			}
 		}
@@ -237,7 +266,7 @@ func (l *IPLogic) IPList(req *types.IPListReq, workspaceId string) (*types.IPLis
 func (l *IPLogic) IPStat(workspaceId string) (*types.IPStatResp, error) {
 	resp := &types.IPStatResp{Code: 0}
 
-	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, workspaceId)
+	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, workspaceId, middleware.PermView)
 	if len(workspaceIds) == 0 {
 		return resp, nil
 	}
@@ -310,7 +339,7 @@ func (l *IPLogic) IPDelete(req *types.IPDeleteReq, workspaceId string) (*types.B
 		return &types.BaseResp{Code: 400, Msg: "IP不能为空"}, nil
 	}
 
-	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, workspaceId)
+	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, workspaceId, middleware.PermAssetEdit)
 	var totalDeleted int64
 
 	for _, wsId := range workspaceIds {
@@ -350,7 +379,7 @@ func (l *IPLogic) IPBatchDelete(req *types.IPBatchDeleteReq, workspaceId string)
 		return &types.BaseResp{Code: 400, Msg: "请选择要删除的IP"}, nil
 	}
 
-	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, workspaceId)
+	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, workspaceId, middleware.PermAssetEdit)
 	var totalDeleted int64
 
 	for _, wsId := range workspaceIds {
@@ -2,13 +2,17 @@ package logic
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"time"
 
 	"cscan/api/internal/svc"
 	"cscan/api/internal/types"
+	"cscan/model"
 
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/zeromicro/go-zero/core/logx"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 type LoginLogic struct {
@@ -25,23 +29,46 @@ func NewLoginLogic(ctx context.Context, svcCtx *svc.ServiceContext) *LoginLogic
 	}
 }
 
-func (l *LoginLogic) Login(req *types.LoginReq) (resp *types.LoginResp, err error) {
+// 登录失败计数与锁定的Redis键
+const (
+	loginFailUserKey = "cscan:login:fail:user:"
+	loginFailIpKey   = "cscan:login:fail:ip:"
+	loginLockUserKey = "cscan:login:lock:user:"
+	loginLockIpKey   = "cscan:login:lock:ip:"
+)
+
+func (l *LoginLogic) Login(req *types.LoginReq, clientIp, userAgent string) (resp *types.LoginResp, err error) {
+	// 检查用户或IP是否处于锁定状态
+	if ttl := l.lockRemaining(req.Username, clientIp); ttl > 0 {
+		l.audit(req.Username, "", clientIp, userAgent, false, model.LoginReasonLocked)
+		return &types.LoginResp{
+			Code: 429,
+			Msg:  fmt.Sprintf("登录失败次数过多，请%d分钟后重试", int(math.Ceil(ttl.Minutes()))),
+		}, nil
+	}
+
 	// 验证用户名密码
 	user, ok := l.svcCtx.UserModel.VerifyPassword(l.ctx, req.Username, req.Password)
 	if !ok {
+		l.recordFailure(req.Username, clientIp)
+		l.audit(req.Username, "", clientIp, userAgent, false, model.LoginReasonInvalid)
 		return &types.LoginResp{
 			Code: 401,
 			Msg:  "用户名或密码错误",
 		}, nil
 	}
 
+	// 登录成功，清除该用户的失败计数
+	l.svcCtx.RedisClient.Del(l.ctx, loginFailUserKey+req.Username)
+	l.audit(user.Username, user.Id.Hex(), clientIp, userAgent, true, "")
+
 	// 更新登录时间
 	_ = l.svcCtx.UserModel.UpdateLoginTime(l.ctx, user.Id.Hex())
 
 	// 生成JWT Token
 	now := time.Now().Unix()
 	accessExpire := l.svcCtx.Config.Auth.AccessExpire
-	token, err := l.generateToken(user.Id.Hex(), user.Username, now, accessExpire)
+	token, err := l.generateToken(user.Id.Hex(), user.Username, user.Role, now, accessExpire)
 	if err != nil {
 		return &types.LoginResp{
 			Code: 500,
@@ -57,24 +84,162 @@ func (l *LoginLogic) Login(req *types.LoginReq) (resp *types.LoginResp, err erro
 	// 注意：workspaceId 为空时，后端会使用 "default" 工作空间
 
 	return &types.LoginResp{
-		Code:        0,
-		Msg:         "登录成功",
-		Token:       token,
-		UserId:      user.Id.Hex(),
-		Username:    user.Username,
-		Role:        "admin", // 所有用户都是管理员
-		WorkspaceId: workspaceId,
+		Code:           0,
+		Msg:            "登录成功",
+		Token:          token,
+		UserId:         user.Id.Hex(),
+		Username:       user.Username,
+		Role:           user.Role,
+		WorkspaceId:    workspaceId,
+		WorkspaceRoles: toWorkspaceRoles(user.WorkspaceRoles),
 	}, nil
 }
 
-func (l *LoginLogic) generateToken(userId, username string, iat, expire int64) (string, error) {
+func (l *LoginLogic) generateToken(userId, username, role string, iat, expire int64) (string, error) {
 	claims := jwt.MapClaims{
 		"userId":   userId,
 		"username": username,
-		"role":     "admin", // 所有用户都是管理员
+		"role":     role, // 全局角色，接口权限由权限中间件按工作空间实时判断
 		"iat":      iat,
 		"exp":      iat + expire,
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(l.svcCtx.Config.Auth.AccessSecret))
 }
+
+// lockRemaining 返回用户或IP剩余的锁定时间，未锁定返回0
+func (l *LoginLogic) lockRemaining(username, clientIp string) time.Duration {
+	var remaining time.Duration
+	for _, key := range []string{loginLockUserKey + username, loginLockIpKey + clientIp} {
+		ttl, err := l.svcCtx.RedisClient.TTL(l.ctx, key).Result()
+		if err != nil {
+			// Redis不可用时不阻止登录
+			l.Errorf("Check login lock failed: %v", err)
+			continue
+		}
+		if ttl > remaining {
+			remaining = ttl
+		}
+	}
+	return remaining
+}
+
+// recordFailure 累加用户和IP的失败次数，超过阈值后锁定
+func (l *LoginLogic) recordFailure(username, clientIp string) {
+	policy := l.svcCtx.Config.Login
+	maxUser := policy.MaxUserFailures
+	if maxUser <= 0 {
+		maxUser = 5
+	}
+	maxIp := policy.MaxIpFailures
+	if maxIp <= 0 {
+		maxIp = 20
+	}
+	window := time.Duration(policy.FailureWindow) * time.Second
+	if window <= 0 {
+		window = 15 * time.Minute
+	}
+	lockDuration := time.Duration(policy.LockDuration) * time.Second
+	if lockDuration <= 0 {
+		lockDuration = 15 * time.Minute
+	}
+
+	targets := []struct {
+		failKey string
+		lockKey string
+		max     int
+	}{
+		{loginFailUserKey + username, loginLockUserKey + username, maxUser},
+		{loginFailIpKey + clientIp, loginLockIpKey + clientIp, maxIp},
+	}
+	for _, t := range targets {
+		count, err := l.svcCtx.RedisClient.Incr(l.ctx, t.failKey).Result()
+		if err != nil {
+			l.Errorf("Record login failure failed: %v", err)
+			continue
+		}
+		if count == 1 {
+			l.svcCtx.RedisClient.Expire(l.ctx, t.failKey, window)
+		}
+		if count >= int64(t.max) {
+			l.svcCtx.RedisClient.Set(l.ctx, t.lockKey, count, lockDuration)
+			l.svcCtx.RedisClient.Del(l.ctx, t.failKey)
+			l.Infof("Login locked: %s for %v after %d failures", t.lockKey, lockDuration, count)
+		}
+	}
+}
+
+// audit 记录登录审计日志
+func (l *LoginLogic) audit(username, userId, clientIp, userAgent string, success bool, reason string) {
+	err := l.svcCtx.LoginLogModel.Insert(l.ctx, &model.LoginLog{
+		Username:  username,
+		UserId:    userId,
+		Ip:        clientIp,
+		UserAgent: userAgent,
+		Success:   success,
+		Reason:    reason,
+	})
+	if err != nil {
+		l.Errorf("Insert login log failed: %v", err)
+	}
+}
+
+// LoginLogLogic 登录审计日志
+type LoginLogLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewLoginLogLogic(ctx context.Context, svcCtx *svc.ServiceContext) *LoginLogLogic {
+	return &LoginLogLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *LoginLogLogic) LoginLog(req *types.LoginLogReq) (resp *types.LoginLogResp, err error) {
+	filter := bson.M{}
+	if req.Username != "" {
+		filter["username"] = req.Username
+	}
+	if req.Ip != "" {
+		filter["ip"] = req.Ip
+	}
+	switch req.Status {
+	case "success":
+		filter["success"] = true
+	case "failed":
+		filter["success"] = false
+	}
+
+	total, err := l.svcCtx.LoginLogModel.Count(l.ctx, filter)
+	if err != nil {
+		return &types.LoginLogResp{Code: 500, Msg: "查询失败"}, nil
+	}
+	logs, err := l.svcCtx.LoginLogModel.Find(l.ctx, filter, req.Page, req.PageSize)
+	if err != nil {
+		return &types.LoginLogResp{Code: 500, Msg: "查询失败"}, nil
+	}
+
+	list := make([]types.LoginLogItem, 0, len(logs))
+	for _, log := range logs {
+		list = append(list, types.LoginLogItem{
+			Id:         log.Id.Hex(),
+			Username:   log.Username,
+			Ip:         log.Ip,
+			UserAgent:  log.UserAgent,
+			Success:    log.Success,
+			Reason:     log.Reason,
+			CreateTime: log.CreateTime.Local().Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return &types.LoginLogResp{
+		Code:  0,
+		Msg:   "success",
+		Total: int(total),
+		List:  list,
+	}, nil
+}
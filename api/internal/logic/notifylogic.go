@@ -0,0 +1,269 @@
+package logic
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"cscan/api/internal/svc"
+	"cscan/api/internal/types"
+	"cscan/model"
+	"cscan/pkg/notify"
+	"cscan/scheduler"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// NotifyConfigListLogic 通知渠道列表
+type NotifyConfigListLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewNotifyConfigListLogic(ctx context.Context, svcCtx *svc.ServiceContext) *NotifyConfigListLogic {
+	return &NotifyConfigListLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *NotifyConfigListLogic) NotifyConfigList(req *types.PageReq) (resp *types.NotifyConfigListResp, err error) {
+	filter := bson.M{}
+
+	total, err := l.svcCtx.NotifyConfigModel.Count(l.ctx, filter)
+	if err != nil {
+		return &types.NotifyConfigListResp{Code: 500, Msg: "查询失败"}, nil
+	}
+
+	docs, err := l.svcCtx.NotifyConfigModel.Find(l.ctx, filter, req.Page, req.PageSize)
+	if err != nil {
+		return &types.NotifyConfigListResp{Code: 500, Msg: "查询失败"}, nil
+	}
+
+	list := make([]types.NotifyConfig, 0, len(docs))
+	for _, d := range docs {
+		secret, smtpPass := "", ""
+		if d.Secret != "" {
+			secret = maskKey(d.Secret)
+		}
+		if d.SmtpPass != "" {
+			smtpPass = maskKey(d.SmtpPass)
+		}
+		list = append(list, types.NotifyConfig{
+			Id:          d.Id.Hex(),
+			Name:        d.Name,
+			Type:        d.Type,
+			WebhookUrl:  d.WebhookUrl,
+			Secret:      secret,
+			SmtpHost:    d.SmtpHost,
+			SmtpPort:    d.SmtpPort,
+			SmtpUser:    d.SmtpUser,
+			SmtpPass:    smtpPass,
+			SmtpFrom:    d.SmtpFrom,
+			SmtpTo:      d.SmtpTo,
+			Template:    d.Template,
+			Events:      d.Events,
+			MinSeverity: d.MinSeverity,
+			Status:      d.Status,
+			CreateTime:  d.CreateTime.Local().Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return &types.NotifyConfigListResp{
+		Code:  0,
+		Msg:   "success",
+		Total: int(total),
+		List:  list,
+	}, nil
+}
+
+// NotifyConfigSaveLogic 保存通知渠道
+type NotifyConfigSaveLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewNotifyConfigSaveLogic(ctx context.Context, svcCtx *svc.ServiceContext) *NotifyConfigSaveLogic {
+	return &NotifyConfigSaveLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *NotifyConfigSaveLogic) NotifyConfigSave(req *types.NotifyConfigSaveReq) (resp *types.BaseResp, err error) {
+	if req.Name == "" {
+		return &types.BaseResp{Code: 400, Msg: "名称不能为空"}, nil
+	}
+	switch req.Type {
+	case notify.TypeWebhook, notify.TypeDingTalk, notify.TypeWeCom, notify.TypeFeishu:
+		if req.WebhookUrl == "" {
+			return &types.BaseResp{Code: 400, Msg: "Webhook地址不能为空"}, nil
+		}
+	case notify.TypeEmail:
+		if req.SmtpHost == "" || len(req.SmtpTo) == 0 {
+			return &types.BaseResp{Code: 400, Msg: "SMTP服务器和收件人不能为空"}, nil
+		}
+	default:
+		return &types.BaseResp{Code: 400, Msg: "不支持的通知类型"}, nil
+	}
+	if req.Template != "" {
+		if _, err := notify.Render(req.Template, &notify.TemplateData{}); err != nil {
+			return &types.BaseResp{Code: 400, Msg: "消息模板格式错误: " + err.Error()}, nil
+		}
+	}
+
+	if req.Id != "" {
+		// 更新
+		update := bson.M{
+			"name":         req.Name,
+			"type":         req.Type,
+			"webhook_url":  req.WebhookUrl,
+			"smtp_host":    req.SmtpHost,
+			"smtp_port":    req.SmtpPort,
+			"smtp_user":    req.SmtpUser,
+			"smtp_from":    req.SmtpFrom,
+			"smtp_to":      req.SmtpTo,
+			"template":     req.Template,
+			"events":       req.Events,
+			"min_severity": req.MinSeverity,
+		}
+		// 密钥为空或为脱敏值时保留原值
+		if req.Secret != "" && !strings.Contains(req.Secret, "****") {
+			update["secret"] = req.Secret
+		}
+		if req.SmtpPass != "" && !strings.Contains(req.SmtpPass, "****") {
+			update["smtp_pass"] = req.SmtpPass
+		}
+		if req.Status != "" {
+			update["status"] = req.Status
+		}
+		if err = l.svcCtx.NotifyConfigModel.Update(l.ctx, req.Id, update); err != nil {
+			return &types.BaseResp{Code: 500, Msg: "更新失败"}, nil
+		}
+		return &types.BaseResp{Code: 0, Msg: "更新成功"}, nil
+	}
+
+	// 新增
+	doc := &model.NotifyConfig{
+		Name:        req.Name,
+		Type:        req.Type,
+		WebhookUrl:  req.WebhookUrl,
+		Secret:      req.Secret,
+		SmtpHost:    req.SmtpHost,
+		SmtpPort:    req.SmtpPort,
+		SmtpUser:    req.SmtpUser,
+		SmtpPass:    req.SmtpPass,
+		SmtpFrom:    req.SmtpFrom,
+		SmtpTo:      req.SmtpTo,
+		Template:    req.Template,
+		Events:      req.Events,
+		MinSeverity: req.MinSeverity,
+		Status:      req.Status,
+	}
+	if len(doc.Events) == 0 {
+		doc.Events = []string{model.NotifyEventSuccess, model.NotifyEventFailure}
+	}
+	if err = l.svcCtx.NotifyConfigModel.Insert(l.ctx, doc); err != nil {
+		return &types.BaseResp{Code: 500, Msg: "创建失败"}, nil
+	}
+
+	return &types.BaseResp{Code: 0, Msg: "创建成功"}, nil
+}
+
+// NotifyConfigDeleteLogic 删除通知渠道
+type NotifyConfigDeleteLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewNotifyConfigDeleteLogic(ctx context.Context, svcCtx *svc.ServiceContext) *NotifyConfigDeleteLogic {
+	return &NotifyConfigDeleteLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *NotifyConfigDeleteLogic) NotifyConfigDelete(req *types.NotifyConfigDeleteReq) (resp *types.BaseResp, err error) {
+	if req.Id == "" {
+		return &types.BaseResp{Code: 400, Msg: "ID不能为空"}, nil
+	}
+
+	if err = l.svcCtx.NotifyConfigModel.Delete(l.ctx, req.Id); err != nil {
+		return &types.BaseResp{Code: 500, Msg: "删除失败"}, nil
+	}
+
+	return &types.BaseResp{Code: 0, Msg: "删除成功"}, nil
+}
+
+// NotifyConfigTestLogic 发送测试通知
+type NotifyConfigTestLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewNotifyConfigTestLogic(ctx context.Context, svcCtx *svc.ServiceContext) *NotifyConfigTestLogic {
+	return &NotifyConfigTestLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *NotifyConfigTestLogic) NotifyConfigTest(req *types.NotifyConfigTestReq) (resp *types.BaseResp, err error) {
+	cfg, err := l.svcCtx.NotifyConfigModel.FindById(l.ctx, req.Id)
+	if err != nil {
+		return &types.BaseResp{Code: 400, Msg: "通知配置不存在"}, nil
+	}
+
+	data := &notify.TemplateData{
+		TaskResult: scheduler.TaskResult{
+			TaskId:     "test",
+			Status:     scheduler.TaskStatusSuccess,
+			Message:    "这是一条测试通知",
+			AssetCount: 10,
+			VulCount:   2,
+			Duration:   60,
+		},
+		TaskName:       "测试任务",
+		Target:         "example.com",
+		MinSeverity:    cfg.MinSeverity,
+		SevereVulCount: 1,
+		FinishTime:     time.Now().Local().Format("2006-01-02 15:04:05"),
+	}
+	content, err := notify.Render(cfg.Template, data)
+	if err != nil {
+		return &types.BaseResp{Code: 400, Msg: "消息模板格式错误: " + err.Error()}, nil
+	}
+
+	notifier, err := notify.New(&notify.Config{
+		Type:       cfg.Type,
+		WebhookUrl: cfg.WebhookUrl,
+		Secret:     cfg.Secret,
+		SmtpHost:   cfg.SmtpHost,
+		SmtpPort:   cfg.SmtpPort,
+		SmtpUser:   cfg.SmtpUser,
+		SmtpPass:   cfg.SmtpPass,
+		SmtpFrom:   cfg.SmtpFrom,
+		SmtpTo:     cfg.SmtpTo,
+	})
+	if err != nil {
+		return &types.BaseResp{Code: 400, Msg: "通知配置无效: " + err.Error()}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(l.ctx, 30*time.Second)
+	defer cancel()
+	if err := notifier.Send(ctx, &notify.Message{Title: "[CSCAN] 测试通知", Content: content, Data: data}); err != nil {
+		l.Logger.Errorf("NotifyConfigTest: send failed, id=%s, error=%v", req.Id, err)
+		return &types.BaseResp{Code: 500, Msg: "发送失败: " + err.Error()}, nil
+	}
+
+	return &types.BaseResp{Code: 0, Msg: "发送成功"}, nil
+}
@@ -99,6 +99,17 @@ func (l *ReportDetailLogic) ReportDetail(req *types.ReportDetailReq, workspaceId
 		l.Logger.Infof("Found %d vuls for taskId(ObjectID)=%s", len(vuls), task.Id.Hex())
 	}
 
+	// 获取目录扫描结果
+	dirScans, err := l.svcCtx.GetDirScanModel(workspaceId).Find(l.ctx, bson.M{
+		"$or": []bson.M{
+			{"taskId": queryTaskId},
+			{"taskId": bson.M{"$regex": "^" + queryTaskId + "-\\d+$"}},
+		},
+	}, 0, 0)
+	if err != nil {
+		l.Logger.Errorf("查询目录扫描结果失败: %v", err)
+	}
+
 	// 统计信息
 	portStats := make(map[int]int)
 	serviceStats := make(map[string]int)
@@ -126,17 +137,18 @@ func (l *ReportDetailLogic) ReportDetail(req *types.ReportDetailReq, workspaceId
 	assetList := make([]types.ReportAsset, 0, len(assets))
 	for _, a := range assets {
 		assetList = append(assetList, types.ReportAsset{
-			Authority:  a.Authority,
-			Host:       a.Host,
-			Port:       a.Port,
-			Service:    a.Service,
-			Title:      a.Title,
-			App:        a.App,
-			HttpStatus: a.HttpStatus,
-			Server:     a.Server,
-			IconHash:   a.IconHash,
-			Screenshot: a.Screenshot,
-			CreateTime: a.CreateTime.Local().Format("2006-01-02 15:04:05"),
+			Authority:     a.Authority,
+			Host:          a.Host,
+			Port:          a.Port,
+			Service:       a.Service,
+			Title:         a.Title,
+			App:           a.App,
+			HttpStatus:    a.HttpStatus,
+			Server:        a.Server,
+			IconHash:      a.IconHash,
+			Screenshot:    a.Screenshot,
+			ScreenshotUrl: blobUrl(a.ScreenshotRef),
+			CreateTime:    a.CreateTime.Local().Format("2006-01-02 15:04:05"),
 		})
 	}
 
@@ -153,6 +165,21 @@ func (l *ReportDetailLogic) ReportDetail(req *types.ReportDetailReq, workspaceId
 		})
 	}
 
+	// 转换目录扫描结果
+	dirScanList := make([]types.ReportDirScan, 0, len(dirScans))
+	for _, d := range dirScans {
+		dirScanList = append(dirScanList, types.ReportDirScan{
+			Authority:  d.Authority,
+			Url:        d.Url,
+			Path:       d.Path,
+			Status:     d.Status,
+			Length:     d.Length,
+			Title:      d.Title,
+			Location:   d.Location,
+			CreateTime: d.CreateTime.Local().Format("2006-01-02 15:04:05"),
+		})
+	}
+
 	// 转换统计
 	topPorts := make([]types.StatItem, 0)
 	for port, count := range portStats {
@@ -171,19 +198,21 @@ func (l *ReportDetailLogic) ReportDetail(req *types.ReportDetailReq, workspaceId
 		Code: 0,
 		Msg:  "success",
 		Data: &types.ReportData{
-			TaskId:      req.TaskId,
-			TaskName:    task.Name,
-			Target:      task.Target,
-			Status:      task.Status,
-			CreateTime:  task.CreateTime.Local().Format("2006-01-02 15:04:05"),
-			AssetCount:  len(assets),
-			VulCount:    len(vuls),
-			Assets:      assetList,
-			Vuls:        vulList,
-			TopPorts:    topPorts,
-			TopServices: topServices,
-			TopApps:     topApps,
-			VulStats:    severityStats,
+			TaskId:       req.TaskId,
+			TaskName:     task.Name,
+			Target:       task.Target,
+			Status:       task.Status,
+			CreateTime:   task.CreateTime.Local().Format("2006-01-02 15:04:05"),
+			AssetCount:   len(assets),
+			VulCount:     len(vuls),
+			DirScanCount: len(dirScans),
+			Assets:       assetList,
+			Vuls:         vulList,
+			DirScans:     dirScanList,
+			TopPorts:     topPorts,
+			TopServices:  topServices,
+			TopApps:      topApps,
+			VulStats:     severityStats,
 		},
 	}, nil
 }
@@ -231,6 +260,9 @@ func (l *ReportExportLogic) ReportExport(req *types.ReportExportReq, workspaceId
 	}
 	vuls, _ := vulModel.Find(l.ctx, vulFilter, 0, 0)
 
+	// 获取目录扫描结果（匹配主任务ID或子任务ID）
+	dirScans, _ := l.svcCtx.GetDirScanModel(workspaceId).Find(l.ctx, assetFilter, 0, 0)
+
 	// 创建Excel文件
 	f := excelize.NewFile()
 	defer f.Close()
@@ -250,6 +282,8 @@ func (l *ReportExportLogic) ReportExport(req *types.ReportExportReq, workspaceId
 	f.SetCellValue("概览", "B7", len(assets))
 	f.SetCellValue("概览", "A8", "漏洞数量")
 	f.SetCellValue("概览", "B8", len(vuls))
+	f.SetCellValue("概览", "A9", "目录数量")
+	f.SetCellValue("概览", "B9", len(dirScans))
 
 	// 设置概览样式
 	titleStyle, _ := f.NewStyle(&excelize.Style{
@@ -297,6 +331,24 @@ func (l *ReportExportLogic) ReportExport(req *types.ReportExportReq, workspaceId
 		f.SetCellValue("漏洞列表", fmt.Sprintf("F%d", row), v.CreateTime.Local().Format("2006-01-02 15:04:05"))
 	}
 
+	// 目录扫描Sheet
+	f.NewSheet("目录扫描")
+	dirScanHeaders := []string{"地址", "URL", "状态码", "长度", "标题", "跳转地址", "发现时间"}
+	for i, h := range dirScanHeaders {
+		cell := fmt.Sprintf("%c1", 'A'+i)
+		f.SetCellValue("目录扫描", cell, h)
+	}
+	for i, d := range dirScans {
+		row := i + 2
+		f.SetCellValue("目录扫描", fmt.Sprintf("A%d", row), d.Authority)
+		f.SetCellValue("目录扫描", fmt.Sprintf("B%d", row), d.Url)
+		f.SetCellValue("目录扫描", fmt.Sprintf("C%d", row), d.Status)
+		f.SetCellValue("目录扫描", fmt.Sprintf("D%d", row), d.Length)
+		f.SetCellValue("目录扫描", fmt.Sprintf("E%d", row), d.Title)
+		f.SetCellValue("目录扫描", fmt.Sprintf("F%d", row), d.Location)
+		f.SetCellValue("目录扫描", fmt.Sprintf("G%d", row), d.CreateTime.Local().Format("2006-01-02 15:04:05"))
+	}
+
 	// 设置列宽
 	f.SetColWidth("资产列表", "A", "A", 30)
 	f.SetColWidth("资产列表", "B", "B", 15)
@@ -306,6 +358,9 @@ func (l *ReportExportLogic) ReportExport(req *types.ReportExportReq, workspaceId
 	f.SetColWidth("漏洞列表", "B", "B", 50)
 	f.SetColWidth("漏洞列表", "C", "C", 40)
 	f.SetColWidth("漏洞列表", "E", "E", 50)
+	f.SetColWidth("目录扫描", "A", "A", 30)
+	f.SetColWidth("目录扫描", "B", "B", 50)
+	f.SetColWidth("目录扫描", "E", "E", 40)
 
 	// 写入buffer
 	var buf bytes.Buffer
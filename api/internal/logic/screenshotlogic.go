@@ -0,0 +1,182 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"cscan/api/internal/svc"
+	"cscan/api/internal/types"
+	"cscan/model"
+	"cscan/pkg/imghash"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// workspaceIds 返回要统计的工作空间，workspaceId 为空时返回所有工作空间
+func workspaceIds(ctx context.Context, svcCtx *svc.ServiceContext, workspaceId string) []string {
+	if workspaceId != "" {
+		return []string{workspaceId}
+	}
+	workspaces, _ := svcCtx.WorkspaceModel.Find(ctx, bson.M{}, 1, 100)
+	ids := make([]string, 0, len(workspaces))
+	for _, ws := range workspaces {
+		ids = append(ids, ws.Id.Hex())
+	}
+	return ids
+}
+
+// screenshotHashStats 统计工作空间内所有不同的截图哈希，多个工作空间的相同哈希合并计数
+func screenshotHashStats(ctx context.Context, svcCtx *svc.ServiceContext, workspaceId string) ([]model.ScreenshotHashStatResult, error) {
+	var results []model.ScreenshotHashStatResult
+	index := make(map[string]int)
+	for _, wsId := range workspaceIds(ctx, svcCtx, workspaceId) {
+		stats, err := svcCtx.GetAssetModel(wsId).AggregateScreenshotHash(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range stats {
+			if i, ok := index[s.ScreenshotHash]; ok {
+				results[i].Count += s.Count
+				continue
+			}
+			index[s.ScreenshotHash] = len(results)
+			results = append(results, s)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Count > results[j].Count
+	})
+	return results, nil
+}
+
+// screenshotResolver 解析资产查询语法中的 screenshot_similar 字段
+type screenshotResolver struct {
+	ctx         context.Context
+	svcCtx      *svc.ServiceContext
+	workspaceId string
+}
+
+func newScreenshotResolver(ctx context.Context, svcCtx *svc.ServiceContext, workspaceId string) *screenshotResolver {
+	return &screenshotResolver{ctx: ctx, svcCtx: svcCtx, workspaceId: workspaceId}
+}
+
+// SimilarScreenshots 返回与指定资产截图的汉明距离不超过默认阈值的所有截图哈希
+func (r *screenshotResolver) SimilarScreenshots(assetId string) ([]string, error) {
+	var asset *model.Asset
+	for _, wsId := range workspaceIds(r.ctx, r.svcCtx, r.workspaceId) {
+		if a, err := r.svcCtx.GetAssetModel(wsId).FindById(r.ctx, assetId); err == nil {
+			asset = a
+			break
+		}
+	}
+	if asset == nil {
+		return nil, fmt.Errorf("资产 %s 不存在", assetId)
+	}
+	target, err := imghash.Parse(asset.ScreenshotHash)
+	if err != nil {
+		return nil, fmt.Errorf("资产 %s 没有截图哈希", assetId)
+	}
+
+	stats, err := screenshotHashStats(r.ctx, r.svcCtx, r.workspaceId)
+	if err != nil {
+		return nil, fmt.Errorf("查询截图哈希失败: %v", err)
+	}
+	hashes := []string{asset.ScreenshotHash}
+	for _, s := range stats {
+		h, err := imghash.Parse(s.ScreenshotHash)
+		if err != nil || s.ScreenshotHash == asset.ScreenshotHash {
+			continue
+		}
+		if imghash.Distance(target, h) <= imghash.DefaultThreshold {
+			hashes = append(hashes, s.ScreenshotHash)
+		}
+	}
+	return hashes, nil
+}
+
+// ScreenshotClusterLogic 按截图外观相似度聚类资产
+type ScreenshotClusterLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewScreenshotClusterLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ScreenshotClusterLogic {
+	return &ScreenshotClusterLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// ScreenshotCluster 先按哈希精确分组，再按数量从多到少贪心合并：
+// 每个哈希归入第一个代表哈希与其距离不超过阈值的类，否则成为新类的代表
+func (l *ScreenshotClusterLogic) ScreenshotCluster(req *types.ScreenshotClusterReq, workspaceId string) (resp *types.ScreenshotClusterResp, err error) {
+	if req.Threshold < 0 || req.Threshold > 32 {
+		return &types.ScreenshotClusterResp{Code: 400, Msg: "阈值范围为0-32"}, nil
+	}
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+
+	stats, err := screenshotHashStats(l.ctx, l.svcCtx, workspaceId)
+	if err != nil {
+		l.Logger.Errorf("Aggregate screenshot hash failed: %v", err)
+		return &types.ScreenshotClusterResp{Code: 500, Msg: "查询失败"}, nil
+	}
+
+	type cluster struct {
+		hash uint64
+		item *types.ScreenshotClusterItem
+	}
+	var clusters []cluster
+	for _, s := range stats {
+		h, err := imghash.Parse(s.ScreenshotHash)
+		if err != nil {
+			continue
+		}
+		merged := false
+		for _, c := range clusters {
+			if imghash.Distance(c.hash, h) <= req.Threshold {
+				c.item.Hashes = append(c.item.Hashes, s.ScreenshotHash)
+				c.item.Count += s.Count
+				merged = true
+				break
+			}
+		}
+		if merged {
+			continue
+		}
+		clusters = append(clusters, cluster{hash: h, item: &types.ScreenshotClusterItem{
+			ScreenshotHash: s.ScreenshotHash,
+			ScreenshotUrl:  blobUrl(s.ScreenshotRef),
+			AssetId:        s.AssetId.Hex(),
+			Host:           s.Host,
+			Port:           s.Port,
+			Title:          s.Title,
+			Hashes:         []string{s.ScreenshotHash},
+			Count:          s.Count,
+		}})
+	}
+
+	list := make([]types.ScreenshotClusterItem, 0, len(clusters))
+	for _, c := range clusters {
+		if c.item.Count >= req.MinCount {
+			list = append(list, *c.item)
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Count > list[j].Count
+	})
+	if len(list) > req.Limit {
+		list = list[:req.Limit]
+	}
+
+	return &types.ScreenshotClusterResp{
+		Code: 0,
+		Msg:  "success",
+		List: list,
+	}, nil
+}
@@ -0,0 +1,242 @@
+package logic
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"cscan/api/internal/svc"
+	"cscan/api/internal/types"
+	"cscan/model"
+	"cscan/pkg/risk"
+	"cscan/pkg/secret"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SecretRuleLogic 敏感信息检测规则管理
+type SecretRuleLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSecretRuleLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SecretRuleLogic {
+	return &SecretRuleLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// SecretRuleList 规则列表
+func (l *SecretRuleLogic) SecretRuleList(req *types.SecretRuleListReq) (resp *types.SecretRuleListResp, err error) {
+	filter := bson.M{}
+	if req.Category != "" {
+		filter["category"] = req.Category
+	}
+	if req.Keyword != "" {
+		filter["name"] = bson.M{"$regex": regexp.QuoteMeta(req.Keyword), "$options": "i"}
+	}
+	if req.IsBuiltin != nil {
+		filter["is_builtin"] = *req.IsBuiltin
+	}
+
+	total, err := l.svcCtx.SecretRuleModel.Count(l.ctx, filter)
+	if err != nil {
+		return &types.SecretRuleListResp{Code: 500, Msg: "查询失败"}, nil
+	}
+	docs, err := l.svcCtx.SecretRuleModel.Find(l.ctx, filter, req.Page, req.PageSize)
+	if err != nil {
+		return &types.SecretRuleListResp{Code: 500, Msg: "查询失败"}, nil
+	}
+
+	list := make([]types.SecretRule, 0, len(docs))
+	for _, doc := range docs {
+		list = append(list, types.SecretRule{
+			Id:          doc.Id.Hex(),
+			Name:        doc.Name,
+			Category:    doc.Category,
+			Severity:    doc.Severity,
+			Pattern:     doc.Pattern,
+			MinEntropy:  doc.MinEntropy,
+			Validator:   doc.Validator,
+			Description: doc.Description,
+			IsBuiltin:   doc.IsBuiltin,
+			Enabled:     doc.Enabled,
+			CreateTime:  doc.CreateTime.Local().Format("2006-01-02 15:04:05"),
+			UpdateTime:  doc.UpdateTime.Local().Format("2006-01-02 15:04:05"),
+		})
+	}
+	return &types.SecretRuleListResp{
+		Code:       0,
+		Msg:        "success",
+		Total:      int(total),
+		List:       list,
+		Validators: secret.Validators,
+	}, nil
+}
+
+// SecretRuleSave 新增或更新自定义规则，内置规则只能启用或禁用
+func (l *SecretRuleLogic) SecretRuleSave(req *types.SecretRuleSaveReq) (resp *types.BaseResp, err error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return &types.BaseResp{Code: 400, Msg: "规则名称不能为空"}, nil
+	}
+	severity := strings.ToLower(req.Severity)
+	if _, ok := risk.SeverityWeight[severity]; !ok {
+		return &types.BaseResp{Code: 400, Msg: "无效的严重级别: " + req.Severity}, nil
+	}
+	if _, err := secret.Compile(secret.Rule{Pattern: req.Pattern, Validator: req.Validator}); err != nil {
+		return &types.BaseResp{Code: 400, Msg: "规则无效: " + err.Error()}, nil
+	}
+
+	if req.Id == "" {
+		err = l.svcCtx.SecretRuleModel.Insert(l.ctx, &model.SecretRule{
+			Name:        name,
+			Category:    req.Category,
+			Severity:    severity,
+			Pattern:     req.Pattern,
+			MinEntropy:  req.MinEntropy,
+			Validator:   req.Validator,
+			Description: req.Description,
+			Enabled:     req.Enabled,
+		})
+	} else {
+		existing, findErr := l.svcCtx.SecretRuleModel.FindById(l.ctx, req.Id)
+		if findErr != nil {
+			return &types.BaseResp{Code: 404, Msg: "规则不存在"}, nil
+		}
+		if existing.IsBuiltin {
+			return &types.BaseResp{Code: 400, Msg: "内置规则不能修改，可禁用后新建自定义规则"}, nil
+		}
+		err = l.svcCtx.SecretRuleModel.Update(l.ctx, req.Id, bson.M{
+			"name":        name,
+			"category":    req.Category,
+			"severity":    severity,
+			"pattern":     req.Pattern,
+			"min_entropy": req.MinEntropy,
+			"validator":   req.Validator,
+			"description": req.Description,
+			"enabled":     req.Enabled,
+		})
+	}
+	if mongo.IsDuplicateKeyError(err) {
+		return &types.BaseResp{Code: 400, Msg: "规则名称已存在"}, nil
+	}
+	if err != nil {
+		l.Errorf("SecretRuleSave: %v", err)
+		return &types.BaseResp{Code: 500, Msg: "保存失败: " + err.Error()}, nil
+	}
+	return &types.BaseResp{Code: 0, Msg: "保存成功"}, nil
+}
+
+// SecretRuleDelete 删除自定义规则
+func (l *SecretRuleLogic) SecretRuleDelete(req *types.SecretRuleDeleteReq) (resp *types.BaseResp, err error) {
+	existing, err := l.svcCtx.SecretRuleModel.FindById(l.ctx, req.Id)
+	if err != nil {
+		return &types.BaseResp{Code: 404, Msg: "规则不存在"}, nil
+	}
+	if existing.IsBuiltin {
+		return &types.BaseResp{Code: 400, Msg: "内置规则不能删除，可以禁用"}, nil
+	}
+	if err := l.svcCtx.SecretRuleModel.Delete(l.ctx, req.Id); err != nil {
+		return &types.BaseResp{Code: 500, Msg: "删除失败: " + err.Error()}, nil
+	}
+	return &types.BaseResp{Code: 0, Msg: "删除成功"}, nil
+}
+
+// SecretRuleUpdateEnabled 启用或禁用规则
+func (l *SecretRuleLogic) SecretRuleUpdateEnabled(req *types.SecretRuleUpdateEnabledReq) (resp *types.BaseResp, err error) {
+	if err := l.svcCtx.SecretRuleModel.Update(l.ctx, req.Id, bson.M{"enabled": req.Enabled}); err != nil {
+		return &types.BaseResp{Code: 500, Msg: "更新失败"}, nil
+	}
+	return &types.BaseResp{Code: 0, Msg: "更新成功"}, nil
+}
+
+// SecretListLogic 敏感信息检测结果列表
+type SecretListLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSecretListLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SecretListLogic {
+	return &SecretListLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *SecretListLogic) SecretList(req *types.SecretListReq, workspaceId string) (resp *types.SecretListResp, err error) {
+	findingModel := l.svcCtx.GetSecretFindingModel(workspaceId)
+
+	filter := bson.M{}
+	if req.Keyword != "" {
+		filter["url"] = bson.M{"$regex": regexp.QuoteMeta(req.Keyword), "$options": "i"}
+	}
+	if req.Authority != "" {
+		filter["authority"] = req.Authority
+	}
+	if req.Rule != "" {
+		filter["rule"] = req.Rule
+	}
+	if req.Category != "" {
+		filter["category"] = req.Category
+	}
+	if req.Severity != "" {
+		filter["severity"] = strings.ToLower(req.Severity)
+	}
+	if req.Location != "" {
+		filter["location"] = req.Location
+	}
+	if req.TaskId != "" {
+		filter["taskId"] = req.TaskId
+	}
+
+	total, err := findingModel.Count(l.ctx, filter)
+	if err != nil {
+		return &types.SecretListResp{Code: 500, Msg: "查询失败"}, nil
+	}
+
+	docs, err := findingModel.Find(l.ctx, filter, req.Page, req.PageSize)
+	if err != nil {
+		return &types.SecretListResp{Code: 500, Msg: "查询失败"}, nil
+	}
+
+	stat, err := findingModel.SeverityStats(l.ctx, filter)
+	if err != nil {
+		l.Errorf("SecretList: severity stats failed: %v", err)
+		stat = map[string]int{}
+	}
+
+	list := make([]types.SecretItem, 0, len(docs))
+	for _, doc := range docs {
+		list = append(list, types.SecretItem{
+			Id:         doc.Id.Hex(),
+			Authority:  doc.Authority,
+			Host:       doc.Host,
+			Port:       doc.Port,
+			Url:        doc.Url,
+			Location:   doc.Location,
+			Rule:       doc.Rule,
+			Category:   doc.Category,
+			Severity:   doc.Severity,
+			Evidence:   doc.Evidence,
+			TaskId:     doc.TaskId,
+			CreateTime: doc.CreateTime.Local().Format("2006-01-02 15:04:05"),
+			UpdateTime: doc.UpdateTime.Local().Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return &types.SecretListResp{
+		Code:  0,
+		Msg:   "success",
+		Total: int(total),
+		List:  list,
+		Stat:  stat,
+	}, nil
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 
 	"cscan/api/internal/logic/common"
+	"cscan/api/internal/middleware"
 	"cscan/api/internal/svc"
 	"cscan/api/internal/types"
 	"cscan/model"
+	"cscan/pkg/query"
 
 	"go.mongodb.org/mongo-driver/bson"
 )
@@ -29,11 +31,17 @@ func NewSiteLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SiteLogic {
 func (l *SiteLogic) SiteList(req *types.SiteListReq, workspaceId string) (*types.SiteListResp, error) {
 	resp := &types.SiteListResp{Code: 0, List: []types.Site{}}
 
-	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, workspaceId)
+	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, workspaceId, middleware.PermView)
 	if len(workspaceIds) == 0 {
 		return resp, nil
 	}
 
+	// 语法查询
+	queryFilter, err := query.Compile(req.Query)
+	if err != nil {
+		return &types.SiteListResp{Code: 400, Msg: "查询语法错误: " + err.Error()}, nil
+	}
+
 	orgMap := common.LoadOrgMap(l.ctx, l.svcCtx)
 
 	var allSites []types.Site
@@ -50,6 +58,7 @@ func (l *SiteLogic) SiteList(req *types.SiteListReq, workspaceId string) (*types
 				{"service": bson.M{"$in": []string{"http", "https", "http-proxy", "https-alt"}}},
 				{"title": bson.M{"$exists": true, "$ne": ""}},
 				{"screenshot": bson.M{"$exists": true, "$ne": ""}},
+				{"screenshot_ref": bson.M{"$exists": true, "$ne": ""}},
 				{"port": bson.M{"$in": []int{80, 443, 8080, 8443, 8000, 8888, 9000, 3000, 5000}}},
 			},
 		}
@@ -78,6 +87,9 @@ func (l *SiteLogic) SiteList(req *types.SiteListReq, workspaceId string) (*types
 		if req.OrgId != "" {
 			conditions = append(conditions, bson.M{"org_id": req.OrgId})
 		}
+		if len(queryFilter) > 0 {
+			conditions = append(conditions, queryFilter)
+		}
 
 		if len(conditions) > 1 {
 			filter["$and"] = conditions
@@ -97,17 +109,18 @@ func (l *SiteLogic) SiteList(req *types.SiteListReq, workspaceId string) (*types
 
 		for _, asset := range assets {
 			site := types.Site{
-				Id:         asset.Id.Hex(),
-				Title:      asset.Title,
-				IP:         asset.Host,
-				Port:       asset.Port,
-				Service:    asset.Service,
-				HttpStatus: asset.HttpStatus,
-				App:        asset.App,
-				Screenshot: asset.Screenshot,
-				OrgId:      asset.OrgId,
-				HttpHeader: asset.HttpHeader,
-				IconHash:   asset.IconHash,
+				Id:            asset.Id.Hex(),
+				Title:         asset.Title,
+				IP:            asset.Host,
+				Port:          asset.Port,
+				Service:       asset.Service,
+				HttpStatus:    asset.HttpStatus,
+				App:           asset.App,
+				Screenshot:    asset.Screenshot,
+				ScreenshotUrl: blobUrl(asset.ScreenshotRef),
+				OrgId:         asset.OrgId,
+				HttpHeader:    asset.HttpHeader,
+				IconHash:      asset.IconHash,
 			}
 
 			// 构建站点URL
@@ -145,7 +158,7 @@ func (l *SiteLogic) SiteList(req *types.SiteListReq, workspaceId string) (*types
 func (l *SiteLogic) SiteStat(workspaceId string) (*types.SiteStatResp, error) {
 	resp := &types.SiteStatResp{Code: 0}
 
-	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, workspaceId)
+	workspaceIds := common.GetWorkspaceIds(l.ctx, l.svcCtx, workspaceId, middleware.PermView)
 	if len(workspaceIds) == 0 {
 		return resp, nil
 	}
@@ -160,6 +173,7 @@ func (l *SiteLogic) SiteStat(workspaceId string) (*types.SiteStatResp, error) {
 				{"service": bson.M{"$in": []string{"http", "https"}}},
 				{"title": bson.M{"$exists": true, "$ne": ""}},
 				{"screenshot": bson.M{"$exists": true, "$ne": ""}},
+				{"screenshot_ref": bson.M{"$exists": true, "$ne": ""}},
 			},
 		}
 
@@ -207,6 +207,8 @@ func (l *MainTaskListLogic) MainTaskList(req *types.MainTaskListReq, workspaceId
 			EndTime:      endTime,
 			SubTaskCount: t.SubTaskCount,
 			SubTaskDone:  subTaskDone,
+			NotifyId:     t.NotifyId,
+			NewSubdomains: t.NewSubdomains,
 		})
 	}
 
@@ -302,6 +304,7 @@ func (l *MainTaskCreateLogic) MainTaskCreate(req *types.MainTaskCreateReq, works
 		OrgId:       req.OrgId,
 		IsCron:      req.IsCron,
 		CronRule:    req.CronRule,
+		NotifyId:    req.NotifyId,
 		Config:      string(configBytes),
 	}
 
@@ -566,6 +569,7 @@ func (l *MainTaskRetryLogic) MainTaskRetry(req *types.MainTaskRetryReq, workspac
 		ProfileId:   oldTask.ProfileId,
 		ProfileName: oldTask.ProfileName,
 		OrgId:       oldTask.OrgId,
+		NotifyId:    oldTask.NotifyId,
 		Config:      string(configBytes),
 	}
 
@@ -589,8 +593,10 @@ func (l *MainTaskRetryLogic) MainTaskRetry(req *types.MainTaskRetryReq, workspac
 	// 更新新任务状态为PENDING，记录子任务数量
 	taskModel.Update(l.ctx, newTask.Id.Hex(), bson.M{
 		"status":         model.TaskStatusPending,
-		"sub_task_count": len(batches),
-		"sub_task_done":  0,
+		"sub_task_count":  len(batches),
+		"sub_task_done":   0,
+		"sub_task_failed": 0,
+		"new_subdomains":  []string{},
 	})
 
 	// 保存主任务信息到 Redis
@@ -721,8 +727,9 @@ func (l *MainTaskStartLogic) MainTaskStart(req *types.MainTaskControlReq, worksp
 	// 更新主任务状态为PENDING，记录子任务数量
 	update := bson.M{
 		"status":      model.TaskStatusPending,
-		"sub_task_count": len(batches),
-		"sub_task_done":  0,
+		"sub_task_count":  len(batches),
+		"sub_task_done":   0,
+		"sub_task_failed": 0,
 	}
 	if err := taskModel.Update(l.ctx, req.Id, update); err != nil {
 		return &types.BaseResp{Code: 500, Msg: "更新任务状态失败"}, nil
@@ -1079,6 +1086,10 @@ func (l *MainTaskUpdateLogic) MainTaskUpdate(req *types.MainTaskUpdateReq, works
 		update["target"] = req.Target
 	}
 
+	if req.NotifyId != "" {
+		update["notify_id"] = req.NotifyId
+	}
+
 	if req.ProfileId != "" {
 		// 验证配置是否存在
 		profile, err := l.svcCtx.ProfileModel.FindById(l.ctx, req.ProfileId)
@@ -1249,3 +1260,85 @@ func getMainTaskIdFromLog(taskId string) string {
 	}
 	return taskId
 }
+
+// TaskLeaseListLogic 任务租约列表逻辑
+type TaskLeaseListLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewTaskLeaseListLogic(ctx context.Context, svcCtx *svc.ServiceContext) *TaskLeaseListLogic {
+	return &TaskLeaseListLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// TaskLeaseList 返回子任务当前由哪个Worker持有，以及重试次数用尽进入死信的子任务
+func (l *TaskLeaseListLogic) TaskLeaseList(req *types.TaskLeaseListReq) (*types.TaskLeaseListResp, error) {
+	if req.TaskId == "" {
+		return &types.TaskLeaseListResp{Code: 400, Msg: "任务ID不能为空", List: []types.TaskLease{}}, nil
+	}
+
+	leases, err := l.svcCtx.TaskLeases.List(l.ctx, req.TaskId)
+	if err != nil {
+		l.Logger.Errorf("TaskLeaseList: list leases failed, taskId=%s: %v", req.TaskId, err)
+		return &types.TaskLeaseListResp{Code: 500, Msg: "查询租约失败", List: []types.TaskLease{}}, nil
+	}
+	deadLetters, err := l.svcCtx.TaskLeases.ListDeadLetters(l.ctx, req.TaskId)
+	if err != nil {
+		l.Logger.Errorf("TaskLeaseList: list dead letters failed, taskId=%s: %v", req.TaskId, err)
+	}
+
+	now := time.Now().Unix()
+	list := make([]types.TaskLease, 0, len(leases)+len(deadLetters))
+	for _, lease := range leases {
+		state := scheduler.LeaseStateLeased
+		if lease.ExpireTime <= now {
+			state = "expired"
+		}
+		list = append(list, types.TaskLease{
+			TaskId:     lease.TaskId,
+			Worker:     lease.Worker,
+			State:      state,
+			Retry:      lease.Retry,
+			AssignTime: formatUnixTime(lease.AssignTime),
+			RenewTime:  formatUnixTime(lease.RenewTime),
+			ExpireTime: formatUnixTime(lease.ExpireTime),
+		})
+	}
+	for _, letter := range deadLetters {
+		list = append(list, types.TaskLease{
+			TaskId:     letter.TaskId,
+			Worker:     letter.Worker,
+			State:      scheduler.LeaseStateDeadLetter,
+			Retry:      letter.Retry,
+			ExpireTime: formatUnixTime(letter.Time),
+			Reason:     letter.Reason,
+		})
+	}
+	// 没有满足要求的在线Worker、一直无法被领取的排队任务
+	unschedulable, err := scheduler.Unschedulable(l.ctx, l.svcCtx.RedisClient, req.TaskId)
+	if err != nil {
+		l.Logger.Errorf("TaskLeaseList: list unschedulable tasks failed, taskId=%s: %v", req.TaskId, err)
+	}
+	for _, task := range unschedulable {
+		list = append(list, types.TaskLease{
+			TaskId: task.TaskId,
+			State:  scheduler.QueueStateUnschedulable,
+			Reason: "没有满足要求的在线Worker: " + task.Selector,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].TaskId < list[j].TaskId })
+
+	return &types.TaskLeaseListResp{Code: 0, Msg: "success", List: list}, nil
+}
+
+func formatUnixTime(ts int64) string {
+	if ts <= 0 {
+		return ""
+	}
+	return time.Unix(ts, 0).Local().Format("2006-01-02 15:04:05")
+}
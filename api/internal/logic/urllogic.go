@@ -0,0 +1,97 @@
+package logic
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"cscan/api/internal/svc"
+	"cscan/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// UrlListLogic 爬虫发现的URL列表
+type UrlListLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUrlListLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UrlListLogic {
+	return &UrlListLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *UrlListLogic) UrlList(req *types.UrlListReq, workspaceId string) (resp *types.UrlListResp, err error) {
+	urlModel := l.svcCtx.GetUrlModel(workspaceId)
+
+	filter := bson.M{}
+	if req.Keyword != "" {
+		filter["url"] = bson.M{"$regex": regexp.QuoteMeta(req.Keyword), "$options": "i"}
+	}
+	if req.Authority != "" {
+		filter["authority"] = req.Authority
+	}
+	if req.Source != "" {
+		filter["source"] = req.Source
+	}
+	if req.Method != "" {
+		filter["method"] = strings.ToUpper(req.Method)
+	}
+	if req.HasParams {
+		filter["params.0"] = bson.M{"$exists": true}
+	}
+	if req.TaskId != "" {
+		filter["taskId"] = req.TaskId
+	}
+
+	total, err := urlModel.Count(l.ctx, filter)
+	if err != nil {
+		return &types.UrlListResp{Code: 500, Msg: "查询失败"}, nil
+	}
+
+	urls, err := urlModel.Find(l.ctx, filter, req.Page, req.PageSize)
+	if err != nil {
+		return &types.UrlListResp{Code: 500, Msg: "查询失败"}, nil
+	}
+
+	list := make([]types.UrlItem, 0, len(urls))
+	for _, u := range urls {
+		params := u.Params
+		if params == nil {
+			params = []string{}
+		}
+		list = append(list, types.UrlItem{
+			Id:          u.Id.Hex(),
+			Authority:   u.Authority,
+			Host:        u.Host,
+			Port:        u.Port,
+			Url:         u.Url,
+			Endpoint:    u.Endpoint,
+			Method:      u.Method,
+			Path:        u.Path,
+			Params:      params,
+			Source:      u.Source,
+			StatusCode:  u.StatusCode,
+			ContentType: u.ContentType,
+			Length:      u.Length,
+			Title:       u.Title,
+			Referer:     u.Referer,
+			TaskId:      u.TaskId,
+			CreateTime:  u.CreateTime.Local().Format("2006-01-02 15:04:05"),
+			UpdateTime:  u.UpdateTime.Local().Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return &types.UrlListResp{
+		Code:  0,
+		Msg:   "success",
+		Total: int(total),
+		List:  list,
+	}, nil
+}
@@ -3,6 +3,7 @@ package logic
 import (
 	"context"
 	"net/http"
+	"sort"
 	"time"
 
 	"cscan/api/internal/middleware"
@@ -44,9 +45,11 @@ func (l *UserListLogic) UserList(req *types.PageReq) (resp *types.UserListResp,
 	list := make([]types.UserInfo, 0, len(users))
 	for _, u := range users {
 		list = append(list, types.UserInfo{
-			Id:       u.Id.Hex(),
-			Username: u.Username,
-			Status:   u.Status,
+			Id:             u.Id.Hex(),
+			Username:       u.Username,
+			Status:         u.Status,
+			Role:           u.Role,
+			WorkspaceRoles: toWorkspaceRoles(u.WorkspaceRoles),
 		})
 	}
 
@@ -84,11 +87,26 @@ func (l *UserCreateLogic) UserCreate(req *types.UserCreateReq) (resp *types.Base
 		return &types.BaseResp{Code: 400, Msg: "用户名已存在"}, nil
 	}
 
+	role := req.Role
+	if role == "" {
+		role = model.RoleViewer
+	}
+	if !model.IsValidRole(role) {
+		return &types.BaseResp{Code: 400, Msg: "无效的角色: " + role}, nil
+	}
+	workspaceRoles, workspaceIds, msg := parseWorkspaceRoles(req.WorkspaceRoles)
+	if msg != "" {
+		return &types.BaseResp{Code: 400, Msg: msg}, nil
+	}
+
 	// 创建用户
 	user := &model.User{
-		Username: req.Username,
-		Password: req.Password, // 在model层会自动MD5加密
-		Status:   req.Status,
+		Username:       req.Username,
+		Password:       req.Password, // 在model层会自动bcrypt加密
+		Status:         req.Status,
+		Role:           role,
+		WorkspaceRoles: workspaceRoles,
+		WorkspaceIds:   workspaceIds,
 	}
 
 	err = l.svcCtx.UserModel.Insert(l.ctx, user)
@@ -154,6 +172,88 @@ func (l *UserUpdateLogic) UserUpdate(req *types.UserUpdateReq) (resp *types.Base
 	return &types.BaseResp{Code: 0, Msg: "更新成功"}, nil
 }
 
+// UserSetRolesLogic 设置用户角色逻辑
+type UserSetRolesLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUserSetRolesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UserSetRolesLogic {
+	return &UserSetRolesLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *UserSetRolesLogic) UserSetRoles(req *types.UserSetRolesReq) (resp *types.BaseResp, err error) {
+	user, err := l.svcCtx.UserModel.FindById(l.ctx, req.Id)
+	if err != nil {
+		logx.Errorf("查询用户失败: %v", err)
+		return &types.BaseResp{Code: 500, Msg: "系统错误"}, nil
+	}
+	if user == nil {
+		return &types.BaseResp{Code: 404, Msg: "用户不存在"}, nil
+	}
+
+	if req.Role != "" && !model.IsValidRole(req.Role) {
+		return &types.BaseResp{Code: 400, Msg: "无效的角色: " + req.Role}, nil
+	}
+	// 防止管理员移除自己的管理员角色导致无人可以管理系统
+	if req.Id == middleware.GetUserId(l.ctx) && req.Role != model.RoleAdmin {
+		return &types.BaseResp{Code: 400, Msg: "不能移除自己的管理员角色"}, nil
+	}
+	workspaceRoles, workspaceIds, msg := parseWorkspaceRoles(req.WorkspaceRoles)
+	if msg != "" {
+		return &types.BaseResp{Code: 400, Msg: msg}, nil
+	}
+
+	err = l.svcCtx.UserModel.UpdateById(l.ctx, req.Id, bson.M{
+		"role":            req.Role,
+		"workspace_roles": workspaceRoles,
+		"workspace_ids":   workspaceIds,
+		"update_time":     time.Now(),
+	})
+	if err != nil {
+		logx.Errorf("设置用户角色失败: %v", err)
+		return &types.BaseResp{Code: 500, Msg: "设置角色失败"}, nil
+	}
+
+	return &types.BaseResp{Code: 0, Msg: "设置成功"}, nil
+}
+
+// parseWorkspaceRoles 校验工作空间角色，返回角色映射和工作空间ID列表
+func parseWorkspaceRoles(items []types.WorkspaceRole) (map[string]string, []string, string) {
+	roles := make(map[string]string, len(items))
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.WorkspaceId == "" || item.WorkspaceId == "all" {
+			return nil, nil, "无效的工作空间ID"
+		}
+		if !model.IsValidRole(item.Role) {
+			return nil, nil, "无效的角色: " + item.Role
+		}
+		if _, ok := roles[item.WorkspaceId]; !ok {
+			ids = append(ids, item.WorkspaceId)
+		}
+		roles[item.WorkspaceId] = item.Role
+	}
+	return roles, ids, ""
+}
+
+// toWorkspaceRoles 将角色映射转换为列表，按工作空间ID排序
+func toWorkspaceRoles(roles map[string]string) []types.WorkspaceRole {
+	list := make([]types.WorkspaceRole, 0, len(roles))
+	for wsId, role := range roles {
+		list = append(list, types.WorkspaceRole{WorkspaceId: wsId, Role: role})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].WorkspaceId < list[j].WorkspaceId
+	})
+	return list
+}
+
 // UserDeleteLogic 删除用户逻辑
 type UserDeleteLogic struct {
 	logx.Logger
@@ -192,6 +292,11 @@ func (l *UserDeleteLogic) UserDelete(req *types.UserDeleteReq) (resp *types.Base
 		return &types.BaseResp{Code: 500, Msg: "删除用户失败"}, nil
 	}
 
+	// 吊销该用户的API令牌
+	if err := l.svcCtx.ApiTokenModel.RevokeByUser(l.ctx, req.Id); err != nil {
+		logx.Errorf("吊销用户令牌失败: %v", err)
+	}
+
 	return &types.BaseResp{Code: 0, Msg: "删除成功"}, nil
 }
 
@@ -0,0 +1,164 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"cscan/api/internal/svc"
+	"cscan/api/internal/types"
+	"cscan/model"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// maxWordlistEntries 单个字典的条目数上限，避免超过MongoDB文档大小限制
+const maxWordlistEntries = 200000
+
+// WordlistLogic 爆破字典管理
+type WordlistLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewWordlistLogic(ctx context.Context, svcCtx *svc.ServiceContext) *WordlistLogic {
+	return &WordlistLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// WordlistList 字典列表，不返回字典内容
+func (l *WordlistLogic) WordlistList(req *types.WordlistListReq) (resp *types.WordlistListResp, err error) {
+	filter := bson.M{}
+	if req.Type != "" {
+		filter["type"] = req.Type
+	}
+	if req.Keyword != "" {
+		filter["name"] = bson.M{"$regex": regexp.QuoteMeta(req.Keyword), "$options": "i"}
+	}
+
+	total, err := l.svcCtx.WordlistModel.Count(l.ctx, filter)
+	if err != nil {
+		return &types.WordlistListResp{Code: 500, Msg: "查询失败"}, nil
+	}
+	docs, err := l.svcCtx.WordlistModel.Find(l.ctx, filter, req.Page, req.PageSize)
+	if err != nil {
+		return &types.WordlistListResp{Code: 500, Msg: "查询失败"}, nil
+	}
+
+	list := make([]types.Wordlist, 0, len(docs))
+	for _, doc := range docs {
+		list = append(list, toWordlistType(&doc))
+	}
+	return &types.WordlistListResp{Code: 0, Msg: "success", Total: int(total), List: list}, nil
+}
+
+// WordlistSave 新增或更新字典
+func (l *WordlistLogic) WordlistSave(req *types.WordlistSaveReq) (resp *types.BaseResp, err error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return &types.BaseResp{Code: 400, Msg: "字典名称不能为空"}, nil
+	}
+	wordlistType := req.Type
+	if wordlistType == "" {
+		wordlistType = model.WordlistTypeDir
+	}
+	if wordlistType != model.WordlistTypeDir && wordlistType != model.WordlistTypeSubdomain {
+		return &types.BaseResp{Code: 400, Msg: "不支持的字典类型: " + wordlistType}, nil
+	}
+	words := parseWordlistContent(req.Content)
+	if len(words) > maxWordlistEntries {
+		return &types.BaseResp{Code: 400, Msg: fmt.Sprintf("字典条目数超过上限%d", maxWordlistEntries)}, nil
+	}
+
+	if req.Id == "" {
+		if len(words) == 0 {
+			return &types.BaseResp{Code: 400, Msg: "字典内容不能为空"}, nil
+		}
+		err = l.svcCtx.WordlistModel.Insert(l.ctx, &model.Wordlist{
+			Name:        name,
+			Type:        wordlistType,
+			Description: req.Description,
+			Words:       words,
+			Enabled:     req.Enabled,
+		})
+	} else {
+		update := bson.M{
+			"name":        name,
+			"type":        wordlistType,
+			"description": req.Description,
+			"enabled":     req.Enabled,
+		}
+		// 内容为空表示只修改基本信息
+		if len(words) > 0 {
+			update["words"] = words
+		}
+		err = l.svcCtx.WordlistModel.Update(l.ctx, req.Id, update)
+	}
+	if mongo.IsDuplicateKeyError(err) {
+		return &types.BaseResp{Code: 400, Msg: "同类型字典名称已存在"}, nil
+	}
+	if err != nil {
+		l.Errorf("WordlistSave: %v", err)
+		return &types.BaseResp{Code: 500, Msg: "保存失败: " + err.Error()}, nil
+	}
+	return &types.BaseResp{Code: 0, Msg: fmt.Sprintf("保存成功，共%d条", len(words))}, nil
+}
+
+// WordlistDelete 删除字典
+func (l *WordlistLogic) WordlistDelete(req *types.WordlistDeleteReq) (resp *types.BaseResp, err error) {
+	if err := l.svcCtx.WordlistModel.Delete(l.ctx, req.Id); err != nil {
+		return &types.BaseResp{Code: 500, Msg: "删除失败: " + err.Error()}, nil
+	}
+	return &types.BaseResp{Code: 0, Msg: "删除成功"}, nil
+}
+
+// WordlistDetail 字典详情，包含字典内容
+func (l *WordlistLogic) WordlistDetail(req *types.WordlistDetailReq) (resp *types.WordlistDetailResp, err error) {
+	doc, err := l.svcCtx.WordlistModel.FindById(l.ctx, req.Id)
+	if err != nil {
+		return &types.WordlistDetailResp{Code: 404, Msg: "字典不存在"}, nil
+	}
+	data := toWordlistType(doc)
+	return &types.WordlistDetailResp{
+		Code:    0,
+		Msg:     "success",
+		Data:    &data,
+		Content: strings.Join(doc.Words, "\n"),
+	}, nil
+}
+
+func toWordlistType(doc *model.Wordlist) types.Wordlist {
+	return types.Wordlist{
+		Id:          doc.Id.Hex(),
+		Name:        doc.Name,
+		Type:        doc.Type,
+		Description: doc.Description,
+		Count:       doc.Count,
+		Enabled:     doc.Enabled,
+		CreateTime:  doc.CreateTime.Local().Format("2006-01-02 15:04:05"),
+		UpdateTime:  doc.UpdateTime.Local().Format("2006-01-02 15:04:05"),
+	}
+}
+
+// parseWordlistContent 按行解析字典内容，去掉空行和#注释并去重
+func parseWordlistContent(content string) []string {
+	lines := strings.Split(content, "\n")
+	seen := make(map[string]bool, len(lines))
+	words := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || seen[line] {
+			continue
+		}
+		seen[line] = true
+		words = append(words, line)
+	}
+	return words
+}
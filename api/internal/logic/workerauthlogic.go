@@ -0,0 +1,161 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"cscan/api/internal/middleware"
+	"cscan/api/internal/svc"
+	"cscan/api/internal/types"
+	"cscan/model"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// WorkerAuthLogic Worker注册令牌和凭证管理
+type WorkerAuthLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewWorkerAuthLogic(ctx context.Context, svcCtx *svc.ServiceContext) *WorkerAuthLogic {
+	return &WorkerAuthLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// EnrollTokenList 注册令牌列表
+func (l *WorkerAuthLogic) EnrollTokenList() (*types.WorkerEnrollTokenListResp, error) {
+	tokens, err := l.svcCtx.WorkerEnrollTokenModel.FindAll(l.ctx)
+	if err != nil {
+		return &types.WorkerEnrollTokenListResp{Code: 500, Msg: "查询失败"}, nil
+	}
+
+	list := make([]types.WorkerEnrollTokenItem, 0, len(tokens))
+	for _, t := range tokens {
+		item := types.WorkerEnrollTokenItem{
+			Id:         t.Id.Hex(),
+			Name:       t.Name,
+			Prefix:     t.Prefix,
+			MaxUses:    t.MaxUses,
+			UsedCount:  t.UsedCount,
+			CreatedBy:  t.CreatedBy,
+			Status:     "active",
+			CreateTime: t.CreateTime.Local().Format("2006-01-02 15:04:05"),
+		}
+		if t.ExpireTime != nil {
+			item.ExpireTime = t.ExpireTime.Local().Format("2006-01-02 15:04:05")
+		}
+		switch {
+		case t.Revoked:
+			item.Status = "revoked"
+		case t.Expired():
+			item.Status = "expired"
+		case t.Exhausted():
+			item.Status = "exhausted"
+		}
+		list = append(list, item)
+	}
+
+	return &types.WorkerEnrollTokenListResp{Code: 0, Msg: "success", List: list}, nil
+}
+
+// EnrollTokenCreate 创建注册令牌，令牌明文只在此时返回
+func (l *WorkerAuthLogic) EnrollTokenCreate(req *types.WorkerEnrollTokenCreateReq) (*types.WorkerEnrollTokenCreateResp, error) {
+	if req.Name == "" {
+		return &types.WorkerEnrollTokenCreateResp{Code: 400, Msg: "令牌名称不能为空"}, nil
+	}
+	if req.MaxUses < 0 || req.ExpireHours < 0 {
+		return &types.WorkerEnrollTokenCreateResp{Code: 400, Msg: "使用次数和有效期不能为负数"}, nil
+	}
+
+	plain, hash, err := model.GenerateWorkerSecret(model.WorkerEnrollTokenPrefix)
+	if err != nil {
+		l.Errorf("Generate worker enroll token failed: %v", err)
+		return &types.WorkerEnrollTokenCreateResp{Code: 500, Msg: "生成令牌失败"}, nil
+	}
+
+	token := &model.WorkerEnrollToken{
+		Name:      req.Name,
+		TokenHash: hash,
+		Prefix:    plain[:len(model.WorkerEnrollTokenPrefix)+6],
+		MaxUses:   req.MaxUses,
+		CreatedBy: middleware.GetUsername(l.ctx),
+	}
+	if req.ExpireHours > 0 {
+		expire := time.Now().Add(time.Duration(req.ExpireHours) * time.Hour)
+		token.ExpireTime = &expire
+	}
+	if err := l.svcCtx.WorkerEnrollTokenModel.Insert(l.ctx, token); err != nil {
+		l.Errorf("Insert worker enroll token failed: %v", err)
+		return &types.WorkerEnrollTokenCreateResp{Code: 500, Msg: "创建令牌失败"}, nil
+	}
+
+	return &types.WorkerEnrollTokenCreateResp{
+		Code:  0,
+		Msg:   "创建成功，请妥善保存令牌，关闭后将无法再次查看",
+		Id:    token.Id.Hex(),
+		Token: plain,
+	}, nil
+}
+
+// EnrollTokenRevoke 吊销注册令牌，已注册Worker的凭证不受影响
+func (l *WorkerAuthLogic) EnrollTokenRevoke(req *types.WorkerAuthRevokeReq) (*types.BaseResp, error) {
+	found, err := l.svcCtx.WorkerEnrollTokenModel.Revoke(l.ctx, req.Id)
+	if err != nil {
+		return &types.BaseResp{Code: 500, Msg: "吊销失败"}, nil
+	}
+	if !found {
+		return &types.BaseResp{Code: 404, Msg: "令牌不存在"}, nil
+	}
+	return &types.BaseResp{Code: 0, Msg: "已吊销"}, nil
+}
+
+// CredentialList Worker凭证列表
+func (l *WorkerAuthLogic) CredentialList() (*types.WorkerCredentialListResp, error) {
+	creds, err := l.svcCtx.WorkerCredentialModel.FindAll(l.ctx)
+	if err != nil {
+		return &types.WorkerCredentialListResp{Code: 500, Msg: "查询失败"}, nil
+	}
+
+	list := make([]types.WorkerCredentialItem, 0, len(creds))
+	for _, c := range creds {
+		item := types.WorkerCredentialItem{
+			Id:         c.Id.Hex(),
+			WorkerName: c.WorkerName,
+			Prefix:     c.Prefix,
+			EnrollIp:   c.EnrollIp,
+			LastSeenIp: c.LastSeenIp,
+			Status:     "active",
+			CreateTime: c.CreateTime.Local().Format("2006-01-02 15:04:05"),
+		}
+		if c.LastSeenTime != nil {
+			item.LastSeenTime = c.LastSeenTime.Local().Format("2006-01-02 15:04:05")
+		}
+		if c.Revoked {
+			item.Status = "revoked"
+			if c.RevokeTime != nil {
+				item.RevokeTime = c.RevokeTime.Local().Format("2006-01-02 15:04:05")
+			}
+		}
+		list = append(list, item)
+	}
+
+	return &types.WorkerCredentialListResp{Code: 0, Msg: "success", List: list}, nil
+}
+
+// CredentialRevoke 吊销Worker凭证，任务服务最多15秒后拒绝该Worker的调用
+func (l *WorkerAuthLogic) CredentialRevoke(req *types.WorkerAuthRevokeReq) (*types.BaseResp, error) {
+	found, err := l.svcCtx.WorkerCredentialModel.Revoke(l.ctx, req.Id)
+	if err != nil {
+		return &types.BaseResp{Code: 500, Msg: "吊销失败"}, nil
+	}
+	if !found {
+		return &types.BaseResp{Code: 404, Msg: "凭证不存在或已吊销"}, nil
+	}
+	l.Infof("Worker credential %s revoked by %s", req.Id, middleware.GetUsername(l.ctx))
+	return &types.BaseResp{Code: 0, Msg: "已吊销"}, nil
+}
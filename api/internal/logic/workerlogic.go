@@ -27,16 +27,18 @@ func NewWorkerListLogic(ctx context.Context, svcCtx *svc.ServiceContext) *Worker
 }
 
 type WorkerStatus struct {
-	WorkerName         string          `json:"workerName"`
-	IP                 string          `json:"ip"`
-	CPULoad            float64         `json:"cpuLoad"`
-	MemUsed            float64         `json:"memUsed"`
-	TaskStartedNumber  int             `json:"taskStartedNumber"`
-	TaskExecutedNumber int             `json:"taskExecutedNumber"`
-	Concurrency        int             `json:"concurrency"`
-	RunningTasks       int             `json:"runningTasks"`
-	UpdateTime         string          `json:"updateTime"`
-	Tools              map[string]bool `json:"tools"`
+	WorkerName         string            `json:"workerName"`
+	IP                 string            `json:"ip"`
+	CPULoad            float64           `json:"cpuLoad"`
+	MemUsed            float64           `json:"memUsed"`
+	TaskStartedNumber  int               `json:"taskStartedNumber"`
+	TaskExecutedNumber int               `json:"taskExecutedNumber"`
+	Concurrency        int               `json:"concurrency"`
+	RunningTasks       int               `json:"runningTasks"`
+	UpdateTime         string            `json:"updateTime"`
+	Tools              map[string]bool   `json:"tools"`
+	Labels             map[string]string `json:"labels"`
+	Capabilities       []string          `json:"capabilities"`
 }
 
 func (l *WorkerListLogic) WorkerList() (resp *types.WorkerListResp, err error) {
@@ -102,6 +104,8 @@ func (l *WorkerListLogic) WorkerList() (resp *types.WorkerListResp, err error) {
 			Status:       workerStatus,
 			UpdateTime:   status.UpdateTime,
 			Tools:        status.Tools,
+			Labels:       status.Labels,
+			Capabilities: status.Capabilities,
 		})
 	}
 
@@ -149,6 +153,13 @@ func (l *WorkerDeleteLogic) WorkerDelete(req *types.WorkerDeleteReq) (resp *type
 
 	l.Logger.Infof("[WorkerDelete] Deleted worker data: %s", req.Name)
 
+	// 4. 吊销Worker凭证，被删除的Worker需要重新注册才能连接
+	if n, err := l.svcCtx.WorkerCredentialModel.RevokeByWorker(l.ctx, req.Name); err != nil {
+		l.Logger.Errorf("[WorkerDelete] Revoke worker credential failed: %v", err)
+	} else if n > 0 {
+		l.Logger.Infof("[WorkerDelete] Revoked %d credentials of worker: %s", n, req.Name)
+	}
+
 	return &types.WorkerDeleteResp{Code: 0, Msg: "Worker已删除，停止信号已发送"}, nil
 }
 
@@ -206,7 +217,12 @@ func (l *WorkerRenameLogic) WorkerRename(req *types.WorkerRenameReq) (resp *type
 	// 5. 删除旧key
 	rdb.Del(l.ctx, oldKey)
 
-	// 6. 发送重命名命令给Worker（让Worker更新自己的名称）
+	// 6. 同步凭证绑定的名称
+	if err := l.svcCtx.WorkerCredentialModel.RenameWorker(l.ctx, req.OldName, req.NewName); err != nil {
+		l.Logger.Errorf("[WorkerRename] Rename worker credential failed: %v", err)
+	}
+
+	// 7. 发送重命名命令给Worker（让Worker更新自己的名称）
 	renameMsg := fmt.Sprintf(`{"action":"rename","workerName":"%s","newName":"%s"}`, req.OldName, req.NewName)
 	rdb.Publish(l.ctx, "cscan:worker:control", renameMsg)
 
@@ -3,12 +3,14 @@ package logic
 import (
 	"context"
 
+	"cscan/api/internal/middleware"
 	"cscan/api/internal/svc"
 	"cscan/api/internal/types"
 	"cscan/model"
 
 	"github.com/zeromicro/go-zero/core/logx"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type WorkspaceListLogic struct {
@@ -28,6 +30,17 @@ func NewWorkspaceListLogic(ctx context.Context, svcCtx *svc.ServiceContext) *Wor
 func (l *WorkspaceListLogic) WorkspaceList(req *types.PageReq) (resp *types.WorkspaceListResp, err error) {
 	filter := bson.M{}
 
+	// 非全局角色的用户只能看到分配了角色的工作空间
+	if accessible := middleware.GetAccessibleWorkspaces(l.ctx); accessible != nil {
+		oids := make([]primitive.ObjectID, 0, len(accessible))
+		for _, id := range accessible {
+			if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+				oids = append(oids, oid)
+			}
+		}
+		filter["_id"] = bson.M{"$in": oids}
+	}
+
 	total, err := l.svcCtx.WorkspaceModel.Count(l.ctx, filter)
 	if err != nil {
 		return &types.WorkspaceListResp{Code: 500, Msg: "查询失败"}, nil
@@ -0,0 +1,221 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"cscan/model"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const (
+	auditSummaryLimit  = 2048 // 请求摘要最大长度
+	auditResponseLimit = 4096 // 解析响应业务码时最多缓存的字节数
+)
+
+// auditReadOnlyRoutes 权限矩阵中非查看权限但不修改数据的路由，不记录审计日志
+var auditReadOnlyRoutes = map[string]bool{
+	"/api/v1/user/list":                 true,
+	"/api/v1/user/loginLog":             true,
+	"/api/v1/user/scanConfig/get":       true,
+	"/api/v1/user/token/list":           true,
+	"/api/v1/workspace/list":            true,
+	"/api/v1/onlineapi/search":          true,
+	"/api/v1/onlineapi/config/list":     true,
+	"/api/v1/fingerprint/validate":      true,
+	"/api/v1/fingerprint/batchValidate": true,
+	"/api/v1/fingerprint/matchAssets":   true,
+	"/api/v1/subfinder/provider/list":   true,
+	"/api/v1/subfinder/provider/info":   true,
+	"/api/v1/notify/list":               true,
+	"/api/v1/audit/list":                true,
+}
+
+// sensitiveKeys 请求摘要中需要脱敏的字段（小写，按包含匹配），另外以key/keys结尾的字段也会脱敏
+var sensitiveKeys = []string{"password", "secret", "token", "webhook"}
+
+var responseCodeRegex = regexp.MustCompile(`^\s*\{\s*"code"\s*:\s*(-?\d+)`)
+
+// NeedAudit 判断路由是否需要记录审计日志：查看类接口不记录
+func NeedAudit(path string, perm Permission) bool {
+	return perm != PermView && !auditReadOnlyRoutes[path]
+}
+
+// AuditMiddleware 审计中间件，记录修改类接口的操作人、参数摘要和结果，需要先经过认证中间件
+type AuditMiddleware struct {
+	AuditLogModel *model.AuditLogModel
+}
+
+func NewAuditMiddleware(auditLogModel *model.AuditLogModel) *AuditMiddleware {
+	return &AuditMiddleware{
+		AuditLogModel: auditLogModel,
+	}
+}
+
+func (m *AuditMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		summary := requestSummary(r)
+
+		rec := &auditRecorder{ResponseWriter: w, status: http.StatusOK}
+		next(rec, r)
+
+		ctx := r.Context()
+		authType := "jwt"
+		if IsApiTokenAuth(ctx) {
+			authType = "token"
+		}
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+
+		doc := &model.AuditLog{
+			UserId:      GetUserId(ctx),
+			Username:    GetUsername(ctx),
+			AuthType:    authType,
+			WorkspaceId: GetWorkspaceId(ctx),
+			Method:      r.Method,
+			Path:        r.URL.Path,
+			Summary:     summary,
+			Ip:          host,
+			Status:      rec.status,
+			Duration:    time.Since(start).Milliseconds(),
+		}
+		doc.Code, doc.Msg = rec.result()
+
+		// 请求结束后上下文可能已取消，使用独立的上下文写入
+		insertCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := m.AuditLogModel.Insert(insertCtx, doc); err != nil {
+			logx.WithContext(ctx).Errorf("Insert audit log failed: %v", err)
+		}
+	}
+}
+
+// requestSummary 生成请求参数摘要，读取后恢复请求体供后续处理
+func requestSummary(r *http.Request) string {
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/") {
+		return "[multipart upload]"
+	}
+	if r.Body == nil {
+		return ""
+	}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil || len(body) == 0 {
+		return ""
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return truncate(string(body), auditSummaryLimit)
+	}
+	redacted, _ := json.Marshal(redact(data))
+	return truncate(string(redacted), auditSummaryLimit)
+}
+
+// redact 递归脱敏敏感字段，并截断过长的字符串（如POC内容）
+func redact(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			if isSensitiveKey(k) {
+				if s, ok := item.(string); !ok || s != "" {
+					val[k] = "***"
+				}
+				continue
+			}
+			val[k] = redact(item)
+		}
+		return val
+	case []interface{}:
+		for i, item := range val {
+			val[i] = redact(item)
+		}
+		return val
+	case string:
+		return truncate(val, 256)
+	}
+	return v
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return strings.HasSuffix(key, "key") || strings.HasSuffix(key, "keys")
+}
+
+// truncate 按字节截断，不截断在UTF-8字符中间
+func truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	for limit > 0 && !utf8.RuneStart(s[limit]) {
+		limit--
+	}
+	return s[:limit] + "...(truncated)"
+}
+
+// auditRecorder 记录响应状态码和响应体开头，用于解析业务码
+type auditRecorder struct {
+	http.ResponseWriter
+	status int
+	buf    bytes.Buffer
+}
+
+func (r *auditRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *auditRecorder) Write(p []byte) (int, error) {
+	if remain := auditResponseLimit - r.buf.Len(); remain > 0 {
+		if len(p) < remain {
+			remain = len(p)
+		}
+		r.buf.Write(p[:remain])
+	}
+	return r.ResponseWriter.Write(p)
+}
+
+func (r *auditRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// result 从响应体解析业务码和消息，非JSON响应（如文件导出）按HTTP状态判断
+func (r *auditRecorder) result() (int, string) {
+	var resp struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err := json.Unmarshal(r.buf.Bytes(), &resp); err == nil {
+		return resp.Code, resp.Msg
+	}
+	// 响应体过长被截断时，从开头提取业务码
+	if m := responseCodeRegex.FindSubmatch(r.buf.Bytes()); m != nil {
+		code, _ := strconv.Atoi(string(m[1]))
+		return code, ""
+	}
+	if r.status >= http.StatusBadRequest {
+		return r.status, ""
+	}
+	return 0, ""
+}
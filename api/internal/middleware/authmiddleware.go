@@ -3,10 +3,15 @@ package middleware
 import (
 	"context"
 	"encoding/json"
+	"net"
 	"net/http"
 	"strings"
+	"time"
+
+	"cscan/model"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/zeromicro/go-zero/core/logx"
 )
 
 type ContextKey string
@@ -16,15 +21,24 @@ const (
 	UsernameKey    ContextKey = "username"
 	RoleKey        ContextKey = "role"
 	WorkspaceIdKey ContextKey = "workspaceId"
+
+	UserKey                 ContextKey = "user"
+	AccessibleWorkspacesKey ContextKey = "accessibleWorkspaces"
+	TokenScopesKey          ContextKey = "tokenScopes"
 )
 
+// lastUsedInterval API令牌最后使用时间的更新间隔，避免每个请求都写库
+const lastUsedInterval = time.Minute
+
 type AuthMiddleware struct {
-	AccessSecret string
+	AccessSecret  string
+	ApiTokenModel *model.ApiTokenModel
 }
 
-func NewAuthMiddleware(accessSecret string) *AuthMiddleware {
+func NewAuthMiddleware(accessSecret string, apiTokenModel *model.ApiTokenModel) *AuthMiddleware {
 	return &AuthMiddleware{
-		AccessSecret: accessSecret,
+		AccessSecret:  accessSecret,
+		ApiTokenModel: apiTokenModel,
 	}
 }
 
@@ -52,6 +66,12 @@ func (m *AuthMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
+		// API令牌
+		if model.IsApiToken(tokenStr) {
+			m.handleApiToken(w, r, tokenStr, next)
+			return
+		}
+
 		// 验证Token
 		token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
 			return []byte(m.AccessSecret), nil
@@ -82,6 +102,38 @@ func (m *AuthMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// handleApiToken 验证个人API令牌，令牌的权限范围由权限中间件检查
+func (m *AuthMiddleware) handleApiToken(w http.ResponseWriter, r *http.Request, tokenStr string, next http.HandlerFunc) {
+	token, err := m.ApiTokenModel.FindActiveByToken(r.Context(), tokenStr)
+	if err != nil {
+		logx.WithContext(r.Context()).Errorf("Find api token failed: %v", err)
+		unauthorized(w, "Token验证失败")
+		return
+	}
+	if token == nil || token.Expired() {
+		unauthorized(w, "Token无效或已过期")
+		return
+	}
+
+	if token.LastUsedTime == nil || time.Since(*token.LastUsedTime) > lastUsedInterval {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		if err := m.ApiTokenModel.UpdateLastUsed(r.Context(), token.Id, host); err != nil {
+			logx.WithContext(r.Context()).Errorf("Update api token last used failed: %v", err)
+		}
+	}
+
+	ctx := r.Context()
+	ctx = context.WithValue(ctx, UserIdKey, token.UserId)
+	ctx = context.WithValue(ctx, UsernameKey, token.Username)
+	ctx = context.WithValue(ctx, TokenScopesKey, token.Scopes)
+	ctx = context.WithValue(ctx, WorkspaceIdKey, r.Header.Get("X-Workspace-Id"))
+
+	next(w, r.WithContext(ctx))
+}
+
 func unauthorized(w http.ResponseWriter, msg string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusUnauthorized)
@@ -123,6 +175,20 @@ func GetWorkspaceId(ctx context.Context) string {
 	return ""
 }
 
+// GetTokenScopes 从Context获取API令牌的权限范围，JWT认证时返回nil
+func GetTokenScopes(ctx context.Context) []string {
+	if v, ok := ctx.Value(TokenScopesKey).([]string); ok {
+		return v
+	}
+	return nil
+}
+
+// IsApiTokenAuth 当前请求是否使用API令牌认证
+func IsApiTokenAuth(ctx context.Context) bool {
+	_, ok := ctx.Value(TokenScopesKey).([]string)
+	return ok
+}
+
 // RequireAdmin 管理员权限中间件，需要先经过认证中间件
 func RequireAdmin(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,350 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"cscan/model"
+)
+
+// Permission 接口权限
+type Permission string
+
+const (
+	PermSelf      Permission = "self"       // 任意登录用户（个人设置、工作空间列表）
+	PermView      Permission = "view"       // 查看资产、任务、漏洞等数据
+	PermAssetEdit Permission = "asset:edit" // 删除/清空资产和漏洞，导入资产，管理组织
+	PermTaskEdit  Permission = "task:edit"  // 创建/启动/停止任务，管理任务模板，执行POC验证
+	PermPocEdit   Permission = "poc:edit"   // 管理POC、指纹、标签映射
+	PermSystem    Permission = "system"     // 用户、工作空间、Worker、通知及数据源配置，仅全局管理员
+)
+
+// TokenScopes API令牌可申请的权限范围，PermSelf 对所有令牌开放
+var TokenScopes = []Permission{PermView, PermAssetEdit, PermTaskEdit, PermPocEdit, PermSystem}
+
+// rolePermissions 角色权限矩阵
+var rolePermissions = map[string]map[Permission]bool{
+	model.RoleAdmin: {
+		PermSelf: true, PermView: true, PermAssetEdit: true, PermTaskEdit: true, PermPocEdit: true, PermSystem: true,
+	},
+	model.RoleOperator: {
+		PermSelf: true, PermView: true, PermAssetEdit: true, PermTaskEdit: true, PermPocEdit: true,
+	},
+	model.RoleViewer: {
+		PermSelf: true, PermView: true,
+	},
+}
+
+// workspacePermissions 按当前工作空间角色判断的权限，其余权限作用于全局资源，按用户最高角色判断
+var workspacePermissions = map[Permission]bool{
+	PermView:      true,
+	PermAssetEdit: true,
+	PermTaskEdit:  true,
+}
+
+// RoutePermissions 路由权限矩阵，routes.go 中每个需要认证的路由都必须在此登记
+var RoutePermissions = map[string]Permission{
+	// 用户管理
+	"/api/v1/user/list":            PermSystem,
+	"/api/v1/user/create":          PermSystem,
+	"/api/v1/user/update":          PermSystem,
+	"/api/v1/user/delete":          PermSystem,
+	"/api/v1/user/resetPassword":   PermSystem,
+	"/api/v1/user/setRoles":        PermSystem,
+	"/api/v1/user/loginLog":        PermSystem,
+	"/api/v1/user/scanConfig/save": PermSelf,
+	"/api/v1/user/scanConfig/get":  PermSelf,
+
+	// API令牌（管理自己的令牌）
+	"/api/v1/user/token/list":   PermSelf,
+	"/api/v1/user/token/create": PermSelf,
+	"/api/v1/user/token/revoke": PermSelf,
+
+	// Worker日志
+	"/api/v1/worker/logs/stream":  PermView,
+	"/api/v1/worker/logs/history": PermView,
+	"/api/v1/worker/logs/clear":   PermSystem,
+
+	// 工作空间
+	"/api/v1/workspace/list":   PermSelf,
+	"/api/v1/workspace/save":   PermSystem,
+	"/api/v1/workspace/delete": PermSystem,
+
+	// 组织管理
+	"/api/v1/organization/list":         PermView,
+	"/api/v1/organization/save":         PermAssetEdit,
+	"/api/v1/organization/delete":       PermAssetEdit,
+	"/api/v1/organization/updateStatus": PermAssetEdit,
+
+	// 资产管理
+	"/api/v1/asset/list":               PermView,
+	"/api/v1/asset/stat":               PermView,
+	"/api/v1/asset/delete":             PermAssetEdit,
+	"/api/v1/asset/batchDelete":        PermAssetEdit,
+	"/api/v1/asset/clear":              PermAssetEdit,
+	"/api/v1/asset/history":            PermView,
+	"/api/v1/asset/changes":            PermView,
+	"/api/v1/asset/screenshot/cluster": PermView,
+	"/api/v1/asset/url/list":           PermView,
+	"/api/v1/asset/dirscan/list":       PermView,
+	"/api/v1/asset/secret/list":        PermView,
+	"/api/v1/asset/cert/list":          PermView,
+	"/api/v1/asset/site/list":          PermView,
+	"/api/v1/asset/site/stat":          PermView,
+	"/api/v1/asset/domain/list":        PermView,
+	"/api/v1/asset/domain/stat":        PermView,
+	"/api/v1/asset/domain/delete":      PermAssetEdit,
+	"/api/v1/asset/domain/batchDelete": PermAssetEdit,
+	"/api/v1/asset/subdomain/list":     PermView,
+	"/api/v1/asset/ip/list":            PermView,
+	"/api/v1/asset/ip/stat":            PermView,
+	"/api/v1/asset/ip/delete":          PermAssetEdit,
+	"/api/v1/asset/ip/batchDelete":     PermAssetEdit,
+
+	// 任务管理
+	"/api/v1/task/list":           PermView,
+	"/api/v1/task/create":         PermTaskEdit,
+	"/api/v1/task/update":         PermTaskEdit,
+	"/api/v1/task/delete":         PermTaskEdit,
+	"/api/v1/task/batchDelete":    PermTaskEdit,
+	"/api/v1/task/retry":          PermTaskEdit,
+	"/api/v1/task/start":          PermTaskEdit,
+	"/api/v1/task/pause":          PermTaskEdit,
+	"/api/v1/task/resume":         PermTaskEdit,
+	"/api/v1/task/stop":           PermTaskEdit,
+	"/api/v1/task/stat":           PermView,
+	"/api/v1/task/profile/list":   PermView,
+	"/api/v1/task/profile/save":   PermTaskEdit,
+	"/api/v1/task/profile/delete": PermTaskEdit,
+	"/api/v1/task/logs":           PermView,
+	"/api/v1/task/logs/stream":    PermView,
+	"/api/v1/task/lease/list":     PermView,
+
+	// 漏洞管理
+	"/api/v1/vul/list":        PermView,
+	"/api/v1/vul/detail":      PermView,
+	"/api/v1/vul/stat":        PermView,
+	"/api/v1/vul/delete":      PermAssetEdit,
+	"/api/v1/vul/batchDelete": PermAssetEdit,
+	"/api/v1/vul/clear":       PermAssetEdit,
+
+	// Worker管理
+	"/api/v1/worker/list":              PermView,
+	"/api/v1/worker/delete":            PermSystem,
+	"/api/v1/worker/rename":            PermSystem,
+	"/api/v1/worker/restart":           PermSystem,
+	"/api/v1/worker/concurrency":       PermSystem,
+	"/api/v1/worker/enroll/list":       PermSystem,
+	"/api/v1/worker/enroll/create":     PermSystem,
+	"/api/v1/worker/enroll/revoke":     PermSystem,
+	"/api/v1/worker/credential/list":   PermSystem,
+	"/api/v1/worker/credential/revoke": PermSystem,
+
+	// 在线API搜索（消耗API额度）
+	"/api/v1/onlineapi/search":      PermAssetEdit,
+	"/api/v1/onlineapi/import":      PermAssetEdit,
+	"/api/v1/onlineapi/importAll":   PermAssetEdit,
+	"/api/v1/onlineapi/config/list": PermSystem,
+	"/api/v1/onlineapi/config/save": PermSystem,
+
+	// POC标签映射
+	"/api/v1/poc/tagmapping/list":   PermView,
+	"/api/v1/poc/tagmapping/save":   PermPocEdit,
+	"/api/v1/poc/tagmapping/delete": PermPocEdit,
+
+	// 自定义POC
+	"/api/v1/poc/custom/list":        PermView,
+	"/api/v1/poc/custom/save":        PermPocEdit,
+	"/api/v1/poc/custom/delete":      PermPocEdit,
+	"/api/v1/poc/custom/batchImport": PermPocEdit,
+	"/api/v1/poc/custom/clearAll":    PermPocEdit,
+	"/api/v1/poc/custom/scanAssets":  PermTaskEdit,
+
+	// Nuclei默认模板
+	"/api/v1/poc/nuclei/templates":     PermView,
+	"/api/v1/poc/nuclei/categories":    PermView,
+	"/api/v1/poc/nuclei/sync":          PermPocEdit,
+	"/api/v1/poc/nuclei/updateEnabled": PermPocEdit,
+	"/api/v1/poc/nuclei/detail":        PermView,
+
+	// 指纹管理
+	"/api/v1/fingerprint/list":           PermView,
+	"/api/v1/fingerprint/save":           PermPocEdit,
+	"/api/v1/fingerprint/delete":         PermPocEdit,
+	"/api/v1/fingerprint/categories":     PermView,
+	"/api/v1/fingerprint/sync":           PermPocEdit,
+	"/api/v1/fingerprint/updateEnabled":  PermPocEdit,
+	"/api/v1/fingerprint/import":         PermPocEdit,
+	"/api/v1/fingerprint/importFromFile": PermPocEdit,
+	"/api/v1/fingerprint/clearCustom":    PermPocEdit,
+	"/api/v1/fingerprint/validate":       PermPocEdit,
+	"/api/v1/fingerprint/batchValidate":  PermPocEdit,
+	"/api/v1/fingerprint/matchAssets":    PermPocEdit,
+
+	// POC验证（会向目标发送请求）
+	"/api/v1/poc/custom/validate": PermTaskEdit,
+	"/api/v1/poc/batchValidate":   PermTaskEdit,
+	"/api/v1/poc/queryResult":     PermView,
+
+	// HTTP服务映射
+	"/api/v1/fingerprint/httpservice/list":   PermView,
+	"/api/v1/fingerprint/httpservice/save":   PermPocEdit,
+	"/api/v1/fingerprint/httpservice/delete": PermPocEdit,
+
+	// 报告管理
+	"/api/v1/report/detail": PermView,
+	"/api/v1/report/export": PermView,
+
+	// Subfinder数据源配置（包含API密钥）
+	"/api/v1/subfinder/provider/list": PermSystem,
+	"/api/v1/subfinder/provider/save": PermSystem,
+	"/api/v1/subfinder/provider/info": PermSystem,
+
+	// 敏感信息规则
+	"/api/v1/secret/rule/list":          PermView,
+	"/api/v1/secret/rule/save":          PermPocEdit,
+	"/api/v1/secret/rule/delete":        PermPocEdit,
+	"/api/v1/secret/rule/updateEnabled": PermPocEdit,
+
+	// 爆破字典
+	"/api/v1/wordlist/list":   PermView,
+	"/api/v1/wordlist/save":   PermPocEdit,
+	"/api/v1/wordlist/delete": PermPocEdit,
+	"/api/v1/wordlist/detail": PermView,
+
+	// 通知配置
+	"/api/v1/notify/list":   PermSystem,
+	"/api/v1/notify/save":   PermSystem,
+	"/api/v1/notify/delete": PermSystem,
+	"/api/v1/notify/test":   PermSystem,
+
+	// 审计日志
+	"/api/v1/audit/list": PermSystem,
+
+	// 截图、图标等对象下载
+	"/api/v1/blob/:hash": PermView,
+}
+
+// HasPermission 判断用户在指定工作空间是否拥有权限
+// workspaceId 为空或 "all" 时，查看类权限按最高角色判断（查询结果再按可访问工作空间过滤），
+// 修改类权限按 default 工作空间的角色判断，实际操作的工作空间由 common.GetWorkspaceIds 按权限逐个过滤
+func HasPermission(user *model.User, perm Permission, workspaceId string) bool {
+	if perm == PermSystem {
+		return user.Role == model.RoleAdmin
+	}
+
+	role := user.HighestRole()
+	if workspacePermissions[perm] {
+		switch {
+		case workspaceId != "" && workspaceId != "all":
+			role = user.RoleFor(workspaceId)
+		case perm != PermView:
+			role = user.RoleFor("default")
+		}
+	}
+	return rolePermissions[role][perm]
+}
+
+// PermissionMiddleware 权限中间件，需要先经过认证中间件
+type PermissionMiddleware struct {
+	UserModel *model.UserModel
+}
+
+func NewPermissionMiddleware(userModel *model.UserModel) *PermissionMiddleware {
+	return &PermissionMiddleware{
+		UserModel: userModel,
+	}
+}
+
+func (m *PermissionMiddleware) Handle(perm Permission, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		// 每次请求重新加载用户，角色变更和禁用即时生效
+		user, err := m.UserModel.FindById(ctx, GetUserId(ctx))
+		if err != nil || user == nil || user.Status != model.StatusEnable {
+			unauthorized(w, "用户不存在或已被禁用")
+			return
+		}
+
+		if !HasPermission(user, perm, GetWorkspaceId(ctx)) {
+			forbidden(w, "无权限执行该操作")
+			return
+		}
+
+		// API令牌只能使用创建时授予的权限范围，且不超过用户当前角色
+		if IsApiTokenAuth(ctx) && !scopeAllows(GetTokenScopes(ctx), perm) {
+			forbidden(w, "API令牌未授予该权限: "+string(perm))
+			return
+		}
+
+		ctx = context.WithValue(ctx, RoleKey, user.RoleFor(GetWorkspaceId(ctx)))
+		ctx = context.WithValue(ctx, AccessibleWorkspacesKey, user.AccessibleWorkspaces())
+		ctx = context.WithValue(ctx, UserKey, user)
+		next(w, r.WithContext(ctx))
+	}
+}
+
+func scopeAllows(scopes []string, perm Permission) bool {
+	if perm == PermSelf {
+		return true
+	}
+	for _, scope := range scopes {
+		if Permission(scope) == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidTokenScope 检查API令牌权限范围是否有效
+func IsValidTokenScope(scope string) bool {
+	for _, p := range TokenScopes {
+		if string(p) == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func forbidden(w http.ResponseWriter, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"code": 403,
+		"msg":  msg,
+	})
+}
+
+// GetAccessibleWorkspaces 从Context获取用户可访问的工作空间，返回nil表示全部可访问
+func GetAccessibleWorkspaces(ctx context.Context) []string {
+	if v, ok := ctx.Value(AccessibleWorkspacesKey).([]string); ok {
+		return v
+	}
+	return nil
+}
+
+// CanAccessWorkspace 判断用户是否可以访问指定工作空间
+func CanAccessWorkspace(ctx context.Context, workspaceId string) bool {
+	ids, ok := ctx.Value(AccessibleWorkspacesKey).([]string)
+	if !ok || ids == nil {
+		return true
+	}
+	for _, id := range ids {
+		if id == workspaceId {
+			return true
+		}
+	}
+	return false
+}
+
+// HasWorkspacePermission 判断当前用户在指定工作空间是否拥有权限，
+// 用于 workspaceId 为 "all" 时逐个检查涉及的工作空间
+func HasWorkspacePermission(ctx context.Context, perm Permission, workspaceId string) bool {
+	user, ok := ctx.Value(UserKey).(*model.User)
+	if !ok || user == nil {
+		return false
+	}
+	return HasPermission(user, perm, workspaceId)
+}
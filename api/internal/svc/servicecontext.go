@@ -7,6 +7,9 @@ import (
 	"cscan/api/internal/config"
 	"cscan/api/internal/svc/sync"
 	"cscan/model"
+	"cscan/pkg/blob"
+	"cscan/pkg/rpcauth"
+	"cscan/pkg/secret"
 	"cscan/rpc/task/pb"
 	"cscan/scheduler"
 
@@ -15,6 +18,7 @@ import (
 	"github.com/zeromicro/go-zero/zrpc"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc"
 )
 
 type ServiceContext struct {
@@ -32,9 +36,22 @@ type ServiceContext struct {
 	NucleiTemplateModel     *model.NucleiTemplateModel
 	FingerprintModel        *model.FingerprintModel
 	HttpServiceMappingModel *model.HttpServiceMappingModel
+	NotifyConfigModel       *model.NotifyConfigModel
+	LoginLogModel           *model.LoginLogModel
+	ApiTokenModel           *model.ApiTokenModel
+	AuditLogModel           *model.AuditLogModel
+	WordlistModel           *model.WordlistModel
+	SecretRuleModel         *model.SecretRuleModel
+	WorkerEnrollTokenModel  *model.WorkerEnrollTokenModel
+	WorkerCredentialModel   *model.WorkerCredentialModel
+
+	// 对象存储，为nil时只能读取内联保存的截图等数据
+	Blob blob.Store
 
 	// 调度器
 	Scheduler *scheduler.Scheduler
+	// 任务租约，查看子任务由哪个Worker执行
+	TaskLeases *scheduler.TaskLeases
 
 	// 同步服务
 	SyncMethods *sync.SyncMethods
@@ -65,7 +82,13 @@ func NewServiceContext(c config.Config) *ServiceContext {
 	})
 
 	// 创建RPC客户端
-	taskRpcClient := pb.NewTaskServiceClient(zrpc.MustNewClient(c.TaskRpc).Conn())
+	taskRpcClient := pb.NewTaskServiceClient(zrpc.MustNewClient(c.TaskRpc, taskRpcOptions(c)...).Conn())
+
+	blobStore, err := blob.New(c.Blob)
+	if err != nil {
+		logx.Errorf("Init blob store failed: %v", err)
+		blobStore = nil
+	}
 
 	svcCtx := &ServiceContext{
 		Config:                  c,
@@ -82,7 +105,17 @@ func NewServiceContext(c config.Config) *ServiceContext {
 		NucleiTemplateModel:     model.NewNucleiTemplateModel(mongoDB),
 		FingerprintModel:        model.NewFingerprintModel(mongoDB),
 		HttpServiceMappingModel: model.NewHttpServiceMappingModel(mongoDB),
+		NotifyConfigModel:       model.NewNotifyConfigModel(mongoDB),
+		LoginLogModel:           model.NewLoginLogModel(mongoDB),
+		ApiTokenModel:           model.NewApiTokenModel(mongoDB),
+		AuditLogModel:           model.NewAuditLogModel(mongoDB, auditRetentionDays(c.Audit.RetentionDays)),
+		WordlistModel:           model.NewWordlistModel(mongoDB),
+		SecretRuleModel:         model.NewSecretRuleModel(mongoDB),
+		WorkerEnrollTokenModel:  model.NewWorkerEnrollTokenModel(mongoDB),
+		WorkerCredentialModel:   model.NewWorkerCredentialModel(mongoDB),
+		Blob:                    blobStore,
 		Scheduler:               scheduler.NewScheduler(rdb),
+		TaskLeases:              scheduler.NewTaskLeases(rdb),
 		TemplateCategories:      []string{},
 		TemplateTags:            []string{},
 		TemplateStats:           map[string]int{},
@@ -95,9 +128,31 @@ func NewServiceContext(c config.Config) *ServiceContext {
 		svcCtx.CustomPocModel,
 	)
 
+	// 写入内置敏感信息检测规则
+	svcCtx.initBuiltinSecretRules()
+
 	return svcCtx
 }
 
+// initBuiltinSecretRules 写入内置敏感信息检测规则，已存在的内置规则更新匹配内容并保留启用状态
+func (s *ServiceContext) initBuiltinSecretRules() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for _, rule := range secret.BuiltinRules {
+		err := s.SecretRuleModel.UpsertBuiltin(ctx, &model.SecretRule{
+			Name:       rule.Name,
+			Category:   rule.Category,
+			Severity:   rule.Severity,
+			Pattern:    rule.Pattern,
+			MinEntropy: rule.MinEntropy,
+			Validator:  rule.Validator,
+		})
+		if err != nil {
+			logx.Errorf("Init builtin secret rule %s failed: %v", rule.Name, err)
+		}
+	}
+}
+
 // GetAssetModel 根据workspaceId获取资产模型
 func (s *ServiceContext) GetAssetModel(workspaceId string) *model.AssetModel {
 	if workspaceId == "" {
@@ -130,6 +185,53 @@ func (s *ServiceContext) GetAssetHistoryModel(workspaceId string) *model.AssetHi
 	return model.NewAssetHistoryModel(s.MongoDB, workspaceId)
 }
 
+func (s *ServiceContext) GetAssetChangeModel(workspaceId string) *model.AssetChangeModel {
+	if workspaceId == "" {
+		workspaceId = "default"
+	}
+	return model.NewAssetChangeModel(s.MongoDB, workspaceId)
+}
+
+// GetUrlModel 根据workspaceId获取爬虫URL模型
+func (s *ServiceContext) GetUrlModel(workspaceId string) *model.UrlModel {
+	if workspaceId == "" {
+		workspaceId = "default"
+	}
+	return model.NewUrlModel(s.MongoDB, workspaceId)
+}
+
+// GetDirScanModel 根据workspaceId获取目录扫描结果模型
+func (s *ServiceContext) GetDirScanModel(workspaceId string) *model.DirScanModel {
+	if workspaceId == "" {
+		workspaceId = "default"
+	}
+	return model.NewDirScanModel(s.MongoDB, workspaceId)
+}
+
+// GetSecretFindingModel 根据workspaceId获取敏感信息检测结果模型
+func (s *ServiceContext) GetSecretFindingModel(workspaceId string) *model.SecretFindingModel {
+	if workspaceId == "" {
+		workspaceId = "default"
+	}
+	return model.NewSecretFindingModel(s.MongoDB, workspaceId)
+}
+
+// GetDNSRecordModel 根据workspaceId获取DNS记录模型
+func (s *ServiceContext) GetDNSRecordModel(workspaceId string) *model.DNSRecordModel {
+	if workspaceId == "" {
+		workspaceId = "default"
+	}
+	return model.NewDNSRecordModel(s.MongoDB, workspaceId)
+}
+
+// GetSubdomainModel 根据workspaceId获取子域名监控模型
+func (s *ServiceContext) GetSubdomainModel(workspaceId string) *model.SubdomainModel {
+	if workspaceId == "" {
+		workspaceId = "default"
+	}
+	return model.NewSubdomainModel(s.MongoDB, workspaceId)
+}
+
 // RefreshTemplateCache 刷新模板元数据缓存
 func (s *ServiceContext) RefreshTemplateCache() {
 	ctx := context.Background()
@@ -164,3 +266,30 @@ func (s *ServiceContext) SyncWappalyzerFingerprints() {
 func (s *ServiceContext) ImportCustomPocAndFingerprints() {
 	s.SyncMethods.ImportCustomPocAndFingerprints()
 }
+
+// auditRetentionDays 审计日志保留天数，未配置时默认180天，负数表示永久保留
+func auditRetentionDays(days int) int {
+	if days == 0 {
+		return 180
+	}
+	return days
+}
+
+// taskRpcOptions 任务服务开启认证时附加服务令牌和TLS
+func taskRpcOptions(c config.Config) []zrpc.ClientOption {
+	var opts []zrpc.ClientOption
+	tlsConf := c.TaskRpcAuth.TLS
+	secure := tlsConf.CAFile != "" || tlsConf.CertFile != ""
+	if secure {
+		creds, err := rpcauth.ClientCredentials(tlsConf)
+		if err != nil {
+			panic(err)
+		}
+		opts = append(opts, zrpc.WithTransportCredentials(creds))
+	}
+	if c.TaskRpcAuth.ServiceToken != "" {
+		creds := rpcauth.NewTokenCredentials(c.TaskRpcAuth.ServiceToken, secure)
+		opts = append(opts, zrpc.WithDialOption(grpc.WithPerRPCCredentials(creds)))
+	}
+	return opts
+}
@@ -24,19 +24,53 @@ type LoginReq struct {
 }
 
 type LoginResp struct {
-	Code        int    `json:"code"`
-	Msg         string `json:"msg"`
-	Token       string `json:"token"`
-	UserId      string `json:"userId"`
-	Username    string `json:"username"`
-	Role        string `json:"role"`
-	WorkspaceId string `json:"workspaceId"`
+	Code           int             `json:"code"`
+	Msg            string          `json:"msg"`
+	Token          string          `json:"token"`
+	UserId         string          `json:"userId"`
+	Username       string          `json:"username"`
+	Role           string          `json:"role"`
+	WorkspaceId    string          `json:"workspaceId"`
+	WorkspaceRoles []WorkspaceRole `json:"workspaceRoles"`
+}
+
+type LoginLogReq struct {
+	Page     int    `json:"page,default=1"`
+	PageSize int    `json:"pageSize,default=20"`
+	Username string `json:"username,optional"`
+	Ip       string `json:"ip,optional"`
+	Status   string `json:"status,optional"` // success/failed
+}
+
+type LoginLogItem struct {
+	Id         string `json:"id"`
+	Username   string `json:"username"`
+	Ip         string `json:"ip"`
+	UserAgent  string `json:"userAgent"`
+	Success    bool   `json:"success"`
+	Reason     string `json:"reason"`
+	CreateTime string `json:"createTime"`
+}
+
+type LoginLogResp struct {
+	Code  int            `json:"code"`
+	Msg   string         `json:"msg"`
+	Total int            `json:"total"`
+	List  []LoginLogItem `json:"list"`
 }
 
 type UserInfo struct {
-	Id       string `json:"id"`
-	Username string `json:"username"`
-	Status   string `json:"status"`
+	Id             string          `json:"id"`
+	Username       string          `json:"username"`
+	Status         string          `json:"status"`
+	Role           string          `json:"role"`
+	WorkspaceRoles []WorkspaceRole `json:"workspaceRoles"`
+}
+
+// WorkspaceRole 用户在工作空间中的角色
+type WorkspaceRole struct {
+	WorkspaceId string `json:"workspaceId"`
+	Role        string `json:"role"` // admin/operator/viewer
 }
 
 type UserListResp struct {
@@ -48,9 +82,11 @@ type UserListResp struct {
 
 // ==================== 用户管理 ====================
 type UserCreateReq struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
-	Status   string `json:"status"`
+	Username       string          `json:"username"`
+	Password       string          `json:"password"`
+	Status         string          `json:"status"`
+	Role           string          `json:"role,optional"` // 全局角色，默认viewer
+	WorkspaceRoles []WorkspaceRole `json:"workspaceRoles,optional"`
 }
 
 type UserUpdateReq struct {
@@ -59,6 +95,12 @@ type UserUpdateReq struct {
 	Status   string `json:"status"`
 }
 
+type UserSetRolesReq struct {
+	Id             string          `json:"id"`
+	Role           string          `json:"role,optional"` // 全局角色，为空表示仅按工作空间授权
+	WorkspaceRoles []WorkspaceRole `json:"workspaceRoles,optional"`
+}
+
 type UserDeleteReq struct {
 	Id string `json:"id"`
 }
@@ -68,6 +110,42 @@ type UserResetPasswordReq struct {
 	NewPassword string `json:"newPassword"`
 }
 
+// ==================== API令牌 ====================
+type ApiTokenItem struct {
+	Id           string   `json:"id"`
+	Name         string   `json:"name"`
+	Prefix       string   `json:"prefix"`
+	Scopes       []string `json:"scopes"`
+	ExpireTime   string   `json:"expireTime"`
+	LastUsedTime string   `json:"lastUsedTime"`
+	LastUsedIp   string   `json:"lastUsedIp"`
+	Status       string   `json:"status"` // active/expired/revoked
+	CreateTime   string   `json:"createTime"`
+}
+
+type ApiTokenListResp struct {
+	Code int            `json:"code"`
+	Msg  string         `json:"msg"`
+	List []ApiTokenItem `json:"list"`
+}
+
+type ApiTokenCreateReq struct {
+	Name       string   `json:"name"`
+	Scopes     []string `json:"scopes"`              // view/asset:edit/task:edit/poc:edit/system
+	ExpireDays int      `json:"expireDays,optional"` // 有效天数，0表示永不过期
+}
+
+type ApiTokenCreateResp struct {
+	Code  int    `json:"code"`
+	Msg   string `json:"msg"`
+	Id    string `json:"id"`
+	Token string `json:"token"` // 令牌明文，仅在创建时返回一次
+}
+
+type ApiTokenRevokeReq struct {
+	Id string `json:"id"`
+}
+
 // ==================== 工作空间 ====================
 type Workspace struct {
 	Id          string `json:"id"`
@@ -128,28 +206,32 @@ type OrganizationUpdateStatusReq struct {
 
 // ==================== 资产管理 ====================
 type Asset struct {
-	Id         string   `json:"id"`
-	Authority  string   `json:"authority"`
-	Host       string   `json:"host"`
-	Port       int      `json:"port"`
-	Category   string   `json:"category"`
-	Service    string   `json:"service"`
-	Title      string   `json:"title"`
-	App        []string `json:"app"`
-	HttpStatus string   `json:"httpStatus"`
-	HttpHeader string   `json:"httpHeader"`
-	HttpBody   string   `json:"httpBody"`
-	Banner     string   `json:"banner"`
-	IconHash   string   `json:"iconHash"`
-	IconData   string   `json:"iconData,omitempty"` // favicon 图片 base64
-	Screenshot string   `json:"screenshot"`
-	Location   string   `json:"location"`
-	IsCDN      bool     `json:"isCdn"`
-	IsCloud    bool     `json:"isCloud"`
-	IsNew      bool     `json:"isNew"`
-	IsUpdated  bool     `json:"isUpdated"`
-	CreateTime string   `json:"createTime"`
-	UpdateTime string   `json:"updateTime"`
+	Id             string   `json:"id"`
+	Authority      string   `json:"authority"`
+	Host           string   `json:"host"`
+	Port           int      `json:"port"`
+	Category       string   `json:"category"`
+	Service        string   `json:"service"`
+	Title          string   `json:"title"`
+	App            []string `json:"app"`
+	HttpStatus     string   `json:"httpStatus"`
+	HttpHeader     string   `json:"httpHeader"`
+	HttpBody       string   `json:"httpBody"`
+	BodyUrl        string   `json:"bodyUrl,omitempty"` // httpBody被截断时完整响应体的下载地址
+	Banner         string   `json:"banner"`
+	IconHash       string   `json:"iconHash"`
+	IconData       string   `json:"iconData,omitempty"` // favicon 图片 base64
+	IconUrl        string   `json:"iconUrl,omitempty"`  // favicon 存储在对象存储时的下载地址
+	Screenshot     string   `json:"screenshot"`
+	ScreenshotUrl  string   `json:"screenshotUrl,omitempty"`  // 截图存储在对象存储时的下载地址
+	ScreenshotHash string   `json:"screenshotHash,omitempty"` // 截图感知哈希
+	Location       string   `json:"location"`
+	IsCDN          bool     `json:"isCdn"`
+	IsCloud        bool     `json:"isCloud"`
+	IsNew          bool     `json:"isNew"`
+	IsUpdated      bool     `json:"isUpdated"`
+	CreateTime     string   `json:"createTime"`
+	UpdateTime     string   `json:"updateTime"`
 	// 组织
 	OrgId   string `json:"orgId,omitempty"`
 	OrgName string `json:"orgName,omitempty"`
@@ -209,9 +291,33 @@ type StatItem struct {
 type IconHashStatItem struct {
 	IconHash string `json:"iconHash"`
 	IconData string `json:"iconData"` // base64 图片数据
+	IconUrl  string `json:"iconUrl,omitempty"`
 	Count    int    `json:"count"`
 }
 
+type ScreenshotClusterReq struct {
+	Threshold int `json:"threshold,default=10"` // 汉明距离阈值(0-32)，越小越严格
+	MinCount  int `json:"minCount,default=2"`   // 资产数少于该值的类不返回
+	Limit     int `json:"limit,default=20"`
+}
+
+type ScreenshotClusterItem struct {
+	ScreenshotHash string   `json:"screenshotHash"` // 代表截图的哈希
+	ScreenshotUrl  string   `json:"screenshotUrl,omitempty"`
+	AssetId        string   `json:"assetId"` // 代表资产，可用 screenshot_similar=<assetId> 查询相似资产
+	Host           string   `json:"host"`
+	Port           int      `json:"port"`
+	Title          string   `json:"title"`
+	Hashes         []string `json:"hashes"` // 归入该类的所有截图哈希
+	Count          int      `json:"count"`
+}
+
+type ScreenshotClusterResp struct {
+	Code int                     `json:"code"`
+	Msg  string                  `json:"msg"`
+	List []ScreenshotClusterItem `json:"list"`
+}
+
 type AssetDeleteReq struct {
 	Id string `json:"id"`
 }
@@ -226,21 +332,23 @@ type AssetHistoryReq struct {
 }
 
 type AssetHistoryItem struct {
-	Id         string   `json:"id"`
-	Authority  string   `json:"authority"`
-	Host       string   `json:"host"`
-	Port       int      `json:"port"`
-	Service    string   `json:"service"`
-	Title      string   `json:"title"`
-	App        []string `json:"app"`
-	HttpStatus string   `json:"httpStatus"`
-	HttpHeader string   `json:"httpHeader"`
-	HttpBody   string   `json:"httpBody"`
-	Banner     string   `json:"banner"`
-	IconHash   string   `json:"iconHash"`
-	Screenshot string   `json:"screenshot"`
-	TaskId     string   `json:"taskId"`
-	CreateTime string   `json:"createTime"`
+	Id            string   `json:"id"`
+	Authority     string   `json:"authority"`
+	Host          string   `json:"host"`
+	Port          int      `json:"port"`
+	Service       string   `json:"service"`
+	Title         string   `json:"title"`
+	App           []string `json:"app"`
+	HttpStatus    string   `json:"httpStatus"`
+	HttpHeader    string   `json:"httpHeader"`
+	HttpBody      string   `json:"httpBody"`
+	BodyUrl       string   `json:"bodyUrl,omitempty"`
+	Banner        string   `json:"banner"`
+	IconHash      string   `json:"iconHash"`
+	Screenshot    string   `json:"screenshot"`
+	ScreenshotUrl string   `json:"screenshotUrl,omitempty"`
+	TaskId        string   `json:"taskId"`
+	CreateTime    string   `json:"createTime"`
 }
 
 type AssetHistoryResp struct {
@@ -249,6 +357,190 @@ type AssetHistoryResp struct {
 	List []AssetHistoryItem `json:"list"`
 }
 
+type AssetChangeReq struct {
+	Page       int    `json:"page,default=1"`
+	PageSize   int    `json:"pageSize,default=20"`
+	ChangeType string `json:"changeType,optional"` // new/port_open/port_close/modify
+	Field      string `json:"field,optional"`      // modify类型的字段: title/app/banner/service/status/server/icon_hash/cert
+	TaskId     string `json:"taskId,optional"`     // 主任务UUID
+	AssetId    string `json:"assetId,optional"`
+	Host       string `json:"host,optional"`
+}
+
+type AssetChangeItem struct {
+	Id         string `json:"id"`
+	AssetId    string `json:"assetId"`
+	Authority  string `json:"authority"`
+	Host       string `json:"host"`
+	Port       int    `json:"port"`
+	ChangeType string `json:"changeType"`
+	Field      string `json:"field"`
+	OldValue   string `json:"oldValue"`
+	NewValue   string `json:"newValue"`
+	TaskId     string `json:"taskId"`
+	PrevTaskId string `json:"prevTaskId"`
+	CreateTime string `json:"createTime"`
+}
+
+type AssetChangeResp struct {
+	Code  int               `json:"code"`
+	Msg   string            `json:"msg"`
+	Total int               `json:"total"`
+	List  []AssetChangeItem `json:"list"`
+}
+
+type UrlListReq struct {
+	Page      int    `json:"page,default=1"`
+	PageSize  int    `json:"pageSize,default=20"`
+	Keyword   string `json:"keyword,optional"`   // 匹配URL
+	Authority string `json:"authority,optional"` // host:port
+	Source    string `json:"source,optional"`    // page/form/js/xhr/robots/sitemap
+	Method    string `json:"method,optional"`
+	HasParams bool   `json:"hasParams,optional"` // 只看带参数的URL
+	TaskId    string `json:"taskId,optional"`
+}
+
+type UrlItem struct {
+	Id          string   `json:"id"`
+	Authority   string   `json:"authority"`
+	Host        string   `json:"host"`
+	Port        int      `json:"port"`
+	Url         string   `json:"url"`
+	Endpoint    string   `json:"endpoint"`
+	Method      string   `json:"method"`
+	Path        string   `json:"path"`
+	Params      []string `json:"params"`
+	Source      string   `json:"source"`
+	StatusCode  int      `json:"statusCode"`
+	ContentType string   `json:"contentType"`
+	Length      int      `json:"length"`
+	Title       string   `json:"title"`
+	Referer     string   `json:"referer"`
+	TaskId      string   `json:"taskId"`
+	CreateTime  string   `json:"createTime"`
+	UpdateTime  string   `json:"updateTime"`
+}
+
+type UrlListResp struct {
+	Code  int       `json:"code"`
+	Msg   string    `json:"msg"`
+	Total int       `json:"total"`
+	List  []UrlItem `json:"list"`
+}
+
+type DirScanListReq struct {
+	Page      int    `json:"page,default=1"`
+	PageSize  int    `json:"pageSize,default=20"`
+	Keyword   string `json:"keyword,optional"`   // 匹配路径或标题
+	Authority string `json:"authority,optional"` // host:port
+	Status    int    `json:"status,optional"`    // 状态码
+	TaskId    string `json:"taskId,optional"`
+}
+
+type DirScanItem struct {
+	Id          string `json:"id"`
+	Authority   string `json:"authority"`
+	Host        string `json:"host"`
+	Port        int    `json:"port"`
+	Url         string `json:"url"`
+	Path        string `json:"path"`
+	Status      int    `json:"status"`
+	Length      int    `json:"length"`
+	Title       string `json:"title"`
+	ContentType string `json:"contentType"`
+	Location    string `json:"location"`
+	TaskId      string `json:"taskId"`
+	CreateTime  string `json:"createTime"`
+	UpdateTime  string `json:"updateTime"`
+}
+
+type DirScanListResp struct {
+	Code  int           `json:"code"`
+	Msg   string        `json:"msg"`
+	Total int           `json:"total"`
+	List  []DirScanItem `json:"list"`
+}
+
+type SecretListReq struct {
+	Page      int    `json:"page,default=1"`
+	PageSize  int    `json:"pageSize,default=20"`
+	Keyword   string `json:"keyword,optional"`   // 匹配URL
+	Authority string `json:"authority,optional"` // host:port
+	Rule      string `json:"rule,optional"`
+	Category  string `json:"category,optional"`
+	Severity  string `json:"severity,optional"`
+	Location  string `json:"location,optional"` // header/body/js
+	TaskId    string `json:"taskId,optional"`
+}
+
+type SecretItem struct {
+	Id         string `json:"id"`
+	Authority  string `json:"authority"`
+	Host       string `json:"host"`
+	Port       int    `json:"port"`
+	Url        string `json:"url"`
+	Location   string `json:"location"`
+	Rule       string `json:"rule"`
+	Category   string `json:"category"`
+	Severity   string `json:"severity"`
+	Evidence   string `json:"evidence"` // 已脱敏
+	TaskId     string `json:"taskId"`
+	CreateTime string `json:"createTime"`
+	UpdateTime string `json:"updateTime"`
+}
+
+type SecretListResp struct {
+	Code  int            `json:"code"`
+	Msg   string         `json:"msg"`
+	Total int            `json:"total"`
+	List  []SecretItem   `json:"list"`
+	Stat  map[string]int `json:"stat"` // 当前筛选条件下按严重级别统计
+}
+
+// ==================== 证书管理 ====================
+type CertListReq struct {
+	Page       int    `json:"page,default=1"`
+	PageSize   int    `json:"pageSize,default=20"`
+	Keyword    string `json:"keyword,optional"`    // 匹配主题/颁发者/SAN/指纹
+	Status     string `json:"status,optional"`     // expired/expiring/valid
+	ExpireDays int    `json:"expireDays,optional"` // 即将过期阈值(天)，默认30
+	SelfSigned bool   `json:"selfSigned,optional"` // 只看自签名证书
+	OrgId      string `json:"orgId,optional"`
+}
+
+type CertItem struct {
+	Sha256      string   `json:"sha256"`
+	Subject     string   `json:"subject"`
+	SubjectDN   string   `json:"subjectDn"`
+	Issuer      string   `json:"issuer"`
+	IssuerDN    string   `json:"issuerDn"`
+	Sans        []string `json:"sans"`
+	NotBefore   string   `json:"notBefore"`
+	NotAfter    string   `json:"notAfter"`
+	DaysLeft    int      `json:"daysLeft"`
+	Status      string   `json:"status"` // expired/expiring/valid
+	SelfSigned  bool     `json:"selfSigned"`
+	Trusted     bool     `json:"trusted"`
+	SigAlg      string   `json:"sigAlg"`
+	KeyAlg      string   `json:"keyAlg"`
+	KeyBits     int      `json:"keyBits"`
+	TlsVersion  string   `json:"tlsVersion"`
+	Jarm        string   `json:"jarm,omitempty"`
+	ChainLength int      `json:"chainLength"`
+	Assets      []string `json:"assets"`
+	AssetCount  int      `json:"assetCount"`
+	LastSeen    string   `json:"lastSeen"`
+}
+
+type CertListResp struct {
+	Code     int        `json:"code"`
+	Msg      string     `json:"msg"`
+	Total    int        `json:"total"`
+	Expired  int        `json:"expired"`  // 已过期证书数
+	Expiring int        `json:"expiring"` // 即将过期证书数
+	List     []CertItem `json:"list"`
+}
+
 // ==================== 站点管理 ====================
 type SiteListReq struct {
 	Page       int    `json:"page,default=1"`
@@ -258,24 +550,26 @@ type SiteListReq struct {
 	App        string `json:"app,optional"`
 	HttpStatus string `json:"httpStatus,optional"`
 	OrgId      string `json:"orgId,optional"`
+	Query      string `json:"query,optional"` // 语法查询，与资产列表相同
 }
 
 type Site struct {
-	Id         string   `json:"id"`
-	Site       string   `json:"site"`
-	Title      string   `json:"title"`
-	IP         string   `json:"ip"`
-	Port       int      `json:"port"`
-	Service    string   `json:"service"`
-	HttpStatus string   `json:"httpStatus"`
-	App        []string `json:"app"`
-	Screenshot string   `json:"screenshot"`
-	Location   string   `json:"location"`
-	OrgId      string   `json:"orgId,omitempty"`
-	OrgName    string   `json:"orgName,omitempty"`
-	UpdateTime string   `json:"updateTime"`
-	HttpHeader string   `json:"httpHeader,omitempty"`
-	IconHash   string   `json:"iconHash,omitempty"`
+	Id            string   `json:"id"`
+	Site          string   `json:"site"`
+	Title         string   `json:"title"`
+	IP            string   `json:"ip"`
+	Port          int      `json:"port"`
+	Service       string   `json:"service"`
+	HttpStatus    string   `json:"httpStatus"`
+	App           []string `json:"app"`
+	Screenshot    string   `json:"screenshot"`
+	ScreenshotUrl string   `json:"screenshotUrl,omitempty"`
+	Location      string   `json:"location"`
+	OrgId         string   `json:"orgId,omitempty"`
+	OrgName       string   `json:"orgName,omitempty"`
+	UpdateTime    string   `json:"updateTime"`
+	HttpHeader    string   `json:"httpHeader,omitempty"`
+	IconHash      string   `json:"iconHash,omitempty"`
 }
 
 type SiteListResp struct {
@@ -301,6 +595,7 @@ type DomainListReq struct {
 	RootDomain string `json:"rootDomain,optional"`
 	IP         string `json:"ip,optional"`
 	OrgId      string `json:"orgId,optional"`
+	Query      string `json:"query,optional"` // 语法查询，与资产列表相同
 }
 
 type Domain struct {
@@ -314,6 +609,26 @@ type Domain struct {
 	OrgName    string   `json:"orgName,omitempty"`
 	IsNew      bool     `json:"isNew"`
 	CreateTime string   `json:"createTime"`
+	// DNS记录，开启DNS记录收集后才有
+	DNSRecords   []DomainDNSRecord `json:"dnsRecords,omitempty"`
+	SPF          string            `json:"spf,omitempty"`
+	DMARC        string            `json:"dmarc,omitempty"`
+	ZoneTransfer []string          `json:"zoneTransfer,omitempty"` // 允许区域传送的NS
+	DNSIssues    []DomainDNSIssue  `json:"dnsIssues,omitempty"`
+	DNSTime      string            `json:"dnsTime,omitempty"` // DNS记录收集时间
+}
+
+type DomainDNSRecord struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	TTL   uint32 `json:"ttl"`
+}
+
+type DomainDNSIssue struct {
+	Type     string `json:"type"`
+	Severity string `json:"severity"`
+	Detail   string `json:"detail"`
 }
 
 type DomainListResp struct {
@@ -339,6 +654,36 @@ type DomainBatchDeleteReq struct {
 	Ids []string `json:"ids"`
 }
 
+// 子域名监控
+type SubdomainListReq struct {
+	Page       int    `json:"page,default=1"`
+	PageSize   int    `json:"pageSize,default=20"`
+	Domain     string `json:"domain,optional"`
+	RootDomain string `json:"rootDomain,optional"`
+	TaskId     string `json:"taskId,optional"` // 只返回该任务首次发现的子域名，即本次新增
+}
+
+type SubdomainItem struct {
+	Id          string   `json:"id"`
+	Domain      string   `json:"domain"`
+	RootDomain  string   `json:"rootDomain"`
+	IPs         []string `json:"ips"`
+	CName       string   `json:"cname"`
+	FirstTaskId string   `json:"firstTaskId"`
+	LastTaskId  string   `json:"lastTaskId"`
+	FirstSeen   string   `json:"firstSeen"`
+	LastSeen    string   `json:"lastSeen"`
+	ChangeTime  string   `json:"changeTime"`
+	Pending     string   `json:"pending,omitempty"` // 待确认：new 或 changed，扫描完成后才转为已知
+}
+
+type SubdomainListResp struct {
+	Code  int             `json:"code"`
+	Msg   string          `json:"msg"`
+	Total int             `json:"total"`
+	List  []SubdomainItem `json:"list"`
+}
+
 // ==================== IP管理 ====================
 type IPListReq struct {
 	Page     int    `json:"page,default=1"`
@@ -348,6 +693,7 @@ type IPListReq struct {
 	Service  string `json:"service,optional"`
 	Location string `json:"location,optional"`
 	OrgId    string `json:"orgId,optional"`
+	Query    string `json:"query,optional"` // 语法查询，与资产列表相同
 }
 
 type PortInfo struct {
@@ -413,6 +759,8 @@ type MainTask struct {
 	EndTime      string `json:"endTime"`    // 结束时间
 	SubTaskCount int    `json:"subTaskCount"` // 子任务总数
 	SubTaskDone  int    `json:"subTaskDone"`  // 已完成子任务数
+	NotifyId     string `json:"notifyId"`     // 关联的通知渠道ID
+	NewSubdomains []string `json:"newSubdomains,omitempty"` // 子域名监控确认的新增子域名
 }
 
 type MainTaskListReq struct {
@@ -438,6 +786,7 @@ type MainTaskCreateReq struct {
 	IsCron    bool     `json:"isCron,optional"`
 	CronRule  string   `json:"cronRule,optional"`
 	Workers   []string `json:"workers,optional"` // 指定执行任务的 Worker 列表
+	NotifyId  string   `json:"notifyId,optional"` // 任务结束后通知的渠道ID
 }
 
 type TaskProfile struct {
@@ -486,6 +835,7 @@ type MainTaskUpdateReq struct {
 	Name      string `json:"name,optional"`       // 任务名称
 	Target    string `json:"target,optional"`     // 扫描目标
 	ProfileId string `json:"profileId,optional"`  // 配置ID
+	NotifyId  string `json:"notifyId,optional"`   // 通知渠道ID
 }
 
 // GetTaskLogsReq 获取任务日志请求
@@ -511,6 +861,30 @@ type GetTaskLogsResp struct {
 	List []TaskLogEntry `json:"list"`
 }
 
+// TaskLeaseListReq 任务租约列表请求
+type TaskLeaseListReq struct {
+	TaskId string `json:"taskId"` // 主任务taskId
+}
+
+// TaskLease 子任务租约
+type TaskLease struct {
+	TaskId     string `json:"taskId"`
+	Worker     string `json:"worker"`
+	State      string `json:"state"` // leased: 执行中, expired: 已到期等待回收, deadletter: 重试次数用尽, unschedulable: 没有满足要求的Worker
+	Retry      int    `json:"retry"`
+	AssignTime string `json:"assignTime,omitempty"`
+	RenewTime  string `json:"renewTime,omitempty"`
+	ExpireTime string `json:"expireTime,omitempty"`
+	Reason     string `json:"reason,omitempty"` // 进入死信或无法调度的原因
+}
+
+// TaskLeaseListResp 任务租约列表响应
+type TaskLeaseListResp struct {
+	Code int         `json:"code"`
+	Msg  string      `json:"msg"`
+	List []TaskLease `json:"list"`
+}
+
 // ==================== 漏洞管理 ====================
 type Vul struct {
 	Id         string `json:"id"`
@@ -641,6 +1015,8 @@ type Worker struct {
 	Status       string            `json:"status"`
 	UpdateTime   string            `json:"updateTime"`
 	Tools        map[string]bool   `json:"tools"`        // 工具安装状态
+	Labels       map[string]string `json:"labels"`       // 标签
+	Capabilities []string          `json:"capabilities"` // 扫描能力
 }
 
 type WorkerListResp struct {
@@ -687,6 +1063,60 @@ type WorkerSetConcurrencyResp struct {
 	Msg  string `json:"msg"`
 }
 
+// Worker注册令牌和凭证
+type WorkerEnrollTokenItem struct {
+	Id         string `json:"id"`
+	Name       string `json:"name"`
+	Prefix     string `json:"prefix"`
+	MaxUses    int    `json:"maxUses"`
+	UsedCount  int    `json:"usedCount"`
+	ExpireTime string `json:"expireTime"`
+	CreatedBy  string `json:"createdBy"`
+	Status     string `json:"status"` // active/expired/exhausted/revoked
+	CreateTime string `json:"createTime"`
+}
+
+type WorkerEnrollTokenListResp struct {
+	Code int                     `json:"code"`
+	Msg  string                  `json:"msg"`
+	List []WorkerEnrollTokenItem `json:"list"`
+}
+
+type WorkerEnrollTokenCreateReq struct {
+	Name        string `json:"name"`
+	MaxUses     int    `json:"maxUses,optional"`     // 可注册的Worker数量，0表示不限
+	ExpireHours int    `json:"expireHours,optional"` // 有效小时数，0表示永不过期
+}
+
+type WorkerEnrollTokenCreateResp struct {
+	Code  int    `json:"code"`
+	Msg   string `json:"msg"`
+	Id    string `json:"id"`
+	Token string `json:"token"` // 令牌明文，仅在创建时返回一次
+}
+
+type WorkerCredentialItem struct {
+	Id           string `json:"id"`
+	WorkerName   string `json:"workerName"`
+	Prefix       string `json:"prefix"`
+	EnrollIp     string `json:"enrollIp"`
+	LastSeenTime string `json:"lastSeenTime"`
+	LastSeenIp   string `json:"lastSeenIp"`
+	Status       string `json:"status"` // active/revoked
+	RevokeTime   string `json:"revokeTime"`
+	CreateTime   string `json:"createTime"`
+}
+
+type WorkerCredentialListResp struct {
+	Code int                    `json:"code"`
+	Msg  string                 `json:"msg"`
+	List []WorkerCredentialItem `json:"list"`
+}
+
+type WorkerAuthRevokeReq struct {
+	Id string `json:"id"`
+}
+
 // ==================== 在线API搜索 ====================
 type OnlineSearchReq struct {
 	Platform string `json:"platform"` // fofa/hunter/quake
@@ -1259,17 +1689,18 @@ type ReportDetailReq struct {
 }
 
 type ReportAsset struct {
-	Authority  string   `json:"authority"`
-	Host       string   `json:"host"`
-	Port       int      `json:"port"`
-	Service    string   `json:"service"`
-	Title      string   `json:"title"`
-	App        []string `json:"app"`
-	HttpStatus string   `json:"httpStatus"`
-	Server     string   `json:"server"`
-	IconHash   string   `json:"iconHash"`
-	Screenshot string   `json:"screenshot"`
-	CreateTime string   `json:"createTime"`
+	Authority     string   `json:"authority"`
+	Host          string   `json:"host"`
+	Port          int      `json:"port"`
+	Service       string   `json:"service"`
+	Title         string   `json:"title"`
+	App           []string `json:"app"`
+	HttpStatus    string   `json:"httpStatus"`
+	Server        string   `json:"server"`
+	IconHash      string   `json:"iconHash"`
+	Screenshot    string   `json:"screenshot"`
+	ScreenshotUrl string   `json:"screenshotUrl,omitempty"`
+	CreateTime    string   `json:"createTime"`
 }
 
 type ReportVul struct {
@@ -1281,20 +1712,33 @@ type ReportVul struct {
 	CreateTime string `json:"createTime"`
 }
 
+type ReportDirScan struct {
+	Authority  string `json:"authority"`
+	Url        string `json:"url"`
+	Path       string `json:"path"`
+	Status     int    `json:"status"`
+	Length     int    `json:"length"`
+	Title      string `json:"title"`
+	Location   string `json:"location"`
+	CreateTime string `json:"createTime"`
+}
+
 type ReportData struct {
-	TaskId      string         `json:"taskId"`
-	TaskName    string         `json:"taskName"`
-	Target      string         `json:"target"`
-	Status      string         `json:"status"`
-	CreateTime  string         `json:"createTime"`
-	AssetCount  int            `json:"assetCount"`
-	VulCount    int            `json:"vulCount"`
-	Assets      []ReportAsset  `json:"assets"`
-	Vuls        []ReportVul    `json:"vuls"`
-	TopPorts    []StatItem     `json:"topPorts"`
-	TopServices []StatItem     `json:"topServices"`
-	TopApps     []StatItem     `json:"topApps"`
-	VulStats    map[string]int `json:"vulStats"`
+	TaskId       string          `json:"taskId"`
+	TaskName     string          `json:"taskName"`
+	Target       string          `json:"target"`
+	Status       string          `json:"status"`
+	CreateTime   string          `json:"createTime"`
+	AssetCount   int             `json:"assetCount"`
+	VulCount     int             `json:"vulCount"`
+	DirScanCount int             `json:"dirScanCount"`
+	Assets       []ReportAsset   `json:"assets"`
+	Vuls         []ReportVul     `json:"vuls"`
+	DirScans     []ReportDirScan `json:"dirScans"`
+	TopPorts     []StatItem      `json:"topPorts"`
+	TopServices  []StatItem      `json:"topServices"`
+	TopApps      []StatItem      `json:"topApps"`
+	VulStats     map[string]int  `json:"vulStats"`
 }
 
 type ReportDetailResp struct {
@@ -1357,3 +1801,199 @@ type SubfinderProviderInfoResp struct {
 	Msg  string                  `json:"msg"`
 	List []SubfinderProviderMeta `json:"list"`
 }
+
+// ==================== 敏感信息规则 ====================
+type SecretRule struct {
+	Id          string  `json:"id"`
+	Name        string  `json:"name"`
+	Category    string  `json:"category"`
+	Severity    string  `json:"severity"`
+	Pattern     string  `json:"pattern"`    // 正则，包含分组时以第一个分组作为敏感值
+	MinEntropy  float64 `json:"minEntropy"` // 敏感值的香农熵下限，0表示不检查
+	Validator   string  `json:"validator"`  // 内置校验器: idcard/jwt/ip/email
+	Description string  `json:"description"`
+	IsBuiltin   bool    `json:"isBuiltin"`
+	Enabled     bool    `json:"enabled"`
+	CreateTime  string  `json:"createTime"`
+	UpdateTime  string  `json:"updateTime"`
+}
+
+type SecretRuleListReq struct {
+	Page      int    `json:"page,default=1"`
+	PageSize  int    `json:"pageSize,default=50"`
+	Category  string `json:"category,optional"`
+	Keyword   string `json:"keyword,optional"` // 搜索：名称
+	IsBuiltin *bool  `json:"isBuiltin,optional"`
+}
+
+type SecretRuleListResp struct {
+	Code       int          `json:"code"`
+	Msg        string       `json:"msg"`
+	Total      int          `json:"total"`
+	List       []SecretRule `json:"list"`
+	Validators []string     `json:"validators"` // 可选的内置校验器
+}
+
+type SecretRuleSaveReq struct {
+	Id          string  `json:"id,optional"`
+	Name        string  `json:"name"`
+	Category    string  `json:"category"`
+	Severity    string  `json:"severity"`
+	Pattern     string  `json:"pattern"`
+	MinEntropy  float64 `json:"minEntropy,optional"`
+	Validator   string  `json:"validator,optional"`
+	Description string  `json:"description,optional"`
+	Enabled     bool    `json:"enabled"`
+}
+
+type SecretRuleDeleteReq struct {
+	Id string `json:"id"`
+}
+
+type SecretRuleUpdateEnabledReq struct {
+	Id      string `json:"id"`
+	Enabled bool   `json:"enabled"`
+}
+
+// ==================== 爆破字典 ====================
+type Wordlist struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Type        string `json:"type"` // dir/subdomain
+	Description string `json:"description"`
+	Count       int    `json:"count"` // 条目数
+	Enabled     bool   `json:"enabled"`
+	CreateTime  string `json:"createTime"`
+	UpdateTime  string `json:"updateTime"`
+}
+
+type WordlistListReq struct {
+	Page     int    `json:"page,default=1"`
+	PageSize int    `json:"pageSize,default=20"`
+	Type     string `json:"type,optional"`
+	Keyword  string `json:"keyword,optional"` // 搜索：名称
+}
+
+type WordlistListResp struct {
+	Code  int        `json:"code"`
+	Msg   string     `json:"msg"`
+	Total int        `json:"total"`
+	List  []Wordlist `json:"list"`
+}
+
+type WordlistSaveReq struct {
+	Id          string `json:"id,optional"`
+	Name        string `json:"name"`
+	Type        string `json:"type,optional"` // 默认dir
+	Description string `json:"description,optional"`
+	Content     string `json:"content,optional"` // 字典内容，每行一条，#开头为注释；编辑时为空表示不修改
+	Enabled     bool   `json:"enabled"`
+}
+
+type WordlistDeleteReq struct {
+	Id string `json:"id"`
+}
+
+type WordlistDetailReq struct {
+	Id string `json:"id"`
+}
+
+type WordlistDetailResp struct {
+	Code    int       `json:"code"`
+	Msg     string    `json:"msg"`
+	Data    *Wordlist `json:"data,omitempty"`
+	Content string    `json:"content"` // 字典内容，每行一条
+}
+
+// ==================== 通知配置 ====================
+type NotifyConfig struct {
+	Id          string   `json:"id"`
+	Name        string   `json:"name"`
+	Type        string   `json:"type"` // webhook/dingtalk/wecom/feishu/email
+	WebhookUrl  string   `json:"webhookUrl"`
+	Secret      string   `json:"secret"` // 脱敏后
+	SmtpHost    string   `json:"smtpHost"`
+	SmtpPort    int      `json:"smtpPort"`
+	SmtpUser    string   `json:"smtpUser"`
+	SmtpPass    string   `json:"smtpPass"` // 脱敏后
+	SmtpFrom    string   `json:"smtpFrom"`
+	SmtpTo      []string `json:"smtpTo"`
+	Template    string   `json:"template"`
+	Events      []string `json:"events"`      // success/failure/vul
+	MinSeverity string   `json:"minSeverity"` // vul事件的最低漏洞等级
+	Status      string   `json:"status"`
+	CreateTime  string   `json:"createTime"`
+}
+
+type NotifyConfigListResp struct {
+	Code  int            `json:"code"`
+	Msg   string         `json:"msg"`
+	Total int            `json:"total"`
+	List  []NotifyConfig `json:"list"`
+}
+
+type NotifyConfigSaveReq struct {
+	Id          string   `json:"id,optional"`
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	WebhookUrl  string   `json:"webhookUrl,optional"`
+	Secret      string   `json:"secret,optional"` // 为空或脱敏值时保留原密钥
+	SmtpHost    string   `json:"smtpHost,optional"`
+	SmtpPort    int      `json:"smtpPort,optional"`
+	SmtpUser    string   `json:"smtpUser,optional"`
+	SmtpPass    string   `json:"smtpPass,optional"` // 为空或脱敏值时保留原密码
+	SmtpFrom    string   `json:"smtpFrom,optional"`
+	SmtpTo      []string `json:"smtpTo,optional"`
+	Template    string   `json:"template,optional"`
+	Events      []string `json:"events,optional"`
+	MinSeverity string   `json:"minSeverity,optional"`
+	Status      string   `json:"status,optional"`
+}
+
+type NotifyConfigDeleteReq struct {
+	Id string `json:"id"`
+}
+
+type NotifyConfigTestReq struct {
+	Id string `json:"id"`
+}
+
+// ==================== 审计日志 ====================
+type AuditLogListReq struct {
+	Page        int    `json:"page,default=1"`
+	PageSize    int    `json:"pageSize,default=20"`
+	Username    string `json:"username,optional"`
+	WorkspaceId string `json:"workspaceId,optional"`
+	Path        string `json:"path,optional"`   // 路由，模糊匹配
+	Status      string `json:"status,optional"` // success/failed
+	StartTime   string `json:"startTime,optional"`
+	EndTime     string `json:"endTime,optional"`
+}
+
+type AuditLogItem struct {
+	Id          string `json:"id"`
+	Username    string `json:"username"`
+	AuthType    string `json:"authType"`
+	WorkspaceId string `json:"workspaceId"`
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	Summary     string `json:"summary"`
+	Ip          string `json:"ip"`
+	Status      int    `json:"status"`
+	Code        int    `json:"code"`
+	Msg         string `json:"msg"`
+	Duration    int64  `json:"duration"`
+	CreateTime  string `json:"createTime"`
+}
+
+type AuditLogListResp struct {
+	Code  int            `json:"code"`
+	Msg   string         `json:"msg"`
+	Total int            `json:"total"`
+	List  []AuditLogItem `json:"list"`
+}
+
+// ==================== 对象存储 ====================
+type BlobGetReq struct {
+	Hash string `path:"hash"` // 内容SHA256
+}
@@ -0,0 +1,192 @@
+// blobmigrate 将历史数据中内联保存的截图、图标和超长响应体迁移到对象存储。
+// 使用RPC服务的配置文件，保证与新数据写入同一存储：
+//
+//	blobmigrate -f rpc/task/etc/task.yaml [-dry-run]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"cscan/pkg/blob"
+
+	"github.com/zeromicro/go-zero/core/conf"
+	"github.com/zeromicro/go-zero/core/logx"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	configFile = flag.String("f", "etc/task.yaml", "the config file (Mongo and Blob sections are used)")
+	dryRun     = flag.Bool("dry-run", false, "only count documents that would be migrated")
+	batchSize  = flag.Int("batch", 100, "cursor batch size")
+)
+
+type Config struct {
+	Mongo struct {
+		Uri    string
+		DbName string
+	}
+	Blob blob.Config `json:",optional"`
+}
+
+// inlineDoc 需要迁移的字段
+type inlineDoc struct {
+	Id            primitive.ObjectID `bson:"_id"`
+	HttpBody      string             `bson:"body"`
+	IconHashBytes []byte             `bson:"icon_hash_bytes"`
+	Screenshot    string             `bson:"screenshot"`
+}
+
+type stats struct {
+	docs, screenshots, icons, bodies, failed int
+}
+
+func main() {
+	flag.Parse()
+	logx.DisableStat()
+
+	var c Config
+	conf.MustLoad(*configFile, &c, conf.UseEnv())
+
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(c.Mongo.Uri))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "connect mongo failed: %v\n", err)
+		os.Exit(1)
+	}
+	defer client.Disconnect(ctx)
+	db := client.Database(c.Mongo.DbName)
+
+	store, err := blob.New(c.Blob)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "init blob store failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	names, err := db.ListCollectionNames(ctx, bson.M{"name": bson.M{"$regex": "_asset(_history)?$"}})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "list collections failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	var total stats
+	for _, name := range names {
+		s, err := migrateCollection(ctx, db.Collection(name), store, c.Blob.InlineLimit())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "migrate %s failed: %v\n", name, err)
+			os.Exit(1)
+		}
+		fmt.Printf("%-40s docs=%d screenshots=%d icons=%d bodies=%d failed=%d\n", name, s.docs, s.screenshots, s.icons, s.bodies, s.failed)
+		total.docs += s.docs
+		total.screenshots += s.screenshots
+		total.icons += s.icons
+		total.bodies += s.bodies
+		total.failed += s.failed
+	}
+
+	mode := "migrated"
+	if *dryRun {
+		mode = "to migrate (dry run)"
+	}
+	fmt.Printf("total %s: docs=%d screenshots=%d icons=%d bodies=%d failed=%d\n", mode, total.docs, total.screenshots, total.icons, total.bodies, total.failed)
+}
+
+func migrateCollection(ctx context.Context, coll *mongo.Collection, store blob.Store, inlineLimit int) (stats, error) {
+	var s stats
+	filter := bson.M{"$or": []bson.M{
+		{"screenshot": bson.M{"$exists": true, "$ne": ""}},
+		{"icon_hash_bytes": bson.M{"$exists": true, "$ne": nil}},
+		{"$expr": bson.M{"$gt": bson.A{bson.M{"$strLenBytes": bson.M{"$ifNull": bson.A{"$body", ""}}}, inlineLimit}}},
+	}}
+	opts := options.Find().
+		SetProjection(bson.M{"body": 1, "icon_hash_bytes": 1, "screenshot": 1}).
+		SetBatchSize(int32(*batchSize))
+
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return s, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc inlineDoc
+		if err := cursor.Decode(&doc); err != nil {
+			s.failed++
+			continue
+		}
+		s.docs++
+
+		set := bson.M{}
+		unset := bson.M{}
+
+		if doc.Screenshot != "" {
+			s.screenshots++
+			if !*dryRun {
+				if hash, err := putScreenshot(ctx, store, doc.Screenshot); err != nil {
+					logx.Errorf("%s %s screenshot: %v", coll.Name(), doc.Id.Hex(), err)
+					s.failed++
+				} else {
+					set["screenshot_ref"] = hash
+					unset["screenshot"] = ""
+				}
+			}
+		}
+
+		if len(doc.IconHashBytes) > 0 {
+			s.icons++
+			if !*dryRun {
+				if hash, err := store.Put(ctx, doc.IconHashBytes); err != nil {
+					logx.Errorf("%s %s icon: %v", coll.Name(), doc.Id.Hex(), err)
+					s.failed++
+				} else {
+					set["icon_ref"] = hash
+					unset["icon_hash_bytes"] = ""
+				}
+			}
+		}
+
+		if len(doc.HttpBody) > inlineLimit {
+			s.bodies++
+			if !*dryRun {
+				if hash, err := store.Put(ctx, []byte(doc.HttpBody)); err != nil {
+					logx.Errorf("%s %s body: %v", coll.Name(), doc.Id.Hex(), err)
+					s.failed++
+				} else {
+					set["body_ref"] = hash
+					set["body"] = blob.TruncateBody(doc.HttpBody, inlineLimit)
+				}
+			}
+		}
+
+		if len(set) == 0 {
+			continue
+		}
+		update := bson.M{"$set": set}
+		if len(unset) > 0 {
+			update["$unset"] = unset
+		}
+		updateCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		_, err := coll.UpdateByID(updateCtx, doc.Id, update)
+		cancel()
+		if err != nil {
+			logx.Errorf("%s %s update: %v", coll.Name(), doc.Id.Hex(), err)
+			s.failed++
+		}
+	}
+	return s, cursor.Err()
+}
+
+func putScreenshot(ctx context.Context, store blob.Store, screenshot string) (string, error) {
+	data, err := blob.DecodeScreenshot(strings.TrimSpace(screenshot))
+	if err != nil {
+		return "", err
+	}
+	return store.Put(ctx, data)
+}
@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
+	"cscan/pkg/cdn"
+	"cscan/pkg/rpcauth"
+	"cscan/pkg/takeover"
 	"cscan/worker"
 
 	"github.com/zeromicro/go-zero/core/logx"
@@ -14,11 +18,22 @@ import (
 )
 
 var (
-	serverAddr  = flag.String("s", "localhost:9000", "server address")
-	redisAddr   = flag.String("r", "localhost:6379", "redis address for log streaming")
-	redisPass   = flag.String("rp", "", "redis password")
-	workerName  = flag.String("n", "", "worker name (default: hostname-pid)")
-	concurrency = flag.Int("c", 5, "concurrency")
+	serverAddr   = flag.String("s", "localhost:9000", "server address")
+	redisAddr    = flag.String("r", "", "deprecated, logs and status are reported through the server")
+	redisPass    = flag.String("rp", "", "deprecated, logs and status are reported through the server")
+	workerName   = flag.String("n", "", "worker name (default: hostname-pid)")
+	concurrency  = flag.Int("c", 5, "concurrency")
+	cdnData      = flag.String("cdn", "", "cdn/waf/cloud provider data file (default: built-in)")
+	takeoverData = flag.String("takeover", "", "subdomain takeover fingerprint file (default: built-in)")
+	labels       = flag.String("labels", "", "worker labels used to route tasks, e.g. region=cn,zone=dmz")
+
+	enrollToken    = flag.String("enroll", "", "enroll token, used to obtain a worker credential when no credential file exists")
+	credentialFile = flag.String("cred", worker.DefaultCredentialFile, "worker credential file")
+	useTLS         = flag.Bool("tls", false, "connect to server with TLS")
+	tlsCA          = flag.String("tls-ca", "", "CA file to verify the server certificate (default: system CAs)")
+	tlsCert        = flag.String("tls-cert", "", "client certificate file for mTLS")
+	tlsKey         = flag.String("tls-key", "", "client key file for mTLS")
+	tlsServerName  = flag.String("tls-server-name", "", "server name to verify (default: server address host)")
 )
 
 func main() {
@@ -28,12 +43,40 @@ func main() {
 	stat.DisableLog()
 	logx.DisableStat()
 
+	// 加载外部CDN特征数据
+	if *cdnData != "" {
+		if err := cdn.LoadFile(*cdnData); err != nil {
+			logx.Errorf("load cdn data failed: %v", err)
+			os.Exit(1)
+		}
+	}
+
+	// 加载外部子域名接管特征数据
+	if *takeoverData != "" {
+		if err := takeover.LoadFile(*takeoverData); err != nil {
+			logx.Errorf("load takeover data failed: %v", err)
+			os.Exit(1)
+		}
+	}
+
+	// Worker只需连接任务服务，Redis仅在服务端使用
+	if *redisAddr != "" || *redisPass != "" {
+		fmt.Println("[Worker] -r/-rp flags are deprecated and ignored, logs and status are reported through the server")
+	}
+
 	// 生成Worker名称
 	name := *workerName
 	if name == "" {
 		name = worker.GetWorkerName()
 	}
 
+	// 解析Worker标签
+	workerLabels, err := worker.ParseLabels(*labels)
+	if err != nil {
+		logx.Errorf("parse labels failed: %v", err)
+		os.Exit(1)
+	}
+
 	// 获取本机IP
 	ip := worker.GetLocalIP()
 
@@ -41,10 +84,19 @@ func main() {
 		Name:        name,
 		IP:          ip,
 		ServerAddr:  *serverAddr,
-		RedisAddr:   *redisAddr,
-		RedisPass:   *redisPass,
 		Concurrency: *concurrency,
 		Timeout:     3600,
+		Labels:      workerLabels,
+
+		EnrollToken:    *enrollToken,
+		CredentialFile: *credentialFile,
+		UseTLS:         *useTLS || *tlsCA != "" || *tlsCert != "",
+		TLS: rpcauth.TLSConf{
+			CertFile:   *tlsCert,
+			KeyFile:    *tlsKey,
+			CAFile:     *tlsCA,
+			ServerName: *tlsServerName,
+		},
 	}
 
 	w, err := worker.NewWorker(config)
@@ -57,10 +109,14 @@ func main() {
 	w.Start()
 
 	fmt.Printf("Worker started:\n")
-	fmt.Printf("  Name: %s\n", name)
+	fmt.Printf("  Name: %s\n", w.Name())
 	fmt.Printf("  IP: %s\n", ip)
 	fmt.Printf("  Server: %s\n", *serverAddr)
 	fmt.Printf("  Concurrency: %d\n", *concurrency)
+	if *labels != "" {
+		fmt.Printf("  Labels: %s\n", *labels)
+	}
+	fmt.Printf("  Capabilities: %s\n", strings.Join(w.Capabilities(), ","))
 
 	// 等待退出信号
 	quit := make(chan os.Signal, 1)
@@ -15,8 +15,8 @@ var defaultWorkspaceId = workspaceResult.insertedId.toString();
 // 创建用户集合并插入默认管理员，关联默认工作空间
 db.user.insertOne({
     username: "admin",
-    password: "e10adc3949ba59abbe56e057f20f883e", // 123456的MD5
-    role: "superadmin",
+    password: "e10adc3949ba59abbe56e057f20f883e", // 123456的MD5，首次登录后自动升级为bcrypt
+    role: "admin",
     status: "enable",
     workspace_ids: [defaultWorkspaceId],
     create_time: new Date(),
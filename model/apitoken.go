@@ -0,0 +1,146 @@
+package model
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ApiTokenPrefix API令牌前缀，用于和JWT区分
+const ApiTokenPrefix = "cst_"
+
+// ApiToken 个人API令牌，只保存令牌的SHA256
+type ApiToken struct {
+	Id           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserId       string             `bson:"user_id" json:"userId"`
+	Username     string             `bson:"username" json:"username"`
+	Name         string             `bson:"name" json:"name"`
+	TokenHash    string             `bson:"token_hash" json:"-"`
+	Prefix       string             `bson:"prefix" json:"prefix"` // 令牌前几位，便于用户识别
+	Scopes       []string           `bson:"scopes" json:"scopes"`
+	ExpireTime   *time.Time         `bson:"expire_time,omitempty" json:"expireTime"`
+	LastUsedTime *time.Time         `bson:"last_used_time,omitempty" json:"lastUsedTime"`
+	LastUsedIp   string             `bson:"last_used_ip,omitempty" json:"lastUsedIp"`
+	Revoked      bool               `bson:"revoked" json:"revoked"`
+	CreateTime   time.Time          `bson:"create_time" json:"createTime"`
+}
+
+// Expired 令牌是否已过期
+func (t *ApiToken) Expired() bool {
+	return t.ExpireTime != nil && time.Now().After(*t.ExpireTime)
+}
+
+type ApiTokenModel struct {
+	coll *mongo.Collection
+}
+
+func NewApiTokenModel(db *mongo.Database) *ApiTokenModel {
+	coll := db.Collection("api_token")
+
+	// 创建索引
+	ctx := context.Background()
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "create_time", Value: -1}}},
+	}
+	coll.Indexes().CreateMany(ctx, indexes)
+
+	return &ApiTokenModel{
+		coll: coll,
+	}
+}
+
+// GenerateApiToken 生成新的API令牌，返回明文和哈希，明文只在创建时返回给用户
+func GenerateApiToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token := ApiTokenPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashApiToken(token), nil
+}
+
+// HashApiToken 计算令牌哈希，令牌本身是高熵随机串，无需慢哈希
+func HashApiToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsApiToken 判断认证串是否为API令牌
+func IsApiToken(token string) bool {
+	return strings.HasPrefix(token, ApiTokenPrefix)
+}
+
+func (m *ApiTokenModel) Insert(ctx context.Context, doc *ApiToken) error {
+	if doc.Id.IsZero() {
+		doc.Id = primitive.NewObjectID()
+	}
+	doc.CreateTime = time.Now()
+	_, err := m.coll.InsertOne(ctx, doc)
+	return err
+}
+
+// FindActiveByToken 根据令牌明文查找未吊销的令牌，未找到返回nil
+func (m *ApiTokenModel) FindActiveByToken(ctx context.Context, token string) (*ApiToken, error) {
+	var doc ApiToken
+	err := m.coll.FindOne(ctx, bson.M{"token_hash": HashApiToken(token), "revoked": false}).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func (m *ApiTokenModel) FindByUser(ctx context.Context, userId string) ([]ApiToken, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "create_time", Value: -1}})
+	cursor, err := m.coll.Find(ctx, bson.M{"user_id": userId}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []ApiToken
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// UpdateLastUsed 记录令牌最后使用时间和来源IP
+func (m *ApiTokenModel) UpdateLastUsed(ctx context.Context, id primitive.ObjectID, ip string) error {
+	_, err := m.coll.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{
+		"last_used_time": time.Now(),
+		"last_used_ip":   ip,
+	}})
+	return err
+}
+
+// Revoke 吊销令牌，保留记录便于审计；返回是否找到该用户的令牌
+func (m *ApiTokenModel) Revoke(ctx context.Context, id, userId string) (bool, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+	result, err := m.coll.UpdateOne(ctx, bson.M{"_id": oid, "user_id": userId}, bson.M{"$set": bson.M{"revoked": true}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// RevokeByUser 吊销用户的全部令牌，用于删除或禁用用户
+func (m *ApiTokenModel) RevokeByUser(ctx context.Context, userId string) error {
+	_, err := m.coll.UpdateMany(ctx, bson.M{"user_id": userId}, bson.M{"$set": bson.M{"revoked": true}})
+	return err
+}
@@ -14,11 +14,23 @@ type IPV4 struct {
 	IPName   string `bson:"ip" json:"ip"`
 	IPInt    uint32 `bson:"uint32" json:"uint32"`
 	Location string `bson:"location" json:"location"`
+	Country  string `bson:"country,omitempty" json:"country,omitempty"`
+	Region   string `bson:"region,omitempty" json:"region,omitempty"`
+	City     string `bson:"city,omitempty" json:"city,omitempty"`
+	ISP      string `bson:"isp,omitempty" json:"isp,omitempty"`
+	ASN      int    `bson:"asn,omitempty" json:"asn,omitempty"`
+	Org      string `bson:"org,omitempty" json:"org,omitempty"` // ASN所属组织
 }
 
 type IPV6 struct {
 	IPName   string `bson:"ip" json:"ip"`
 	Location string `bson:"location" json:"location"`
+	Country  string `bson:"country,omitempty" json:"country,omitempty"`
+	Region   string `bson:"region,omitempty" json:"region,omitempty"`
+	City     string `bson:"city,omitempty" json:"city,omitempty"`
+	ISP      string `bson:"isp,omitempty" json:"isp,omitempty"`
+	ASN      int    `bson:"asn,omitempty" json:"asn,omitempty"`
+	Org      string `bson:"org,omitempty" json:"org,omitempty"`
 }
 
 type IP struct {
@@ -27,39 +39,44 @@ type IP struct {
 }
 
 type Asset struct {
-	Id            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	Authority     string             `bson:"authority" json:"authority"`
-	Host          string             `bson:"host" json:"host"`
-	Port          int                `bson:"port" json:"port"`
-	Category      string             `bson:"category" json:"category"`
-	Ip            IP                 `bson:"ip" json:"ip"`
-	Domain        string             `bson:"domain,omitempty" json:"domain"`
-	Service       string             `bson:"service,omitempty" json:"service"`
-	Server        string             `bson:"server,omitempty" json:"server"`
-	Banner        string             `bson:"banner,omitempty" json:"banner"`
-	Title         string             `bson:"title,omitempty" json:"title"`
-	App           []string           `bson:"app,omitempty" json:"app"`
-	HttpStatus    string             `bson:"status,omitempty" json:"httpStatus"`
-	HttpHeader    string             `bson:"header,omitempty" json:"httpHeader"`
-	HttpBody      string             `bson:"body,omitempty" json:"httpBody"`
-	Cert          string             `bson:"cert,omitempty" json:"cert"`
-	IconHash      string             `bson:"icon_hash,omitempty" json:"iconHash"`
-	IconHashFile  string             `bson:"icon_hash_file,omitempty" json:"iconHashFile"`
-	IconHashBytes []byte             `bson:"icon_hash_bytes,omitempty" json:"-"`
-	Screenshot    string             `bson:"screenshot,omitempty" json:"screenshot"`
-	OrgId         string             `bson:"org_id,omitempty" json:"orgId"`
-	ColorTag      string             `bson:"color,omitempty" json:"colorTag"`
-	Memo          string             `bson:"memo,omitempty" json:"memo"`
-	IsCDN         bool               `bson:"cdn,omitempty" json:"isCdn"`
-	CName         string             `bson:"cname,omitempty" json:"cname"`
-	IsCloud       bool               `bson:"cloud,omitempty" json:"isCloud"`
-	IsHTTP        bool               `bson:"is_http" json:"isHttp"`
-	IsNewAsset    bool               `bson:"new" json:"isNew"`
-	IsUpdated     bool               `bson:"update" json:"isUpdated"`
-	TaskId        string             `bson:"taskId" json:"taskId"`
-	Source        string             `bson:"source,omitempty" json:"source"`
-	CreateTime    time.Time          `bson:"create_time" json:"createTime"`
-	UpdateTime    time.Time          `bson:"update_time" json:"updateTime"`
+	Id             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Authority      string             `bson:"authority" json:"authority"`
+	Host           string             `bson:"host" json:"host"`
+	Port           int                `bson:"port" json:"port"`
+	Category       string             `bson:"category" json:"category"`
+	Ip             IP                 `bson:"ip" json:"ip"`
+	Domain         string             `bson:"domain,omitempty" json:"domain"`
+	Service        string             `bson:"service,omitempty" json:"service"`
+	Server         string             `bson:"server,omitempty" json:"server"`
+	Banner         string             `bson:"banner,omitempty" json:"banner"`
+	Title          string             `bson:"title,omitempty" json:"title"`
+	App            []string           `bson:"app,omitempty" json:"app"`
+	HttpStatus     string             `bson:"status,omitempty" json:"httpStatus"`
+	HttpHeader     string             `bson:"header,omitempty" json:"httpHeader"`
+	HttpBody       string             `bson:"body,omitempty" json:"httpBody"`    // 超长时只保留开头部分，完整内容见BodyRef
+	BodyRef        string             `bson:"body_ref,omitempty" json:"bodyRef"` // 完整响应体在对象存储中的SHA256
+	Cert           string             `bson:"cert,omitempty" json:"cert"`        // 叶子证书SHA256指纹
+	TLS            *AssetTLS          `bson:"tls,omitempty" json:"tls,omitempty"`
+	IconHash       string             `bson:"icon_hash,omitempty" json:"iconHash"`
+	IconHashFile   string             `bson:"icon_hash_file,omitempty" json:"iconHashFile"`
+	IconHashBytes  []byte             `bson:"icon_hash_bytes,omitempty" json:"-"`              // 旧数据内联的图标，新数据见IconRef
+	IconRef        string             `bson:"icon_ref,omitempty" json:"iconRef"`               // 图标在对象存储中的SHA256
+	Screenshot     string             `bson:"screenshot,omitempty" json:"screenshot"`          // 旧数据内联的base64截图，新数据见ScreenshotRef
+	ScreenshotRef  string             `bson:"screenshot_ref,omitempty" json:"screenshotRef"`   // 截图在对象存储中的SHA256
+	ScreenshotHash string             `bson:"screenshot_hash,omitempty" json:"screenshotHash"` // 截图感知哈希(dHash)，用于相似页面聚类
+	OrgId          string             `bson:"org_id,omitempty" json:"orgId"`
+	ColorTag       string             `bson:"color,omitempty" json:"colorTag"`
+	Memo           string             `bson:"memo,omitempty" json:"memo"`
+	IsCDN          bool               `bson:"cdn,omitempty" json:"isCdn"`
+	CName          string             `bson:"cname,omitempty" json:"cname"`
+	IsCloud        bool               `bson:"cloud,omitempty" json:"isCloud"`
+	IsHTTP         bool               `bson:"is_http" json:"isHttp"`
+	IsNewAsset     bool               `bson:"new" json:"isNew"`
+	IsUpdated      bool               `bson:"update" json:"isUpdated"`
+	TaskId         string             `bson:"taskId" json:"taskId"`
+	Source         string             `bson:"source,omitempty" json:"source"`
+	CreateTime     time.Time          `bson:"create_time" json:"createTime"`
+	UpdateTime     time.Time          `bson:"update_time" json:"updateTime"`
 
 	// 新增字段 - 风险评分
 	RiskScore float64 `bson:"risk_score,omitempty" json:"riskScore,omitempty"` // 0-100
@@ -83,6 +100,13 @@ func NewAssetModel(db *mongo.Database, workspaceId string) *AssetModel {
 		{Keys: bson.D{{Key: "app", Value: 1}}},
 		// 新增索引 - 支持按风险评分排序
 		{Keys: bson.D{{Key: "risk_score", Value: -1}}},
+		{Keys: bson.D{{Key: "ip.ipv4.asn", Value: 1}}},
+		{Keys: bson.D{{Key: "tls.sha256", Value: 1}}},
+		{Keys: bson.D{{Key: "screenshot_hash", Value: 1}}},
+		// 对象存储引用，用于下载时校验对象属于该工作空间
+		{Keys: bson.D{{Key: "body_ref", Value: 1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "icon_ref", Value: 1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "screenshot_ref", Value: 1}}, Options: options.Index().SetSparse(true)},
 	}
 	coll.Indexes().CreateMany(ctx, indexes)
 
@@ -135,6 +159,22 @@ func (m *AssetModel) FindByHostPort(ctx context.Context, host string, port int)
 	return &doc, err
 }
 
+// FindPortAssetsByHost 查找主机下所有带端口的资产
+func (m *AssetModel) FindPortAssetsByHost(ctx context.Context, host string) ([]Asset, error) {
+	opts := options.Find().SetProjection(bson.M{"body": 0, "header": 0, "icon_hash_bytes": 0})
+	cursor, err := m.coll.Find(ctx, bson.M{"host": host, "port": bson.M{"$gt": 0}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []Asset
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
 func (m *AssetModel) Find(ctx context.Context, filter bson.M, page, pageSize int) ([]Asset, error) {
 	return m.FindWithSort(ctx, filter, page, pageSize, "update_time")
 }
@@ -379,6 +419,7 @@ func (m *AssetModel) AggregateApp(ctx context.Context, limit int) ([]StatResult,
 type IconHashStatResult struct {
 	IconHash string `bson:"_id"`
 	IconData []byte `bson:"iconData"`
+	IconRef  string `bson:"iconRef"`
 	Count    int    `bson:"count"`
 }
 
@@ -393,6 +434,7 @@ func (m *AssetModel) AggregateIconHash(ctx context.Context, limit int) ([]IconHa
 		{{Key: "$group", Value: bson.D{
 			{Key: "_id", Value: "$icon_hash"},
 			{Key: "iconData", Value: bson.D{{Key: "$first", Value: "$icon_hash_bytes"}}},
+			{Key: "iconRef", Value: bson.D{{Key: "$max", Value: "$icon_ref"}}},
 			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
 		}}},
 		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}}}},
@@ -412,24 +454,71 @@ func (m *AssetModel) AggregateIconHash(ctx context.Context, limit int) ([]IconHa
 	return results, nil
 }
 
+// ScreenshotHashStatResult 截图哈希统计结果，附带一个使用该截图的资产
+type ScreenshotHashStatResult struct {
+	ScreenshotHash string             `bson:"_id"`
+	ScreenshotRef  string             `bson:"screenshotRef"`
+	AssetId        primitive.ObjectID `bson:"assetId"`
+	Host           string             `bson:"host"`
+	Port           int                `bson:"port"`
+	Title          string             `bson:"title"`
+	Count          int                `bson:"count"`
+}
+
+// AggregateScreenshotHash 按截图感知哈希分组统计，返回所有不同的哈希，按数量降序
+func (m *AssetModel) AggregateScreenshotHash(ctx context.Context) ([]ScreenshotHashStatResult, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "screenshot_hash", Value: bson.D{{Key: "$exists", Value: true}, {Key: "$ne", Value: ""}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$screenshot_hash"},
+			{Key: "screenshotRef", Value: bson.D{{Key: "$max", Value: "$screenshot_ref"}}},
+			{Key: "assetId", Value: bson.D{{Key: "$first", Value: "$_id"}}},
+			{Key: "host", Value: bson.D{{Key: "$first", Value: "$host"}}},
+			{Key: "port", Value: bson.D{{Key: "$first", Value: "$port"}}},
+			{Key: "title", Value: bson.D{{Key: "$first", Value: "$title"}}},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}}}},
+	}
+
+	cursor, err := m.coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []ScreenshotHashStatResult
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
 // AssetHistory 资产历史记录
 type AssetHistory struct {
-	Id         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	AssetId    string             `bson:"assetId" json:"assetId"`
-	Authority  string             `bson:"authority" json:"authority"`
-	Host       string             `bson:"host" json:"host"`
-	Port       int                `bson:"port" json:"port"`
-	Service    string             `bson:"service,omitempty" json:"service"`
-	Title      string             `bson:"title,omitempty" json:"title"`
-	App        []string           `bson:"app,omitempty" json:"app"`
-	HttpStatus string             `bson:"status,omitempty" json:"httpStatus"`
-	HttpHeader string             `bson:"header,omitempty" json:"httpHeader"`
-	HttpBody   string             `bson:"body,omitempty" json:"httpBody"`
-	Banner     string             `bson:"banner,omitempty" json:"banner"`
-	IconHash   string             `bson:"icon_hash,omitempty" json:"iconHash"`
-	Screenshot string             `bson:"screenshot,omitempty" json:"screenshot"`
-	TaskId     string             `bson:"taskId" json:"taskId"`
-	CreateTime time.Time          `bson:"create_time" json:"createTime"`
+	Id             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	AssetId        string             `bson:"assetId" json:"assetId"`
+	Authority      string             `bson:"authority" json:"authority"`
+	Host           string             `bson:"host" json:"host"`
+	Port           int                `bson:"port" json:"port"`
+	Service        string             `bson:"service,omitempty" json:"service"`
+	Title          string             `bson:"title,omitempty" json:"title"`
+	App            []string           `bson:"app,omitempty" json:"app"`
+	HttpStatus     string             `bson:"status,omitempty" json:"httpStatus"`
+	HttpHeader     string             `bson:"header,omitempty" json:"httpHeader"`
+	HttpBody       string             `bson:"body,omitempty" json:"httpBody"`
+	BodyRef        string             `bson:"body_ref,omitempty" json:"bodyRef"`
+	Banner         string             `bson:"banner,omitempty" json:"banner"`
+	Server         string             `bson:"server,omitempty" json:"server"`
+	Cert           string             `bson:"cert,omitempty" json:"cert"`
+	IconHash       string             `bson:"icon_hash,omitempty" json:"iconHash"`
+	Screenshot     string             `bson:"screenshot,omitempty" json:"screenshot"`
+	ScreenshotRef  string             `bson:"screenshot_ref,omitempty" json:"screenshotRef"`
+	ScreenshotHash string             `bson:"screenshot_hash,omitempty" json:"screenshotHash"`
+	TaskId         string             `bson:"taskId" json:"taskId"`
+	CreateTime     time.Time          `bson:"create_time" json:"createTime"`
 }
 
 // AssetHistoryModel 资产历史模型
@@ -492,6 +581,17 @@ func (m *AssetHistoryModel) FindByAuthority(ctx context.Context, authority strin
 	return docs, nil
 }
 
+// FindLatestBeforeTask 查找资产在指定任务之前的最近一次快照
+func (m *AssetHistoryModel) FindLatestBeforeTask(ctx context.Context, assetId, taskId string) (*AssetHistory, error) {
+	var doc AssetHistory
+	opts := options.FindOne().SetSort(bson.D{{Key: "create_time", Value: -1}})
+	err := m.coll.FindOne(ctx, bson.M{"assetId": assetId, "taskId": bson.M{"$ne": taskId}}, opts).Decode(&doc)
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
 // Clear 清空所有历史记录
 func (m *AssetHistoryModel) Clear(ctx context.Context) (int64, error) {
 	result, err := m.coll.DeleteMany(ctx, bson.M{})
@@ -501,6 +601,18 @@ func (m *AssetHistoryModel) Clear(ctx context.Context) (int64, error) {
 	return result.DeletedCount, nil
 }
 
+// ReferencesBlob 检查是否有历史记录引用了对象存储中的对象
+func (m *AssetHistoryModel) ReferencesBlob(ctx context.Context, hash string) (bool, error) {
+	count, err := m.coll.CountDocuments(ctx, bson.M{"$or": []bson.M{
+		{"body_ref": hash},
+		{"screenshot_ref": hash},
+	}}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 // ExistsByAssetIdAndTaskId 检查是否已存在同一资产同一任务的历史记录
 func (m *AssetHistoryModel) ExistsByAssetIdAndTaskId(ctx context.Context, assetId, taskId string) (bool, error) {
 	count, err := m.coll.CountDocuments(ctx, bson.M{"assetId": assetId, "taskId": taskId})
@@ -510,6 +622,19 @@ func (m *AssetHistoryModel) ExistsByAssetIdAndTaskId(ctx context.Context, assetI
 	return count > 0, nil
 }
 
+// ReferencesBlob 检查是否有资产引用了对象存储中的对象
+func (m *AssetModel) ReferencesBlob(ctx context.Context, hash string) (bool, error) {
+	count, err := m.coll.CountDocuments(ctx, bson.M{"$or": []bson.M{
+		{"body_ref": hash},
+		{"icon_ref": hash},
+		{"screenshot_ref": hash},
+	}}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 // Upsert 插入或更新资产
 func (m *AssetModel) Upsert(ctx context.Context, doc *Asset) error {
 	filter := bson.M{"authority": doc.Authority}
@@ -554,27 +679,30 @@ func (m *AssetModel) BulkUpsert(ctx context.Context, assets []*Asset) (*mongo.Bu
 		filter := bson.M{"host": asset.Host, "port": asset.Port}
 		update := bson.M{
 			"$set": bson.M{
-				"authority":   asset.Authority,
-				"category":    asset.Category,
-				"service":     asset.Service,
-				"server":      asset.Server,
-				"banner":      asset.Banner,
-				"title":       asset.Title,
-				"app":         asset.App,
-				"status":      asset.HttpStatus,
-				"header":      asset.HttpHeader,
-				"body":        asset.HttpBody,
-				"cert":        asset.Cert,
-				"icon_hash":   asset.IconHash,
-				"screenshot":  asset.Screenshot,
-				"cdn":         asset.IsCDN,
-				"cname":       asset.CName,
-				"cloud":       asset.IsCloud,
-				"is_http":     asset.IsHTTP,
-				"taskId":      asset.TaskId,
-				"source":      asset.Source,
-				"update_time": now,
-				"update":      true,
+				"authority":       asset.Authority,
+				"category":        asset.Category,
+				"service":         asset.Service,
+				"server":          asset.Server,
+				"banner":          asset.Banner,
+				"title":           asset.Title,
+				"app":             asset.App,
+				"status":          asset.HttpStatus,
+				"header":          asset.HttpHeader,
+				"body":            asset.HttpBody,
+				"body_ref":        asset.BodyRef,
+				"cert":            asset.Cert,
+				"icon_hash":       asset.IconHash,
+				"screenshot":      asset.Screenshot,
+				"screenshot_ref":  asset.ScreenshotRef,
+				"screenshot_hash": asset.ScreenshotHash,
+				"cdn":             asset.IsCDN,
+				"cname":           asset.CName,
+				"cloud":           asset.IsCloud,
+				"is_http":         asset.IsHTTP,
+				"taskId":          asset.TaskId,
+				"source":          asset.Source,
+				"update_time":     now,
+				"update":          true,
 			},
 			"$setOnInsert": bson.M{
 				"_id":         primitive.NewObjectID(),
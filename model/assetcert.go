@@ -0,0 +1,85 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AssetTLS 资产TLS证书信息
+type AssetTLS struct {
+	Subject     string          `bson:"subject" json:"subject"`
+	SubjectDN   string          `bson:"subject_dn,omitempty" json:"subjectDn"`
+	Issuer      string          `bson:"issuer" json:"issuer"`
+	IssuerDN    string          `bson:"issuer_dn,omitempty" json:"issuerDn"`
+	SANs        []string        `bson:"sans,omitempty" json:"sans"`
+	IPs         []string        `bson:"ips,omitempty" json:"ips"`
+	NotBefore   time.Time       `bson:"not_before" json:"notBefore"`
+	NotAfter    time.Time       `bson:"not_after" json:"notAfter"`
+	Serial      string          `bson:"serial,omitempty" json:"serial"`
+	SHA256      string          `bson:"sha256" json:"sha256"`
+	SHA1        string          `bson:"sha1,omitempty" json:"sha1"`
+	SigAlg      string          `bson:"sig_alg,omitempty" json:"sigAlg"`
+	KeyAlg      string          `bson:"key_alg,omitempty" json:"keyAlg"`
+	KeyBits     int             `bson:"key_bits,omitempty" json:"keyBits"`
+	SelfSigned  bool            `bson:"self_signed" json:"selfSigned"`
+	Trusted     bool            `bson:"trusted" json:"trusted"`
+	TLSVersion  string          `bson:"tls_version,omitempty" json:"tlsVersion"`
+	CipherSuite string          `bson:"cipher_suite,omitempty" json:"cipherSuite"`
+	Jarm        string          `bson:"jarm,omitempty" json:"jarm,omitempty"`
+	Chain       []AssetTLSChain `bson:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// AssetTLSChain 证书链中的中间/根证书
+type AssetTLSChain struct {
+	Subject  string    `bson:"subject" json:"subject"`
+	Issuer   string    `bson:"issuer" json:"issuer"`
+	NotAfter time.Time `bson:"not_after" json:"notAfter"`
+	SHA256   string    `bson:"sha256" json:"sha256"`
+	IsCA     bool      `bson:"is_ca" json:"isCa"`
+}
+
+// CertStatResult 按证书指纹聚合的结果
+type CertStatResult struct {
+	SHA256   string    `bson:"_id"`
+	TLS      AssetTLS  `bson:"tls"`
+	Assets   []string  `bson:"assets"`
+	OrgIds   []string  `bson:"orgIds"`
+	Count    int       `bson:"count"`
+	LastSeen time.Time `bson:"lastSeen"`
+}
+
+// AggregateCert 按证书指纹聚合资产，按到期时间升序
+func (m *AssetModel) AggregateCert(ctx context.Context, filter bson.M) ([]CertStatResult, error) {
+	match := bson.M{"tls.sha256": bson.M{"$exists": true, "$ne": ""}}
+	for k, v := range filter {
+		match[k] = v
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: bson.D{{Key: "update_time", Value: -1}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$tls.sha256"},
+			{Key: "tls", Value: bson.D{{Key: "$first", Value: "$tls"}}},
+			{Key: "assets", Value: bson.D{{Key: "$addToSet", Value: "$authority"}}},
+			{Key: "orgIds", Value: bson.D{{Key: "$addToSet", Value: "$org_id"}}},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "lastSeen", Value: bson.D{{Key: "$max", Value: "$update_time"}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "tls.not_after", Value: 1}}}},
+	}
+
+	cursor, err := m.coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []CertStatResult
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 资产变更类型
+const (
+	AssetChangeNew       = "new"        // 新发现资产（无端口，如域名）
+	AssetChangePortOpen  = "port_open"  // 新开放端口
+	AssetChangePortClose = "port_close" // 端口消失
+	AssetChangeModify    = "modify"     // 字段变更
+)
+
+// AssetChange 资产变更记录，每个字段一条
+type AssetChange struct {
+	Id         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	AssetId    string             `bson:"assetId" json:"assetId"`
+	Authority  string             `bson:"authority" json:"authority"`
+	Host       string             `bson:"host" json:"host"`
+	Port       int                `bson:"port" json:"port"`
+	ChangeType string             `bson:"change_type" json:"changeType"`
+	Field      string             `bson:"field,omitempty" json:"field"` // modify类型对应的字段: title/app/banner/service/status/server/icon_hash/cert
+	OldValue   string             `bson:"old_value,omitempty" json:"oldValue"`
+	NewValue   string             `bson:"new_value,omitempty" json:"newValue"`
+	TaskId     string             `bson:"taskId" json:"taskId"`                     // 发现变更的任务
+	PrevTaskId string             `bson:"prev_task_id,omitempty" json:"prevTaskId"` // 对比的上一轮任务
+	CreateTime time.Time          `bson:"create_time" json:"createTime"`
+}
+
+// AssetChangeModel 资产变更模型
+type AssetChangeModel struct {
+	coll *mongo.Collection
+}
+
+func NewAssetChangeModel(db *mongo.Database, workspaceId string) *AssetChangeModel {
+	coll := db.Collection(workspaceId + "_asset_change")
+
+	ctx := context.Background()
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "assetId", Value: 1}, {Key: "taskId", Value: 1}, {Key: "change_type", Value: 1}, {Key: "field", Value: 1}}},
+		{Keys: bson.D{{Key: "taskId", Value: 1}}},
+		{Keys: bson.D{{Key: "create_time", Value: -1}}},
+	}
+	coll.Indexes().CreateMany(ctx, indexes)
+
+	return &AssetChangeModel{
+		coll: coll,
+	}
+}
+
+// Upsert 按 资产+任务+类型+字段 写入变更，同一任务多次保存时覆盖
+func (m *AssetChangeModel) Upsert(ctx context.Context, doc *AssetChange) error {
+	filter := bson.M{
+		"assetId":     doc.AssetId,
+		"taskId":      doc.TaskId,
+		"change_type": doc.ChangeType,
+		"field":       doc.Field,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"authority":    doc.Authority,
+			"host":         doc.Host,
+			"port":         doc.Port,
+			"old_value":    doc.OldValue,
+			"new_value":    doc.NewValue,
+			"prev_task_id": doc.PrevTaskId,
+		},
+		"$setOnInsert": bson.M{
+			"create_time": time.Now(),
+		},
+	}
+	_, err := m.coll.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// Remove 删除指定资产在某任务中的变更（值已恢复时撤销）
+func (m *AssetChangeModel) Remove(ctx context.Context, assetId, taskId, changeType, field string) error {
+	_, err := m.coll.DeleteMany(ctx, bson.M{
+		"assetId":     assetId,
+		"taskId":      taskId,
+		"change_type": changeType,
+		"field":       field,
+	})
+	return err
+}
+
+func (m *AssetChangeModel) Find(ctx context.Context, filter bson.M, page, pageSize int) ([]AssetChange, error) {
+	opts := options.Find()
+	if page > 0 && pageSize > 0 {
+		opts.SetSkip(int64((page - 1) * pageSize))
+		opts.SetLimit(int64(pageSize))
+	}
+	opts.SetSort(bson.D{{Key: "create_time", Value: -1}})
+
+	cursor, err := m.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []AssetChange
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (m *AssetChangeModel) Count(ctx context.Context, filter bson.M) (int64, error) {
+	return m.coll.CountDocuments(ctx, filter)
+}
+
+// Clear 清空所有变更记录
+func (m *AssetChangeModel) Clear(ctx context.Context) (int64, error) {
+	result, err := m.coll.DeleteMany(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
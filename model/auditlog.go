@@ -0,0 +1,114 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// auditTTLIndex 审计日志过期索引名称
+const auditTTLIndex = "create_time_ttl"
+
+// AuditLog 修改类接口的操作审计记录
+type AuditLog struct {
+	Id          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserId      string             `bson:"user_id" json:"userId"`
+	Username    string             `bson:"username" json:"username"`
+	AuthType    string             `bson:"auth_type" json:"authType"` // jwt/token
+	WorkspaceId string             `bson:"workspace_id" json:"workspaceId"`
+	Method      string             `bson:"method" json:"method"`
+	Path        string             `bson:"path" json:"path"`
+	Summary     string             `bson:"summary" json:"summary"` // 请求参数摘要，敏感字段已脱敏
+	Ip          string             `bson:"ip" json:"ip"`
+	Status      int                `bson:"status" json:"status"` // HTTP状态码
+	Code        int                `bson:"code" json:"code"`     // 响应中的业务码，0为成功
+	Msg         string             `bson:"msg,omitempty" json:"msg"`
+	Duration    int64              `bson:"duration" json:"duration"` // 耗时(毫秒)
+	CreateTime  time.Time          `bson:"create_time" json:"createTime"`
+}
+
+type AuditLogModel struct {
+	coll *mongo.Collection
+}
+
+// NewAuditLogModel 创建审计日志模型，retentionDays<=0 表示永久保留
+func NewAuditLogModel(db *mongo.Database, retentionDays int) *AuditLogModel {
+	coll := db.Collection("audit_log")
+
+	// 创建索引
+	ctx := context.Background()
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "username", Value: 1}, {Key: "create_time", Value: -1}}},
+		{Keys: bson.D{{Key: "workspace_id", Value: 1}, {Key: "create_time", Value: -1}}},
+		{Keys: bson.D{{Key: "path", Value: 1}}},
+	}
+	coll.Indexes().CreateMany(ctx, indexes)
+
+	m := &AuditLogModel{coll: coll}
+	m.applyRetention(ctx, retentionDays)
+	return m
+}
+
+// applyRetention 通过TTL索引自动清理过期日志，保留期变更时更新索引
+func (m *AuditLogModel) applyRetention(ctx context.Context, retentionDays int) {
+	if retentionDays <= 0 {
+		m.coll.Indexes().DropOne(ctx, auditTTLIndex)
+		return
+	}
+
+	seconds := int32(retentionDays * 24 * 3600)
+	_, err := m.coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "create_time", Value: 1}},
+		Options: options.Index().SetName(auditTTLIndex).SetExpireAfterSeconds(seconds),
+	})
+	if err != nil {
+		// 索引已存在但过期时间不同
+		m.coll.Database().RunCommand(ctx, bson.D{
+			{Key: "collMod", Value: m.coll.Name()},
+			{Key: "index", Value: bson.D{
+				{Key: "name", Value: auditTTLIndex},
+				{Key: "expireAfterSeconds", Value: seconds},
+			}},
+		})
+	}
+}
+
+func (m *AuditLogModel) Insert(ctx context.Context, doc *AuditLog) error {
+	if doc.Id.IsZero() {
+		doc.Id = primitive.NewObjectID()
+	}
+	if doc.CreateTime.IsZero() {
+		doc.CreateTime = time.Now()
+	}
+	_, err := m.coll.InsertOne(ctx, doc)
+	return err
+}
+
+func (m *AuditLogModel) Find(ctx context.Context, filter bson.M, page, pageSize int) ([]AuditLog, error) {
+	opts := options.Find()
+	if page > 0 && pageSize > 0 {
+		opts.SetSkip(int64((page - 1) * pageSize))
+		opts.SetLimit(int64(pageSize))
+	}
+	opts.SetSort(bson.D{{Key: "create_time", Value: -1}})
+
+	cursor, err := m.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []AuditLog
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (m *AuditLogModel) Count(ctx context.Context, filter bson.M) (int64, error) {
+	return m.coll.CountDocuments(ctx, filter)
+}
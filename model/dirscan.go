@@ -0,0 +1,121 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DirScanResult 目录扫描发现的路径，按 资产地址+路径 去重
+type DirScanResult struct {
+	Id          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Authority   string             `bson:"authority" json:"authority"`
+	Host        string             `bson:"host" json:"host"`
+	Port        int                `bson:"port" json:"port"`
+	Url         string             `bson:"url" json:"url"`
+	Path        string             `bson:"path" json:"path"`
+	Status      int                `bson:"status" json:"status"`
+	Length      int                `bson:"length" json:"length"`
+	Title       string             `bson:"title,omitempty" json:"title"`
+	ContentType string             `bson:"content_type,omitempty" json:"contentType"`
+	Location    string             `bson:"location,omitempty" json:"location"` // 跳转地址
+	TaskId      string             `bson:"taskId" json:"taskId"`
+	CreateTime  time.Time          `bson:"create_time" json:"createTime"`
+	UpdateTime  time.Time          `bson:"update_time" json:"updateTime"`
+}
+
+// DirScanModel 目录扫描结果模型
+type DirScanModel struct {
+	coll *mongo.Collection
+}
+
+func NewDirScanModel(db *mongo.Database, workspaceId string) *DirScanModel {
+	coll := db.Collection(workspaceId + "_dirscan")
+
+	ctx := context.Background()
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "authority", Value: 1}, {Key: "path", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "taskId", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}}},
+		{Keys: bson.D{{Key: "update_time", Value: -1}}},
+	}
+	coll.Indexes().CreateMany(ctx, indexes)
+
+	return &DirScanModel{
+		coll: coll,
+	}
+}
+
+// BulkUpsert 批量写入，已存在的路径更新响应信息，返回新增数量
+func (m *DirScanModel) BulkUpsert(ctx context.Context, docs []*DirScanResult) (int, error) {
+	if len(docs) == 0 {
+		return 0, nil
+	}
+	now := time.Now()
+	var models []mongo.WriteModel
+	for _, doc := range docs {
+		update := bson.M{
+			"$set": bson.M{
+				"host":         doc.Host,
+				"port":         doc.Port,
+				"url":          doc.Url,
+				"status":       doc.Status,
+				"length":       doc.Length,
+				"title":        doc.Title,
+				"content_type": doc.ContentType,
+				"location":     doc.Location,
+				"taskId":       doc.TaskId,
+				"update_time":  now,
+			},
+			"$setOnInsert": bson.M{
+				"create_time": now,
+			},
+		}
+		filter := bson.M{"authority": doc.Authority, "path": doc.Path}
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true))
+	}
+
+	result, err := m.coll.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if result == nil {
+		return 0, err
+	}
+	return int(result.UpsertedCount), err
+}
+
+func (m *DirScanModel) Find(ctx context.Context, filter bson.M, page, pageSize int) ([]DirScanResult, error) {
+	opts := options.Find()
+	if page > 0 && pageSize > 0 {
+		opts.SetSkip(int64((page - 1) * pageSize))
+		opts.SetLimit(int64(pageSize))
+	}
+	opts.SetSort(bson.D{{Key: "authority", Value: 1}, {Key: "path", Value: 1}})
+
+	cursor, err := m.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []DirScanResult
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (m *DirScanModel) Count(ctx context.Context, filter bson.M) (int64, error) {
+	return m.coll.CountDocuments(ctx, filter)
+}
+
+// Clear 清空所有目录扫描结果
+func (m *DirScanModel) Clear(ctx context.Context) (int64, error) {
+	result, err := m.coll.DeleteMany(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DNSRecordItem 单条DNS记录
+type DNSRecordItem struct {
+	Type  string `bson:"type" json:"type"`
+	Name  string `bson:"name" json:"name"`
+	Value string `bson:"value" json:"value"`
+	TTL   uint32 `bson:"ttl" json:"ttl"`
+}
+
+// DNSIssue DNS配置问题，如缺少SPF/DMARC、允许区域传送
+type DNSIssue struct {
+	Type     string `bson:"type" json:"type"`
+	Severity string `bson:"severity" json:"severity"`
+	Detail   string `bson:"detail" json:"detail"`
+}
+
+// DNSRecord 域名的完整DNS记录，每个域名一条，重新扫描时整体替换
+type DNSRecord struct {
+	Id           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Domain       string             `bson:"domain" json:"domain"`
+	Zone         string             `bson:"zone" json:"zone"` // 所属区域
+	Records      []DNSRecordItem    `bson:"records" json:"records"`
+	SPF          string             `bson:"spf" json:"spf"`
+	DMARC        string             `bson:"dmarc" json:"dmarc"`
+	ZoneTransfer []string           `bson:"zone_transfer" json:"zoneTransfer"` // 允许区域传送的NS
+	Issues       []DNSIssue         `bson:"issues" json:"issues"`
+	TaskId       string             `bson:"taskId" json:"taskId"`
+	CreateTime   time.Time          `bson:"create_time" json:"createTime"`
+	UpdateTime   time.Time          `bson:"update_time" json:"updateTime"`
+}
+
+// DNSRecordModel DNS记录模型
+type DNSRecordModel struct {
+	coll *mongo.Collection
+}
+
+func NewDNSRecordModel(db *mongo.Database, workspaceId string) *DNSRecordModel {
+	coll := db.Collection(workspaceId + "_dns_record")
+
+	ctx := context.Background()
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "domain", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "zone", Value: 1}}},
+		{Keys: bson.D{{Key: "issues.type", Value: 1}}},
+	}
+	coll.Indexes().CreateMany(ctx, indexes)
+
+	return &DNSRecordModel{
+		coll: coll,
+	}
+}
+
+// BulkUpsert 按域名批量写入，返回新增数量
+func (m *DNSRecordModel) BulkUpsert(ctx context.Context, docs []*DNSRecord) (int, error) {
+	if len(docs) == 0 {
+		return 0, nil
+	}
+	now := time.Now()
+	var models []mongo.WriteModel
+	for _, doc := range docs {
+		update := bson.M{
+			"$set": bson.M{
+				"zone":          doc.Zone,
+				"records":       doc.Records,
+				"spf":           doc.SPF,
+				"dmarc":         doc.DMARC,
+				"zone_transfer": doc.ZoneTransfer,
+				"issues":        doc.Issues,
+				"taskId":        doc.TaskId,
+				"update_time":   now,
+			},
+			"$setOnInsert": bson.M{
+				"create_time": now,
+			},
+		}
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(bson.M{"domain": doc.Domain}).SetUpdate(update).SetUpsert(true))
+	}
+
+	result, err := m.coll.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if result == nil {
+		return 0, err
+	}
+	return int(result.UpsertedCount), err
+}
+
+// FindByDomains 按域名批量查询
+func (m *DNSRecordModel) FindByDomains(ctx context.Context, domains []string) ([]DNSRecord, error) {
+	if len(domains) == 0 {
+		return nil, nil
+	}
+	cursor, err := m.coll.Find(ctx, bson.M{"domain": bson.M{"$in": domains}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []DNSRecord
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// Clear 清空所有DNS记录
+func (m *DNSRecordModel) Clear(ctx context.Context) (int64, error) {
+	result, err := m.coll.DeleteMany(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
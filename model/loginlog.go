@@ -0,0 +1,86 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 登录失败原因
+const (
+	LoginReasonInvalid = "invalid" // 用户名或密码错误
+	LoginReasonLocked  = "locked"  // 失败次数过多被锁定
+)
+
+// LoginLog 登录审计记录
+type LoginLog struct {
+	Id         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Username   string             `bson:"username" json:"username"`
+	UserId     string             `bson:"user_id,omitempty" json:"userId"`
+	Ip         string             `bson:"ip" json:"ip"`
+	UserAgent  string             `bson:"user_agent,omitempty" json:"userAgent"`
+	Success    bool               `bson:"success" json:"success"`
+	Reason     string             `bson:"reason,omitempty" json:"reason"` // invalid/locked
+	CreateTime time.Time          `bson:"create_time" json:"createTime"`
+}
+
+type LoginLogModel struct {
+	coll *mongo.Collection
+}
+
+func NewLoginLogModel(db *mongo.Database) *LoginLogModel {
+	coll := db.Collection("login_log")
+
+	// 创建索引
+	ctx := context.Background()
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "username", Value: 1}, {Key: "create_time", Value: -1}}},
+		{Keys: bson.D{{Key: "ip", Value: 1}}},
+		{Keys: bson.D{{Key: "create_time", Value: -1}}},
+	}
+	coll.Indexes().CreateMany(ctx, indexes)
+
+	return &LoginLogModel{
+		coll: coll,
+	}
+}
+
+func (m *LoginLogModel) Insert(ctx context.Context, doc *LoginLog) error {
+	if doc.Id.IsZero() {
+		doc.Id = primitive.NewObjectID()
+	}
+	if doc.CreateTime.IsZero() {
+		doc.CreateTime = time.Now()
+	}
+	_, err := m.coll.InsertOne(ctx, doc)
+	return err
+}
+
+func (m *LoginLogModel) Find(ctx context.Context, filter bson.M, page, pageSize int) ([]LoginLog, error) {
+	opts := options.Find()
+	if page > 0 && pageSize > 0 {
+		opts.SetSkip(int64((page - 1) * pageSize))
+		opts.SetLimit(int64(pageSize))
+	}
+	opts.SetSort(bson.D{{Key: "create_time", Value: -1}})
+
+	cursor, err := m.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []LoginLog
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (m *LoginLogModel) Count(ctx context.Context, filter bson.M) (int64, error) {
+	return m.coll.CountDocuments(ctx, filter)
+}
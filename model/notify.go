@@ -0,0 +1,128 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 通知触发事件
+const (
+	NotifyEventSuccess = "success" // 任务完成
+	NotifyEventFailure = "failure" // 任务失败
+	NotifyEventVul     = "vul"     // 发现达到阈值的漏洞
+	NotifyEventChange  = "change"  // 资产发生变更
+)
+
+// NotifyConfig 通知渠道配置
+type NotifyConfig struct {
+	Id          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name        string             `bson:"name" json:"name"`
+	Type        string             `bson:"type" json:"type"`              // webhook/dingtalk/wecom/feishu/email
+	WebhookUrl  string             `bson:"webhook_url" json:"webhookUrl"` // Webhook/钉钉/企业微信/飞书机器人地址
+	Secret      string             `bson:"secret" json:"-"`               // 钉钉/飞书加签密钥
+	SmtpHost    string             `bson:"smtp_host" json:"smtpHost"`
+	SmtpPort    int                `bson:"smtp_port" json:"smtpPort"`
+	SmtpUser    string             `bson:"smtp_user" json:"smtpUser"`
+	SmtpPass    string             `bson:"smtp_pass" json:"-"`
+	SmtpFrom    string             `bson:"smtp_from" json:"smtpFrom"`
+	SmtpTo      []string           `bson:"smtp_to" json:"smtpTo"`
+	Template    string             `bson:"template" json:"template"`        // 消息模板，为空使用默认模板
+	Events      []string           `bson:"events" json:"events"`            // 触发事件: success/failure/vul/change
+	MinSeverity string             `bson:"min_severity" json:"minSeverity"` // vul事件的最低漏洞等级
+	Status      string             `bson:"status" json:"status"`            // enable/disable
+	CreateTime  time.Time          `bson:"create_time" json:"createTime"`
+	UpdateTime  time.Time          `bson:"update_time" json:"updateTime"`
+}
+
+// HasEvent 判断是否订阅了指定事件
+func (c *NotifyConfig) HasEvent(event string) bool {
+	for _, e := range c.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+type NotifyConfigModel struct {
+	coll *mongo.Collection
+}
+
+func NewNotifyConfigModel(db *mongo.Database) *NotifyConfigModel {
+	return &NotifyConfigModel{
+		coll: db.Collection("notify_config"),
+	}
+}
+
+func (m *NotifyConfigModel) Insert(ctx context.Context, doc *NotifyConfig) error {
+	if doc.Id.IsZero() {
+		doc.Id = primitive.NewObjectID()
+	}
+	now := time.Now()
+	doc.CreateTime = now
+	doc.UpdateTime = now
+	if doc.Status == "" {
+		doc.Status = StatusEnable
+	}
+	_, err := m.coll.InsertOne(ctx, doc)
+	return err
+}
+
+func (m *NotifyConfigModel) FindById(ctx context.Context, id string) (*NotifyConfig, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	var doc NotifyConfig
+	err = m.coll.FindOne(ctx, bson.M{"_id": oid}).Decode(&doc)
+	return &doc, err
+}
+
+func (m *NotifyConfigModel) Find(ctx context.Context, filter bson.M, page, pageSize int) ([]NotifyConfig, error) {
+	opts := options.Find()
+	if page > 0 && pageSize > 0 {
+		opts.SetSkip(int64((page - 1) * pageSize))
+		opts.SetLimit(int64(pageSize))
+	}
+	opts.SetSort(bson.D{{Key: "create_time", Value: -1}})
+
+	cursor, err := m.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []NotifyConfig
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (m *NotifyConfigModel) Count(ctx context.Context, filter bson.M) (int64, error) {
+	return m.coll.CountDocuments(ctx, filter)
+}
+
+func (m *NotifyConfigModel) Update(ctx context.Context, id string, update bson.M) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	update["update_time"] = time.Now()
+	_, err = m.coll.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": update})
+	return err
+}
+
+func (m *NotifyConfigModel) Delete(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	_, err = m.coll.DeleteOne(ctx, bson.M{"_id": oid})
+	return err
+}
@@ -0,0 +1,275 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 敏感信息出现的位置
+const (
+	SecretLocationHeader = "header" // 资产响应头
+	SecretLocationBody   = "body"   // 资产响应体
+	SecretLocationJS     = "js"     // 爬虫发现的JS文件
+)
+
+// SecretRule 敏感信息检测规则
+type SecretRule struct {
+	Id          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name        string             `bson:"name" json:"name"`
+	Category    string             `bson:"category" json:"category"` // cloud, token, key, generic, network, pii
+	Severity    string             `bson:"severity" json:"severity"`
+	Pattern     string             `bson:"pattern" json:"pattern"`               // 正则，包含分组时以第一个分组作为敏感值
+	MinEntropy  float64            `bson:"min_entropy" json:"minEntropy"`        // 敏感值的香农熵下限，0表示不检查
+	Validator   string             `bson:"validator,omitempty" json:"validator"` // 内置校验器
+	Description string             `bson:"description" json:"description"`
+	IsBuiltin   bool               `bson:"is_builtin" json:"isBuiltin"`
+	Enabled     bool               `bson:"enabled" json:"enabled"`
+	CreateTime  time.Time          `bson:"create_time" json:"createTime"`
+	UpdateTime  time.Time          `bson:"update_time" json:"updateTime"`
+}
+
+// SecretRuleModel 敏感信息规则模型
+type SecretRuleModel struct {
+	coll *mongo.Collection
+}
+
+func NewSecretRuleModel(db *mongo.Database) *SecretRuleModel {
+	coll := db.Collection("secret_rule")
+	coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "name", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "category", Value: 1}}},
+		{Keys: bson.D{{Key: "enabled", Value: 1}}},
+	})
+	return &SecretRuleModel{coll: coll}
+}
+
+func (m *SecretRuleModel) Insert(ctx context.Context, doc *SecretRule) error {
+	if doc.Id.IsZero() {
+		doc.Id = primitive.NewObjectID()
+	}
+	now := time.Now()
+	doc.CreateTime = now
+	doc.UpdateTime = now
+	_, err := m.coll.InsertOne(ctx, doc)
+	return err
+}
+
+// UpsertBuiltin 写入内置规则，已存在时更新匹配内容，保留启用状态
+func (m *SecretRuleModel) UpsertBuiltin(ctx context.Context, doc *SecretRule) error {
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"category":    doc.Category,
+			"severity":    doc.Severity,
+			"pattern":     doc.Pattern,
+			"min_entropy": doc.MinEntropy,
+			"validator":   doc.Validator,
+			"description": doc.Description,
+			"update_time": now,
+		},
+		"$setOnInsert": bson.M{
+			"enabled":     true,
+			"create_time": now,
+		},
+	}
+	filter := bson.M{"name": doc.Name, "is_builtin": true}
+	_, err := m.coll.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (m *SecretRuleModel) Find(ctx context.Context, filter bson.M, page, pageSize int) ([]SecretRule, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "is_builtin", Value: -1}, {Key: "category", Value: 1}, {Key: "name", Value: 1}})
+	if page > 0 && pageSize > 0 {
+		opts.SetSkip(int64((page - 1) * pageSize))
+		opts.SetLimit(int64(pageSize))
+	}
+
+	cursor, err := m.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []SecretRule
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (m *SecretRuleModel) FindEnabled(ctx context.Context) ([]SecretRule, error) {
+	return m.Find(ctx, bson.M{"enabled": true}, 0, 0)
+}
+
+func (m *SecretRuleModel) FindById(ctx context.Context, id string) (*SecretRule, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	var doc SecretRule
+	err = m.coll.FindOne(ctx, bson.M{"_id": oid}).Decode(&doc)
+	return &doc, err
+}
+
+func (m *SecretRuleModel) Count(ctx context.Context, filter bson.M) (int64, error) {
+	return m.coll.CountDocuments(ctx, filter)
+}
+
+func (m *SecretRuleModel) Update(ctx context.Context, id string, update bson.M) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	update["update_time"] = time.Now()
+	_, err = m.coll.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": update})
+	return err
+}
+
+func (m *SecretRuleModel) Delete(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	_, err = m.coll.DeleteOne(ctx, bson.M{"_id": oid})
+	return err
+}
+
+// SecretFinding 敏感信息检测结果，只保存脱敏后的证据和敏感值的哈希
+type SecretFinding struct {
+	Id         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Authority  string             `bson:"authority" json:"authority"` // 关联的资产
+	Host       string             `bson:"host" json:"host"`
+	Port       int                `bson:"port" json:"port"`
+	Url        string             `bson:"url" json:"url"`
+	Location   string             `bson:"location" json:"location"` // header, body, js
+	Rule       string             `bson:"rule" json:"rule"`
+	Category   string             `bson:"category" json:"category"`
+	Severity   string             `bson:"severity" json:"severity"`
+	Evidence   string             `bson:"evidence" json:"evidence"`
+	Hash       string             `bson:"hash" json:"hash"` // 敏感值的SHA256
+	TaskId     string             `bson:"taskId" json:"taskId"`
+	CreateTime time.Time          `bson:"create_time" json:"createTime"`
+	UpdateTime time.Time          `bson:"update_time" json:"updateTime"`
+}
+
+// SecretFindingModel 敏感信息检测结果模型
+type SecretFindingModel struct {
+	coll *mongo.Collection
+}
+
+func NewSecretFindingModel(db *mongo.Database, workspaceId string) *SecretFindingModel {
+	coll := db.Collection(workspaceId + "_secret")
+
+	ctx := context.Background()
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "url", Value: 1}, {Key: "rule", Value: 1}, {Key: "hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "authority", Value: 1}}},
+		{Keys: bson.D{{Key: "severity", Value: 1}}},
+		{Keys: bson.D{{Key: "update_time", Value: -1}}},
+	}
+	coll.Indexes().CreateMany(ctx, indexes)
+
+	return &SecretFindingModel{
+		coll: coll,
+	}
+}
+
+// BulkUpsert 按 URL+规则+敏感值哈希 批量写入，返回新增数量
+func (m *SecretFindingModel) BulkUpsert(ctx context.Context, docs []*SecretFinding) (int, error) {
+	if len(docs) == 0 {
+		return 0, nil
+	}
+	now := time.Now()
+	var models []mongo.WriteModel
+	for _, doc := range docs {
+		update := bson.M{
+			"$set": bson.M{
+				"authority":   doc.Authority,
+				"host":        doc.Host,
+				"port":        doc.Port,
+				"location":    doc.Location,
+				"category":    doc.Category,
+				"severity":    doc.Severity,
+				"evidence":    doc.Evidence,
+				"taskId":      doc.TaskId,
+				"update_time": now,
+			},
+			"$setOnInsert": bson.M{
+				"create_time": now,
+			},
+		}
+		filter := bson.M{"url": doc.Url, "rule": doc.Rule, "hash": doc.Hash}
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true))
+	}
+
+	result, err := m.coll.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if result == nil {
+		return 0, err
+	}
+	return int(result.UpsertedCount), err
+}
+
+func (m *SecretFindingModel) Find(ctx context.Context, filter bson.M, page, pageSize int) ([]SecretFinding, error) {
+	opts := options.Find()
+	if page > 0 && pageSize > 0 {
+		opts.SetSkip(int64((page - 1) * pageSize))
+		opts.SetLimit(int64(pageSize))
+	}
+	opts.SetSort(bson.D{{Key: "update_time", Value: -1}, {Key: "_id", Value: 1}})
+
+	cursor, err := m.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []SecretFinding
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (m *SecretFindingModel) Count(ctx context.Context, filter bson.M) (int64, error) {
+	return m.coll.CountDocuments(ctx, filter)
+}
+
+// SeverityStats 按严重级别统计
+func (m *SecretFindingModel) SeverityStats(ctx context.Context, filter bson.M) (map[string]int, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": "$severity", "count": bson.M{"$sum": 1}}}},
+	}
+	cursor, err := m.coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Severity string `bson:"_id"`
+		Count    int    `bson:"count"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	stats := make(map[string]int, len(results))
+	for _, r := range results {
+		stats[r.Severity] = r.Count
+	}
+	return stats, nil
+}
+
+// Clear 清空所有检测结果
+func (m *SecretFindingModel) Clear(ctx context.Context) (int64, error) {
+	result, err := m.coll.DeleteMany(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
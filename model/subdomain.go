@@ -0,0 +1,223 @@
+package model
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 子域名在本次扫描中的状态
+const (
+	SubdomainStatusNew       = "new"       // 首次发现
+	SubdomainStatusChanged   = "changed"   // 解析结果变化
+	SubdomainStatusUnchanged = "unchanged" // 已知且未变化
+)
+
+// Subdomain 子域名监控的已知子域名，记录首次和最近发现时间。
+// 新增或解析变化的子域名先标记为待确认，扫描完成后才转为已知，扫描中断时下次仍会扫描
+type Subdomain struct {
+	Id          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Domain      string             `bson:"domain" json:"domain"`
+	RootDomain  string             `bson:"root_domain" json:"rootDomain"`
+	IPs         []string           `bson:"ips" json:"ips"` // 已排序
+	CName       string             `bson:"cname" json:"cname"`
+	FirstTaskId string             `bson:"first_task_id" json:"firstTaskId"` // 首次发现的任务
+	LastTaskId  string             `bson:"last_task_id" json:"lastTaskId"`
+	FirstSeen   time.Time          `bson:"first_seen" json:"firstSeen"`
+	LastSeen    time.Time          `bson:"last_seen" json:"lastSeen"`
+	ChangeTime  time.Time          `bson:"change_time" json:"changeTime"` // 解析结果最近变化时间
+
+	Pending       string   `bson:"pending,omitempty" json:"pending,omitempty"` // 待确认的状态：new 或 changed
+	PendingTaskId string   `bson:"pending_task_id,omitempty" json:"-"`
+	PendingIPs    []string `bson:"pending_ips,omitempty" json:"-"` // 变化后的解析结果，确认后写入 ips
+	PendingCName  string   `bson:"pending_cname,omitempty" json:"-"`
+}
+
+// pendingFields 待确认状态的字段，确认或解析恢复后清除
+var pendingFields = bson.M{"pending": "", "pending_task_id": "", "pending_ips": "", "pending_cname": ""}
+
+// SubdomainModel 子域名监控模型
+type SubdomainModel struct {
+	coll *mongo.Collection
+}
+
+func NewSubdomainModel(db *mongo.Database, workspaceId string) *SubdomainModel {
+	coll := db.Collection(workspaceId + "_subdomain")
+
+	ctx := context.Background()
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "domain", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "root_domain", Value: 1}}},
+		{Keys: bson.D{{Key: "first_task_id", Value: 1}}},
+		{Keys: bson.D{{Key: "last_seen", Value: -1}}},
+	}
+	coll.Indexes().CreateMany(ctx, indexes)
+
+	return &SubdomainModel{
+		coll: coll,
+	}
+}
+
+// Sync 与已知子域名比对并写入，返回每个子域名的状态。
+// 未变化的子域名更新最近发现时间；新增和解析变化的子域名标记为待确认，
+// 待确认的新增子域名再次同步时仍为新增，由 Confirm 在扫描完成后转为已知
+func (m *SubdomainModel) Sync(ctx context.Context, docs []*Subdomain, taskId string) (map[string]string, error) {
+	status := make(map[string]string, len(docs))
+	if len(docs) == 0 {
+		return status, nil
+	}
+
+	domains := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		slices.Sort(doc.IPs)
+		domains = append(domains, doc.Domain)
+	}
+	known := make(map[string]*Subdomain)
+	cursor, err := m.coll.Find(ctx, bson.M{"domain": bson.M{"$in": domains}})
+	if err != nil {
+		return nil, err
+	}
+	var existing []Subdomain
+	if err = cursor.All(ctx, &existing); err != nil {
+		return nil, err
+	}
+	for i := range existing {
+		known[existing[i].Domain] = &existing[i]
+	}
+
+	now := time.Now()
+	var models []mongo.WriteModel
+	for _, doc := range docs {
+		if _, ok := status[doc.Domain]; ok {
+			continue
+		}
+		set := bson.M{}
+		var unset bson.M
+		old, ok := known[doc.Domain]
+		switch {
+		case !ok || old.Pending == SubdomainStatusNew:
+			status[doc.Domain] = SubdomainStatusNew
+			set["pending"] = SubdomainStatusNew
+			set["pending_task_id"] = taskId
+			set["ips"] = doc.IPs
+			set["cname"] = doc.CName
+		case resolveChanged(old, doc):
+			status[doc.Domain] = SubdomainStatusChanged
+			set["pending"] = SubdomainStatusChanged
+			set["pending_task_id"] = taskId
+			set["pending_ips"] = doc.IPs
+			set["pending_cname"] = doc.CName
+		default:
+			status[doc.Domain] = SubdomainStatusUnchanged
+			set["last_seen"] = now
+			set["last_task_id"] = taskId
+			// 解析结果恢复为已知结果，不再需要确认
+			if old.Pending != "" {
+				unset = pendingFields
+			}
+		}
+		setOnInsert := bson.M{
+			"root_domain":   doc.RootDomain,
+			"first_task_id": taskId,
+			"first_seen":    now,
+			"change_time":   now,
+		}
+		update := bson.M{"$set": set, "$setOnInsert": setOnInsert}
+		if unset != nil {
+			update["$unset"] = unset
+		}
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(bson.M{"domain": doc.Domain}).SetUpdate(update).SetUpsert(true))
+	}
+
+	if _, err := m.coll.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// Confirm 扫描完成后将待确认的子域名转为已知：新增的记录首次发现的任务，变化的写入新的解析结果。
+// 返回确认的新增子域名
+func (m *SubdomainModel) Confirm(ctx context.Context, domains []string, taskId string) ([]string, error) {
+	if len(domains) == 0 {
+		return nil, nil
+	}
+	cursor, err := m.coll.Find(ctx, bson.M{"domain": bson.M{"$in": domains}, "pending": bson.M{"$in": []string{SubdomainStatusNew, SubdomainStatusChanged}}})
+	if err != nil {
+		return nil, err
+	}
+	var docs []Subdomain
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	var confirmed []string
+	models := make([]mongo.WriteModel, 0, len(docs))
+	for _, doc := range docs {
+		set := bson.M{"last_seen": now, "last_task_id": taskId, "change_time": now}
+		if doc.Pending == SubdomainStatusNew {
+			set["first_task_id"] = taskId
+			confirmed = append(confirmed, doc.Domain)
+		} else {
+			set["ips"] = doc.PendingIPs
+			set["cname"] = doc.PendingCName
+		}
+		// 确认期间再次同步可能改变了待确认状态，只在状态未变时更新
+		filter := bson.M{"_id": doc.Id, "pending": doc.Pending, "pending_task_id": doc.PendingTaskId}
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(bson.M{"$set": set, "$unset": pendingFields}))
+	}
+	if _, err := m.coll.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return nil, err
+	}
+	return confirmed, nil
+}
+
+// resolveChanged 解析结果是否变化，本次未解析(没有IP和CNAME)时不比较
+func resolveChanged(old, doc *Subdomain) bool {
+	if len(doc.IPs) == 0 && doc.CName == "" {
+		return false
+	}
+	return old.CName != doc.CName || !slices.Equal(old.IPs, doc.IPs)
+}
+
+func (m *SubdomainModel) Find(ctx context.Context, filter bson.M, page, pageSize int) ([]Subdomain, error) {
+	opts := options.Find()
+	if page > 0 && pageSize > 0 {
+		opts.SetSkip(int64((page - 1) * pageSize))
+		opts.SetLimit(int64(pageSize))
+	}
+	opts.SetSort(bson.D{{Key: "first_seen", Value: -1}, {Key: "domain", Value: 1}})
+
+	cursor, err := m.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []Subdomain
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (m *SubdomainModel) Count(ctx context.Context, filter bson.M) (int64, error) {
+	return m.coll.CountDocuments(ctx, filter)
+}
+
+// Clear 清空已知子域名
+func (m *SubdomainModel) Clear(ctx context.Context) (int64, error) {
+	result, err := m.coll.DeleteMany(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
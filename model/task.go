@@ -48,6 +48,8 @@ type MainTask struct {
 	// 子任务拆分（用于分布式并发）
 	SubTaskCount int               `bson:"sub_task_count" json:"subTaskCount"` // 子任务总数
 	SubTaskDone  int               `bson:"sub_task_done" json:"subTaskDone"`   // 已完成子任务数
+	SubTaskFailed int              `bson:"sub_task_failed" json:"subTaskFailed"` // 已完成子任务中失败的数量
+	NewSubdomains []string         `bson:"new_subdomains,omitempty" json:"newSubdomains,omitempty"` // 子域名监控本次确认的新增子域名
 }
 
 type ExecutorTask struct {
@@ -193,15 +195,39 @@ func (m *MainTaskModel) UpdateByTaskId(ctx context.Context, taskId string, updat
 	return err
 }
 
-// IncrSubTaskDone 递增已完成子任务数
-func (m *MainTaskModel) IncrSubTaskDone(ctx context.Context, id string) error {
+// IncrSubTaskDone 递增已完成子任务数，failed为true时同时递增失败数，返回更新后的任务
+func (m *MainTaskModel) IncrSubTaskDone(ctx context.Context, id string, failed bool) (*MainTask, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	inc := bson.M{"sub_task_done": 1}
+	if failed {
+		inc["sub_task_failed"] = 1
+	}
+	var doc MainTask
+	err = m.coll.FindOneAndUpdate(ctx, bson.M{"_id": oid}, bson.M{
+		"$inc": inc,
+		"$set": bson.M{"update_time": time.Now()},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&doc)
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// AddNewSubdomains 记录子域名监控确认的新增子域名
+func (m *MainTaskModel) AddNewSubdomains(ctx context.Context, id string, domains []string) error {
+	if len(domains) == 0 {
+		return nil
+	}
 	oid, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return err
 	}
 	_, err = m.coll.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{
-		"$inc": bson.M{"sub_task_done": 1},
-		"$set": bson.M{"update_time": time.Now()},
+		"$addToSet": bson.M{"new_subdomains": bson.M{"$each": domains}},
+		"$set":      bson.M{"update_time": time.Now()},
 	})
 	return err
 }
@@ -0,0 +1,145 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// URL来源
+const (
+	UrlSourcePage    = "page"    // 页面中的链接
+	UrlSourceForm    = "form"    // 表单提交地址
+	UrlSourceJS      = "js"      // 从JS代码中提取的接口
+	UrlSourceXHR     = "xhr"     // 无头浏览器渲染时页面发出的XHR/fetch请求
+	UrlSourceRobots  = "robots"  // robots.txt
+	UrlSourceSitemap = "sitemap" // sitemap.xml
+)
+
+// Url 爬虫发现的URL，同一个不含查询参数的地址和请求方法只保存一条，参数名合并
+type Url struct {
+	Id          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Authority   string             `bson:"authority" json:"authority"`
+	Host        string             `bson:"host" json:"host"`
+	Port        int                `bson:"port" json:"port"`
+	Url         string             `bson:"url" json:"url"`           // 首次发现时的完整URL
+	Endpoint    string             `bson:"endpoint" json:"endpoint"` // 不含查询参数的URL
+	Method      string             `bson:"method" json:"method"`
+	Path        string             `bson:"path" json:"path"`
+	Params      []string           `bson:"params,omitempty" json:"params"` // 查询参数和表单字段名
+	Source      string             `bson:"source" json:"source"`
+	StatusCode  int                `bson:"status,omitempty" json:"statusCode"`
+	ContentType string             `bson:"content_type,omitempty" json:"contentType"`
+	Length      int                `bson:"length,omitempty" json:"length"`
+	Title       string             `bson:"title,omitempty" json:"title"`
+	Referer     string             `bson:"referer,omitempty" json:"referer"` // 发现该URL的页面
+	TaskId      string             `bson:"taskId" json:"taskId"`
+	CreateTime  time.Time          `bson:"create_time" json:"createTime"`
+	UpdateTime  time.Time          `bson:"update_time" json:"updateTime"`
+}
+
+// UrlModel 爬虫URL模型
+type UrlModel struct {
+	coll *mongo.Collection
+}
+
+func NewUrlModel(db *mongo.Database, workspaceId string) *UrlModel {
+	coll := db.Collection(workspaceId + "_url")
+
+	ctx := context.Background()
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "endpoint", Value: 1}, {Key: "method", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "authority", Value: 1}}},
+		{Keys: bson.D{{Key: "source", Value: 1}}},
+		{Keys: bson.D{{Key: "update_time", Value: -1}}},
+	}
+	coll.Indexes().CreateMany(ctx, indexes)
+
+	return &UrlModel{
+		coll: coll,
+	}
+}
+
+// BulkUpsert 按 地址+方法 批量写入，已存在的URL合并参数名并更新响应信息，返回新增数量
+func (m *UrlModel) BulkUpsert(ctx context.Context, docs []*Url) (int, error) {
+	if len(docs) == 0 {
+		return 0, nil
+	}
+	now := time.Now()
+	var models []mongo.WriteModel
+	for _, doc := range docs {
+		set := bson.M{
+			"authority":   doc.Authority,
+			"host":        doc.Host,
+			"port":        doc.Port,
+			"path":        doc.Path,
+			"taskId":      doc.TaskId,
+			"update_time": now,
+		}
+		// 只抓取过的页面才有响应信息，避免JS提取的同一接口覆盖已有的响应信息
+		if doc.StatusCode > 0 {
+			set["status"] = doc.StatusCode
+			set["content_type"] = doc.ContentType
+			set["length"] = doc.Length
+			set["title"] = doc.Title
+		}
+		update := bson.M{
+			"$set": set,
+			"$setOnInsert": bson.M{
+				"url":         doc.Url,
+				"source":      doc.Source,
+				"referer":     doc.Referer,
+				"create_time": now,
+			},
+		}
+		if len(doc.Params) > 0 {
+			update["$addToSet"] = bson.M{"params": bson.M{"$each": doc.Params}}
+		}
+		filter := bson.M{"endpoint": doc.Endpoint, "method": doc.Method}
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true))
+	}
+
+	result, err := m.coll.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if result == nil {
+		return 0, err
+	}
+	return int(result.UpsertedCount), err
+}
+
+func (m *UrlModel) Find(ctx context.Context, filter bson.M, page, pageSize int) ([]Url, error) {
+	opts := options.Find()
+	if page > 0 && pageSize > 0 {
+		opts.SetSkip(int64((page - 1) * pageSize))
+		opts.SetLimit(int64(pageSize))
+	}
+	opts.SetSort(bson.D{{Key: "update_time", Value: -1}, {Key: "_id", Value: 1}})
+
+	cursor, err := m.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []Url
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (m *UrlModel) Count(ctx context.Context, filter bson.M) (int64, error) {
+	return m.coll.CountDocuments(ctx, filter)
+}
+
+// Clear 清空所有URL
+func (m *UrlModel) Clear(ctx context.Context) (int64, error) {
+	result, err := m.coll.DeleteMany(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
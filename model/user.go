@@ -3,13 +3,17 @@ package model
 import (
 	"context"
 	"crypto/md5"
+	"crypto/subtle"
 	"encoding/hex"
+	"strings"
+	"sync"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/crypto/bcrypt"
 )
 
 const (
@@ -17,12 +21,38 @@ const (
 	StatusDisable = "disable"
 )
 
+// 用户角色，按权限从高到低
+const (
+	RoleAdmin    = "admin"    // 管理员：全部权限
+	RoleOperator = "operator" // 操作员：执行任务、管理资产和POC
+	RoleViewer   = "viewer"   // 只读：仅查看
+)
+
+var roleLevel = map[string]int{
+	RoleViewer:   1,
+	RoleOperator: 2,
+	RoleAdmin:    3,
+}
+
+// IsValidRole 检查角色名是否有效
+func IsValidRole(role string) bool {
+	_, ok := roleLevel[role]
+	return ok
+}
+
+// RoleAtLeast 判断角色是否不低于指定角色，空角色视为无权限
+func RoleAtLeast(role, min string) bool {
+	return roleLevel[role] > 0 && roleLevel[role] >= roleLevel[min]
+}
+
 type User struct {
 	Id              primitive.ObjectID `bson:"_id,omitempty" json:"id"`
 	Username        string             `bson:"username" json:"username"`
 	Password        string             `bson:"password" json:"-"`
 	Status          string             `bson:"status" json:"status"`
 	WorkspaceIds    []string           `bson:"workspace_ids" json:"workspaceIds"`
+	Role            string             `bson:"role" json:"role"`                                 // 全局角色，对所有工作空间生效；admin同时拥有系统管理权限
+	WorkspaceRoles  map[string]string  `bson:"workspace_roles,omitempty" json:"workspaceRoles"` // 工作空间ID -> 角色，覆盖全局角色
 	ScanConfig      string             `bson:"scan_config" json:"scanConfig"` // 用户默认扫描配置JSON
 	LastLoginTime   *time.Time         `bson:"last_login_time" json:"lastLoginTime"`
 	CreateTime      time.Time          `bson:"create_time" json:"createTime"`
@@ -34,9 +64,53 @@ type UserModel struct {
 }
 
 func NewUserModel(db *mongo.Database) *UserModel {
+	coll := db.Collection("user")
+
+	// 引入角色之前所有用户均为管理员（初始化脚本写入的是superadmin），统一迁移为admin以保持原有权限
+	coll.UpdateMany(context.Background(),
+		bson.M{"$or": []bson.M{
+			{"role": bson.M{"$exists": false}},
+			{"role": "superadmin"},
+		}},
+		bson.M{"$set": bson.M{"role": RoleAdmin}},
+	)
+
 	return &UserModel{
-		coll: db.Collection("user"),
+		coll: coll,
+	}
+}
+
+// RoleFor 获取用户在指定工作空间的角色，未分配时返回空字符串
+func (u *User) RoleFor(workspaceId string) string {
+	if role, ok := u.WorkspaceRoles[workspaceId]; ok {
+		return role
+	}
+	return u.Role
+}
+
+// HighestRole 获取用户在所有工作空间中的最高角色
+func (u *User) HighestRole() string {
+	highest := u.Role
+	for _, role := range u.WorkspaceRoles {
+		if roleLevel[role] > roleLevel[highest] {
+			highest = role
+		}
+	}
+	return highest
+}
+
+// AccessibleWorkspaces 获取用户可访问的工作空间，返回nil表示全部可访问
+func (u *User) AccessibleWorkspaces() []string {
+	if IsValidRole(u.Role) {
+		return nil
+	}
+	ids := make([]string, 0, len(u.WorkspaceRoles))
+	for wsId, role := range u.WorkspaceRoles {
+		if IsValidRole(role) {
+			ids = append(ids, wsId)
+		}
 	}
+	return ids
 }
 
 func (m *UserModel) Insert(ctx context.Context, doc *User) error {
@@ -46,8 +120,12 @@ func (m *UserModel) Insert(ctx context.Context, doc *User) error {
 	now := time.Now()
 	doc.CreateTime = now
 	doc.UpdateTime = now
-	doc.Password = HashPassword(doc.Password)
-	_, err := m.coll.InsertOne(ctx, doc)
+	hash, err := HashPassword(doc.Password)
+	if err != nil {
+		return err
+	}
+	doc.Password = hash
+	_, err = m.coll.InsertOne(ctx, doc)
 	return err
 }
 
@@ -123,8 +201,12 @@ func (m *UserModel) UpdatePassword(ctx context.Context, id string, newPassword s
 	if err != nil {
 		return err
 	}
+	hash, err := HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
 	update := bson.M{
-		"password": HashPassword(newPassword),
+		"password":    hash,
 		"update_time": time.Now(),
 	}
 	_, err = m.coll.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": update})
@@ -178,21 +260,65 @@ func (m *UserModel) Delete(ctx context.Context, id string) error {
 	return err
 }
 
+// VerifyPassword 校验用户名密码，旧版MD5密码校验成功后自动升级为bcrypt
 func (m *UserModel) VerifyPassword(ctx context.Context, username, password string) (*User, bool) {
 	user, err := m.FindByUsername(ctx, username)
-	if err != nil {
+	if err != nil || user == nil {
+		// 用户不存在时同样执行一次bcrypt比较，避免通过响应时间枚举用户名
+		bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
 		return nil, false
 	}
-	if user.Password != HashPassword(password) {
+	if !CheckPassword(user.Password, password) {
 		return nil, false
 	}
 	if user.Status != StatusEnable {
 		return nil, false
 	}
+	if IsLegacyPasswordHash(user.Password) {
+		if hash, err := HashPassword(password); err == nil {
+			if _, err := m.coll.UpdateOne(ctx, bson.M{"_id": user.Id}, bson.M{"$set": bson.M{"password": hash}}); err == nil {
+				user.Password = hash
+			}
+		}
+	}
 	return user, true
 }
 
-func HashPassword(password string) string {
-	hash := md5.Sum([]byte(password))
-	return hex.EncodeToString(hash[:])
+// HashPassword 使用bcrypt计算密码哈希
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword 校验密码，兼容旧版无盐MD5哈希
+func CheckPassword(hash, password string) bool {
+	if IsLegacyPasswordHash(hash) {
+		sum := md5.Sum([]byte(password))
+		return subtle.ConstantTimeCompare([]byte(hash), []byte(hex.EncodeToString(sum[:]))) == 1
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// IsLegacyPasswordHash 是否为旧版MD5密码哈希
+func IsLegacyPasswordHash(hash string) bool {
+	if len(hash) != 32 || strings.HasPrefix(hash, "$") {
+		return false
+	}
+	_, err := hex.DecodeString(hash)
+	return err == nil
+}
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     []byte
+)
+
+func dummyPasswordHash() []byte {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = bcrypt.GenerateFromPassword([]byte("cscan-dummy-password"), bcrypt.DefaultCost)
+	})
+	return dummyHash
 }
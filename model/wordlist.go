@@ -0,0 +1,148 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 字典类型
+const (
+	WordlistTypeDir       = "dir"       // 目录/文件爆破
+	WordlistTypeSubdomain = "subdomain" // 子域名爆破
+)
+
+// Wordlist 爆破字典，字典内容较大，列表查询时不返回
+type Wordlist struct {
+	Id          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name        string             `bson:"name" json:"name"`
+	Type        string             `bson:"type" json:"type"`
+	Description string             `bson:"description" json:"description"`
+	Words       []string           `bson:"words,omitempty" json:"words,omitempty"`
+	Count       int                `bson:"count" json:"count"`
+	Enabled     bool               `bson:"enabled" json:"enabled"`
+	CreateTime  time.Time          `bson:"create_time" json:"createTime"`
+	UpdateTime  time.Time          `bson:"update_time" json:"updateTime"`
+}
+
+// WordlistModel 爆破字典模型
+type WordlistModel struct {
+	coll *mongo.Collection
+}
+
+func NewWordlistModel(db *mongo.Database) *WordlistModel {
+	coll := db.Collection("wordlist")
+
+	ctx := context.Background()
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "type", Value: 1}, {Key: "name", Value: 1}}, Options: options.Index().SetUnique(true)},
+	}
+	coll.Indexes().CreateMany(ctx, indexes)
+
+	return &WordlistModel{coll: coll}
+}
+
+func (m *WordlistModel) Insert(ctx context.Context, doc *Wordlist) error {
+	if doc.Id.IsZero() {
+		doc.Id = primitive.NewObjectID()
+	}
+	now := time.Now()
+	doc.CreateTime = now
+	doc.UpdateTime = now
+	doc.Count = len(doc.Words)
+	_, err := m.coll.InsertOne(ctx, doc)
+	return err
+}
+
+func (m *WordlistModel) Update(ctx context.Context, id string, update bson.M) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	if words, ok := update["words"].([]string); ok {
+		update["count"] = len(words)
+	}
+	update["update_time"] = time.Now()
+	_, err = m.coll.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": update})
+	return err
+}
+
+// FindById 查询字典，包含字典内容
+func (m *WordlistModel) FindById(ctx context.Context, id string) (*Wordlist, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	var doc Wordlist
+	err = m.coll.FindOne(ctx, bson.M{"_id": oid}).Decode(&doc)
+	return &doc, err
+}
+
+// Find 分页查询字典，不返回字典内容
+func (m *WordlistModel) Find(ctx context.Context, filter bson.M, page, pageSize int) ([]Wordlist, error) {
+	opts := options.Find().
+		SetProjection(bson.M{"words": 0}).
+		SetSort(bson.D{{Key: "type", Value: 1}, {Key: "name", Value: 1}})
+	if page > 0 && pageSize > 0 {
+		opts.SetSkip(int64((page - 1) * pageSize))
+		opts.SetLimit(int64(pageSize))
+	}
+
+	cursor, err := m.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []Wordlist
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (m *WordlistModel) Count(ctx context.Context, filter bson.M) (int64, error) {
+	return m.coll.CountDocuments(ctx, filter)
+}
+
+// FindEnabledByIds 查询指定类型的已启用字典，包含字典内容
+func (m *WordlistModel) FindEnabledByIds(ctx context.Context, ids []string, wordlistType string) ([]Wordlist, error) {
+	oids := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			oids = append(oids, oid)
+		}
+	}
+	if len(oids) == 0 {
+		return nil, nil
+	}
+
+	filter := bson.M{"_id": bson.M{"$in": oids}, "enabled": true}
+	if wordlistType != "" {
+		filter["type"] = wordlistType
+	}
+	cursor, err := m.coll.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []Wordlist
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (m *WordlistModel) Delete(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	_, err = m.coll.DeleteOne(ctx, bson.M{"_id": oid})
+	return err
+}
@@ -0,0 +1,140 @@
+package model
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WorkerCredentialPrefix Worker凭证前缀
+const WorkerCredentialPrefix = "cwk_"
+
+// WorkerCredential 每个Worker注册后获得的凭证，调用任务服务时携带，只保存凭证的SHA256
+type WorkerCredential struct {
+	Id            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	WorkerName    string             `bson:"worker_name" json:"workerName"`
+	TokenHash     string             `bson:"token_hash" json:"-"`
+	Prefix        string             `bson:"prefix" json:"prefix"`
+	EnrollTokenId string             `bson:"enroll_token_id" json:"enrollTokenId"` // 注册时使用的令牌
+	EnrollIp      string             `bson:"enroll_ip" json:"enrollIp"`
+	LastSeenTime  *time.Time         `bson:"last_seen_time,omitempty" json:"lastSeenTime"`
+	LastSeenIp    string             `bson:"last_seen_ip,omitempty" json:"lastSeenIp"`
+	Revoked       bool               `bson:"revoked" json:"revoked"`
+	RevokeTime    *time.Time         `bson:"revoke_time,omitempty" json:"revokeTime"`
+	CreateTime    time.Time          `bson:"create_time" json:"createTime"`
+}
+
+type WorkerCredentialModel struct {
+	coll *mongo.Collection
+}
+
+func NewWorkerCredentialModel(db *mongo.Database) *WorkerCredentialModel {
+	coll := db.Collection("worker_credential")
+
+	ctx := context.Background()
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "worker_name", Value: 1}}},
+	}
+	coll.Indexes().CreateMany(ctx, indexes)
+
+	return &WorkerCredentialModel{
+		coll: coll,
+	}
+}
+
+// GenerateWorkerSecret 生成带前缀的随机令牌，返回明文和哈希，用于注册令牌和Worker凭证
+func GenerateWorkerSecret(prefix string) (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token := prefix + base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashApiToken(token), nil
+}
+
+func (m *WorkerCredentialModel) Insert(ctx context.Context, doc *WorkerCredential) error {
+	if doc.Id.IsZero() {
+		doc.Id = primitive.NewObjectID()
+	}
+	doc.CreateTime = time.Now()
+	_, err := m.coll.InsertOne(ctx, doc)
+	return err
+}
+
+// FindActiveByToken 根据凭证明文查找未吊销的凭证，未找到返回nil
+func (m *WorkerCredentialModel) FindActiveByToken(ctx context.Context, token string) (*WorkerCredential, error) {
+	var doc WorkerCredential
+	err := m.coll.FindOne(ctx, bson.M{"token_hash": HashApiToken(token), "revoked": false}).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func (m *WorkerCredentialModel) FindAll(ctx context.Context) ([]WorkerCredential, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "create_time", Value: -1}})
+	cursor, err := m.coll.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []WorkerCredential
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// UpdateLastSeen 记录凭证最后使用时间和来源IP
+func (m *WorkerCredentialModel) UpdateLastSeen(ctx context.Context, id primitive.ObjectID, ip string) error {
+	_, err := m.coll.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{
+		"last_seen_time": time.Now(),
+		"last_seen_ip":   ip,
+	}})
+	return err
+}
+
+// Revoke 吊销凭证，保留记录便于审计；返回是否找到凭证
+func (m *WorkerCredentialModel) Revoke(ctx context.Context, id string) (bool, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+	result, err := m.coll.UpdateOne(ctx, bson.M{"_id": oid, "revoked": false}, bson.M{"$set": bson.M{
+		"revoked":     true,
+		"revoke_time": time.Now(),
+	}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// RevokeByWorker 吊销指定Worker的全部凭证，用于删除Worker
+func (m *WorkerCredentialModel) RevokeByWorker(ctx context.Context, workerName string) (int64, error) {
+	result, err := m.coll.UpdateMany(ctx, bson.M{"worker_name": workerName, "revoked": false}, bson.M{"$set": bson.M{
+		"revoked":     true,
+		"revoke_time": time.Now(),
+	}})
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// RenameWorker Worker重命名后同步凭证绑定的名称
+func (m *WorkerCredentialModel) RenameWorker(ctx context.Context, oldName, newName string) error {
+	_, err := m.coll.UpdateMany(ctx, bson.M{"worker_name": oldName, "revoked": false}, bson.M{"$set": bson.M{"worker_name": newName}})
+	return err
+}
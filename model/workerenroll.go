@@ -0,0 +1,122 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WorkerEnrollTokenPrefix Worker注册令牌前缀
+const WorkerEnrollTokenPrefix = "cwe_"
+
+// WorkerEnrollToken Worker注册令牌，Worker首次启动时用它换取自己的凭证，只保存令牌的SHA256
+type WorkerEnrollToken struct {
+	Id         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name       string             `bson:"name" json:"name"`
+	TokenHash  string             `bson:"token_hash" json:"-"`
+	Prefix     string             `bson:"prefix" json:"prefix"`
+	MaxUses    int                `bson:"max_uses" json:"maxUses"` // 可注册的Worker数量，0表示不限
+	UsedCount  int                `bson:"used_count" json:"usedCount"`
+	ExpireTime *time.Time         `bson:"expire_time,omitempty" json:"expireTime"`
+	Revoked    bool               `bson:"revoked" json:"revoked"`
+	CreatedBy  string             `bson:"created_by" json:"createdBy"`
+	CreateTime time.Time          `bson:"create_time" json:"createTime"`
+}
+
+// Expired 令牌是否已过期
+func (t *WorkerEnrollToken) Expired() bool {
+	return t.ExpireTime != nil && time.Now().After(*t.ExpireTime)
+}
+
+// Exhausted 注册次数是否已用完
+func (t *WorkerEnrollToken) Exhausted() bool {
+	return t.MaxUses > 0 && t.UsedCount >= t.MaxUses
+}
+
+type WorkerEnrollTokenModel struct {
+	coll *mongo.Collection
+}
+
+func NewWorkerEnrollTokenModel(db *mongo.Database) *WorkerEnrollTokenModel {
+	coll := db.Collection("worker_enroll_token")
+
+	ctx := context.Background()
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "create_time", Value: -1}}},
+	}
+	coll.Indexes().CreateMany(ctx, indexes)
+
+	return &WorkerEnrollTokenModel{
+		coll: coll,
+	}
+}
+
+func (m *WorkerEnrollTokenModel) Insert(ctx context.Context, doc *WorkerEnrollToken) error {
+	if doc.Id.IsZero() {
+		doc.Id = primitive.NewObjectID()
+	}
+	doc.CreateTime = time.Now()
+	_, err := m.coll.InsertOne(ctx, doc)
+	return err
+}
+
+// Consume 校验令牌并原子地增加使用次数，令牌无效、过期或次数用完时返回nil
+func (m *WorkerEnrollTokenModel) Consume(ctx context.Context, token string) (*WorkerEnrollToken, error) {
+	filter := bson.M{
+		"token_hash": HashApiToken(token),
+		"revoked":    false,
+		"$and": []bson.M{
+			{"$or": []bson.M{
+				{"expire_time": bson.M{"$exists": false}},
+				{"expire_time": bson.M{"$gt": time.Now()}},
+			}},
+			{"$or": []bson.M{
+				{"max_uses": 0},
+				{"$expr": bson.M{"$lt": bson.A{"$used_count", "$max_uses"}}},
+			}},
+		},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var doc WorkerEnrollToken
+	err := m.coll.FindOneAndUpdate(ctx, filter, bson.M{"$inc": bson.M{"used_count": 1}}, opts).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func (m *WorkerEnrollTokenModel) FindAll(ctx context.Context) ([]WorkerEnrollToken, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "create_time", Value: -1}})
+	cursor, err := m.coll.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []WorkerEnrollToken
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// Revoke 吊销注册令牌，已注册的Worker凭证不受影响；返回是否找到令牌
+func (m *WorkerEnrollTokenModel) Revoke(ctx context.Context, id string) (bool, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+	result, err := m.coll.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"revoked": true}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
@@ -0,0 +1,113 @@
+package blob
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrNotFound 对象不存在
+var ErrNotFound = errors.New("blob not found")
+
+// Store 按内容寻址的对象存储，对象以内容的SHA256作为键
+type Store interface {
+	// Put 保存数据并返回其SHA256，内容已存在时不重复写入
+	Put(ctx context.Context, data []byte) (string, error)
+	// Get 读取数据，不存在时返回 ErrNotFound
+	Get(ctx context.Context, hash string) ([]byte, error)
+	// Exists 判断对象是否存在
+	Exists(ctx context.Context, hash string) (bool, error)
+}
+
+// Config 存储后端配置
+type Config struct {
+	Type     string   `json:",optional"` // local/s3，默认local
+	LocalDir string   `json:",optional"` // 本地存储目录，默认 data/blob
+	S3       S3Config `json:",optional"`
+	// 响应体超过该长度时转存到对象存储，Mongo中只保留前N字节用于检索，默认4096
+	BodyInlineLimit int `json:",optional"`
+}
+
+// S3Config S3兼容存储配置，也可用于MinIO
+type S3Config struct {
+	Endpoint  string `json:",optional"` // 如 http://minio:9000，AWS S3留空
+	Region    string `json:",optional"` // 默认 us-east-1
+	Bucket    string `json:",optional"`
+	AccessKey string `json:",optional"`
+	SecretKey string `json:",optional"`
+	Prefix    string `json:",optional"` // 对象键前缀，如 cscan/
+	PathStyle bool   `json:",optional"` // MinIO等需要路径风格访问
+}
+
+// New 根据配置创建存储
+func New(c Config) (Store, error) {
+	switch c.Type {
+	case "", "local":
+		dir := c.LocalDir
+		if dir == "" {
+			dir = "data/blob"
+		}
+		return NewLocalStore(dir)
+	case "s3":
+		return NewS3Store(c.S3)
+	}
+	return nil, fmt.Errorf("unknown blob store type: %s", c.Type)
+}
+
+// InlineLimit 响应体保留在Mongo中的长度
+func (c Config) InlineLimit() int {
+	if c.BodyInlineLimit <= 0 {
+		return 4096
+	}
+	return c.BodyInlineLimit
+}
+
+// Hash 计算内容的SHA256
+func Hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// IsValidHash 检查是否为合法的SHA256十六进制串，防止路径穿越
+func IsValidHash(hash string) bool {
+	if len(hash) != 64 {
+		return false
+	}
+	for _, c := range hash {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// DecodeScreenshot 解码base64截图，兼容带data URL前缀的格式
+func DecodeScreenshot(s string) ([]byte, error) {
+	if strings.HasPrefix(s, "data:") {
+		if idx := strings.Index(s, ","); idx >= 0 {
+			s = s[idx+1:]
+		}
+	}
+	return base64.StdEncoding.DecodeString(s)
+}
+
+// TruncateBody 保留响应体开头用于检索，不截断在UTF-8字符中间
+func TruncateBody(body string, limit int) string {
+	if len(body) <= limit {
+		return body
+	}
+	for limit > 0 && !utf8.RuneStart(body[limit]) {
+		limit--
+	}
+	return body[:limit]
+}
+
+// objectKey 对象键按哈希前两位分目录，避免单目录文件过多
+func objectKey(hash string) string {
+	return hash[:2] + "/" + hash
+}
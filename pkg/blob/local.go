@@ -0,0 +1,77 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// LocalStore 本地文件系统存储，API和RPC服务需要挂载同一目录
+type LocalStore struct {
+	dir string
+}
+
+func NewLocalStore(dir string) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create blob dir: %v", err)
+	}
+	return &LocalStore{dir: dir}, nil
+}
+
+func (s *LocalStore) path(hash string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(objectKey(hash)))
+}
+
+func (s *LocalStore) Put(ctx context.Context, data []byte) (string, error) {
+	hash := Hash(data)
+	path := s.path(hash)
+	if _, err := os.Stat(path); err == nil {
+		return hash, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+
+	// 先写临时文件再重命名，避免并发写入时读到不完整的文件
+	tmp, err := os.CreateTemp(filepath.Dir(path), hash+".*.tmp")
+	if err != nil {
+		return "", err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	return hash, nil
+}
+
+func (s *LocalStore) Get(ctx context.Context, hash string) ([]byte, error) {
+	if !IsValidHash(hash) {
+		return nil, ErrNotFound
+	}
+	data, err := os.ReadFile(s.path(hash))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+func (s *LocalStore) Exists(ctx context.Context, hash string) (bool, error) {
+	if !IsValidHash(hash) {
+		return false, nil
+	}
+	_, err := os.Stat(s.path(hash))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
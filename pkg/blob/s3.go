@@ -0,0 +1,107 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3Store S3兼容对象存储
+type S3Store struct {
+	client *s3.Client
+	bucket string
+	prefix string
+}
+
+func NewS3Store(c S3Config) (*S3Store, error) {
+	if c.Bucket == "" {
+		return nil, fmt.Errorf("s3 bucket is required")
+	}
+	region := c.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	client := s3.New(s3.Options{
+		Region:       region,
+		Credentials:  aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(c.AccessKey, c.SecretKey, "")),
+		UsePathStyle: c.PathStyle,
+	}, func(o *s3.Options) {
+		if c.Endpoint != "" {
+			o.BaseEndpoint = aws.String(c.Endpoint)
+		}
+	})
+
+	return &S3Store{client: client, bucket: c.Bucket, prefix: c.Prefix}, nil
+}
+
+func (s *S3Store) key(hash string) string {
+	return s.prefix + objectKey(hash)
+}
+
+func (s *S3Store) Put(ctx context.Context, data []byte) (string, error) {
+	hash := Hash(data)
+	exists, err := s.Exists(ctx, hash)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return hash, nil
+	}
+
+	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(s.key(hash)),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(http.DetectContentType(data)),
+	})
+	if err != nil {
+		return "", fmt.Errorf("s3 put %s: %v", hash, err)
+	}
+	return hash, nil
+}
+
+func (s *S3Store) Get(ctx context.Context, hash string) ([]byte, error) {
+	if !IsValidHash(hash) {
+		return nil, ErrNotFound
+	}
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(hash)),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("s3 get %s: %v", hash, err)
+	}
+	defer out.Body.Close()
+	return io.ReadAll(out.Body)
+}
+
+func (s *S3Store) Exists(ctx context.Context, hash string) (bool, error) {
+	if !IsValidHash(hash) {
+		return false, nil
+	}
+	_, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(hash)),
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("s3 head %s: %v", hash, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,426 @@
+package cdn
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// 服务商类型
+const (
+	TypeCDN   = "cdn"
+	TypeWAF   = "waf"
+	TypeCloud = "cloud"
+)
+
+// 默认CDN/WAF/云厂商特征数据，可通过 LoadFile 使用外部文件覆盖
+//
+//go:embed data/cdn.json
+var defaultData []byte
+
+// DefaultResolvers 默认用于多地解析比对的DNS服务器
+var DefaultResolvers = []string{
+	"223.5.5.5:53",
+	"119.29.29.29:53",
+	"8.8.8.8:53",
+	"1.1.1.1:53",
+}
+
+// Provider 服务商特征
+type Provider struct {
+	Name   string   `json:"name"`
+	Type   string   `json:"type"`   // cdn/waf/cloud
+	CNames []string `json:"cnames"` // CNAME后缀
+	CIDRs  []string `json:"cidrs"`  // IP段
+}
+
+// Data 特征数据文件格式
+type Data struct {
+	Version   string     `json:"version"`
+	Providers []Provider `json:"providers"`
+}
+
+// Result 检测结果
+type Result struct {
+	IsCDN    bool     `json:"isCdn"`
+	IsWAF    bool     `json:"isWaf"`
+	IsCloud  bool     `json:"isCloud"`
+	Provider string   `json:"provider"`
+	CNames   []string `json:"cnames"` // CNAME链，按解析顺序
+	IPs      []string `json:"ips"`    // 各解析器返回的IP并集
+	Reason   string   `json:"reason"` // 命中依据: cname/cidr/divergence
+
+	providerType string
+}
+
+// CName 返回CNAME链的最终目标
+func (r *Result) CName() string {
+	if len(r.CNames) == 0 {
+		return ""
+	}
+	return r.CNames[len(r.CNames)-1]
+}
+
+type provider struct {
+	Provider
+	nets []*net.IPNet
+}
+
+// Detector CDN/WAF/云检测器
+type Detector struct {
+	version   string
+	providers []provider
+	resolvers []string
+	timeout   time.Duration
+}
+
+// NewDetector 根据特征数据创建检测器，resolvers为空时使用 DefaultResolvers
+func NewDetector(data *Data, resolvers []string) *Detector {
+	if len(resolvers) == 0 {
+		resolvers = DefaultResolvers
+	}
+	d := &Detector{
+		version:   data.Version,
+		resolvers: normalizeResolvers(resolvers),
+		timeout:   3 * time.Second,
+	}
+	for _, p := range data.Providers {
+		item := provider{Provider: p}
+		for i, suffix := range item.CNames {
+			item.CNames[i] = strings.ToLower(strings.TrimSuffix(suffix, "."))
+		}
+		for _, cidr := range p.CIDRs {
+			if _, ipNet, err := net.ParseCIDR(cidr); err == nil {
+				item.nets = append(item.nets, ipNet)
+			}
+		}
+		d.providers = append(d.providers, item)
+	}
+	return d
+}
+
+// WithResolvers 返回使用指定解析器的检测器副本
+func (d *Detector) WithResolvers(resolvers []string) *Detector {
+	if len(resolvers) == 0 {
+		return d
+	}
+	c := *d
+	c.resolvers = normalizeResolvers(resolvers)
+	return &c
+}
+
+// Version 特征数据版本
+func (d *Detector) Version() string {
+	return d.version
+}
+
+var (
+	defaultDetector *Detector
+	defaultMu       sync.RWMutex
+)
+
+// Default 返回全局检测器，首次调用时加载内置特征数据
+func Default() *Detector {
+	defaultMu.RLock()
+	d := defaultDetector
+	defaultMu.RUnlock()
+	if d != nil {
+		return d
+	}
+
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	if defaultDetector == nil {
+		var data Data
+		json.Unmarshal(defaultData, &data)
+		defaultDetector = NewDetector(&data, nil)
+	}
+	return defaultDetector
+}
+
+// LoadFile 从外部文件加载特征数据替换全局检测器，便于不重新编译即可更新CDN列表
+func LoadFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var data Data
+	if err := json.Unmarshal(content, &data); err != nil {
+		return fmt.Errorf("parse cdn data: %v", err)
+	}
+	if len(data.Providers) == 0 {
+		return fmt.Errorf("cdn data has no providers")
+	}
+
+	defaultMu.Lock()
+	defaultDetector = NewDetector(&data, nil)
+	defaultMu.Unlock()
+	return nil
+}
+
+// MatchCName 按CNAME后缀匹配服务商
+func (d *Detector) MatchCName(name string) *Provider {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	if name == "" {
+		return nil
+	}
+	for i := range d.providers {
+		for _, suffix := range d.providers[i].CNames {
+			if name == suffix || strings.HasSuffix(name, "."+suffix) {
+				return &d.providers[i].Provider
+			}
+		}
+	}
+	return nil
+}
+
+// MatchIP 按IP段匹配服务商
+func (d *Detector) MatchIP(ip string) *Provider {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return nil
+	}
+	for i := range d.providers {
+		for _, ipNet := range d.providers[i].nets {
+			if ipNet.Contains(parsed) {
+				return &d.providers[i].Provider
+			}
+		}
+	}
+	return nil
+}
+
+// Detect 检测域名是否使用CDN/WAF/云服务。
+// 依次使用CNAME链、IP段以及多解析器结果差异判断，ips为调用方已解析出的IP。
+func (d *Detector) Detect(ctx context.Context, domain string, ips []string) *Result {
+	result := &Result{}
+
+	chain, resolverIPs := d.resolve(ctx, domain)
+	result.CNames = chain
+
+	ipSet := make(map[string]bool)
+	for _, ip := range ips {
+		if !ipSet[ip] {
+			ipSet[ip] = true
+			result.IPs = append(result.IPs, ip)
+		}
+	}
+	for _, list := range resolverIPs {
+		for _, ip := range list {
+			if !ipSet[ip] {
+				ipSet[ip] = true
+				result.IPs = append(result.IPs, ip)
+			}
+		}
+	}
+
+	for _, cname := range chain {
+		if p := d.MatchCName(cname); p != nil {
+			result.apply(p, "cname")
+		}
+	}
+	for _, ip := range result.IPs {
+		if p := d.MatchIP(ip); p != nil {
+			result.apply(p, "cidr")
+		}
+	}
+
+	// 未命中已知特征时，根据不同解析器返回的IP网段差异判断
+	if !result.IsCDN && divergent(resolverIPs) {
+		result.IsCDN = true
+		if result.Reason == "" {
+			result.Reason = "divergence"
+		}
+	}
+	return result
+}
+
+// DetectIP 仅按IP段检测
+func (d *Detector) DetectIP(ip string) *Result {
+	result := &Result{IPs: []string{ip}}
+	if p := d.MatchIP(ip); p != nil {
+		result.apply(p, "cidr")
+	}
+	return result
+}
+
+func (r *Result) apply(p *Provider, reason string) {
+	switch p.Type {
+	case TypeCDN:
+		r.IsCDN = true
+	case TypeWAF:
+		// WAF同样是反向代理，源站IP不可见
+		r.IsCDN = true
+		r.IsWAF = true
+	case TypeCloud:
+		r.IsCloud = true
+	default:
+		return
+	}
+	// CDN/WAF优先于云厂商作为服务商名称
+	if r.Provider == "" || (r.providerType == TypeCloud && p.Type != TypeCloud) {
+		r.Provider = p.Name
+		r.providerType = p.Type
+	}
+	if r.Reason == "" {
+		r.Reason = reason
+	} else if !strings.Contains(r.Reason, reason) {
+		r.Reason += "," + reason
+	}
+}
+
+// resolve 并发向各解析器查询A记录，返回CNAME链及每个解析器的IP列表
+func (d *Detector) resolve(ctx context.Context, domain string) ([]string, [][]string) {
+	fqdn := dns.Fqdn(domain)
+	type answer struct {
+		chain []string
+		ips   []string
+	}
+
+	answers := make([]answer, len(d.resolvers))
+	var wg sync.WaitGroup
+	for i, server := range d.resolvers {
+		wg.Add(1)
+		go func(i int, server string) {
+			defer wg.Done()
+			chain, ips, err := d.query(ctx, server, fqdn)
+			if err != nil {
+				return
+			}
+			answers[i] = answer{chain: chain, ips: ips}
+		}(i, server)
+	}
+	wg.Wait()
+
+	var chain []string
+	var resolverIPs [][]string
+	for _, a := range answers {
+		if len(a.chain) > len(chain) {
+			chain = a.chain
+		}
+		if len(a.ips) > 0 {
+			resolverIPs = append(resolverIPs, a.ips)
+		}
+	}
+
+	// 所有解析器都不可达时回退到系统解析器获取CNAME
+	if len(chain) == 0 && len(resolverIPs) == 0 {
+		if cname, err := net.DefaultResolver.LookupCNAME(ctx, domain); err == nil {
+			cname = strings.TrimSuffix(cname, ".")
+			if cname != "" && !strings.EqualFold(cname, strings.TrimSuffix(domain, ".")) {
+				chain = []string{cname}
+			}
+		}
+	}
+	return chain, resolverIPs
+}
+
+// query 查询单个解析器，CNAME链按应答中的跳转顺序还原
+func (d *Detector) query(ctx context.Context, server, fqdn string) ([]string, []string, error) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(fqdn, dns.TypeA)
+	msg.RecursionDesired = true
+
+	client := &dns.Client{Timeout: d.timeout}
+	resp, _, err := client.ExchangeContext(ctx, msg, server)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.Rcode != dns.RcodeSuccess {
+		return nil, nil, fmt.Errorf("rcode %s", dns.RcodeToString[resp.Rcode])
+	}
+
+	next := make(map[string]string)
+	var ips []string
+	for _, rr := range resp.Answer {
+		switch v := rr.(type) {
+		case *dns.CNAME:
+			next[strings.ToLower(v.Hdr.Name)] = strings.ToLower(v.Target)
+		case *dns.A:
+			ips = append(ips, v.A.String())
+		}
+	}
+
+	var chain []string
+	cur := strings.ToLower(fqdn)
+	for i := 0; i < 16; i++ {
+		target, ok := next[cur]
+		if !ok {
+			break
+		}
+		chain = append(chain, strings.TrimSuffix(target, "."))
+		cur = target
+	}
+	return chain, ips, nil
+}
+
+// divergent 判断各解析器返回的IP是否分布在互不相交的网段（IPv4按/24），
+// 普通负载均衡在各地返回相同的IP集合，CDN则按解析器位置返回不同的边缘节点
+func divergent(resolverIPs [][]string) bool {
+	if len(resolverIPs) < 2 {
+		return false
+	}
+	sets := make([]map[string]bool, 0, len(resolverIPs))
+	for _, ips := range resolverIPs {
+		set := make(map[string]bool)
+		for _, ip := range ips {
+			if prefix := ipPrefix(ip); prefix != "" {
+				set[prefix] = true
+			}
+		}
+		if len(set) > 0 {
+			sets = append(sets, set)
+		}
+	}
+	for i := 0; i < len(sets); i++ {
+		for j := i + 1; j < len(sets); j++ {
+			if !intersects(sets[i], sets[j]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func ipPrefix(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ""
+	}
+	if v4 := parsed.To4(); v4 != nil {
+		return v4.Mask(net.CIDRMask(24, 32)).String()
+	}
+	return parsed.Mask(net.CIDRMask(48, 128)).String()
+}
+
+func intersects(a, b map[string]bool) bool {
+	for k := range a {
+		if b[k] {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeResolvers(resolvers []string) []string {
+	list := make([]string, 0, len(resolvers))
+	for _, r := range resolvers {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(r); err != nil {
+			r = net.JoinHostPort(r, "53")
+		}
+		list = append(list, r)
+	}
+	return list
+}
@@ -0,0 +1,461 @@
+// Package dnsrecord 收集域名的完整DNS记录，检查区域传送(AXFR)和SPF/DMARC配置
+package dnsrecord
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"cscan/pkg/cdn"
+
+	"github.com/miekg/dns"
+)
+
+// QueryTypes 收集的记录类型
+var QueryTypes = []uint16{dns.TypeA, dns.TypeAAAA, dns.TypeCNAME, dns.TypeMX, dns.TypeNS, dns.TypeTXT, dns.TypeSOA, dns.TypeCAA}
+
+// 问题类型
+const (
+	IssueSpfMissing      = "spf_missing"       // 没有SPF记录
+	IssueSpfMultiple     = "spf_multiple"      // 多条SPF记录，接收方按permerror处理
+	IssueSpfPassAll      = "spf_pass_all"      // +all，任何服务器都可以代发
+	IssueSpfNeutral      = "spf_neutral"       // ?all，不做判断
+	IssueSpfSoftFail     = "spf_softfail"      // ~all，只标记不拒绝
+	IssueSpfNoAll        = "spf_no_all"        // 没有all机制也没有redirect
+	IssueDmarcMissing    = "dmarc_missing"     // 没有DMARC记录
+	IssueDmarcMultiple   = "dmarc_multiple"    // 多条DMARC记录
+	IssueDmarcInvalid    = "dmarc_invalid"     // 缺少p标签
+	IssueDmarcPolicyNone = "dmarc_policy_none" // p=none，只监控不拦截
+	IssueDmarcPartial    = "dmarc_partial"     // pct<100，只对部分邮件生效
+	IssueZoneTransfer    = "zone_transfer"     // 权威NS允许区域传送
+)
+
+// Record DNS记录
+type Record struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	TTL   uint32 `json:"ttl"`
+}
+
+// Issue 配置问题
+type Issue struct {
+	Type     string `json:"type"`
+	Severity string `json:"severity"`
+	Detail   string `json:"detail"`
+}
+
+// Result 单个域名的收集结果
+type Result struct {
+	Domain       string   `json:"domain"`
+	Zone         string   `json:"zone"` // 所属区域，即SOA所在的域名
+	Records      []Record `json:"records"`
+	SPF          string   `json:"spf"`
+	DMARC        string   `json:"dmarc"`
+	ZoneTransfer []string `json:"zoneTransfer"` // 允许区域传送的NS
+	Issues       []Issue  `json:"issues"`
+}
+
+// IsZoneApex 是否为区域顶点
+func (r *Result) IsZoneApex() bool {
+	return r.Zone != "" && r.Domain == r.Zone
+}
+
+// Options 收集选项
+type Options struct {
+	Resolvers     []string      // DNS服务器，host或host:port，为空时使用 cdn.DefaultResolvers
+	Timeout       time.Duration // 单次查询超时，默认3秒
+	CheckTransfer bool          // 对区域顶点的权威NS尝试AXFR
+	TransferPort  int           // AXFR连接的端口，默认53
+	// 权威NS的地址(host:port)，键为NS名称；未指定的NS通过Resolvers解析地址，使用TransferPort连接
+	NameserverAddrs map[string][]string
+}
+
+// Collector DNS记录收集器，可并发使用
+type Collector struct {
+	opts      Options
+	resolvers []string
+}
+
+// NewCollector 创建收集器
+func NewCollector(opts Options) *Collector {
+	if len(opts.Resolvers) == 0 {
+		opts.Resolvers = cdn.DefaultResolvers
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 3 * time.Second
+	}
+	if opts.TransferPort <= 0 {
+		opts.TransferPort = 53
+	}
+	return &Collector{opts: opts, resolvers: normalizeResolvers(opts.Resolvers)}
+}
+
+// Collect 收集域名的DNS记录。区域顶点和有MX记录的域名检查SPF/DMARC，区域顶点检查区域传送。
+// 域名不存在或所有服务器都无法访问时返回nil
+func (c *Collector) Collect(ctx context.Context, domain string) *Result {
+	domain = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+	if domain == "" {
+		return nil
+	}
+	fqdn := dns.Fqdn(domain)
+	result := &Result{Domain: domain}
+	seen := make(map[string]bool)
+	answered := false
+
+	for _, qtype := range QueryTypes {
+		if ctx.Err() != nil {
+			return nil
+		}
+		resp, err := c.query(ctx, fqdn, qtype)
+		if err != nil {
+			continue
+		}
+		if resp.Rcode == dns.RcodeNameError {
+			return nil
+		}
+		answered = true
+		for _, rr := range resp.Answer {
+			if rr.Header().Rrtype != qtype {
+				continue
+			}
+			record := toRecord(rr)
+			key := record.Type + "|" + record.Name + "|" + record.Value
+			if !seen[key] {
+				seen[key] = true
+				result.Records = append(result.Records, record)
+			}
+		}
+		// SOA查询的权威部分给出所属区域
+		if qtype == dns.TypeSOA {
+			result.Zone = zoneFromSOA(resp, domain)
+		}
+	}
+	if !answered {
+		return nil
+	}
+	if result.Zone == "" {
+		result.Zone = c.findZone(ctx, domain)
+	}
+
+	hasMX := false
+	var txts, nameservers []string
+	for _, r := range result.Records {
+		if r.Name != domain {
+			continue
+		}
+		switch r.Type {
+		case "MX":
+			hasMX = true
+		case "TXT":
+			txts = append(txts, r.Value)
+		case "NS":
+			nameservers = append(nameservers, r.Value)
+		}
+	}
+
+	if result.IsZoneApex() || hasMX {
+		c.checkMail(ctx, result, txts)
+	}
+	if result.IsZoneApex() && c.opts.CheckTransfer && len(nameservers) > 0 {
+		c.checkTransfer(ctx, result, nameservers)
+	}
+	return result
+}
+
+// checkMail 检查SPF和DMARC，子域名没有DMARC记录时使用所属区域的策略
+func (c *Collector) checkMail(ctx context.Context, result *Result, txts []string) {
+	var spfs []string
+	for _, txt := range txts {
+		if isSPF(txt) {
+			spfs = append(spfs, txt)
+		}
+	}
+	switch {
+	case len(spfs) == 0:
+		result.addIssue(IssueSpfMissing, "medium", "未配置SPF记录，可伪造该域名发送邮件")
+	case len(spfs) > 1:
+		result.SPF = spfs[0]
+		result.addIssue(IssueSpfMultiple, "medium", fmt.Sprintf("存在%d条SPF记录，接收方将按permerror处理", len(spfs)))
+	default:
+		result.SPF = spfs[0]
+		if issue := CheckSPF(spfs[0]); issue != nil {
+			result.Issues = append(result.Issues, *issue)
+		}
+	}
+
+	dmarcs := c.lookupDMARC(ctx, result.Domain)
+	if len(dmarcs) == 0 && result.Zone != "" && result.Zone != result.Domain {
+		dmarcs = c.lookupDMARC(ctx, result.Zone)
+	}
+	switch {
+	case len(dmarcs) == 0:
+		result.addIssue(IssueDmarcMissing, "medium", "未配置DMARC记录")
+	case len(dmarcs) > 1:
+		result.DMARC = dmarcs[0]
+		result.addIssue(IssueDmarcMultiple, "medium", fmt.Sprintf("存在%d条DMARC记录，接收方将忽略DMARC", len(dmarcs)))
+	default:
+		result.DMARC = dmarcs[0]
+		if issue := CheckDMARC(dmarcs[0]); issue != nil {
+			result.Issues = append(result.Issues, *issue)
+		}
+	}
+}
+
+// lookupDMARC 查询 _dmarc.<domain> 的DMARC记录
+func (c *Collector) lookupDMARC(ctx context.Context, domain string) []string {
+	resp, err := c.query(ctx, dns.Fqdn("_dmarc."+domain), dns.TypeTXT)
+	if err != nil {
+		return nil
+	}
+	var records []string
+	for _, rr := range resp.Answer {
+		if t, ok := rr.(*dns.TXT); ok {
+			txt := strings.Join(t.Txt, "")
+			if strings.HasPrefix(strings.ToLower(txt), "v=dmarc1") {
+				records = append(records, txt)
+			}
+		}
+	}
+	return records
+}
+
+// checkTransfer 对每个权威NS的每个地址尝试AXFR
+func (c *Collector) checkTransfer(ctx context.Context, result *Result, nameservers []string) {
+	for _, ns := range nameservers {
+		for _, addr := range c.nameserverAddrs(ctx, ns) {
+			if ctx.Err() != nil {
+				return
+			}
+			count, err := c.transfer(ctx, result.Zone, addr)
+			if err != nil || count == 0 {
+				continue
+			}
+			result.ZoneTransfer = append(result.ZoneTransfer, ns)
+			result.addIssue(IssueZoneTransfer, "high", fmt.Sprintf("%s(%s)允许区域传送，返回%d条记录", ns, addr, count))
+			break
+		}
+	}
+}
+
+// nameserverAddrs 权威NS用于AXFR的地址，优先使用指定的地址
+func (c *Collector) nameserverAddrs(ctx context.Context, ns string) []string {
+	if addrs, ok := c.opts.NameserverAddrs[ns]; ok {
+		return addrs
+	}
+	port := fmt.Sprint(c.opts.TransferPort)
+	var addrs []string
+	for _, ip := range c.lookupAddrs(ctx, ns) {
+		addrs = append(addrs, net.JoinHostPort(ip, port))
+	}
+	return addrs
+}
+
+// transfer 执行AXFR，返回获取的记录数
+func (c *Collector) transfer(ctx context.Context, zone, server string) (int, error) {
+	m := new(dns.Msg)
+	m.SetAxfr(dns.Fqdn(zone))
+	t := &dns.Transfer{
+		DialTimeout:  c.opts.Timeout,
+		ReadTimeout:  c.opts.Timeout,
+		WriteTimeout: c.opts.Timeout,
+	}
+	ch, err := t.In(m, server)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for env := range ch {
+		if env.Error != nil {
+			err = env.Error
+			continue
+		}
+		count += len(env.RR)
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// lookupAddrs 通过配置的服务器解析NS的地址
+func (c *Collector) lookupAddrs(ctx context.Context, host string) []string {
+	var addrs []string
+	for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA} {
+		resp, err := c.query(ctx, dns.Fqdn(host), qtype)
+		if err != nil {
+			continue
+		}
+		for _, rr := range resp.Answer {
+			switch v := rr.(type) {
+			case *dns.A:
+				addrs = append(addrs, v.A.String())
+			case *dns.AAAA:
+				addrs = append(addrs, v.AAAA.String())
+			}
+		}
+	}
+	return addrs
+}
+
+// query 依次尝试各服务器，UDP响应被截断时改用TCP
+func (c *Collector) query(ctx context.Context, fqdn string, qtype uint16) (*dns.Msg, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(fqdn, qtype)
+	m.RecursionDesired = true
+	m.SetEdns0(4096, false)
+
+	var lastErr error
+	for _, server := range c.resolvers {
+		client := &dns.Client{Timeout: c.opts.Timeout}
+		resp, _, err := client.ExchangeContext(ctx, m, server)
+		if err == nil && resp.Truncated {
+			client.Net = "tcp"
+			resp, _, err = client.ExchangeContext(ctx, m, server)
+		}
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if resp.Rcode != dns.RcodeSuccess && resp.Rcode != dns.RcodeNameError {
+			lastErr = fmt.Errorf("%s: %s", server, dns.RcodeToString[resp.Rcode])
+			continue
+		}
+		return resp, nil
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no resolvers")
+	}
+	return nil, lastErr
+}
+
+// CheckSPF 检查SPF记录的all机制
+func CheckSPF(spf string) *Issue {
+	terms := strings.Fields(strings.ToLower(spf))
+	for _, term := range terms[1:] {
+		switch term {
+		case "all", "+all":
+			return &Issue{Type: IssueSpfPassAll, Severity: "high", Detail: "SPF使用" + term + "，任何服务器都可以代发邮件"}
+		case "?all":
+			return &Issue{Type: IssueSpfNeutral, Severity: "medium", Detail: "SPF使用?all，接收方不做判断"}
+		case "~all":
+			return &Issue{Type: IssueSpfSoftFail, Severity: "info", Detail: "SPF使用~all，伪造邮件只被标记"}
+		case "-all":
+			return nil
+		}
+		if strings.HasPrefix(term, "redirect=") {
+			return nil
+		}
+	}
+	return &Issue{Type: IssueSpfNoAll, Severity: "medium", Detail: "SPF没有all机制，未匹配的服务器结果为neutral"}
+}
+
+// CheckDMARC 检查DMARC策略
+func CheckDMARC(dmarc string) *Issue {
+	tags := make(map[string]string)
+	for _, part := range strings.Split(dmarc, ";") {
+		k, v, ok := strings.Cut(part, "=")
+		if ok {
+			tags[strings.ToLower(strings.TrimSpace(k))] = strings.ToLower(strings.TrimSpace(v))
+		}
+	}
+	policy, ok := tags["p"]
+	switch {
+	case !ok || (policy != "none" && policy != "quarantine" && policy != "reject"):
+		return &Issue{Type: IssueDmarcInvalid, Severity: "medium", Detail: "DMARC缺少有效的p标签"}
+	case policy == "none":
+		return &Issue{Type: IssueDmarcPolicyNone, Severity: "medium", Detail: "DMARC策略为p=none，只监控不拦截"}
+	}
+	if pct, ok := tags["pct"]; ok && pct != "100" {
+		return &Issue{Type: IssueDmarcPartial, Severity: "low", Detail: "DMARC只对" + pct + "%的邮件生效"}
+	}
+	return nil
+}
+
+func (r *Result) addIssue(typ, severity, detail string) {
+	r.Issues = append(r.Issues, Issue{Type: typ, Severity: severity, Detail: detail})
+}
+
+func isSPF(txt string) bool {
+	lower := strings.ToLower(txt)
+	return lower == "v=spf1" || strings.HasPrefix(lower, "v=spf1 ")
+}
+
+// toRecord 转换为记录，值为去掉记录头后的内容，TXT合并多个字符串
+func toRecord(rr dns.RR) Record {
+	h := rr.Header()
+	record := Record{
+		Type: dns.TypeToString[h.Rrtype],
+		Name: strings.ToLower(strings.TrimSuffix(h.Name, ".")),
+		TTL:  h.Ttl,
+	}
+	switch v := rr.(type) {
+	case *dns.TXT:
+		record.Value = strings.Join(v.Txt, "")
+	case *dns.CNAME:
+		record.Value = strings.ToLower(strings.TrimSuffix(v.Target, "."))
+	case *dns.NS:
+		record.Value = strings.ToLower(strings.TrimSuffix(v.Ns, "."))
+	case *dns.MX:
+		record.Value = fmt.Sprintf("%d %s", v.Preference, strings.ToLower(strings.TrimSuffix(v.Mx, ".")))
+	default:
+		record.Value = strings.TrimPrefix(rr.String(), h.String())
+	}
+	return record
+}
+
+// findZone 域名为CNAME时SOA应答属于CNAME目标，逐级向上查询SOA确定所属区域
+func (c *Collector) findZone(ctx context.Context, domain string) string {
+	name := domain
+	for {
+		_, parent, ok := strings.Cut(name, ".")
+		if !ok || !strings.Contains(parent, ".") {
+			return ""
+		}
+		resp, err := c.query(ctx, dns.Fqdn(parent), dns.TypeSOA)
+		if err != nil {
+			return ""
+		}
+		if zone := zoneFromSOA(resp, parent); zone != "" {
+			return zone
+		}
+		name = parent
+	}
+}
+
+// zoneFromSOA 从SOA应答或权威部分取区域名，区域必须是域名本身或其上级
+func zoneFromSOA(resp *dns.Msg, domain string) string {
+	for _, section := range [][]dns.RR{resp.Answer, resp.Ns} {
+		for _, rr := range section {
+			soa, ok := rr.(*dns.SOA)
+			if !ok {
+				continue
+			}
+			zone := strings.ToLower(strings.TrimSuffix(soa.Hdr.Name, "."))
+			if zone == domain || strings.HasSuffix(domain, "."+zone) {
+				return zone
+			}
+		}
+	}
+	return ""
+}
+
+func normalizeResolvers(resolvers []string) []string {
+	result := make([]string, 0, len(resolvers))
+	for _, r := range resolvers {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(r); err != nil {
+			r = net.JoinHostPort(r, "53")
+		}
+		result = append(result, r)
+	}
+	return result
+}
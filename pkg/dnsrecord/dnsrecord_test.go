@@ -0,0 +1,311 @@
+package dnsrecord
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// testZones 本地DNS服务器的记录：
+// example.test 有MX、弱SPF(~all)和p=none的DMARC，ns1允许区域传送，ns2拒绝；
+// nomail.test 没有SPF和DMARC，唯一的NS拒绝区域传送
+var testZones = []string{
+	"example.test. 300 IN SOA ns1.example.test. admin.example.test. 1 3600 600 86400 300",
+	"example.test. 300 IN NS ns1.example.test.",
+	"example.test. 300 IN NS ns2.example.test.",
+	"example.test. 300 IN MX 10 mail.example.test.",
+	`example.test. 300 IN TXT "v=spf1 include:_spf.example.test ~all"`,
+	`example.test. 300 IN TXT "google-site-verification=abc"`,
+	"example.test. 300 IN A 192.0.2.10",
+	`_dmarc.example.test. 300 IN TXT "v=DMARC1; p=none; rua=mailto:dmarc@example.test"`,
+	"www.example.test. 300 IN CNAME example.test.",
+	"mail.example.test. 300 IN A 192.0.2.25",
+	"ns1.example.test. 300 IN A 127.0.0.1",
+	"ns2.example.test. 300 IN A 127.0.0.1",
+
+	"nomail.test. 300 IN SOA ns1.nomail.test. admin.nomail.test. 1 3600 600 86400 300",
+	"nomail.test. 300 IN NS ns1.nomail.test.",
+	"nomail.test. 300 IN A 192.0.2.20",
+	"ns1.nomail.test. 300 IN A 127.0.0.1",
+}
+
+// testServer 按 testZones 应答的权威DNS服务器，allowTransfer 控制是否允许AXFR
+type testServer struct {
+	records       []dns.RR
+	allowTransfer bool
+}
+
+func newTestServer(t *testing.T, allowTransfer bool) *testServer {
+	s := &testServer{allowTransfer: allowTransfer}
+	for _, line := range testZones {
+		rr, err := dns.NewRR(line)
+		if err != nil {
+			t.Fatalf("parse %q: %v", line, err)
+		}
+		s.records = append(s.records, rr)
+	}
+	return s
+}
+
+func (s *testServer) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+	resp := new(dns.Msg)
+	resp.SetReply(req)
+	resp.Authoritative = true
+	q := req.Question[0]
+	name := strings.ToLower(q.Name)
+	zone := s.zoneOf(name)
+
+	switch {
+	case zone == "":
+		resp.Rcode = dns.RcodeRefused
+	case q.Qtype == dns.TypeAXFR:
+		if !s.allowTransfer {
+			resp.Rcode = dns.RcodeRefused
+			break
+		}
+		soa := s.find(zone, dns.TypeSOA)
+		resp.Answer = append(resp.Answer, soa...)
+		for _, rr := range s.records {
+			if rr.Header().Rrtype != dns.TypeSOA && dns.IsSubDomain(zone, rr.Header().Name) {
+				resp.Answer = append(resp.Answer, rr)
+			}
+		}
+		resp.Answer = append(resp.Answer, soa...)
+	default:
+		exists := false
+		for _, rr := range s.records {
+			if strings.EqualFold(rr.Header().Name, name) {
+				exists = true
+				if rr.Header().Rrtype == q.Qtype || rr.Header().Rrtype == dns.TypeCNAME {
+					resp.Answer = append(resp.Answer, rr)
+				}
+			}
+		}
+		if !exists {
+			resp.Rcode = dns.RcodeNameError
+		}
+		if len(resp.Answer) == 0 {
+			resp.Ns = s.find(zone, dns.TypeSOA)
+		}
+	}
+	w.WriteMsg(resp)
+}
+
+func (s *testServer) zoneOf(name string) string {
+	for _, zone := range []string{"example.test.", "nomail.test."} {
+		if dns.IsSubDomain(zone, name) {
+			return zone
+		}
+	}
+	return ""
+}
+
+func (s *testServer) find(name string, qtype uint16) []dns.RR {
+	var rrs []dns.RR
+	for _, rr := range s.records {
+		if rr.Header().Rrtype == qtype && strings.EqualFold(rr.Header().Name, name) {
+			rrs = append(rrs, rr)
+		}
+	}
+	return rrs
+}
+
+// startServer 在127.0.0.1的随机端口启动UDP和TCP服务，返回地址
+func startServer(t *testing.T, handler dns.Handler) string {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen udp: %v", err)
+	}
+	addr := pc.LocalAddr().String()
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		pc.Close()
+		t.Fatalf("listen tcp: %v", err)
+	}
+
+	udp := &dns.Server{PacketConn: pc, Handler: handler}
+	tcp := &dns.Server{Listener: ln, Handler: handler}
+	for _, srv := range []*dns.Server{udp, tcp} {
+		started := make(chan struct{})
+		srv.NotifyStartedFunc = func() { close(started) }
+		go srv.ActivateAndServe()
+		<-started
+	}
+	t.Cleanup(func() {
+		udp.Shutdown()
+		tcp.Shutdown()
+	})
+	return addr
+}
+
+// newTestCollector 解析使用允许区域传送的服务器，ns2.example.test 和 ns1.nomail.test 指向拒绝区域传送的服务器
+func newTestCollector(t *testing.T) *Collector {
+	allowed := startServer(t, newTestServer(t, true))
+	refused := startServer(t, newTestServer(t, false))
+	return NewCollector(Options{
+		Resolvers:     []string{allowed},
+		Timeout:       time.Second,
+		CheckTransfer: true,
+		NameserverAddrs: map[string][]string{
+			"ns1.example.test": {allowed},
+			"ns2.example.test": {refused},
+			"ns1.nomail.test":  {refused},
+		},
+	})
+}
+
+func recordValues(r *Result, typ string) []string {
+	var values []string
+	for _, record := range r.Records {
+		if record.Type == typ {
+			values = append(values, record.Value)
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+func issueTypes(r *Result) []string {
+	var types []string
+	for _, issue := range r.Issues {
+		types = append(types, issue.Type)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func TestCollectZoneApex(t *testing.T) {
+	c := newTestCollector(t)
+	r := c.Collect(context.Background(), "Example.test.")
+	if r == nil {
+		t.Fatal("expected result for example.test")
+	}
+	if r.Domain != "example.test" || r.Zone != "example.test" || !r.IsZoneApex() {
+		t.Fatalf("domain=%q zone=%q", r.Domain, r.Zone)
+	}
+
+	want := map[string][]string{
+		"A":   {"192.0.2.10"},
+		"MX":  {"10 mail.example.test"},
+		"NS":  {"ns1.example.test", "ns2.example.test"},
+		"TXT": {"google-site-verification=abc", "v=spf1 include:_spf.example.test ~all"},
+	}
+	for typ, values := range want {
+		if got := recordValues(r, typ); strings.Join(got, ",") != strings.Join(values, ",") {
+			t.Errorf("%s records = %v, want %v", typ, got, values)
+		}
+	}
+	if soa := recordValues(r, "SOA"); len(soa) != 1 || !strings.HasPrefix(soa[0], "ns1.example.test. admin.example.test.") {
+		t.Errorf("SOA records = %v", soa)
+	}
+
+	if r.SPF != "v=spf1 include:_spf.example.test ~all" {
+		t.Errorf("SPF = %q", r.SPF)
+	}
+	if !strings.HasPrefix(r.DMARC, "v=DMARC1; p=none") {
+		t.Errorf("DMARC = %q", r.DMARC)
+	}
+	if len(r.ZoneTransfer) != 1 || r.ZoneTransfer[0] != "ns1.example.test" {
+		t.Errorf("ZoneTransfer = %v, want only ns1.example.test", r.ZoneTransfer)
+	}
+	wantIssues := []string{IssueDmarcPolicyNone, IssueSpfSoftFail, IssueZoneTransfer}
+	if got := issueTypes(r); strings.Join(got, ",") != strings.Join(wantIssues, ",") {
+		t.Errorf("issues = %v, want %v", got, wantIssues)
+	}
+}
+
+func TestCollectCNAME(t *testing.T) {
+	c := newTestCollector(t)
+	r := c.Collect(context.Background(), "www.example.test")
+	if r == nil {
+		t.Fatal("expected result for www.example.test")
+	}
+	if got := recordValues(r, "CNAME"); len(got) != 1 || got[0] != "example.test" {
+		t.Errorf("CNAME records = %v", got)
+	}
+	if r.Zone != "example.test" || r.IsZoneApex() {
+		t.Errorf("zone = %q", r.Zone)
+	}
+	// 非区域顶点且没有MX，不检查邮件配置和区域传送
+	if len(r.Issues) != 0 || len(r.ZoneTransfer) != 0 {
+		t.Errorf("issues = %v, zoneTransfer = %v", r.Issues, r.ZoneTransfer)
+	}
+}
+
+func TestCollectMissingMailRecords(t *testing.T) {
+	c := newTestCollector(t)
+	r := c.Collect(context.Background(), "nomail.test")
+	if r == nil {
+		t.Fatal("expected result for nomail.test")
+	}
+	if r.SPF != "" || r.DMARC != "" {
+		t.Errorf("SPF = %q, DMARC = %q", r.SPF, r.DMARC)
+	}
+	// 唯一的NS拒绝区域传送
+	if len(r.ZoneTransfer) != 0 {
+		t.Errorf("ZoneTransfer = %v", r.ZoneTransfer)
+	}
+	wantIssues := []string{IssueDmarcMissing, IssueSpfMissing}
+	if got := issueTypes(r); strings.Join(got, ",") != strings.Join(wantIssues, ",") {
+		t.Errorf("issues = %v, want %v", got, wantIssues)
+	}
+}
+
+func TestCollectNXDomain(t *testing.T) {
+	c := newTestCollector(t)
+	if r := c.Collect(context.Background(), "missing.example.test"); r != nil {
+		t.Errorf("expected nil for nonexistent domain, got %+v", r)
+	}
+}
+
+func TestCheckSPF(t *testing.T) {
+	tests := []struct {
+		spf  string
+		want string
+	}{
+		{"v=spf1 ip4:192.0.2.0/24 -all", ""},
+		{"v=spf1 redirect=_spf.example.test", ""},
+		{"v=spf1 include:_spf.example.test ~all", IssueSpfSoftFail},
+		{"v=spf1 ?all", IssueSpfNeutral},
+		{"v=spf1 +all", IssueSpfPassAll},
+		{"v=spf1 all", IssueSpfPassAll},
+		{"v=spf1 mx", IssueSpfNoAll},
+	}
+	for _, tt := range tests {
+		got := ""
+		if issue := CheckSPF(tt.spf); issue != nil {
+			got = issue.Type
+		}
+		if got != tt.want {
+			t.Errorf("CheckSPF(%q) = %q, want %q", tt.spf, got, tt.want)
+		}
+	}
+}
+
+func TestCheckDMARC(t *testing.T) {
+	tests := []struct {
+		dmarc string
+		want  string
+	}{
+		{"v=DMARC1; p=reject", ""},
+		{"v=DMARC1; p=quarantine; pct=100", ""},
+		{"v=DMARC1; p=none", IssueDmarcPolicyNone},
+		{"v=DMARC1; p=reject; pct=50", IssueDmarcPartial},
+		{"v=DMARC1; rua=mailto:dmarc@example.test", IssueDmarcInvalid},
+		{"v=DMARC1; p=block", IssueDmarcInvalid},
+	}
+	for _, tt := range tests {
+		got := ""
+		if issue := CheckDMARC(tt.dmarc); issue != nil {
+			got = issue.Type
+		}
+		if got != tt.want {
+			t.Errorf("CheckDMARC(%q) = %q, want %q", tt.dmarc, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Package imghash 计算截图的感知哈希(dHash)，外观相似的页面哈希值的汉明距离较小
+package imghash
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"math/bits"
+	"strconv"
+
+	_ "golang.org/x/image/webp"
+)
+
+// DefaultThreshold 默认相似阈值，64位哈希中不同的位数不超过该值视为相似
+const DefaultThreshold = 10
+
+// hashWidth/hashHeight 缩略图尺寸，每行比较相邻像素得到8位，共64位
+const (
+	hashWidth  = 9
+	hashHeight = 8
+)
+
+// DHash 解码图片(jpeg/png/webp)并计算64位差值哈希，返回16位十六进制字符串
+func DHash(data []byte) (string, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("decode image: %v", err)
+	}
+	bounds := img.Bounds()
+	if bounds.Dx() < hashWidth || bounds.Dy() < hashHeight {
+		return "", fmt.Errorf("image too small: %dx%d", bounds.Dx(), bounds.Dy())
+	}
+
+	gray := shrink(img)
+	var hash uint64
+	for y := 0; y < hashHeight; y++ {
+		for x := 0; x < hashWidth-1; x++ {
+			hash <<= 1
+			if gray[y][x] < gray[y][x+1] {
+				hash |= 1
+			}
+		}
+	}
+	return Format(hash), nil
+}
+
+// shrink 按区域平均缩小为 hashWidth x hashHeight 的灰度图
+func shrink(img image.Image) [hashHeight][hashWidth]float64 {
+	var sum [hashHeight][hashWidth]float64
+	var cnt [hashHeight][hashWidth]int
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	ycc, isYCbCr := img.(*image.YCbCr)
+	for y := 0; y < h; y++ {
+		cy := y * hashHeight / h
+		for x := 0; x < w; x++ {
+			cx := x * hashWidth / w
+			var lum float64
+			if isYCbCr {
+				// 截图多为jpeg，直接取亮度分量
+				lum = float64(ycc.Y[ycc.YOffset(b.Min.X+x, b.Min.Y+y)])
+			} else {
+				r, g, bl, _ := img.At(b.Min.X+x, b.Min.Y+y).RGBA()
+				lum = (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(bl)) / 257
+			}
+			sum[cy][cx] += lum
+			cnt[cy][cx]++
+		}
+	}
+	for y := range sum {
+		for x := range sum[y] {
+			if cnt[y][x] > 0 {
+				sum[y][x] /= float64(cnt[y][x])
+			}
+		}
+	}
+	return sum
+}
+
+// Format 哈希值转为16位十六进制字符串
+func Format(hash uint64) string {
+	return fmt.Sprintf("%016x", hash)
+}
+
+// Parse 解析十六进制哈希字符串
+func Parse(s string) (uint64, error) {
+	if len(s) != 16 {
+		return 0, fmt.Errorf("invalid image hash: %s", s)
+	}
+	return strconv.ParseUint(s, 16, 64)
+}
+
+// Distance 两个哈希的汉明距离
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
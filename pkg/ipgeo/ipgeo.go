@@ -0,0 +1,122 @@
+package ipgeo
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// Info IP地理位置与归属信息
+type Info struct {
+	Country string `json:"country"`
+	Region  string `json:"region"` // 省份/州
+	City    string `json:"city"`
+	ISP     string `json:"isp"`
+	ASN     uint   `json:"asn"`
+	Org     string `json:"org"` // ASN所属组织
+}
+
+// Location 拼接为展示用的位置字符串，如 "中国 广东省 深圳市 电信"
+func (i *Info) Location() string {
+	parts := make([]string, 0, 4)
+	for _, s := range []string{i.Country, i.Region, i.City, i.ISP} {
+		if s == "" {
+			continue
+		}
+		// 直辖市等省份与城市相同的情况只保留一个
+		if len(parts) > 0 && parts[len(parts)-1] == s {
+			continue
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Locator 离线IP库
+type Locator interface {
+	Lookup(ip net.IP) (*Info, error)
+}
+
+// Config 离线库路径，均为可选
+type Config struct {
+	CityDB      string // GeoLite2-City.mmdb / GeoLite2-Country.mmdb
+	ASNDB       string // GeoLite2-ASN.mmdb
+	Ip2regionDB string // ip2region.xdb，国内IP定位更准确，优先使用
+}
+
+// Enricher 组合多个离线库补全IP信息
+type Enricher struct {
+	locators []Locator
+}
+
+// New 加载配置的离线库，未配置任何库时返回nil
+func New(c Config) (*Enricher, error) {
+	e := &Enricher{}
+	if c.Ip2regionDB != "" {
+		l, err := openXDB(c.Ip2regionDB)
+		if err != nil {
+			return nil, fmt.Errorf("load ip2region db: %v", err)
+		}
+		e.locators = append(e.locators, l)
+	}
+	for _, path := range []string{c.CityDB, c.ASNDB} {
+		if path == "" {
+			continue
+		}
+		r, err := openMMDB(path)
+		if err != nil {
+			return nil, fmt.Errorf("load mmdb %s: %v", path, err)
+		}
+		e.locators = append(e.locators, &mmdbLocator{reader: r})
+	}
+	if len(e.locators) == 0 {
+		return nil, nil
+	}
+	return e, nil
+}
+
+// Lookup 依次查询各离线库，靠前的库优先，缺失字段由后续库补全
+func (e *Enricher) Lookup(ip string) *Info {
+	if e == nil {
+		return nil
+	}
+	parsed := net.ParseIP(strings.TrimSpace(ip))
+	if parsed == nil || parsed.IsPrivate() || parsed.IsLoopback() || parsed.IsUnspecified() {
+		return nil
+	}
+
+	var result *Info
+	for _, l := range e.locators {
+		info, err := l.Lookup(parsed)
+		if err != nil || info == nil {
+			continue
+		}
+		if result == nil {
+			result = info
+			continue
+		}
+		merge(result, info)
+	}
+	return result
+}
+
+func merge(dst, src *Info) {
+	if dst.Country == "" {
+		dst.Country = src.Country
+	}
+	if dst.Region == "" {
+		dst.Region = src.Region
+	}
+	if dst.City == "" {
+		dst.City = src.City
+	}
+	if dst.ISP == "" {
+		dst.ISP = src.ISP
+	}
+	if dst.ASN == 0 {
+		dst.ASN = src.ASN
+	}
+	if dst.Org == "" {
+		dst.Org = src.Org
+	}
+}
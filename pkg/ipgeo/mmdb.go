@@ -0,0 +1,352 @@
+package ipgeo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"os"
+)
+
+var metadataMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+// mmdbReader MaxMind DB 格式读取器（GeoLite2/GeoIP2 City、Country、ASN 等）
+type mmdbReader struct {
+	buf        []byte
+	nodeCount  uint
+	recordSize uint
+	ipVersion  uint
+	dbType     string
+	dataStart  uint
+	ipv4Start  uint
+}
+
+func openMMDB(path string) (*mmdbReader, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := bytes.LastIndex(buf, metadataMarker)
+	if idx < 0 {
+		return nil, errors.New("invalid mmdb file: metadata not found")
+	}
+	metaStart := uint(idx + len(metadataMarker))
+	meta, _, err := (&mmdbDecoder{buf: buf[metaStart:]}).decode(0)
+	if err != nil {
+		return nil, fmt.Errorf("decode mmdb metadata: %v", err)
+	}
+	m, ok := meta.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("invalid mmdb metadata")
+	}
+
+	r := &mmdbReader{
+		buf:        buf,
+		nodeCount:  uint(toUint(m["node_count"])),
+		recordSize: uint(toUint(m["record_size"])),
+		ipVersion:  uint(toUint(m["ip_version"])),
+	}
+	r.dbType, _ = m["database_type"].(string)
+	if r.recordSize != 24 && r.recordSize != 28 && r.recordSize != 32 {
+		return nil, fmt.Errorf("unsupported mmdb record size: %d", r.recordSize)
+	}
+	treeSize := r.nodeCount * r.recordSize / 4
+	r.dataStart = treeSize + 16
+	if r.dataStart > metaStart {
+		return nil, errors.New("invalid mmdb file: search tree overflow")
+	}
+
+	// IPv6库中IPv4地址位于 ::/96 子树
+	if r.ipVersion == 6 {
+		node := uint(0)
+		for i := 0; i < 96 && node < r.nodeCount; i++ {
+			node = r.readNode(node, 0)
+		}
+		r.ipv4Start = node
+	}
+	return r, nil
+}
+
+func (r *mmdbReader) readNode(node, bit uint) uint {
+	off := node * r.recordSize / 4
+	b := r.buf[off:]
+	switch r.recordSize {
+	case 24:
+		if bit == 0 {
+			return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+		return uint(b[3])<<16 | uint(b[4])<<8 | uint(b[5])
+	case 28:
+		if bit == 0 {
+			return (uint(b[3])&0xF0)<<20 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+		return (uint(b[3])&0x0F)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6])
+	default:
+		if bit == 0 {
+			return uint(binary.BigEndian.Uint32(b[0:4]))
+		}
+		return uint(binary.BigEndian.Uint32(b[4:8]))
+	}
+}
+
+// lookup 查询IP对应的数据记录，未找到返回nil
+func (r *mmdbReader) lookup(ip net.IP) (map[string]interface{}, error) {
+	node := uint(0)
+	bitCount := 128
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+		bitCount = 32
+		if r.ipVersion == 6 {
+			node = r.ipv4Start
+		}
+	} else if r.ipVersion == 4 {
+		return nil, nil
+	}
+
+	for i := 0; i < bitCount && node < r.nodeCount; i++ {
+		bit := uint(ip[i>>3]>>(7-uint(i&7))) & 1
+		node = r.readNode(node, bit)
+	}
+	if node <= r.nodeCount {
+		return nil, nil
+	}
+
+	offset := node - r.nodeCount - 16
+	dec := &mmdbDecoder{buf: r.buf[r.dataStart:]}
+	v, _, err := dec.decode(offset)
+	if err != nil {
+		return nil, err
+	}
+	m, _ := v.(map[string]interface{})
+	return m, nil
+}
+
+// mmdbDecoder MaxMind DB 数据段解码
+type mmdbDecoder struct {
+	buf []byte
+}
+
+const (
+	mmdbExtended  = 0
+	mmdbPointer   = 1
+	mmdbString    = 2
+	mmdbDouble    = 3
+	mmdbBytes     = 4
+	mmdbUint16    = 5
+	mmdbUint32    = 6
+	mmdbMap       = 7
+	mmdbInt32     = 8
+	mmdbUint64    = 9
+	mmdbUint128   = 10
+	mmdbArray     = 11
+	mmdbContainer = 12
+	mmdbEndMarker = 13
+	mmdbBool      = 14
+	mmdbFloat     = 15
+)
+
+var errMMDBCorrupt = errors.New("invalid mmdb data section")
+
+func (d *mmdbDecoder) decode(offset uint) (interface{}, uint, error) {
+	return d.decodeDepth(offset, 0)
+}
+
+func (d *mmdbDecoder) decodeDepth(offset uint, depth int) (interface{}, uint, error) {
+	if depth > 32 || offset >= uint(len(d.buf)) {
+		return nil, 0, errMMDBCorrupt
+	}
+	ctrl := d.buf[offset]
+	offset++
+	typ := uint(ctrl >> 5)
+
+	if typ == mmdbPointer {
+		ptr, next, err := d.pointer(ctrl, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		v, _, err := d.decodeDepth(ptr, depth+1)
+		return v, next, err
+	}
+
+	if typ == mmdbExtended {
+		if offset >= uint(len(d.buf)) {
+			return nil, 0, errMMDBCorrupt
+		}
+		typ = 7 + uint(d.buf[offset])
+		offset++
+	}
+
+	size := uint(ctrl & 0x1f)
+	if size >= 29 {
+		n := size - 28
+		if offset+n > uint(len(d.buf)) {
+			return nil, 0, errMMDBCorrupt
+		}
+		var v uint
+		for _, b := range d.buf[offset : offset+n] {
+			v = v<<8 | uint(b)
+		}
+		offset += n
+		switch size {
+		case 29:
+			size = 29 + v
+		case 30:
+			size = 285 + v
+		default:
+			size = 65821 + v
+		}
+	}
+
+	switch typ {
+	case mmdbMap:
+		m := make(map[string]interface{}, size)
+		for i := uint(0); i < size; i++ {
+			k, next, err := d.decodeDepth(offset, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			key, _ := k.(string)
+			v, next2, err := d.decodeDepth(next, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			m[key] = v
+			offset = next2
+		}
+		return m, offset, nil
+	case mmdbArray:
+		list := make([]interface{}, 0, size)
+		for i := uint(0); i < size; i++ {
+			v, next, err := d.decodeDepth(offset, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			list = append(list, v)
+			offset = next
+		}
+		return list, offset, nil
+	case mmdbBool:
+		return size != 0, offset, nil
+	case mmdbContainer, mmdbEndMarker:
+		return nil, offset, nil
+	}
+
+	if offset+size > uint(len(d.buf)) {
+		return nil, 0, errMMDBCorrupt
+	}
+	data := d.buf[offset : offset+size]
+	offset += size
+
+	switch typ {
+	case mmdbString:
+		return string(data), offset, nil
+	case mmdbBytes:
+		return append([]byte(nil), data...), offset, nil
+	case mmdbDouble:
+		if size != 8 {
+			return nil, 0, errMMDBCorrupt
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(data)), offset, nil
+	case mmdbFloat:
+		if size != 4 {
+			return nil, 0, errMMDBCorrupt
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(data))), offset, nil
+	case mmdbUint16, mmdbUint32, mmdbUint64, mmdbUint128, mmdbInt32:
+		var v uint64
+		for _, b := range data {
+			v = v<<8 | uint64(b)
+		}
+		if typ == mmdbInt32 {
+			return int64(int32(uint32(v))), offset, nil
+		}
+		return v, offset, nil
+	default:
+		return nil, 0, fmt.Errorf("unsupported mmdb data type %d", typ)
+	}
+}
+
+func (d *mmdbDecoder) pointer(ctrl byte, offset uint) (uint, uint, error) {
+	ss := uint(ctrl>>3) & 0x3
+	vvv := uint(ctrl & 0x7)
+	n := ss + 1
+	if offset+n > uint(len(d.buf)) {
+		return 0, 0, errMMDBCorrupt
+	}
+	b := d.buf[offset : offset+n]
+	var ptr uint
+	switch ss {
+	case 0:
+		ptr = vvv<<8 | uint(b[0])
+	case 1:
+		ptr = (vvv<<16 | uint(b[0])<<8 | uint(b[1])) + 2048
+	case 2:
+		ptr = (vvv<<24 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])) + 526336
+	default:
+		ptr = uint(binary.BigEndian.Uint32(b))
+	}
+	return ptr, offset + n, nil
+}
+
+func toUint(v interface{}) uint64 {
+	switch n := v.(type) {
+	case uint64:
+		return n
+	case int64:
+		return uint64(n)
+	case float64:
+		return uint64(n)
+	}
+	return 0
+}
+
+// mmdbLocator 基于MMDB的定位器
+type mmdbLocator struct {
+	reader *mmdbReader
+}
+
+func (l *mmdbLocator) Lookup(ip net.IP) (*Info, error) {
+	record, err := l.reader.lookup(ip)
+	if err != nil || record == nil {
+		return nil, err
+	}
+
+	info := &Info{
+		Country: localizedName(record["country"]),
+		City:    localizedName(record["city"]),
+	}
+	if subs, ok := record["subdivisions"].([]interface{}); ok && len(subs) > 0 {
+		info.Region = localizedName(subs[0])
+	}
+	if info.Country == "" {
+		info.Country = localizedName(record["registered_country"])
+	}
+	// GeoLite2-ASN / GeoIP2-ISP 字段
+	info.ASN = uint(toUint(record["autonomous_system_number"]))
+	info.Org, _ = record["autonomous_system_organization"].(string)
+	info.ISP, _ = record["isp"].(string)
+	if org, ok := record["organization"].(string); ok && info.Org == "" {
+		info.Org = org
+	}
+	return info, nil
+}
+
+// localizedName 优先取中文名称
+func localizedName(v interface{}) string {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	names, ok := m["names"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	if name, ok := names["zh-CN"].(string); ok && name != "" {
+		return name
+	}
+	name, _ := names["en"].(string)
+	return name
+}
@@ -0,0 +1,92 @@
+package ipgeo
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"os"
+	"strings"
+)
+
+// ip2region xdb 格式常量
+const (
+	xdbHeaderLength      = 256
+	xdbVectorIndexRows   = 256
+	xdbVectorIndexCols   = 256
+	xdbVectorIndexSize   = 8
+	xdbSegmentIndexSize  = 14
+	xdbVectorIndexLength = xdbVectorIndexRows * xdbVectorIndexCols * xdbVectorIndexSize
+)
+
+// xdbLocator ip2region xdb 数据库（仅IPv4），区域格式: 国家|区域|省份|城市|ISP
+type xdbLocator struct {
+	buf []byte
+}
+
+func openXDB(path string) (*xdbLocator, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) < xdbHeaderLength+xdbVectorIndexLength {
+		return nil, errors.New("invalid xdb file: too small")
+	}
+	return &xdbLocator{buf: buf}, nil
+}
+
+func (l *xdbLocator) Lookup(ip net.IP) (*Info, error) {
+	v4 := ip.To4()
+	if v4 == nil {
+		return nil, nil
+	}
+	ipNum := binary.BigEndian.Uint32(v4)
+
+	// 一级向量索引定位段索引区间
+	idx := xdbHeaderLength + (int(v4[0])*xdbVectorIndexCols+int(v4[1]))*xdbVectorIndexSize
+	sPtr := binary.LittleEndian.Uint32(l.buf[idx:])
+	ePtr := binary.LittleEndian.Uint32(l.buf[idx+4:])
+	if sPtr == 0 || ePtr < sPtr || int(ePtr)+xdbSegmentIndexSize > len(l.buf) {
+		return nil, nil
+	}
+
+	// 二分查找段索引
+	low, high := 0, int(ePtr-sPtr)/xdbSegmentIndexSize
+	for low <= high {
+		mid := (low + high) >> 1
+		p := int(sPtr) + mid*xdbSegmentIndexSize
+		seg := l.buf[p : p+xdbSegmentIndexSize]
+		startIp := binary.LittleEndian.Uint32(seg[0:4])
+		endIp := binary.LittleEndian.Uint32(seg[4:8])
+		switch {
+		case ipNum < startIp:
+			high = mid - 1
+		case ipNum > endIp:
+			low = mid + 1
+		default:
+			dataLen := int(binary.LittleEndian.Uint16(seg[8:10]))
+			dataPtr := int(binary.LittleEndian.Uint32(seg[10:14]))
+			if dataPtr+dataLen > len(l.buf) {
+				return nil, errors.New("invalid xdb file: data overflow")
+			}
+			return parseRegion(string(l.buf[dataPtr : dataPtr+dataLen])), nil
+		}
+	}
+	return nil, nil
+}
+
+// parseRegion 解析 国家|区域|省份|城市|ISP，0 表示未知
+func parseRegion(region string) *Info {
+	parts := strings.Split(region, "|")
+	field := func(i int) string {
+		if i >= len(parts) || parts[i] == "0" {
+			return ""
+		}
+		return strings.TrimSpace(parts[i])
+	}
+	return &Info{
+		Country: field(0),
+		Region:  field(2),
+		City:    field(3),
+		ISP:     field(4),
+	}
+}
@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// emailNotifier SMTP邮件
+type emailNotifier struct {
+	cfg Config
+}
+
+func (n *emailNotifier) Send(ctx context.Context, msg *Message) error {
+	port := n.cfg.SmtpPort
+	if port <= 0 {
+		port = 25
+	}
+	from := n.cfg.SmtpFrom
+	if from == "" {
+		from = n.cfg.SmtpUser
+	}
+	addr := net.JoinHostPort(n.cfg.SmtpHost, strconv.Itoa(port))
+
+	var sb strings.Builder
+	sb.WriteString("From: " + from + "\r\n")
+	sb.WriteString("To: " + strings.Join(n.cfg.SmtpTo, ",") + "\r\n")
+	sb.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", msg.Title) + "\r\n")
+	sb.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	sb.WriteString(strings.ReplaceAll(msg.Content, "\n", "\r\n"))
+	body := []byte(sb.String())
+
+	var auth smtp.Auth
+	if n.cfg.SmtpUser != "" {
+		auth = smtp.PlainAuth("", n.cfg.SmtpUser, n.cfg.SmtpPass, n.cfg.SmtpHost)
+	}
+
+	// 465端口使用隐式TLS，其余端口由smtp包自动协商STARTTLS
+	if port != 465 {
+		return smtp.SendMail(addr, auth, from, n.cfg.SmtpTo, body)
+	}
+
+	dialer := &net.Dialer{Timeout: 15 * time.Second}
+	conn, err := tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{ServerName: n.cfg.SmtpHost})
+	if err != nil {
+		return fmt.Errorf("smtp dial: %v", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	client, err := smtp.NewClient(conn, n.cfg.SmtpHost)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp client: %v", err)
+	}
+	defer client.Close()
+
+	if auth != nil {
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("smtp auth: %v", err)
+		}
+	}
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, to := range n.cfg.SmtpTo {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,225 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 通知渠道类型
+const (
+	TypeWebhook  = "webhook"
+	TypeDingTalk = "dingtalk"
+	TypeWeCom    = "wecom"
+	TypeFeishu   = "feishu"
+	TypeEmail    = "email"
+)
+
+// Config 通知渠道配置
+type Config struct {
+	Type       string
+	WebhookUrl string
+	Secret     string // 钉钉/飞书加签密钥
+	SmtpHost   string
+	SmtpPort   int
+	SmtpUser   string
+	SmtpPass   string
+	SmtpFrom   string
+	SmtpTo     []string
+}
+
+// Message 通知消息
+type Message struct {
+	Title   string      `json:"title"`
+	Content string      `json:"content"`
+	Data    interface{} `json:"data,omitempty"` // 原始数据，仅通用Webhook发送
+}
+
+// Notifier 通知发送接口
+type Notifier interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// New 根据配置创建通知发送器
+func New(cfg *Config) (Notifier, error) {
+	switch cfg.Type {
+	case TypeWebhook:
+		if cfg.WebhookUrl == "" {
+			return nil, fmt.Errorf("webhook url is empty")
+		}
+		return &webhookNotifier{url: cfg.WebhookUrl}, nil
+	case TypeDingTalk:
+		if cfg.WebhookUrl == "" {
+			return nil, fmt.Errorf("dingtalk webhook url is empty")
+		}
+		return &dingTalkNotifier{url: cfg.WebhookUrl, secret: cfg.Secret}, nil
+	case TypeWeCom:
+		if cfg.WebhookUrl == "" {
+			return nil, fmt.Errorf("wecom webhook url is empty")
+		}
+		return &weComNotifier{url: cfg.WebhookUrl}, nil
+	case TypeFeishu:
+		if cfg.WebhookUrl == "" {
+			return nil, fmt.Errorf("feishu webhook url is empty")
+		}
+		return &feishuNotifier{url: cfg.WebhookUrl, secret: cfg.Secret}, nil
+	case TypeEmail:
+		if cfg.SmtpHost == "" || len(cfg.SmtpTo) == 0 {
+			return nil, fmt.Errorf("smtp host or recipients is empty")
+		}
+		return &emailNotifier{cfg: *cfg}, nil
+	default:
+		return nil, fmt.Errorf("unsupported notify type: %s", cfg.Type)
+	}
+}
+
+var httpClient = &http.Client{Timeout: 15 * time.Second}
+
+// postJSON 发送JSON请求，返回响应体
+func postJSON(ctx context.Context, targetUrl string, payload interface{}) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, targetUrl, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return body, fmt.Errorf("http status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// webhookNotifier 通用Webhook
+type webhookNotifier struct {
+	url string
+}
+
+func (n *webhookNotifier) Send(ctx context.Context, msg *Message) error {
+	_, err := postJSON(ctx, n.url, msg)
+	return err
+}
+
+// dingTalkNotifier 钉钉机器人
+type dingTalkNotifier struct {
+	url    string
+	secret string
+}
+
+func (n *dingTalkNotifier) Send(ctx context.Context, msg *Message) error {
+	targetUrl := n.url
+	if n.secret != "" {
+		// 加签: base64(hmac_sha256(timestamp + "\n" + secret))
+		timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+		mac := hmac.New(sha256.New, []byte(n.secret))
+		mac.Write([]byte(timestamp + "\n" + n.secret))
+		sign := url.QueryEscape(base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+		targetUrl = appendQuery(targetUrl, "timestamp="+timestamp+"&sign="+sign)
+	}
+
+	payload := map[string]interface{}{
+		"msgtype": "markdown",
+		"markdown": map[string]string{
+			"title": msg.Title,
+			"text":  "### " + msg.Title + "\n\n" + strings.ReplaceAll(msg.Content, "\n", "\n\n"),
+		},
+	}
+	body, err := postJSON(ctx, targetUrl, payload)
+	if err != nil {
+		return err
+	}
+	return checkErrCode(body)
+}
+
+// weComNotifier 企业微信机器人
+type weComNotifier struct {
+	url string
+}
+
+func (n *weComNotifier) Send(ctx context.Context, msg *Message) error {
+	payload := map[string]interface{}{
+		"msgtype": "markdown",
+		"markdown": map[string]string{
+			"content": "### " + msg.Title + "\n" + msg.Content,
+		},
+	}
+	body, err := postJSON(ctx, n.url, payload)
+	if err != nil {
+		return err
+	}
+	return checkErrCode(body)
+}
+
+// feishuNotifier 飞书机器人
+type feishuNotifier struct {
+	url    string
+	secret string
+}
+
+func (n *feishuNotifier) Send(ctx context.Context, msg *Message) error {
+	payload := map[string]interface{}{
+		"msg_type": "text",
+		"content": map[string]string{
+			"text": msg.Title + "\n" + msg.Content,
+		},
+	}
+	if n.secret != "" {
+		// 加签: base64(hmac_sha256(key=timestamp + "\n" + secret, data=""))
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		mac := hmac.New(sha256.New, []byte(timestamp+"\n"+n.secret))
+		payload["timestamp"] = timestamp
+		payload["sign"] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	}
+
+	body, err := postJSON(ctx, n.url, payload)
+	if err != nil {
+		return err
+	}
+	var result struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if json.Unmarshal(body, &result) == nil && result.Code != 0 {
+		return fmt.Errorf("feishu error %d: %s", result.Code, result.Msg)
+	}
+	return nil
+}
+
+// checkErrCode 检查钉钉/企业微信返回的errcode
+func checkErrCode(body []byte) error {
+	var result struct {
+		ErrCode int    `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+	}
+	if json.Unmarshal(body, &result) == nil && result.ErrCode != 0 {
+		return fmt.Errorf("errcode %d: %s", result.ErrCode, result.ErrMsg)
+	}
+	return nil
+}
+
+func appendQuery(rawUrl, query string) string {
+	if strings.Contains(rawUrl, "?") {
+		return rawUrl + "&" + query
+	}
+	return rawUrl + "?" + query
+}
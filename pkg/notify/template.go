@@ -0,0 +1,47 @@
+package notify
+
+import (
+	"bytes"
+	"text/template"
+
+	"cscan/scheduler"
+)
+
+// DefaultTemplate 默认消息模板
+const DefaultTemplate = `任务名称: {{.TaskName}}
+扫描目标: {{.Target}}
+任务状态: {{.Status}}
+资产数量: {{.AssetCount}}
+漏洞数量: {{.VulCount}}{{if .MinSeverity}} ({{.MinSeverity}}及以上: {{.SevereVulCount}}){{end}}{{if .ChangeCount}}
+资产变更: {{.ChangeCount}}{{end}}
+执行耗时: {{.Duration}}s
+完成时间: {{.FinishTime}}{{if .Message}}
+执行结果: {{.Message}}{{end}}`
+
+// TemplateData 模板渲染数据，基于 scheduler.TaskResult 扩展
+type TemplateData struct {
+	scheduler.TaskResult
+	TaskName       string `json:"taskName"`
+	Target         string `json:"target"`
+	WorkspaceId    string `json:"workspaceId"`
+	MinSeverity    string `json:"minSeverity"`
+	SevereVulCount int    `json:"severeVulCount"` // 达到最低等级的漏洞数
+	ChangeCount    int    `json:"changeCount"`    // 与上一轮相比的资产变更数
+	FinishTime     string `json:"finishTime"`
+}
+
+// Render 渲染消息模板，tpl为空时使用默认模板
+func Render(tpl string, data *TemplateData) (string, error) {
+	if tpl == "" {
+		tpl = DefaultTemplate
+	}
+	t, err := template.New("notify").Parse(tpl)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,137 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF    tokenKind = iota
+	tokWord             // 字段名或未加引号的值
+	tokString           // 引号包裹的值
+	tokOp               // = == != ~=
+	tokAnd              // &&
+	tokOr               // ||
+	tokNot              // !
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int // 起始字符位置（从0开始，按字符计）
+}
+
+// SyntaxError 查询语法错误，Pos为出错字符位置（从1开始）
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("位置 %d: %s", e.Pos, e.Msg)
+}
+
+func errorAt(pos int, format string, args ...interface{}) *SyntaxError {
+	return &SyntaxError{Pos: pos + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+// isWordRune 未加引号的字段名/值允许的字符
+func isWordRune(r rune) bool {
+	if unicode.IsSpace(r) {
+		return false
+	}
+	return !strings.ContainsRune(`()"'=!~&|`, r)
+}
+
+// lex 将查询表达式切分为token
+func lex(input string) ([]token, error) {
+	runes := []rune(input)
+	var tokens []token
+	i := 0
+	for i < len(runes) {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokLParen, value: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokRParen, value: ")", pos: i})
+			i++
+		case r == '&' || r == '|':
+			if i+1 >= len(runes) || runes[i+1] != r {
+				return nil, errorAt(i, "期望 %c%c", r, r)
+			}
+			kind := tokAnd
+			if r == '|' {
+				kind = tokOr
+			}
+			tokens = append(tokens, token{kind: kind, value: string([]rune{r, r}), pos: i})
+			i += 2
+		case r == '=':
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				tokens = append(tokens, token{kind: tokOp, value: "==", pos: i})
+				i += 2
+			} else {
+				tokens = append(tokens, token{kind: tokOp, value: "=", pos: i})
+				i++
+			}
+		case r == '!':
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				tokens = append(tokens, token{kind: tokOp, value: "!=", pos: i})
+				i += 2
+			} else {
+				tokens = append(tokens, token{kind: tokNot, value: "!", pos: i})
+				i++
+			}
+		case r == '~':
+			if i+1 >= len(runes) || runes[i+1] != '=' {
+				return nil, errorAt(i, "期望 ~=")
+			}
+			tokens = append(tokens, token{kind: tokOp, value: "~=", pos: i})
+			i += 2
+		case r == '"' || r == '\'':
+			start := i
+			var sb strings.Builder
+			i++
+			closed := false
+			for i < len(runes) {
+				c := runes[i]
+				if c == '\\' && i+1 < len(runes) {
+					// 仅转义引号和反斜杠，其余保持原样以便书写正则
+					next := runes[i+1]
+					if next == r || next == '\\' {
+						sb.WriteRune(next)
+						i += 2
+						continue
+					}
+				}
+				if c == r {
+					closed = true
+					i++
+					break
+				}
+				sb.WriteRune(c)
+				i++
+			}
+			if !closed {
+				return nil, errorAt(start, "引号未闭合")
+			}
+			tokens = append(tokens, token{kind: tokString, value: sb.String(), pos: start})
+		default:
+			start := i
+			for i < len(runes) && isWordRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokWord, value: string(runes[start:i]), pos: start})
+		}
+	}
+	tokens = append(tokens, token{kind: tokEOF, pos: len(runes)})
+	return tokens, nil
+}
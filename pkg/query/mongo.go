@@ -0,0 +1,364 @@
+package query
+
+import (
+	"encoding/binary"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fieldKind int
+
+const (
+	kindText    fieldKind = iota // = 模糊匹配，== 精确匹配
+	kindExact                    // = 与 == 均为精确匹配
+	kindLower                    // 精确匹配，值转小写
+	kindPort                     // 端口，支持 80-90 / 80,443
+	kindIP                       // IP，支持CIDR
+	kindASN                      // ASN，支持 AS13335
+	kindBool                     // true/false
+	kindApp                      // 应用指纹，忽略 [来源] 后缀
+	kindCert                     // 证书主题/SAN/颁发者/指纹
+	kindSimilar                  // 截图相似的资产，需要 Resolver 查询
+)
+
+type fieldDef struct {
+	kind  fieldKind
+	paths []string
+}
+
+// fields 支持的查询字段及对应的资产文档路径
+var fields = map[string]fieldDef{
+	"host":               {kindText, []string{"host"}},
+	"ip":                 {kindIP, nil},
+	"port":               {kindPort, []string{"port"}},
+	"domain":             {kindText, []string{"domain"}},
+	"service":            {kindText, []string{"service"}},
+	"protocol":           {kindText, []string{"service"}},
+	"title":              {kindText, []string{"title"}},
+	"app":                {kindApp, []string{"app"}},
+	"finger":             {kindApp, []string{"app"}},
+	"fingerprint":        {kindApp, []string{"app"}},
+	"status":             {kindExact, []string{"status"}},
+	"httpstatus":         {kindExact, []string{"status"}},
+	"banner":             {kindText, []string{"banner"}},
+	"server":             {kindText, []string{"server"}},
+	"header":             {kindText, []string{"header"}},
+	"body":               {kindText, []string{"body"}},
+	"cname":              {kindText, []string{"cname"}},
+	"source":             {kindExact, []string{"source"}},
+	"icon_hash":          {kindExact, []string{"icon_hash"}},
+	"iconhash":           {kindExact, []string{"icon_hash"}},
+	"screenshot_hash":    {kindLower, []string{"screenshot_hash"}},
+	"screenshot_similar": {kindSimilar, []string{"screenshot_hash"}},
+	"cert":               {kindCert, nil},
+	"jarm":               {kindLower, []string{"tls.jarm"}},
+	"org":                {kindText, []string{"ip.ipv4.org", "ip.ipv6.org"}},
+	"asn":                {kindASN, []string{"ip.ipv4.asn", "ip.ipv6.asn"}},
+	"isp":                {kindText, []string{"ip.ipv4.isp", "ip.ipv6.isp"}},
+	"country":            {kindText, []string{"ip.ipv4.country", "ip.ipv6.country"}},
+	"region":             {kindText, []string{"ip.ipv4.region", "ip.ipv6.region"}},
+	"city":               {kindText, []string{"ip.ipv4.city", "ip.ipv6.city"}},
+	"risk_level":         {kindLower, []string{"risk_level"}},
+	"cdn":                {kindBool, []string{"cdn"}},
+	"cloud":              {kindBool, []string{"cloud"}},
+}
+
+// termPaths 未指定字段时搜索的路径
+var termPaths = []string{"host", "title", "app", "banner", "domain"}
+
+// certTextPaths 证书文本字段
+var certTextPaths = []string{"tls.subject", "tls.sans", "tls.issuer"}
+
+var appSuffixRegex = regexp.MustCompile(`\s*\[.*\]\s*$`)
+
+// Resolver 解析需要查询数据库才能确定条件的字段
+type Resolver interface {
+	// SimilarScreenshots 返回与指定资产截图相似的截图哈希列表（包含该资产自身的哈希）
+	SimilarScreenshots(assetId string) ([]string, error)
+}
+
+// Compile 将查询表达式编译为MongoDB过滤条件，空表达式返回空条件
+//
+// 语法示例: (port=80 || port=8000-8100) && title~="^Admin" && !app="nginx" && ip="10.0.0.0/8"
+//
+//	=   模糊匹配（端口、状态码等字段为精确匹配）
+//	==  精确匹配
+//	!=  不匹配
+//	~=  正则匹配
+func Compile(input string) (bson.M, error) {
+	return CompileWith(input, nil)
+}
+
+// CompileWith 同 Compile，使用 r 解析 screenshot_similar 等需要查询数据库的字段，r 为 nil 时不支持这些字段
+func CompileWith(input string, r Resolver) (bson.M, error) {
+	n, err := Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	if n == nil {
+		return bson.M{}, nil
+	}
+	return compileNode(n, r)
+}
+
+func compileNode(n Node, r Resolver) (bson.M, error) {
+	switch v := n.(type) {
+	case *BinaryNode:
+		op := "$and"
+		if v.Op == "||" {
+			op = "$or"
+		}
+		var parts []bson.M
+		for _, child := range []Node{v.Left, v.Right} {
+			m, err := compileNode(child, r)
+			if err != nil {
+				return nil, err
+			}
+			// 相同运算符的子条件展开，避免深层嵌套
+			if sub, ok := m[op].([]bson.M); ok && len(m) == 1 {
+				parts = append(parts, sub...)
+			} else {
+				parts = append(parts, m)
+			}
+		}
+		return bson.M{op: parts}, nil
+	case *NotNode:
+		m, err := compileNode(v.Expr, r)
+		if err != nil {
+			return nil, err
+		}
+		return bson.M{"$nor": []bson.M{m}}, nil
+	case *TermNode:
+		return anyPath(termPaths, containsRegex(v.Value)), nil
+	case *CondNode:
+		return compileCond(v, r)
+	}
+	return nil, errorAt(0, "无法识别的表达式")
+}
+
+func compileCond(c *CondNode, r Resolver) (bson.M, error) {
+	def, ok := fields[c.Field]
+	if !ok {
+		return nil, errorAt(c.FieldPos, "未知字段 %s", c.Field)
+	}
+	negate := c.Op == "!="
+
+	// 正则匹配对所有文本类字段通用
+	if c.Op == "~=" {
+		switch def.kind {
+		case kindPort, kindASN, kindBool, kindSimilar:
+			return nil, errorAt(c.ValuePos, "字段 %s 不支持 ~=", c.Field)
+		}
+		if _, err := regexp.Compile(c.Value); err != nil {
+			return nil, errorAt(c.ValuePos, "正则表达式无效: %v", err)
+		}
+		paths := def.paths
+		switch def.kind {
+		case kindIP:
+			paths = []string{"host", "ip.ipv4.ip", "ip.ipv6.ip"}
+		case kindCert:
+			paths = certTextPaths
+		}
+		return anyPath(paths, primitive.Regex{Pattern: c.Value, Options: "i"}), nil
+	}
+
+	exact := c.Op == "=="
+	switch def.kind {
+	case kindText:
+		if exact {
+			return matchPaths(def.paths, c.Value, negate), nil
+		}
+		return matchPaths(def.paths, containsRegex(c.Value), negate), nil
+
+	case kindExact:
+		return matchPaths(def.paths, c.Value, negate), nil
+
+	case kindLower:
+		return matchPaths(def.paths, strings.ToLower(c.Value), negate), nil
+
+	case kindApp:
+		name := strings.TrimSpace(appSuffixRegex.ReplaceAllString(c.Value, ""))
+		if exact {
+			// 资产中的应用带有 [来源] 后缀，精确匹配时忽略后缀
+			return matchPaths(def.paths, primitive.Regex{Pattern: "^" + regexp.QuoteMeta(name) + `(\s*\[.*\])?$`, Options: "i"}, negate), nil
+		}
+		return matchPaths(def.paths, containsRegex(name), negate), nil
+
+	case kindPort:
+		cond, err := portCondition(c.Value, c.ValuePos)
+		if err != nil {
+			return nil, err
+		}
+		if negate {
+			return bson.M{"port": bson.M{"$not": cond}}, nil
+		}
+		return bson.M{"port": cond}, nil
+
+	case kindASN:
+		asn, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(c.Value), "AS"))
+		if err != nil {
+			return nil, errorAt(c.ValuePos, "无效的ASN: %s", c.Value)
+		}
+		return matchPaths(def.paths, asn, negate), nil
+
+	case kindBool:
+		b, err := strconv.ParseBool(c.Value)
+		if err != nil {
+			return nil, errorAt(c.ValuePos, "字段 %s 的值应为 true 或 false", c.Field)
+		}
+		if b != negate {
+			return bson.M{def.paths[0]: true}, nil
+		}
+		return bson.M{def.paths[0]: bson.M{"$ne": true}}, nil
+
+	case kindIP:
+		return ipCondition(c, negate)
+
+	case kindSimilar:
+		if r == nil {
+			return nil, errorAt(c.FieldPos, "字段 %s 不支持在此处使用", c.Field)
+		}
+		hashes, err := r.SimilarScreenshots(c.Value)
+		if err != nil {
+			return nil, errorAt(c.ValuePos, "%v", err)
+		}
+		if negate {
+			return bson.M{def.paths[0]: bson.M{"$nin": hashes}}, nil
+		}
+		return bson.M{def.paths[0]: bson.M{"$in": hashes}}, nil
+
+	case kindCert:
+		// 匹配证书主题/SAN/颁发者，或叶子证书SHA256指纹
+		var text interface{} = containsRegex(c.Value)
+		if exact {
+			text = c.Value
+		}
+		fingerprint := strings.ToLower(c.Value)
+		if negate {
+			m := matchPaths(certTextPaths, text, true)
+			m["$and"] = append(m["$and"].([]bson.M), bson.M{"cert": bson.M{"$ne": fingerprint}})
+			return m, nil
+		}
+		m := anyPath(certTextPaths, text)
+		m["$or"] = append(m["$or"].([]bson.M), bson.M{"cert": fingerprint})
+		return m, nil
+	}
+	return nil, errorAt(c.FieldPos, "字段 %s 不支持该运算符", c.Field)
+}
+
+// containsRegex 构造不区分大小写的包含匹配
+func containsRegex(value string) primitive.Regex {
+	return primitive.Regex{Pattern: regexp.QuoteMeta(value), Options: "i"}
+}
+
+// anyPath 任意一个路径匹配
+func anyPath(paths []string, value interface{}) bson.M {
+	if len(paths) == 1 {
+		return bson.M{paths[0]: value}
+	}
+	or := make([]bson.M, 0, len(paths))
+	for _, p := range paths {
+		or = append(or, bson.M{p: value})
+	}
+	return bson.M{"$or": or}
+}
+
+// matchPaths 正向匹配任意路径，取反时要求所有路径均不匹配
+func matchPaths(paths []string, value interface{}, negate bool) bson.M {
+	if !negate {
+		return anyPath(paths, value)
+	}
+	var cond bson.M
+	if _, ok := value.(primitive.Regex); ok {
+		cond = bson.M{"$not": value}
+	} else {
+		cond = bson.M{"$ne": value}
+	}
+	if len(paths) == 1 {
+		return bson.M{paths[0]: cond}
+	}
+	and := make([]bson.M, 0, len(paths))
+	for _, p := range paths {
+		and = append(and, bson.M{p: cond})
+	}
+	return bson.M{"$and": and}
+}
+
+// portCondition 解析端口值: 80 / 8000-8100 / 80,443,8080
+func portCondition(value string, pos int) (bson.M, error) {
+	parsePort := func(s string) (int, error) {
+		port, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil || port < 0 || port > 65535 {
+			return 0, errorAt(pos, "无效的端口: %s", s)
+		}
+		return port, nil
+	}
+
+	if strings.Contains(value, ",") {
+		var ports []int
+		for _, s := range strings.Split(value, ",") {
+			port, err := parsePort(s)
+			if err != nil {
+				return nil, err
+			}
+			ports = append(ports, port)
+		}
+		return bson.M{"$in": ports}, nil
+	}
+	if idx := strings.Index(value, "-"); idx > 0 {
+		low, err := parsePort(value[:idx])
+		if err != nil {
+			return nil, err
+		}
+		high, err := parsePort(value[idx+1:])
+		if err != nil {
+			return nil, err
+		}
+		if low > high {
+			return nil, errorAt(pos, "端口范围起始值大于结束值: %s", value)
+		}
+		return bson.M{"$gte": low, "$lte": high}, nil
+	}
+	port, err := parsePort(value)
+	if err != nil {
+		return nil, err
+	}
+	return bson.M{"$eq": port}, nil
+}
+
+// ipCondition 解析IP值: 完整IP精确匹配，CIDR按IPv4整数范围匹配，其他按包含匹配
+func ipCondition(c *CondNode, negate bool) (bson.M, error) {
+	paths := []string{"host", "ip.ipv4.ip", "ip.ipv6.ip"}
+
+	if strings.Contains(c.Value, "/") {
+		_, ipNet, err := net.ParseCIDR(c.Value)
+		if err != nil {
+			return nil, errorAt(c.ValuePos, "无效的CIDR: %s", c.Value)
+		}
+		network := ipNet.IP.To4()
+		if network == nil {
+			return nil, errorAt(c.ValuePos, "仅支持IPv4 CIDR: %s", c.Value)
+		}
+		start := binary.BigEndian.Uint32(network)
+		ones, _ := ipNet.Mask.Size()
+		end := start | (^uint32(0) >> uint(ones))
+		// 按IP整数范围匹配，使用 $elemMatch 保证同一个IP落在范围内
+		inRange := bson.M{"ip.ipv4": bson.M{"$elemMatch": bson.M{
+			"uint32": bson.M{"$gte": int64(start), "$lte": int64(end)},
+		}}}
+		if negate {
+			return bson.M{"$nor": []bson.M{inRange}}, nil
+		}
+		return inRange, nil
+	}
+
+	if net.ParseIP(c.Value) != nil || c.Op == "==" {
+		return matchPaths(paths, c.Value, negate), nil
+	}
+	return matchPaths(paths, containsRegex(c.Value), negate), nil
+}
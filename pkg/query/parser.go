@@ -0,0 +1,175 @@
+package query
+
+import "strings"
+
+// Node 查询语法树节点
+type Node interface {
+	node()
+}
+
+// BinaryNode 逻辑与/或
+type BinaryNode struct {
+	Op    string // && 或 ||
+	Left  Node
+	Right Node
+}
+
+// NotNode 逻辑非
+type NotNode struct {
+	Expr Node
+}
+
+// CondNode 字段条件，如 port=80
+type CondNode struct {
+	Field    string
+	Op       string // = == != ~=
+	Value    string
+	FieldPos int
+	ValuePos int
+}
+
+// TermNode 未指定字段的关键字，如 "nginx"
+type TermNode struct {
+	Value string
+	Pos   int
+}
+
+func (*BinaryNode) node() {}
+func (*NotNode) node()    {}
+func (*CondNode) node()   {}
+func (*TermNode) node()   {}
+
+// Parse 解析查询表达式
+//
+//	expr    := or
+//	or      := and ( "||" and )*
+//	and     := unary ( "&&" unary )*
+//	unary   := "!" unary | "(" expr ")" | cond
+//	cond    := field op value | value
+//	op      := "=" | "==" | "!=" | "~="
+func Parse(input string) (Node, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokEOF {
+		return nil, nil
+	}
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		if t.kind == tokRParen {
+			return nil, errorAt(t.pos, "多余的 )")
+		}
+		return nil, errorAt(t.pos, "期望 && 或 ||，实际为 %s", describe(t))
+	}
+	return n, nil
+}
+
+type parser struct {
+	tokens []token
+	idx    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.idx]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.idx]
+	if t.kind != tokEOF {
+		p.idx++
+	}
+	return t
+}
+
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &BinaryNode{Op: "||", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &BinaryNode{Op: "&&", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokNot:
+		p.next()
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &NotNode{Expr: expr}, nil
+	case tokLParen:
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, errorAt(closing.pos, "期望 )，实际为 %s", describe(closing))
+		}
+		return expr, nil
+	case tokWord, tokString:
+		return p.parseCond()
+	}
+	return nil, errorAt(t.pos, "期望查询条件，实际为 %s", describe(t))
+}
+
+func (p *parser) parseCond() (Node, error) {
+	first := p.next()
+	if p.peek().kind != tokOp {
+		// 单独的关键字
+		return &TermNode{Value: first.value, Pos: first.pos}, nil
+	}
+	if first.kind != tokWord {
+		return nil, errorAt(first.pos, "字段名不能加引号")
+	}
+	op := p.next()
+	value := p.next()
+	if value.kind != tokWord && value.kind != tokString {
+		return nil, errorAt(value.pos, "%s 后缺少值", op.value)
+	}
+	return &CondNode{
+		Field:    strings.ToLower(first.value),
+		Op:       op.value,
+		Value:    value.value,
+		FieldPos: first.pos,
+		ValuePos: value.pos,
+	}, nil
+}
+
+func describe(t token) string {
+	if t.kind == tokEOF {
+		return "结尾"
+	}
+	return "'" + t.value + "'"
+}
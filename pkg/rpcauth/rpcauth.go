@@ -0,0 +1,154 @@
+// Package rpcauth 任务服务的传输加密和调用凭证，供RPC服务、API服务和Worker共用
+package rpcauth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+// AuthorizationKey 凭证所在的gRPC元数据键
+const AuthorizationKey = "authorization"
+
+// TLSConf TLS配置
+type TLSConf struct {
+	CertFile   string `json:",optional"` // 本端证书
+	KeyFile    string `json:",optional"` // 本端私钥
+	CAFile     string `json:",optional"` // 服务端：配置后要求客户端提供该CA签发的证书(mTLS)；客户端：校验服务端证书的CA，为空时使用系统CA
+	ServerName string `json:",optional"` // 客户端：校验的服务端证书名称，为空时使用连接地址
+}
+
+// Enabled 服务端是否开启TLS
+func (c TLSConf) Enabled() bool {
+	return c.CertFile != "" && c.KeyFile != ""
+}
+
+// ServerCredentials 服务端TLS凭证，配置CAFile时校验客户端证书
+func ServerCredentials(c TLSConf) (credentials.TransportCredentials, error) {
+	if !c.Enabled() {
+		return nil, fmt.Errorf("tls cert and key are required")
+	}
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load tls cert: %v", err)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if c.CAFile != "" {
+		pool, err := loadCertPool(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return credentials.NewTLS(config), nil
+}
+
+// ClientConfig 客户端TLS配置
+func ClientConfig(c TLSConf) (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName: c.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+	if c.CAFile != "" {
+		pool, err := loadCertPool(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load tls client cert: %v", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// ClientCredentials 客户端TLS凭证
+func ClientCredentials(c TLSConf) (credentials.TransportCredentials, error) {
+	config, err := ClientConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(config), nil
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read ca file: %v", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(content) {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+	return pool, nil
+}
+
+// TokenCredentials 每次调用携带的Bearer凭证，可在注册完成后设置
+type TokenCredentials struct {
+	mu     sync.RWMutex
+	token  string
+	secure bool
+}
+
+// NewTokenCredentials 创建调用凭证，secure为true时只允许通过TLS连接发送
+func NewTokenCredentials(token string, secure bool) *TokenCredentials {
+	return &TokenCredentials{token: token, secure: secure}
+}
+
+// SetToken 更新凭证
+func (t *TokenCredentials) SetToken(token string) {
+	t.mu.Lock()
+	t.token = token
+	t.mu.Unlock()
+}
+
+// Token 当前凭证
+func (t *TokenCredentials) Token() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.token
+}
+
+func (t *TokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token := t.Token()
+	if token == "" {
+		return nil, nil
+	}
+	return map[string]string{AuthorizationKey: "Bearer " + token}, nil
+}
+
+func (t *TokenCredentials) RequireTransportSecurity() bool {
+	return t.secure
+}
+
+// TokenFromContext 从调用元数据中取出Bearer凭证
+func TokenFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(AuthorizationKey)
+	if len(values) == 0 {
+		return ""
+	}
+	token, found := strings.CutPrefix(values[0], "Bearer ")
+	if !found {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
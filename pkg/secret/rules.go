@@ -0,0 +1,40 @@
+package secret
+
+// 规则分类
+const (
+	CategoryCloud   = "cloud"   // 云服务密钥
+	CategoryToken   = "token"   // 访问令牌
+	CategoryKey     = "key"     // 私钥
+	CategoryGeneric = "generic" // 通用密码/密钥赋值
+	CategoryNetwork = "network" // 内网地址
+	CategoryPII     = "pii"     // 个人信息
+)
+
+// BuiltinRules 内置规则，API服务启动时写入数据库
+var BuiltinRules = []Rule{
+	// 云服务密钥
+	{Name: "AWS Access Key", Category: CategoryCloud, Severity: "high", Pattern: `\b((?:AKIA|ASIA)[0-9A-Z]{16})\b`},
+	{Name: "AWS Secret Key", Category: CategoryCloud, Severity: "high", Pattern: `(?i)aws.{0,20}?(?:secret|sk).{0,20}?['"]([0-9a-zA-Z/+]{40})['"]`, MinEntropy: 4},
+	{Name: "Aliyun AccessKey", Category: CategoryCloud, Severity: "high", Pattern: `\b(LTAI[0-9a-zA-Z]{12,20})\b`},
+	{Name: "Tencent Cloud SecretId", Category: CategoryCloud, Severity: "high", Pattern: `\b(AKID[0-9a-zA-Z]{32})\b`},
+	{Name: "Google API Key", Category: CategoryCloud, Severity: "medium", Pattern: `\b(AIza[0-9A-Za-z_-]{35})\b`},
+
+	// 访问令牌
+	{Name: "GitHub Token", Category: CategoryToken, Severity: "high", Pattern: `\b((?:ghp|gho|ghu|ghs|ghr)_[0-9a-zA-Z]{36})\b`},
+	{Name: "Slack Token", Category: CategoryToken, Severity: "high", Pattern: `\b(xox[baprs]-[0-9a-zA-Z-]{10,48})\b`},
+	{Name: "JWT", Category: CategoryToken, Severity: "medium", Pattern: `\b(eyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,})`, Validator: ValidatorJWT},
+
+	// 私钥，以密钥内容第一行作为敏感值
+	{Name: "Private Key", Category: CategoryKey, Severity: "critical", Pattern: `-----BEGIN (?:RSA |EC |DSA |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY(?: BLOCK)?-----(?:\s|\\n|\\r)+([A-Za-z0-9+/=]{40,})`},
+
+	// 通用赋值，要求一定的随机性以排除占位符
+	{Name: "Generic Secret", Category: CategoryGeneric, Severity: "medium", Pattern: `(?i)\b(?:api[_-]?key|secret[_-]?key|access[_-]?token|client[_-]?secret|app[_-]?secret|password|passwd)\b['"]?\s*[:=]\s*['"]([^'"\s]{8,64})['"]`, MinEntropy: 3.5},
+
+	// 内网地址
+	{Name: "Internal IP", Category: CategoryNetwork, Severity: "low", Pattern: `\b((?:10\.\d{1,3}|172\.(?:1[6-9]|2\d|3[01])|192\.168)\.\d{1,3}\.\d{1,3})\b`, Validator: ValidatorIP},
+
+	// 个人信息
+	{Name: "Email", Category: CategoryPII, Severity: "info", Pattern: `\b([a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,})\b`, Validator: ValidatorEmail},
+	{Name: "China Mobile Phone", Category: CategoryPII, Severity: "low", Pattern: `\b(1[3-9]\d{9})\b`},
+	{Name: "China ID Card", Category: CategoryPII, Severity: "medium", Pattern: `\b([1-9]\d{5}(?:19|20)\d{2}(?:0[1-9]|1[0-2])(?:0[1-9]|[12]\d|3[01])\d{3}[\dXx])\b`, Validator: ValidatorIdCard},
+}
@@ -0,0 +1,253 @@
+// Package secret 使用正则和信息熵检测响应内容中泄露的密钥、令牌和个人信息
+package secret
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"regexp"
+	"strings"
+)
+
+const (
+	maxContentSize    = 2 * 1024 * 1024 // 单个内容最多检测2MB
+	maxMatchesPerRule = 20              // 单个内容每条规则最多记录的结果数
+	maxEvidenceLength = 200
+)
+
+// 内置校验器，用于过滤正则无法排除的误报
+const (
+	ValidatorIdCard = "idcard" // 身份证号校验位
+	ValidatorJWT    = "jwt"    // JWT头部可解码且包含alg
+	ValidatorIP     = "ip"     // 合法的IP地址
+	ValidatorEmail  = "email"  // 排除 logo@2x.png 之类的文件名
+)
+
+// Validators 支持的校验器
+var Validators = []string{ValidatorIdCard, ValidatorJWT, ValidatorIP, ValidatorEmail}
+
+// Rule 检测规则。正则包含分组时以第一个分组作为敏感值，否则为整个匹配内容
+type Rule struct {
+	Name       string  `json:"name"`
+	Category   string  `json:"category"`
+	Severity   string  `json:"severity"`
+	Pattern    string  `json:"pattern"`
+	MinEntropy float64 `json:"minEntropy"` // 敏感值的香农熵下限(bit/字符)，0表示不检查
+	Validator  string  `json:"validator"`  // 内置校验器，可为空
+}
+
+// Finding 检测结果，不包含敏感值原文
+type Finding struct {
+	Rule     string `json:"rule"`
+	Category string `json:"category"`
+	Severity string `json:"severity"`
+	Evidence string `json:"evidence"` // 脱敏后的匹配内容
+	Hash     string `json:"hash"`     // 敏感值的SHA256，用于去重
+}
+
+type compiledRule struct {
+	Rule
+	re *regexp.Regexp
+}
+
+// Engine 检测引擎，创建后只读，可并发使用
+type Engine struct {
+	rules []*compiledRule
+}
+
+// Compile 校验规则是否可用
+func Compile(rule Rule) (*regexp.Regexp, error) {
+	if rule.Pattern == "" {
+		return nil, fmt.Errorf("pattern is empty")
+	}
+	if rule.Validator != "" && !isValidator(rule.Validator) {
+		return nil, fmt.Errorf("unknown validator: %s", rule.Validator)
+	}
+	re, err := regexp.Compile(rule.Pattern)
+	if err != nil {
+		return nil, err
+	}
+	if re.MatchString("") {
+		return nil, fmt.Errorf("pattern matches empty string")
+	}
+	return re, nil
+}
+
+// NewEngine 编译规则，无效的规则被跳过并返回对应的错误
+func NewEngine(rules []Rule) (*Engine, []error) {
+	e := &Engine{}
+	var errs []error
+	for _, rule := range rules {
+		re, err := Compile(rule)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rule %s: %v", rule.Name, err))
+			continue
+		}
+		e.rules = append(e.rules, &compiledRule{Rule: rule, re: re})
+	}
+	return e, errs
+}
+
+// RuleCount 可用的规则数
+func (e *Engine) RuleCount() int {
+	return len(e.rules)
+}
+
+// Scan 检测内容，同一规则的相同敏感值只返回一次
+func (e *Engine) Scan(content string) []*Finding {
+	if len(content) > maxContentSize {
+		content = content[:maxContentSize]
+	}
+	var findings []*Finding
+	for _, rule := range e.rules {
+		seen := make(map[string]bool)
+		for _, loc := range rule.re.FindAllStringSubmatchIndex(content, -1) {
+			start, end := loc[0], loc[1]
+			if len(loc) >= 4 && loc[2] >= 0 {
+				start, end = loc[2], loc[3]
+			}
+			value := content[start:end]
+			if value == "" || seen[value] {
+				continue
+			}
+			if rule.MinEntropy > 0 && Entropy(value) < rule.MinEntropy {
+				continue
+			}
+			if !validate(rule.Validator, value) {
+				continue
+			}
+			seen[value] = true
+
+			sum := sha256.Sum256([]byte(value))
+			findings = append(findings, &Finding{
+				Rule:     rule.Name,
+				Category: rule.Category,
+				Severity: rule.Severity,
+				Evidence: evidence(content, loc[0], loc[1], start, end),
+				Hash:     hex.EncodeToString(sum[:]),
+			})
+			if len(seen) >= maxMatchesPerRule {
+				break
+			}
+		}
+	}
+	return findings
+}
+
+// Entropy 计算字符串的香农熵(bit/字符)
+func Entropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+	var entropy float64
+	for _, c := range counts {
+		p := float64(c) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// Redact 只保留开头和结尾少量字符
+func Redact(s string) string {
+	runes := []rune(s)
+	keep := len(runes) / 4
+	if keep > 4 {
+		keep = 4
+	}
+	if keep == 0 {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:keep]) + strings.Repeat("*", min(len(runes)-2*keep, 16)) + string(runes[len(runes)-keep:])
+}
+
+// evidence 匹配内容中的敏感值替换为脱敏后的内容。不保留匹配以外的上下文，避免带出其他敏感信息
+func evidence(content string, matchStart, matchEnd, valueStart, valueEnd int) string {
+	text := content[matchStart:valueStart] + Redact(content[valueStart:valueEnd]) + content[valueEnd:matchEnd]
+	text = strings.ToValidUTF8(text, "")
+	text = strings.Join(strings.Fields(text), " ")
+	if len(text) > maxEvidenceLength {
+		text = strings.ToValidUTF8(text[:maxEvidenceLength], "") + "..."
+	}
+	return text
+}
+
+func isValidator(name string) bool {
+	for _, v := range Validators {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
+func validate(validator, value string) bool {
+	switch validator {
+	case ValidatorIdCard:
+		return validIdCard(value)
+	case ValidatorJWT:
+		return validJWT(value)
+	case ValidatorIP:
+		return net.ParseIP(value) != nil
+	case ValidatorEmail:
+		return validEmail(value)
+	}
+	return true
+}
+
+// validIdCard 校验18位身份证号的校验码
+func validIdCard(id string) bool {
+	if len(id) != 18 {
+		return false
+	}
+	weights := []int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+	checks := "10X98765432"
+	sum := 0
+	for i := 0; i < 17; i++ {
+		if id[i] < '0' || id[i] > '9' {
+			return false
+		}
+		sum += int(id[i]-'0') * weights[i]
+	}
+	return strings.ToUpper(id[17:]) == string(checks[sum%11])
+}
+
+// validJWT 头部应为包含alg字段的JSON
+func validJWT(token string) bool {
+	header, _, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	data, err := base64.RawURLEncoding.DecodeString(header)
+	if err != nil {
+		return false
+	}
+	var h map[string]interface{}
+	if json.Unmarshal(data, &h) != nil {
+		return false
+	}
+	_, ok = h["alg"]
+	return ok
+}
+
+// staticSuffixes 形如邮箱的静态资源文件名
+var staticSuffixes = []string{".png", ".jpg", ".jpeg", ".gif", ".svg", ".webp", ".ico", ".css", ".js", ".woff", ".woff2"}
+
+func validEmail(email string) bool {
+	lower := strings.ToLower(email)
+	for _, suffix := range staticSuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,377 @@
+// Package takeover 根据CNAME链和服务商"未认领"页面特征检测子域名接管
+package takeover
+
+import (
+	"context"
+	"crypto/tls"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"cscan/pkg/cdn"
+
+	"github.com/miekg/dns"
+	"golang.org/x/net/publicsuffix"
+)
+
+// 服务商状态，与 can-i-take-over-xyz 的分类一致
+const (
+	StatusVulnerable = "vulnerable" // 命中特征即可接管
+	StatusEdge       = "edge"       // 部分情况下可接管，需要人工确认
+)
+
+// 检测结论
+const (
+	ConfidenceConfirmed = "confirmed"
+	ConfidenceLikely    = "likely"
+)
+
+// 判断依据
+const (
+	ReasonFingerprint  = "fingerprint"  // 响应命中服务商未认领页面特征
+	ReasonNXDomain     = "nxdomain"     // CNAME指向的服务商主机不存在
+	ReasonUnregistered = "unregistered" // CNAME目标的注册域名不存在，可直接注册
+)
+
+const (
+	maxCNameDepth   = 10
+	maxResponseSize = 256 * 1024
+)
+
+// 默认服务商特征数据，可通过 LoadFile 使用外部文件覆盖
+//
+//go:embed data/fingerprints.json
+var defaultData []byte
+
+// Service 服务商特征
+type Service struct {
+	Service      string   `json:"service"`
+	CNames       []string `json:"cnames"`       // CNAME后缀
+	Fingerprints []string `json:"fingerprints"` // 未认领时响应中包含的内容
+	NXDomain     bool     `json:"nxdomain"`     // 未认领时CNAME目标不存在
+	Status       string   `json:"status"`       // vulnerable/edge
+}
+
+// Data 特征数据文件格式
+type Data struct {
+	Version  string    `json:"version"`
+	Services []Service `json:"services"`
+}
+
+// Finding 检测结果
+type Finding struct {
+	Domain      string   `json:"domain"`
+	CNames      []string `json:"cnames"` // CNAME链，按解析顺序
+	Service     string   `json:"service"`
+	Confidence  string   `json:"confidence"` // confirmed/likely
+	Severity    string   `json:"severity"`
+	Reason      string   `json:"reason"` // fingerprint/nxdomain/unregistered
+	Fingerprint string   `json:"fingerprint"`
+	Url         string   `json:"url"`
+	StatusCode  int      `json:"statusCode"`
+	Response    string   `json:"response"` // 命中特征时的响应片段
+}
+
+// Options 检测选项
+type Options struct {
+	Resolvers  []string      // DNS服务器，为空时使用 cdn.DefaultResolvers
+	Timeout    time.Duration // DNS查询超时，默认3秒
+	HTTPClient *http.Client  // 获取页面使用的客户端，默认10秒超时、不校验证书
+}
+
+// Checker 子域名接管检测器，可并发使用
+type Checker struct {
+	version   string
+	services  []Service
+	resolvers []string
+	timeout   time.Duration
+	client    *http.Client
+}
+
+// NewChecker 根据特征数据创建检测器
+func NewChecker(data *Data, opts Options) *Checker {
+	if len(opts.Resolvers) == 0 {
+		opts.Resolvers = cdn.DefaultResolvers
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 3 * time.Second
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		}
+	}
+	c := &Checker{
+		version:   data.Version,
+		resolvers: normalizeResolvers(opts.Resolvers),
+		timeout:   opts.Timeout,
+		client:    opts.HTTPClient,
+	}
+	for _, s := range data.Services {
+		cnames := make([]string, 0, len(s.CNames))
+		for _, suffix := range s.CNames {
+			cnames = append(cnames, strings.ToLower(strings.TrimSuffix(suffix, ".")))
+		}
+		s.CNames = cnames
+		c.services = append(c.services, s)
+	}
+	return c
+}
+
+// Version 特征数据版本
+func (c *Checker) Version() string {
+	return c.version
+}
+
+var (
+	defaultMu   sync.RWMutex
+	currentData *Data
+)
+
+// DefaultData 返回当前使用的特征数据，首次调用时加载内置数据
+func DefaultData() *Data {
+	defaultMu.RLock()
+	data := currentData
+	defaultMu.RUnlock()
+	if data != nil {
+		return data
+	}
+
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	if currentData == nil {
+		var d Data
+		json.Unmarshal(defaultData, &d)
+		currentData = &d
+	}
+	return currentData
+}
+
+// LoadFile 从外部文件加载特征数据，便于不重新编译即可更新服务商列表
+func LoadFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var data Data
+	if err := json.Unmarshal(content, &data); err != nil {
+		return fmt.Errorf("parse takeover data: %v", err)
+	}
+	if len(data.Services) == 0 {
+		return fmt.Errorf("takeover data has no services")
+	}
+
+	defaultMu.Lock()
+	currentData = &data
+	defaultMu.Unlock()
+	return nil
+}
+
+// MatchCName 按CNAME后缀匹配服务商
+func (c *Checker) MatchCName(name string) *Service {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	if name == "" {
+		return nil
+	}
+	for i := range c.services {
+		for _, suffix := range c.services[i].CNames {
+			if name == suffix || strings.HasSuffix(name, "."+suffix) {
+				return &c.services[i]
+			}
+		}
+	}
+	return nil
+}
+
+// Check 检测单个域名，没有CNAME或未发现接管风险时返回nil
+func (c *Checker) Check(ctx context.Context, domain string) *Finding {
+	domain = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+	chain, targetMissing := c.resolveChain(ctx, domain)
+	if len(chain) == 0 {
+		return nil
+	}
+
+	var service *Service
+	for _, name := range chain {
+		if service = c.MatchCName(name); service != nil {
+			break
+		}
+	}
+
+	finding := &Finding{Domain: domain, CNames: chain}
+	if service == nil {
+		// 未知服务商：只有CNAME目标的注册域名本身不存在时才可能被直接注册
+		if !targetMissing || !c.unregistered(ctx, chain[len(chain)-1]) {
+			return nil
+		}
+		finding.Confidence = ConfidenceLikely
+		finding.Severity = "high"
+		finding.Reason = ReasonUnregistered
+		finding.Url = "http://" + domain
+		return finding
+	}
+
+	finding.Service = service.Service
+	switch {
+	case targetMissing && service.NXDomain:
+		finding.Reason = ReasonNXDomain
+		finding.Url = "http://" + domain
+	case len(service.Fingerprints) > 0:
+		if !c.matchFingerprint(ctx, domain, service, finding) {
+			return nil
+		}
+		finding.Reason = ReasonFingerprint
+	default:
+		return nil
+	}
+
+	if service.Status == StatusEdge {
+		finding.Confidence = ConfidenceLikely
+		finding.Severity = "medium"
+	} else {
+		finding.Confidence = ConfidenceConfirmed
+		finding.Severity = "high"
+	}
+	return finding
+}
+
+// matchFingerprint 依次请求HTTPS和HTTP，响应包含服务商特征时记录证据
+func (c *Checker) matchFingerprint(ctx context.Context, domain string, service *Service, finding *Finding) bool {
+	for _, scheme := range []string{"https", "http"} {
+		target := scheme + "://" + domain + "/"
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+		if err != nil {
+			continue
+		}
+		resp, err := c.client.Do(req)
+		if err != nil {
+			continue
+		}
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+		resp.Body.Close()
+
+		content := string(body)
+		for _, fp := range service.Fingerprints {
+			idx := strings.Index(content, fp)
+			if idx < 0 {
+				continue
+			}
+			finding.Url = strings.TrimSuffix(target, "/")
+			finding.StatusCode = resp.StatusCode
+			finding.Fingerprint = fp
+			finding.Response = snippet(content, idx, len(fp))
+			return true
+		}
+	}
+	return false
+}
+
+// resolveChain 解析CNAME链，返回链和最终目标是否不存在(NXDOMAIN)
+func (c *Checker) resolveChain(ctx context.Context, domain string) ([]string, bool) {
+	var chain []string
+	seen := map[string]bool{domain: true}
+	name := domain
+	for i := 0; i < maxCNameDepth; i++ {
+		resp, err := c.query(ctx, name, dns.TypeCNAME)
+		if err != nil {
+			return chain, false
+		}
+		if resp.Rcode == dns.RcodeNameError {
+			return chain, len(chain) > 0
+		}
+		next := ""
+		for _, rr := range resp.Answer {
+			if cname, ok := rr.(*dns.CNAME); ok && strings.EqualFold(strings.TrimSuffix(cname.Hdr.Name, "."), name) {
+				next = strings.ToLower(strings.TrimSuffix(cname.Target, "."))
+				break
+			}
+		}
+		if next == "" || seen[next] {
+			break
+		}
+		seen[next] = true
+		chain = append(chain, next)
+		name = next
+	}
+	if len(chain) == 0 {
+		return nil, false
+	}
+
+	// 最终目标没有CNAME时确认其是否存在
+	resp, err := c.query(ctx, chain[len(chain)-1], dns.TypeA)
+	if err != nil {
+		return chain, false
+	}
+	return chain, resp.Rcode == dns.RcodeNameError
+}
+
+// unregistered CNAME目标的注册域名是否不存在
+func (c *Checker) unregistered(ctx context.Context, target string) bool {
+	registered, err := publicsuffix.EffectiveTLDPlusOne(target)
+	if err != nil {
+		return false
+	}
+	resp, err := c.query(ctx, registered, dns.TypeSOA)
+	if err != nil {
+		return false
+	}
+	return resp.Rcode == dns.RcodeNameError
+}
+
+// query 依次尝试各服务器
+func (c *Checker) query(ctx context.Context, name string, qtype uint16) (*dns.Msg, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(name), qtype)
+	m.RecursionDesired = true
+
+	var lastErr error
+	for _, server := range c.resolvers {
+		client := &dns.Client{Timeout: c.timeout}
+		resp, _, err := client.ExchangeContext(ctx, m, server)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if resp.Rcode != dns.RcodeSuccess && resp.Rcode != dns.RcodeNameError {
+			lastErr = fmt.Errorf("%s: %s", server, dns.RcodeToString[resp.Rcode])
+			continue
+		}
+		return resp, nil
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no resolvers")
+	}
+	return nil, lastErr
+}
+
+// snippet 截取特征附近的响应内容作为证据
+func snippet(content string, idx, length int) string {
+	start := max(idx-200, 0)
+	end := min(idx+length+200, len(content))
+	return strings.ToValidUTF8(content[start:end], "")
+}
+
+func normalizeResolvers(resolvers []string) []string {
+	result := make([]string, 0, len(resolvers))
+	for _, r := range resolvers {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(r); err != nil {
+			r = net.JoinHostPort(r, "53")
+		}
+		result = append(result, r)
+	}
+	return result
+}
@@ -14,51 +14,87 @@ import (
 )
 
 type (
-	AssetDocument              = pb.AssetDocument
-	BatchValidatePocReq        = pb.BatchValidatePocReq
-	BatchValidatePocResp       = pb.BatchValidatePocResp
-	CheckTaskReq               = pb.CheckTaskReq
-	CheckTaskResp              = pb.CheckTaskResp
-	FingerprintDocument        = pb.FingerprintDocument
-	GetCustomFingerprintsReq   = pb.GetCustomFingerprintsReq
-	GetCustomFingerprintsResp  = pb.GetCustomFingerprintsResp
-	GetHttpServiceMappingsReq  = pb.GetHttpServiceMappingsReq
-	GetHttpServiceMappingsResp = pb.GetHttpServiceMappingsResp
-	GetPocByIdReq              = pb.GetPocByIdReq
-	GetPocByIdResp             = pb.GetPocByIdResp
-	GetPocValidationResultReq  = pb.GetPocValidationResultReq
-	GetPocValidationResultResp = pb.GetPocValidationResultResp
-	GetSubfinderProvidersReq   = pb.GetSubfinderProvidersReq
-	GetSubfinderProvidersResp  = pb.GetSubfinderProvidersResp
-	GetTemplatesByIdsReq       = pb.GetTemplatesByIdsReq
-	GetTemplatesByIdsResp      = pb.GetTemplatesByIdsResp
-	GetTemplatesByTagsReq      = pb.GetTemplatesByTagsReq
-	GetTemplatesByTagsResp     = pb.GetTemplatesByTagsResp
-	GetWorkerConfigReq         = pb.GetWorkerConfigReq
-	GetWorkerConfigResp        = pb.GetWorkerConfigResp
-	HttpServiceMappingDocument = pb.HttpServiceMappingDocument
-	IPV4                       = pb.IPV4
-	IPV6                       = pb.IPV6
-	KeepAliveReq               = pb.KeepAliveReq
-	KeepAliveResp              = pb.KeepAliveResp
-	MatchedFingerprintInfo     = pb.MatchedFingerprintInfo
-	NewTaskReq                 = pb.NewTaskReq
-	NewTaskResp                = pb.NewTaskResp
-	PocValidationResult        = pb.PocValidationResult
-	RequestResourceReq         = pb.RequestResourceReq
-	RequestResourceResp        = pb.RequestResourceResp
-	SaveTaskResultReq          = pb.SaveTaskResultReq
-	SaveTaskResultResp         = pb.SaveTaskResultResp
-	SaveVulResultReq           = pb.SaveVulResultReq
-	SaveVulResultResp          = pb.SaveVulResultResp
-	SubfinderProviderDocument  = pb.SubfinderProviderDocument
-	UpdateTaskReq              = pb.UpdateTaskReq
-	UpdateTaskResp             = pb.UpdateTaskResp
-	ValidateFingerprintReq     = pb.ValidateFingerprintReq
-	ValidateFingerprintResp    = pb.ValidateFingerprintResp
-	ValidatePocReq             = pb.ValidatePocReq
-	ValidatePocResp            = pb.ValidatePocResp
-	VulDocument                = pb.VulDocument
+	AssetDocument               = pb.AssetDocument
+	BatchValidatePocReq         = pb.BatchValidatePocReq
+	BatchValidatePocResp        = pb.BatchValidatePocResp
+	CheckTaskReq                = pb.CheckTaskReq
+	CheckTaskResp               = pb.CheckTaskResp
+	DirScanDocument             = pb.DirScanDocument
+	DnsIssueItem                = pb.DnsIssueItem
+	DnsRecordDocument           = pb.DnsRecordDocument
+	DnsRecordItem               = pb.DnsRecordItem
+	EnrollWorkerReq             = pb.EnrollWorkerReq
+	EnrollWorkerResp            = pb.EnrollWorkerResp
+	FingerprintDocument         = pb.FingerprintDocument
+	GetCustomFingerprintsReq    = pb.GetCustomFingerprintsReq
+	GetCustomFingerprintsResp   = pb.GetCustomFingerprintsResp
+	GetHttpServiceMappingsReq   = pb.GetHttpServiceMappingsReq
+	GetHttpServiceMappingsResp  = pb.GetHttpServiceMappingsResp
+	GetPocByIdReq               = pb.GetPocByIdReq
+	GetPocByIdResp              = pb.GetPocByIdResp
+	GetPocValidationResultReq   = pb.GetPocValidationResultReq
+	GetPocValidationResultResp  = pb.GetPocValidationResultResp
+	GetSecretRulesReq           = pb.GetSecretRulesReq
+	GetSecretRulesResp          = pb.GetSecretRulesResp
+	GetSubfinderProvidersReq    = pb.GetSubfinderProvidersReq
+	GetSubfinderProvidersResp   = pb.GetSubfinderProvidersResp
+	GetTaskControlReq           = pb.GetTaskControlReq
+	GetTaskControlResp          = pb.GetTaskControlResp
+	GetTemplatesByIdsReq        = pb.GetTemplatesByIdsReq
+	GetTemplatesByIdsResp       = pb.GetTemplatesByIdsResp
+	GetTemplatesByTagsReq       = pb.GetTemplatesByTagsReq
+	GetTemplatesByTagsResp      = pb.GetTemplatesByTagsResp
+	GetWordlistsReq             = pb.GetWordlistsReq
+	GetWordlistsResp            = pb.GetWordlistsResp
+	GetWorkerConfigReq          = pb.GetWorkerConfigReq
+	GetWorkerConfigResp         = pb.GetWorkerConfigResp
+	HttpServiceMappingDocument  = pb.HttpServiceMappingDocument
+	IPV4                        = pb.IPV4
+	IPV6                        = pb.IPV6
+	KeepAliveReq                = pb.KeepAliveReq
+	KeepAliveResp               = pb.KeepAliveResp
+	MatchedFingerprintInfo      = pb.MatchedFingerprintInfo
+	NewTaskReq                  = pb.NewTaskReq
+	NewTaskResp                 = pb.NewTaskResp
+	PocValidationResult         = pb.PocValidationResult
+	PushWorkerLogsResp          = pb.PushWorkerLogsResp
+	RequestResourceReq          = pb.RequestResourceReq
+	RequestResourceResp         = pb.RequestResourceResp
+	SaveDirScanResultReq        = pb.SaveDirScanResultReq
+	SaveDirScanResultResp       = pb.SaveDirScanResultResp
+	SaveDnsRecordResultReq      = pb.SaveDnsRecordResultReq
+	SaveDnsRecordResultResp     = pb.SaveDnsRecordResultResp
+	SavePocValidationResultReq  = pb.SavePocValidationResultReq
+	SavePocValidationResultResp = pb.SavePocValidationResultResp
+	SaveSecretResultReq         = pb.SaveSecretResultReq
+	SaveSecretResultResp        = pb.SaveSecretResultResp
+	SaveTaskResultReq           = pb.SaveTaskResultReq
+	SaveTaskResultResp          = pb.SaveTaskResultResp
+	SaveUrlResultReq            = pb.SaveUrlResultReq
+	SaveUrlResultResp           = pb.SaveUrlResultResp
+	SaveVulResultReq            = pb.SaveVulResultReq
+	SaveVulResultResp           = pb.SaveVulResultResp
+	SecretDocument              = pb.SecretDocument
+	SecretRuleDocument          = pb.SecretRuleDocument
+	SubdomainDocument           = pb.SubdomainDocument
+	SubfinderProviderDocument   = pb.SubfinderProviderDocument
+	SyncSubdomainsReq           = pb.SyncSubdomainsReq
+	SyncSubdomainsResp          = pb.SyncSubdomainsResp
+	UpdateTaskProgressReq       = pb.UpdateTaskProgressReq
+	UpdateTaskProgressResp      = pb.UpdateTaskProgressResp
+	UpdateTaskReq               = pb.UpdateTaskReq
+	UpdateTaskResp              = pb.UpdateTaskResp
+	UrlDocument                 = pb.UrlDocument
+	ValidateFingerprintReq      = pb.ValidateFingerprintReq
+	ValidateFingerprintResp     = pb.ValidateFingerprintResp
+	ValidatePocReq              = pb.ValidatePocReq
+	ValidatePocResp             = pb.ValidatePocResp
+	VulDocument                 = pb.VulDocument
+	WorkerCommand               = pb.WorkerCommand
+	WorkerLogEntry              = pb.WorkerLogEntry
+	WorkerOnlineReq             = pb.WorkerOnlineReq
+	WorkerOnlineResp            = pb.WorkerOnlineResp
+	WorkerStatusReport          = pb.WorkerStatusReport
 
 	TaskService interface {
 		// 检查任务状态
@@ -97,6 +133,34 @@ type (
 		GetHttpServiceMappings(ctx context.Context, in *GetHttpServiceMappingsReq, opts ...grpc.CallOption) (*GetHttpServiceMappingsResp, error)
 		// 获取Subfinder数据源配置
 		GetSubfinderProviders(ctx context.Context, in *GetSubfinderProvidersReq, opts ...grpc.CallOption) (*GetSubfinderProvidersResp, error)
+		// 保存爬虫发现的URL
+		SaveUrlResult(ctx context.Context, in *SaveUrlResultReq, opts ...grpc.CallOption) (*SaveUrlResultResp, error)
+		// 获取爆破字典内容
+		GetWordlists(ctx context.Context, in *GetWordlistsReq, opts ...grpc.CallOption) (*GetWordlistsResp, error)
+		// 保存目录扫描结果
+		SaveDirScanResult(ctx context.Context, in *SaveDirScanResultReq, opts ...grpc.CallOption) (*SaveDirScanResultResp, error)
+		// 获取敏感信息检测规则
+		GetSecretRules(ctx context.Context, in *GetSecretRulesReq, opts ...grpc.CallOption) (*GetSecretRulesResp, error)
+		// 保存敏感信息检测结果
+		SaveSecretResult(ctx context.Context, in *SaveSecretResultReq, opts ...grpc.CallOption) (*SaveSecretResultResp, error)
+		// 保存域名DNS记录
+		SaveDnsRecordResult(ctx context.Context, in *SaveDnsRecordResultReq, opts ...grpc.CallOption) (*SaveDnsRecordResultResp, error)
+		// 子域名监控：与已知子域名比对，返回新增和解析变化的子域名
+		SyncSubdomains(ctx context.Context, in *SyncSubdomainsReq, opts ...grpc.CallOption) (*SyncSubdomainsResp, error)
+		// Worker使用注册令牌换取凭证，无需认证
+		EnrollWorker(ctx context.Context, in *EnrollWorkerReq, opts ...grpc.CallOption) (*EnrollWorkerResp, error)
+		// Worker上线：校验凭证并确认使用的名称
+		WorkerOnline(ctx context.Context, in *WorkerOnlineReq, opts ...grpc.CallOption) (*WorkerOnlineResp, error)
+		// Worker日志上报（客户端流）
+		PushWorkerLogs(ctx context.Context, opts ...grpc.CallOption) (pb.TaskService_PushWorkerLogsClient, error)
+		// Worker会话（双向流）：Worker上报状态，服务端下发控制命令和状态查询
+		WorkerSession(ctx context.Context, opts ...grpc.CallOption) (pb.TaskService_WorkerSessionClient, error)
+		// 获取任务控制信号
+		GetTaskControl(ctx context.Context, in *GetTaskControlReq, opts ...grpc.CallOption) (*GetTaskControlResp, error)
+		// 更新任务进度
+		UpdateTaskProgress(ctx context.Context, in *UpdateTaskProgressReq, opts ...grpc.CallOption) (*UpdateTaskProgressResp, error)
+		// 保存POC验证结果
+		SavePocValidationResult(ctx context.Context, in *SavePocValidationResultReq, opts ...grpc.CallOption) (*SavePocValidationResultResp, error)
 	}
 
 	defaultTaskService struct {
@@ -217,3 +281,87 @@ func (m *defaultTaskService) GetSubfinderProviders(ctx context.Context, in *GetS
 	client := pb.NewTaskServiceClient(m.cli.Conn())
 	return client.GetSubfinderProviders(ctx, in, opts...)
 }
+
+// 保存爬虫发现的URL
+func (m *defaultTaskService) SaveUrlResult(ctx context.Context, in *SaveUrlResultReq, opts ...grpc.CallOption) (*SaveUrlResultResp, error) {
+	client := pb.NewTaskServiceClient(m.cli.Conn())
+	return client.SaveUrlResult(ctx, in, opts...)
+}
+
+// 获取爆破字典内容
+func (m *defaultTaskService) GetWordlists(ctx context.Context, in *GetWordlistsReq, opts ...grpc.CallOption) (*GetWordlistsResp, error) {
+	client := pb.NewTaskServiceClient(m.cli.Conn())
+	return client.GetWordlists(ctx, in, opts...)
+}
+
+// 保存目录扫描结果
+func (m *defaultTaskService) SaveDirScanResult(ctx context.Context, in *SaveDirScanResultReq, opts ...grpc.CallOption) (*SaveDirScanResultResp, error) {
+	client := pb.NewTaskServiceClient(m.cli.Conn())
+	return client.SaveDirScanResult(ctx, in, opts...)
+}
+
+// 获取敏感信息检测规则
+func (m *defaultTaskService) GetSecretRules(ctx context.Context, in *GetSecretRulesReq, opts ...grpc.CallOption) (*GetSecretRulesResp, error) {
+	client := pb.NewTaskServiceClient(m.cli.Conn())
+	return client.GetSecretRules(ctx, in, opts...)
+}
+
+// 保存敏感信息检测结果
+func (m *defaultTaskService) SaveSecretResult(ctx context.Context, in *SaveSecretResultReq, opts ...grpc.CallOption) (*SaveSecretResultResp, error) {
+	client := pb.NewTaskServiceClient(m.cli.Conn())
+	return client.SaveSecretResult(ctx, in, opts...)
+}
+
+// 保存域名DNS记录
+func (m *defaultTaskService) SaveDnsRecordResult(ctx context.Context, in *SaveDnsRecordResultReq, opts ...grpc.CallOption) (*SaveDnsRecordResultResp, error) {
+	client := pb.NewTaskServiceClient(m.cli.Conn())
+	return client.SaveDnsRecordResult(ctx, in, opts...)
+}
+
+// 子域名监控：与已知子域名比对，返回新增和解析变化的子域名
+func (m *defaultTaskService) SyncSubdomains(ctx context.Context, in *SyncSubdomainsReq, opts ...grpc.CallOption) (*SyncSubdomainsResp, error) {
+	client := pb.NewTaskServiceClient(m.cli.Conn())
+	return client.SyncSubdomains(ctx, in, opts...)
+}
+
+// Worker使用注册令牌换取凭证，无需认证
+func (m *defaultTaskService) EnrollWorker(ctx context.Context, in *EnrollWorkerReq, opts ...grpc.CallOption) (*EnrollWorkerResp, error) {
+	client := pb.NewTaskServiceClient(m.cli.Conn())
+	return client.EnrollWorker(ctx, in, opts...)
+}
+
+// Worker上线：校验凭证并确认使用的名称
+func (m *defaultTaskService) WorkerOnline(ctx context.Context, in *WorkerOnlineReq, opts ...grpc.CallOption) (*WorkerOnlineResp, error) {
+	client := pb.NewTaskServiceClient(m.cli.Conn())
+	return client.WorkerOnline(ctx, in, opts...)
+}
+
+// Worker日志上报（客户端流）
+func (m *defaultTaskService) PushWorkerLogs(ctx context.Context, opts ...grpc.CallOption) (pb.TaskService_PushWorkerLogsClient, error) {
+	client := pb.NewTaskServiceClient(m.cli.Conn())
+	return client.PushWorkerLogs(ctx, opts...)
+}
+
+// Worker会话（双向流）：Worker上报状态，服务端下发控制命令和状态查询
+func (m *defaultTaskService) WorkerSession(ctx context.Context, opts ...grpc.CallOption) (pb.TaskService_WorkerSessionClient, error) {
+	client := pb.NewTaskServiceClient(m.cli.Conn())
+	return client.WorkerSession(ctx, opts...)
+}
+
+// 获取任务控制信号
+func (m *defaultTaskService) GetTaskControl(ctx context.Context, in *GetTaskControlReq, opts ...grpc.CallOption) (*GetTaskControlResp, error) {
+	client := pb.NewTaskServiceClient(m.cli.Conn())
+	return client.GetTaskControl(ctx, in, opts...)
+}
+
+// 更新任务进度
+func (m *defaultTaskService) UpdateTaskProgress(ctx context.Context, in *UpdateTaskProgressReq, opts ...grpc.CallOption) (*UpdateTaskProgressResp, error) {
+	client := pb.NewTaskServiceClient(m.cli.Conn())
+	return client.UpdateTaskProgress(ctx, in, opts...)
+}
+
+// 保存POC验证结果
+func (m *defaultTaskService) SavePocValidationResult(ctx context.Context, in *SavePocValidationResultReq, opts ...grpc.CallOption) (*SavePocValidationResultResp, error) {
+	client := pb.NewTaskServiceClient(m.cli.Conn())
+	return client.SavePocValidationResult(ctx, in, opts...)
+}
@@ -0,0 +1,247 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"cscan/model"
+	"cscan/pkg/rpcauth"
+	"cscan/rpc/task/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// cacheTTL 凭证校验结果的缓存时间，吊销最多延迟这么久生效
+	cacheTTL = 15 * time.Second
+	// lastSeenInterval 记录凭证最后使用时间的最小间隔
+	lastSeenInterval = time.Minute
+)
+
+// publicMethods 无需认证的方法
+var publicMethods = map[string]bool{
+	pb.TaskService_EnrollWorker_FullMethodName: true,
+	grpc_health_v1.Health_Check_FullMethodName: true,
+	grpc_health_v1.Health_Watch_FullMethodName: true,
+}
+
+// serviceMethods 只允许API服务调用的方法，Worker凭证不能调用
+var serviceMethods = map[string]bool{
+	pb.TaskService_NewTask_FullMethodName:                true,
+	pb.TaskService_ValidateFingerprint_FullMethodName:    true,
+	pb.TaskService_ValidatePoc_FullMethodName:            true,
+	pb.TaskService_BatchValidatePoc_FullMethodName:       true,
+	pb.TaskService_GetPocValidationResult_FullMethodName: true,
+}
+
+type ctxKey struct{}
+
+// Identity 调用方身份，Credential为nil时表示API服务
+type Identity struct {
+	Credential *model.WorkerCredential
+
+	token string
+	auth  *WorkerAuthenticator
+}
+
+// IdentityFromContext 取出调用方身份，未开启认证时返回nil
+func IdentityFromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(ctxKey{}).(*Identity)
+	return identity
+}
+
+// WorkerName 确定调用方的Worker名称。未开启认证或API服务调用时使用请求中的名称；
+// Worker凭证只能以绑定的名称调用，请求中没有名称时使用绑定的名称，名称不符时拒绝
+func WorkerName(ctx context.Context, claimed string) (string, error) {
+	identity := IdentityFromContext(ctx)
+	if identity == nil || identity.Credential == nil {
+		return claimed, nil
+	}
+	if claimed == "" || claimed == identity.Credential.WorkerName {
+		return identity.Credential.WorkerName, nil
+	}
+	// Worker刚被重命名时缓存中仍是旧名称，重新读取凭证确认
+	if identity.auth != nil {
+		credential, err := identity.auth.refresh(ctx, identity.token)
+		if err != nil {
+			logx.WithContext(ctx).Errorf("Find worker credential failed: %v", err)
+			return "", status.Error(codes.Unavailable, "verify worker credential failed")
+		}
+		if credential != nil && credential.WorkerName == claimed {
+			return claimed, nil
+		}
+	}
+	logx.WithContext(ctx).Infof("Rejected worker %s: credential is bound to %s, from %s", claimed, identity.Credential.WorkerName, PeerHost(ctx))
+	return "", status.Errorf(codes.PermissionDenied, "worker credential is bound to %s", identity.Credential.WorkerName)
+}
+
+type cacheEntry struct {
+	credential *model.WorkerCredential
+	expire     time.Time
+}
+
+// WorkerAuthenticator 校验Worker凭证和API服务令牌的gRPC拦截器
+type WorkerAuthenticator struct {
+	credentialModel *model.WorkerCredentialModel
+	serviceToken    string
+
+	mu       sync.Mutex
+	cache    map[string]*cacheEntry
+	lastSeen map[string]time.Time // 凭证ID -> 最后记录使用时间
+}
+
+func NewWorkerAuthenticator(credentialModel *model.WorkerCredentialModel, serviceToken string) *WorkerAuthenticator {
+	return &WorkerAuthenticator{
+		credentialModel: credentialModel,
+		serviceToken:    serviceToken,
+		cache:           make(map[string]*cacheEntry),
+		lastSeen:        make(map[string]time.Time),
+	}
+}
+
+// UnaryInterceptor 一元调用认证
+func (a *WorkerAuthenticator) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if publicMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor 流式调用认证
+func (a *WorkerAuthenticator) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if publicMethods[info.FullMethod] {
+		return handler(srv, ss)
+	}
+	ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authStream{ServerStream: ss, ctx: ctx})
+}
+
+func (a *WorkerAuthenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	token := rpcauth.TokenFromContext(ctx)
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing worker credential")
+	}
+
+	if a.serviceToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(a.serviceToken)) == 1 {
+		return context.WithValue(ctx, ctxKey{}, &Identity{}), nil
+	}
+	if !strings.HasPrefix(token, model.WorkerCredentialPrefix) {
+		return nil, status.Error(codes.Unauthenticated, "invalid worker credential")
+	}
+
+	if serviceMethods[method] {
+		return nil, status.Error(codes.PermissionDenied, "method is only available to the api service")
+	}
+
+	credential, err := a.lookup(ctx, token)
+	if err != nil {
+		logx.WithContext(ctx).Errorf("Find worker credential failed: %v", err)
+		return nil, status.Error(codes.Unavailable, "verify worker credential failed")
+	}
+	if credential == nil {
+		logx.WithContext(ctx).Infof("Rejected %s: invalid or revoked worker credential from %s", method, PeerHost(ctx))
+		return nil, status.Error(codes.Unauthenticated, "invalid or revoked worker credential")
+	}
+
+	if a.shouldRecordSeen(credential) {
+		if err := a.credentialModel.UpdateLastSeen(ctx, credential.Id, PeerHost(ctx)); err != nil {
+			logx.WithContext(ctx).Errorf("Update worker credential last seen failed: %v", err)
+		}
+	}
+	return context.WithValue(ctx, ctxKey{}, &Identity{Credential: credential, token: token, auth: a}), nil
+}
+
+// shouldRecordSeen 同一凭证每隔 lastSeenInterval 才记录一次最后使用时间
+func (a *WorkerAuthenticator) shouldRecordSeen(credential *model.WorkerCredential) bool {
+	id := credential.Id.Hex()
+	now := time.Now()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	last, ok := a.lastSeen[id]
+	if !ok && credential.LastSeenTime != nil {
+		last, ok = *credential.LastSeenTime, true
+	}
+	if ok && now.Sub(last) < lastSeenInterval {
+		return false
+	}
+	a.lastSeen[id] = now
+	return true
+}
+
+// lookup 查找凭证，有效凭证缓存一小段时间避免每次调用都查询Mongo
+func (a *WorkerAuthenticator) lookup(ctx context.Context, token string) (*model.WorkerCredential, error) {
+	key := model.HashApiToken(token)
+	now := time.Now()
+
+	a.mu.Lock()
+	entry, ok := a.cache[key]
+	a.mu.Unlock()
+	if ok && now.Before(entry.expire) {
+		return entry.credential, nil
+	}
+
+	credential, err := a.credentialModel.FindActiveByToken(ctx, token)
+	if err != nil || credential == nil {
+		return nil, err
+	}
+
+	// 只缓存有效凭证，避免随机凭证占满缓存
+	a.mu.Lock()
+	for k, e := range a.cache {
+		if now.After(e.expire) {
+			delete(a.cache, k)
+		}
+	}
+	a.cache[key] = &cacheEntry{credential: credential, expire: now.Add(cacheTTL)}
+	a.mu.Unlock()
+	return credential, nil
+}
+
+// refresh 跳过缓存重新读取凭证，凭证有效时更新缓存
+func (a *WorkerAuthenticator) refresh(ctx context.Context, token string) (*model.WorkerCredential, error) {
+	key := model.HashApiToken(token)
+	a.mu.Lock()
+	delete(a.cache, key)
+	a.mu.Unlock()
+	return a.lookup(ctx, token)
+}
+
+// PeerHost 调用方IP
+func PeerHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// authStream 替换流的Context以传递调用方身份
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"cscan/pkg/blob"
+	"cscan/pkg/rpcauth"
+
 	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/zrpc"
 )
@@ -12,4 +15,25 @@ type Config struct {
 		DbName string
 	}
 	RedisConf redis.RedisConf
+	// 离线IP库，用于补全IP地理位置和ASN
+	IPGeo struct {
+		CityDB      string `json:",optional"` // GeoLite2-City.mmdb
+		ASNDB       string `json:",optional"` // GeoLite2-ASN.mmdb
+		Ip2regionDB string `json:",optional"` // ip2region.xdb
+	} `json:",optional"`
+	// 截图、图标和超长响应体的存储后端，需与API服务配置一致
+	Blob blob.Config `json:",optional"`
+	// Worker认证，开启后调用任务服务需携带Worker凭证或服务令牌
+	WorkerAuth struct {
+		Enable       bool   `json:",optional"`
+		ServiceToken string `json:",optional"` // API服务调用任务服务使用的令牌
+	} `json:",optional"`
+	// 传输加密，配置CAFile时要求Worker提供客户端证书(mTLS)
+	TLS rpcauth.TLSConf `json:",optional"`
+	// 任务租约，Worker通过心跳续约，到期未续约的任务重新入队
+	TaskLease struct {
+		TTL          int `json:",default=180"` // 租约有效期（秒），需大于Worker心跳间隔
+		MaxRetry     int `json:",default=3"`   // 重新入队次数上限，超过后进入死信
+		ReapInterval int `json:",default=30"`  // 回收到期租约的间隔（秒）
+	} `json:",optional"`
 }
@@ -0,0 +1,197 @@
+package logic
+
+import (
+	"sort"
+	"strings"
+
+	"cscan/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// assetDiffItem 本批次保存的资产及其上一轮快照
+type assetDiffItem struct {
+	assetId  string
+	asset    *model.Asset        // 本次扫描结果
+	existing *model.Asset        // 保存前的资产，新资产为nil
+	baseline *model.AssetHistory // 上一轮扫描快照
+}
+
+// diffAssets 对比本次结果与上一轮扫描快照，按字段记录资产变更。
+// 同一任务会分阶段多次保存资产（端口扫描、指纹识别等），因此对比基准始终是
+// 上一轮任务的快照而非本任务中间状态，变更记录按 资产+任务+字段 覆盖写入。
+func (l *SaveTaskResultLogic) diffAssets(workspaceId, taskId string, items []assetDiffItem) {
+	if len(items) == 0 || taskId == "" {
+		return
+	}
+
+	changeModel := l.svcCtx.GetAssetChangeModel(workspaceId)
+	historyModel := l.svcCtx.GetAssetHistoryModel(workspaceId)
+
+	seen := make(map[string]bool, len(items))
+	hosts := make(map[string]bool)
+
+	for _, item := range items {
+		seen[item.assetId] = true
+		if item.asset.Port > 0 {
+			hosts[item.asset.Host] = true
+		}
+
+		// 新资产
+		if item.existing == nil {
+			change := &model.AssetChange{
+				AssetId:    item.assetId,
+				Authority:  item.asset.Authority,
+				Host:       item.asset.Host,
+				Port:       item.asset.Port,
+				ChangeType: model.AssetChangeNew,
+				NewValue:   item.asset.Authority,
+				TaskId:     taskId,
+			}
+			if item.asset.Port > 0 {
+				change.ChangeType = model.AssetChangePortOpen
+				change.NewValue = item.asset.Service
+			}
+			if err := changeModel.Upsert(l.ctx, change); err != nil {
+				l.Logger.Errorf("AssetDiff: save change failed, asset=%s, error=%v", item.asset.Authority, err)
+			}
+			continue
+		}
+
+		// 本轮再次出现，撤销之前批次记录的端口消失
+		changeModel.Remove(l.ctx, item.assetId, taskId, model.AssetChangePortClose, "")
+
+		baseline := item.baseline
+		if baseline == nil {
+			if item.existing.TaskId != "" && item.existing.TaskId != taskId {
+				baseline = snapshotOf(item.existing)
+			} else if item.existing.TaskId == taskId {
+				baseline, _ = historyModel.FindLatestBeforeTask(l.ctx, item.assetId, taskId)
+			}
+		}
+		if baseline == nil {
+			continue
+		}
+
+		for _, fc := range compareAsset(baseline, item.asset) {
+			if !fc.changed {
+				changeModel.Remove(l.ctx, item.assetId, taskId, model.AssetChangeModify, fc.field)
+				continue
+			}
+			err := changeModel.Upsert(l.ctx, &model.AssetChange{
+				AssetId:    item.assetId,
+				Authority:  item.asset.Authority,
+				Host:       item.asset.Host,
+				Port:       item.asset.Port,
+				ChangeType: model.AssetChangeModify,
+				Field:      fc.field,
+				OldValue:   fc.oldValue,
+				NewValue:   fc.newValue,
+				TaskId:     taskId,
+				PrevTaskId: baseline.TaskId,
+			})
+			if err != nil {
+				l.Logger.Errorf("AssetDiff: save change failed, asset=%s, field=%s, error=%v", item.asset.Authority, fc.field, err)
+			}
+		}
+	}
+
+	// 端口消失：本次扫描到的主机下，仍停留在上一轮任务且未在本次出现的端口
+	assetModel := l.svcCtx.GetAssetModel(workspaceId)
+	for host := range hosts {
+		assets, err := assetModel.FindPortAssetsByHost(l.ctx, host)
+		if err != nil {
+			continue
+		}
+		for _, a := range assets {
+			assetId := a.Id.Hex()
+			if seen[assetId] || a.TaskId == "" || a.TaskId == taskId {
+				continue
+			}
+			// 同一端口消失只记录一次
+			if n, _ := changeModel.Count(l.ctx, bson.M{"assetId": assetId, "change_type": model.AssetChangePortClose, "prev_task_id": a.TaskId}); n > 0 {
+				continue
+			}
+			err := changeModel.Upsert(l.ctx, &model.AssetChange{
+				AssetId:    assetId,
+				Authority:  a.Authority,
+				Host:       a.Host,
+				Port:       a.Port,
+				ChangeType: model.AssetChangePortClose,
+				OldValue:   a.Service,
+				TaskId:     taskId,
+				PrevTaskId: a.TaskId,
+			})
+			if err != nil {
+				l.Logger.Errorf("AssetDiff: save change failed, asset=%s, error=%v", a.Authority, err)
+			}
+		}
+	}
+}
+
+type fieldChange struct {
+	field    string
+	oldValue string
+	newValue string
+	changed  bool
+}
+
+// compareAsset 逐字段对比，本次结果中为空的字段视为该阶段未采集，不参与对比
+func compareAsset(old *model.AssetHistory, cur *model.Asset) []fieldChange {
+	pairs := []struct {
+		field    string
+		oldValue string
+		newValue string
+	}{
+		{"service", old.Service, cur.Service},
+		{"title", old.Title, cur.Title},
+		{"app", joinSorted(old.App), joinSorted(cur.App)},
+		{"status", old.HttpStatus, cur.HttpStatus},
+		{"server", old.Server, cur.Server},
+		{"banner", old.Banner, cur.Banner},
+		{"icon_hash", old.IconHash, cur.IconHash},
+		{"cert", old.Cert, cur.Cert},
+	}
+
+	changes := make([]fieldChange, 0, len(pairs))
+	for _, p := range pairs {
+		if p.newValue == "" {
+			continue
+		}
+		changes = append(changes, fieldChange{
+			field:    p.field,
+			oldValue: p.oldValue,
+			newValue: p.newValue,
+			changed:  p.oldValue != p.newValue,
+		})
+	}
+	return changes
+}
+
+func snapshotOf(a *model.Asset) *model.AssetHistory {
+	return &model.AssetHistory{
+		AssetId:    a.Id.Hex(),
+		Authority:  a.Authority,
+		Host:       a.Host,
+		Port:       a.Port,
+		Service:    a.Service,
+		Title:      a.Title,
+		App:        a.App,
+		HttpStatus: a.HttpStatus,
+		IconHash:   a.IconHash,
+		Banner:     a.Banner,
+		Server:     a.Server,
+		Cert:       a.Cert,
+		TaskId:     a.TaskId,
+	}
+}
+
+func joinSorted(list []string) string {
+	if len(list) == 0 {
+		return ""
+	}
+	sorted := make([]string, len(list))
+	copy(sorted, list)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
@@ -0,0 +1,47 @@
+package logic
+
+import (
+	"context"
+
+	"cscan/model"
+	"cscan/pkg/blob"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// offloadBlobs 将截图、图标和超长响应体转存到对象存储，Mongo中只保留引用
+// 未配置存储或写入失败时数据仍内联保存，不影响结果入库
+func offloadBlobs(ctx context.Context, store blob.Store, inlineLimit int, asset *model.Asset) {
+	if store == nil {
+		return
+	}
+
+	if asset.Screenshot != "" {
+		if data, err := blob.DecodeScreenshot(asset.Screenshot); err != nil {
+			logx.WithContext(ctx).Errorf("Decode screenshot of %s failed: %v", asset.Authority, err)
+		} else if hash, err := store.Put(ctx, data); err != nil {
+			logx.WithContext(ctx).Errorf("Store screenshot of %s failed: %v", asset.Authority, err)
+		} else {
+			asset.ScreenshotRef = hash
+			asset.Screenshot = ""
+		}
+	}
+
+	if len(asset.IconHashBytes) > 0 {
+		if hash, err := store.Put(ctx, asset.IconHashBytes); err != nil {
+			logx.WithContext(ctx).Errorf("Store icon of %s failed: %v", asset.Authority, err)
+		} else {
+			asset.IconRef = hash
+			asset.IconHashBytes = nil
+		}
+	}
+
+	if len(asset.HttpBody) > inlineLimit {
+		if hash, err := store.Put(ctx, []byte(asset.HttpBody)); err != nil {
+			logx.WithContext(ctx).Errorf("Store body of %s failed: %v", asset.Authority, err)
+		} else {
+			asset.BodyRef = hash
+			asset.HttpBody = blob.TruncateBody(asset.HttpBody, inlineLimit)
+		}
+	}
+}
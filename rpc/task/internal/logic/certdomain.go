@@ -0,0 +1,79 @@
+package logic
+
+import (
+	"strings"
+	"time"
+
+	"cscan/model"
+	"cscan/pkg/utils"
+	"cscan/rpc/task/pb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxCertDomains 单个证书最多提取的SAN域名数，避免CDN共享证书引入大量无关域名
+const maxCertDomains = 100
+
+// certDomainCandidates 从证书SAN中提取候选子域名
+// 资产为域名时只保留同根域名的SAN；资产为IP时保留全部SAN
+func certDomainCandidates(host string, tlsInfo *model.AssetTLS) []string {
+	names := append([]string{tlsInfo.Subject}, tlsInfo.SANs...)
+	hostIsIP := utils.IsIPAddress(host)
+	rootDomain := ""
+	if !hostIsIP {
+		rootDomain = utils.GetRootDomain(strings.ToLower(host))
+	}
+
+	var result []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		// 通配符证书取其父域名
+		name = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(name)), "*.")
+		if name == "" || seen[name] || name == strings.ToLower(host) || !utils.IsValidDomain(name) {
+			continue
+		}
+		seen[name] = true
+		if !hostIsIP && utils.GetRootDomain(name) != rootDomain {
+			continue
+		}
+		result = append(result, name)
+		if len(result) >= maxCertDomains {
+			break
+		}
+	}
+	return result
+}
+
+// saveCertDomains 将证书中发现的新域名保存为域名资产，已存在的域名跳过
+func (l *SaveTaskResultLogic) saveCertDomains(assetModel *model.AssetModel, in *pb.SaveTaskResultReq, domains map[string]bool, now time.Time) []assetDiffItem {
+	var items []assetDiffItem
+	for domain := range domains {
+		count, err := assetModel.Count(l.ctx, bson.M{"host": domain})
+		if err != nil || count > 0 {
+			continue
+		}
+		asset := &model.Asset{
+			Id:         primitive.NewObjectID(),
+			Authority:  domain,
+			Host:       domain,
+			Domain:     domain,
+			Category:   "domain",
+			Source:     "cert",
+			TaskId:     in.MainTaskId,
+			OrgId:      in.OrgId,
+			IsNewAsset: true,
+			CreateTime: now,
+			UpdateTime: now,
+		}
+		if err := assetModel.Insert(l.ctx, asset); err != nil {
+			l.Logger.Errorf("Insert cert domain failed: %v", err)
+			continue
+		}
+		items = append(items, assetDiffItem{assetId: asset.Id.Hex(), asset: asset})
+	}
+	if len(items) > 0 {
+		l.Logger.Infof("SaveTaskResult: %d new domains discovered from certificates", len(items))
+	}
+	return items
+}
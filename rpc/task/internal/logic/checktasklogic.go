@@ -2,9 +2,8 @@ package logic
 
 import (
 	"context"
-	"encoding/json"
-	"strings"
 
+	"cscan/rpc/task/internal/auth"
 	"cscan/rpc/task/internal/svc"
 	"cscan/rpc/task/pb"
 	"cscan/scheduler"
@@ -26,62 +25,38 @@ func NewCheckTaskLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CheckTa
 	}
 }
 
-// 检查任务状态 - 从Redis队列中获取待执行的任务
+// 检查任务状态 - 从Worker专属队列、共享队列和Worker满足的选择器队列中领取待执行的任务
 func (l *CheckTaskLogic) CheckTask(in *pb.CheckTaskReq) (*pb.CheckTaskResp, error) {
-	workerName := in.TaskId // TaskId 实际上是 Worker 名称
-
-	// 从 Redis 队列中获取优先级最高的任务
-	queueKey := "cscan:task:queue"
-	results, err := l.svcCtx.RedisClient.ZRange(l.ctx, queueKey, 0, -1).Result()
+	// TaskId 实际上是 Worker 名称，使用凭证的Worker只能以绑定的名称领取
+	workerName, err := auth.WorkerName(l.ctx, in.TaskId)
 	if err != nil {
-		l.Logger.Errorf("CheckTask: failed to get tasks from queue: %v", err)
-		return &pb.CheckTaskResp{IsExist: false}, nil
+		return nil, err
 	}
 
-	// 遍历队列中的任务，找到可以执行的任务
-	for _, taskData := range results {
-		var task scheduler.TaskInfo
-		if err := json.Unmarshal([]byte(taskData), &task); err != nil {
-			continue
-		}
-
-		// 检查任务是否指定了 Worker
-		if len(task.Workers) > 0 {
-			// 检查当前 Worker 是否在指定列表中
-			found := false
-			for _, w := range task.Workers {
-				if strings.EqualFold(w, workerName) {
-					found = true
-					break
-				}
-			}
-			if !found {
-				continue // 当前 Worker 不在指定列表中，跳过
-			}
-		}
-
-		// 从队列中移除该任务
-		removed, err := l.svcCtx.RedisClient.ZRem(l.ctx, queueKey, taskData).Result()
-		if err != nil || removed == 0 {
-			// 任务可能已被其他 Worker 取走
-			continue
-		}
-
-		// 添加到处理中集合
-		processingKey := "cscan:task:processing"
-		l.svcCtx.RedisClient.SAdd(l.ctx, processingKey, task.TaskId)
-
-		l.Logger.Infof("CheckTask: assigned task %s to worker %s", task.TaskId, workerName)
+	// Worker心跳上报的标签和能力，未上报时只能领取没有要求的任务
+	profile, err := scheduler.LoadWorkerProfile(l.ctx, l.svcCtx.RedisClient, workerName)
+	if err != nil {
+		l.Logger.Errorf("CheckTask: failed to load worker profile, worker=%s: %v", workerName, err)
+	}
 
-		return &pb.CheckTaskResp{
-			IsExist:     true,
-			IsFinished:  false,
-			TaskId:      task.TaskId,
-			WorkspaceId: task.WorkspaceId,
-			Config:      task.Config,
-		}, nil
+	// 领取优先级最高的任务并创建租约，Worker需通过心跳续约
+	task, err := l.svcCtx.TaskLeases.ClaimNext(l.ctx, workerName, profile, l.svcCtx.LeaseTTL())
+	if err != nil {
+		l.Logger.Errorf("CheckTask: failed to claim task: %v", err)
+		return &pb.CheckTaskResp{IsExist: false}, nil
 	}
+	if task == nil {
+		// 没有可执行的任务
+		return &pb.CheckTaskResp{IsExist: false}, nil
+	}
+
+	l.Logger.Infof("CheckTask: assigned task %s to worker %s, retry=%d", task.TaskId, workerName, task.Retry)
 
-	// 没有可执行的任务
-	return &pb.CheckTaskResp{IsExist: false}, nil
+	return &pb.CheckTaskResp{
+		IsExist:     true,
+		IsFinished:  false,
+		TaskId:      task.TaskId,
+		WorkspaceId: task.WorkspaceId,
+		Config:      task.Config,
+	}, nil
 }
@@ -0,0 +1,83 @@
+package logic
+
+import (
+	"context"
+	"strings"
+
+	"cscan/model"
+	"cscan/rpc/task/internal/auth"
+	"cscan/rpc/task/internal/svc"
+	"cscan/rpc/task/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type EnrollWorkerLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewEnrollWorkerLogic(ctx context.Context, svcCtx *svc.ServiceContext) *EnrollWorkerLogic {
+	return &EnrollWorkerLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// Worker使用注册令牌换取凭证，同名Worker的旧凭证会被吊销
+func (l *EnrollWorkerLogic) EnrollWorker(in *pb.EnrollWorkerReq) (*pb.EnrollWorkerResp, error) {
+	workerName := strings.TrimSpace(in.WorkerName)
+	if workerName == "" {
+		return &pb.EnrollWorkerResp{Success: false, Message: "worker name is required"}, nil
+	}
+	if !strings.HasPrefix(in.Token, model.WorkerEnrollTokenPrefix) {
+		return &pb.EnrollWorkerResp{Success: false, Message: "invalid enroll token"}, nil
+	}
+
+	peerIp := auth.PeerHost(l.ctx)
+	enrollToken, err := l.svcCtx.WorkerEnrollTokenModel.Consume(l.ctx, in.Token)
+	if err != nil {
+		l.Logger.Errorf("EnrollWorker: consume enroll token failed: %v", err)
+		return &pb.EnrollWorkerResp{Success: false, Message: "verify enroll token failed"}, nil
+	}
+	if enrollToken == nil {
+		l.Logger.Infof("EnrollWorker: rejected worker %s from %s: invalid, expired or exhausted token", workerName, peerIp)
+		return &pb.EnrollWorkerResp{Success: false, Message: "invalid, expired or exhausted enroll token"}, nil
+	}
+
+	plain, hash, err := model.GenerateWorkerSecret(model.WorkerCredentialPrefix)
+	if err != nil {
+		l.Logger.Errorf("EnrollWorker: generate credential failed: %v", err)
+		return &pb.EnrollWorkerResp{Success: false, Message: "generate credential failed"}, nil
+	}
+
+	if n, err := l.svcCtx.WorkerCredentialModel.RevokeByWorker(l.ctx, workerName); err != nil {
+		l.Logger.Errorf("EnrollWorker: revoke old credentials failed: %v", err)
+	} else if n > 0 {
+		l.Logger.Infof("EnrollWorker: revoked %d old credentials of worker %s", n, workerName)
+	}
+
+	credential := &model.WorkerCredential{
+		WorkerName:    workerName,
+		TokenHash:     hash,
+		Prefix:        plain[:len(model.WorkerCredentialPrefix)+6],
+		EnrollTokenId: enrollToken.Id.Hex(),
+		EnrollIp:      peerIp,
+	}
+	if err := l.svcCtx.WorkerCredentialModel.Insert(l.ctx, credential); err != nil {
+		l.Logger.Errorf("EnrollWorker: insert credential failed: %v", err)
+		return &pb.EnrollWorkerResp{Success: false, Message: "save credential failed"}, nil
+	}
+
+	l.Logger.Infof("EnrollWorker: worker %s (%s) enrolled with token %s", workerName, peerIp, enrollToken.Name)
+
+	return &pb.EnrollWorkerResp{
+		Success:    true,
+		Message:    "enrolled",
+		WorkerId:   credential.Id.Hex(),
+		WorkerName: workerName,
+		Credential: plain,
+	}, nil
+}
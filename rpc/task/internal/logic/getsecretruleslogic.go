@@ -0,0 +1,61 @@
+package logic
+
+import (
+	"context"
+
+	"cscan/rpc/task/internal/svc"
+	"cscan/rpc/task/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type GetSecretRulesLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetSecretRulesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetSecretRulesLogic {
+	return &GetSecretRulesLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 获取敏感信息检测规则
+func (l *GetSecretRulesLogic) GetSecretRules(in *pb.GetSecretRulesReq) (*pb.GetSecretRulesResp, error) {
+	filter := bson.M{}
+	if in.EnabledOnly {
+		filter["enabled"] = true
+	}
+
+	rules, err := l.svcCtx.SecretRuleModel.Find(l.ctx, filter, 0, 0)
+	if err != nil {
+		l.Logger.Errorf("GetSecretRules: find rules failed: %v", err)
+		return &pb.GetSecretRulesResp{
+			Success: false,
+			Message: "获取规则失败: " + err.Error(),
+		}, nil
+	}
+
+	docs := make([]*pb.SecretRuleDocument, 0, len(rules))
+	for _, r := range rules {
+		docs = append(docs, &pb.SecretRuleDocument{
+			Name:       r.Name,
+			Category:   r.Category,
+			Severity:   r.Severity,
+			Pattern:    r.Pattern,
+			MinEntropy: r.MinEntropy,
+			Validator:  r.Validator,
+		})
+	}
+
+	return &pb.GetSecretRulesResp{
+		Success: true,
+		Message: "success",
+		Rules:   docs,
+		Count:   int32(len(docs)),
+	}, nil
+}
@@ -0,0 +1,46 @@
+package logic
+
+import (
+	"context"
+
+	"cscan/rpc/task/internal/svc"
+	"cscan/rpc/task/pb"
+	"cscan/scheduler"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetTaskControlLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetTaskControlLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetTaskControlLogic {
+	return &GetTaskControlLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 获取任务控制信号，子任务同时检查主任务的控制信号
+func (l *GetTaskControlLogic) GetTaskControl(in *pb.GetTaskControlReq) (*pb.GetTaskControlResp, error) {
+	if in.TaskId == "" {
+		return &pb.GetTaskControlResp{}, nil
+	}
+
+	ctrl, err := l.svcCtx.RedisClient.Get(l.ctx, "cscan:task:ctrl:"+in.TaskId).Result()
+	if err == nil && ctrl != "" {
+		return &pb.GetTaskControlResp{Control: ctrl}, nil
+	}
+
+	if mainTaskId := scheduler.ParentTaskId(in.TaskId); mainTaskId != in.TaskId {
+		ctrl, err = l.svcCtx.RedisClient.Get(l.ctx, "cscan:task:ctrl:"+mainTaskId).Result()
+		if err == nil && ctrl != "" {
+			return &pb.GetTaskControlResp{Control: ctrl}, nil
+		}
+	}
+
+	return &pb.GetTaskControlResp{}, nil
+}
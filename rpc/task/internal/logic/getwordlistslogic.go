@@ -0,0 +1,71 @@
+package logic
+
+import (
+	"context"
+
+	"cscan/rpc/task/internal/svc"
+	"cscan/rpc/task/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetWordlistsLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetWordlistsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetWordlistsLogic {
+	return &GetWordlistsLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 获取爆破字典内容，多个字典按顺序合并去重
+func (l *GetWordlistsLogic) GetWordlists(in *pb.GetWordlistsReq) (*pb.GetWordlistsResp, error) {
+	if len(in.Ids) == 0 {
+		return &pb.GetWordlistsResp{
+			Success: true,
+			Message: "No wordlist ids",
+		}, nil
+	}
+
+	wordlists, err := l.svcCtx.WordlistModel.FindEnabledByIds(l.ctx, in.Ids, in.Type)
+	if err != nil {
+		l.Logger.Errorf("FindEnabledByIds for wordlists failed: %v", err)
+		return &pb.GetWordlistsResp{
+			Success: false,
+			Message: "获取字典失败: " + err.Error(),
+		}, nil
+	}
+
+	// 按请求中的顺序合并
+	order := make(map[string]int, len(in.Ids))
+	for i, id := range in.Ids {
+		order[id] = i
+	}
+	merged := make([][]string, len(in.Ids))
+	for _, wl := range wordlists {
+		merged[order[wl.Id.Hex()]] = wl.Words
+	}
+
+	seen := make(map[string]bool)
+	var words []string
+	for _, list := range merged {
+		for _, w := range list {
+			if !seen[w] {
+				seen[w] = true
+				words = append(words, w)
+			}
+		}
+	}
+
+	return &pb.GetWordlistsResp{
+		Success: true,
+		Message: "success",
+		Words:   words,
+		Count:   int32(len(words)),
+	}, nil
+}
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"time"
 
+	"cscan/rpc/task/internal/auth"
 	"cscan/rpc/task/internal/svc"
 	"cscan/rpc/task/pb"
+	"cscan/scheduler"
 
 	"github.com/zeromicro/go-zero/core/logx"
 )
@@ -27,7 +29,10 @@ func NewKeepAliveLogic(ctx context.Context, svcCtx *svc.ServiceContext) *KeepAli
 
 // Worker心跳
 func (l *KeepAliveLogic) KeepAlive(in *pb.KeepAliveReq) (*pb.KeepAliveResp, error) {
-	workerName := in.WorkerName
+	workerName, err := auth.WorkerName(l.ctx, in.WorkerName)
+	if err != nil {
+		return nil, err
+	}
 
 	// 更新Worker状态到Redis
 	workerKey := "cscan:worker:" + workerName
@@ -69,5 +74,21 @@ func (l *KeepAliveLogic) KeepAlive(in *pb.KeepAliveReq) (*pb.KeepAliveResp, erro
 		l.svcCtx.RedisClient.Del(l.ctx, controlKey)
 	}
 
+	// 保存Worker的标签和能力，领取任务时按任务的选择器匹配
+	profile := &scheduler.WorkerProfile{Labels: in.Labels, Capabilities: in.Capabilities}
+	if err := scheduler.SaveWorkerProfile(l.ctx, l.svcCtx.RedisClient, workerName, profile); err != nil {
+		l.Logger.Errorf("KeepAlive: save worker profile failed, worker=%s: %v", workerName, err)
+	}
+
+	// 续约Worker已领取的任务，告知Worker哪些任务已被回收
+	lost, err := l.svcCtx.TaskLeases.Renew(l.ctx, workerName, in.TaskIds, l.svcCtx.LeaseTTL())
+	if err != nil {
+		l.Logger.Errorf("KeepAlive: renew task leases failed, worker=%s: %v", workerName, err)
+	}
+	if len(lost) > 0 {
+		l.Logger.Infof("KeepAlive: worker %s lost task leases: %v", workerName, lost)
+	}
+	resp.LostTaskIds = lost
+
 	return &resp, nil
 }
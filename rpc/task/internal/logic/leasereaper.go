@@ -0,0 +1,122 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cscan/rpc/task/internal/svc"
+	"cscan/rpc/task/pb"
+	"cscan/scheduler"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// reapBatchSize 每轮最多回收的租约数
+const reapBatchSize = 100
+
+// LeaseReaper 定期回收到期的任务租约：Worker失联或重启后，其领取的任务重新入队，
+// 重试次数用尽的任务标记失败并进入死信
+type LeaseReaper struct {
+	svcCtx *svc.ServiceContext
+	stop   chan struct{}
+	done   chan struct{}
+	logx.Logger
+}
+
+func NewLeaseReaper(svcCtx *svc.ServiceContext) *LeaseReaper {
+	return &LeaseReaper{
+		svcCtx: svcCtx,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+		Logger: logx.WithContext(context.Background()),
+	}
+}
+
+// Start 启动回收
+func (r *LeaseReaper) Start() {
+	interval := time.Duration(r.svcCtx.Config.TaskLease.ReapInterval) * time.Second
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	go func() {
+		defer close(r.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+				r.Reap(context.Background())
+			}
+		}
+	}()
+}
+
+// Stop 停止回收
+func (r *LeaseReaper) Stop() {
+	close(r.stop)
+	<-r.done
+}
+
+// Reap 回收一轮到期的租约
+func (r *LeaseReaper) Reap(ctx context.Context) {
+	leases := r.svcCtx.TaskLeases
+	now := time.Now()
+	taskIds, err := leases.Expired(ctx, now, reapBatchSize)
+	if err != nil {
+		r.Logger.Errorf("LeaseReaper: list expired leases failed: %v", err)
+		return
+	}
+	for _, taskId := range taskIds {
+		lease, err := leases.Reclaim(ctx, taskId, now)
+		if err != nil {
+			r.Logger.Errorf("LeaseReaper: reclaim lease failed, taskId=%s: %v", taskId, err)
+			continue
+		}
+		if lease == nil {
+			// 已续约或已被其他实例回收
+			continue
+		}
+		r.handleExpired(ctx, lease)
+	}
+}
+
+func (r *LeaseReaper) handleExpired(ctx context.Context, lease *scheduler.Lease) {
+	leases := r.svcCtx.TaskLeases
+
+	// 已停止或暂停的任务不再重新入队
+	ctrl, _ := NewGetTaskControlLogic(ctx, r.svcCtx).GetTaskControl(&pb.GetTaskControlReq{TaskId: lease.TaskId})
+	if ctrl != nil && (ctrl.Control == "STOP" || ctrl.Control == "PAUSE") {
+		if err := leases.Drop(ctx, lease); err != nil {
+			r.Logger.Errorf("LeaseReaper: drop lease failed, taskId=%s: %v", lease.TaskId, err)
+		}
+		r.Logger.Infof("LeaseReaper: dropped expired lease of %s task %s, worker=%s", ctrl.Control, lease.TaskId, lease.Worker)
+		return
+	}
+
+	maxRetry := r.svcCtx.Config.TaskLease.MaxRetry
+	if lease.Retry < maxRetry {
+		if err := leases.Requeue(ctx, lease); err != nil {
+			r.Logger.Errorf("LeaseReaper: requeue task failed, taskId=%s: %v", lease.TaskId, err)
+			return
+		}
+		r.Logger.Infof("LeaseReaper: lease of task %s expired, worker=%s, requeued (retry %d/%d)", lease.TaskId, lease.Worker, lease.Retry+1, maxRetry)
+		return
+	}
+
+	// 重试次数用尽，以原持有者身份结束任务，主任务的子任务计数和状态照常更新
+	reason := fmt.Sprintf("租约到期，已重试%d次，任务进入死信", lease.Retry)
+	NewUpdateTaskLogic(ctx, r.svcCtx).UpdateTask(&pb.UpdateTaskReq{
+		TaskId: lease.TaskId,
+		State:  scheduler.TaskStatusFailure,
+		Worker: lease.Worker,
+		Result: reason,
+	})
+	if err := leases.DeadLetter(ctx, lease, reason); err != nil {
+		r.Logger.Errorf("LeaseReaper: save dead letter failed, taskId=%s: %v", lease.TaskId, err)
+		return
+	}
+	r.Logger.Errorf("LeaseReaper: task %s moved to dead letter after %d retries, last worker=%s", lease.TaskId, lease.Retry, lease.Worker)
+}
@@ -2,14 +2,11 @@ package logic
 
 import (
 	"context"
-	"encoding/json"
-	"time"
 
 	"cscan/rpc/task/internal/svc"
 	"cscan/rpc/task/pb"
 	"cscan/scheduler"
 
-	"github.com/redis/go-redis/v9"
 	"github.com/zeromicro/go-zero/core/logx"
 )
 
@@ -38,33 +35,16 @@ func (l *NewTaskLogic) NewTask(in *pb.NewTaskReq) (*pb.NewTaskResp, error) {
 	}
 
 	// 创建任务信息
-	taskInfo := scheduler.TaskInfo{
+	taskInfo := &scheduler.TaskInfo{
 		TaskId:      taskId,
 		MainTaskId:  in.MainTaskId,
 		TaskName:    in.TaskName,
 		Config:      in.Config,
 		WorkspaceId: in.WorkspaceId,
-		CreateTime:  time.Now().Local().Format("2006-01-02 15:04:05"),
 	}
 
-	// 序列化任务信息
-	taskJson, err := json.Marshal(taskInfo)
-	if err != nil {
-		l.Logger.Errorf("NewTask: failed to marshal task: %v", err)
-		return &pb.NewTaskResp{
-			Success: false,
-			Message: "序列化任务失败: " + err.Error(),
-		}, nil
-	}
-
-	// 添加到任务队列（使用时间戳作为分数，实现FIFO）
-	queueKey := "cscan:task:queue"
-	score := float64(time.Now().UnixNano())
-	err = l.svcCtx.RedisClient.ZAdd(l.ctx, queueKey, redis.Z{
-		Score:  score,
-		Member: string(taskJson),
-	}).Err()
-	if err != nil {
+	// 添加到任务队列，按配置中的Worker和选择器放入对应队列
+	if err := l.svcCtx.Scheduler.PushTask(l.ctx, taskInfo); err != nil {
 		l.Logger.Errorf("NewTask: failed to add task to queue: %v", err)
 		return &pb.NewTaskResp{
 			Success: false,
@@ -0,0 +1,111 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"cscan/rpc/task/internal/auth"
+	"cscan/rpc/task/internal/svc"
+	"cscan/rpc/task/pb"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// Worker日志的Redis Key，API服务从这里读取
+const (
+	workerLogStream      = "cscan:worker:logs"
+	workerLogChannel     = "cscan:worker:logs:realtime"
+	taskLogStreamPrefix  = "cscan:task:logs:"
+	taskLogChannelPrefix = "cscan:task:logs:realtime:"
+	workerMaxLogLen      = 10000
+	taskMaxLogLen        = 5000
+)
+
+// workerLogEntry 写入Redis的日志格式，与API服务读取的格式一致
+type workerLogEntry struct {
+	Timestamp  string `json:"timestamp"`
+	Level      string `json:"level"`
+	WorkerName string `json:"workerName"`
+	TaskId     string `json:"taskId,omitempty"`
+	Message    string `json:"message"`
+}
+
+type PushWorkerLogsLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewPushWorkerLogsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *PushWorkerLogsLogic {
+	return &PushWorkerLogsLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// Worker日志上报，逐条写入全局日志流，任务日志同时写入任务专属日志流
+func (l *PushWorkerLogsLogic) PushWorkerLogs(stream pb.TaskService_PushWorkerLogsServer) error {
+	var count int32
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&pb.PushWorkerLogsResp{
+				Success: true,
+				Message: "success",
+				Count:   count,
+			})
+		}
+		if err != nil {
+			return err
+		}
+		// 使用凭证的Worker只能以绑定的名称上报日志
+		if in.WorkerName, err = auth.WorkerName(l.ctx, in.WorkerName); err != nil {
+			return err
+		}
+		if err := l.publish(in); err != nil {
+			l.Logger.Errorf("Publish worker log failed: %v", err)
+			continue
+		}
+		count++
+	}
+}
+
+func (l *PushWorkerLogsLogic) publish(in *pb.WorkerLogEntry) error {
+	entry := workerLogEntry{
+		Timestamp:  in.Timestamp,
+		Level:      in.Level,
+		WorkerName: in.WorkerName,
+		TaskId:     in.TaskId,
+		Message:    in.Message,
+	}
+	if entry.Timestamp == "" {
+		entry.Timestamp = time.Now().Local().Format("2006-01-02 15:04:05")
+	}
+	data, _ := json.Marshal(entry)
+
+	pipe := l.svcCtx.RedisClient.Pipeline()
+	// 全局 Pub/Sub 和 Stream（Worker 日志实时推送和历史查询）
+	pipe.Publish(l.ctx, workerLogChannel, string(data))
+	pipe.XAdd(l.ctx, &redis.XAddArgs{
+		Stream: workerLogStream,
+		MaxLen: workerMaxLogLen,
+		Approx: true,
+		Values: map[string]interface{}{"data": string(data)},
+	})
+	// 任务专属 Stream 和 Pub/Sub
+	if entry.TaskId != "" {
+		pipe.XAdd(l.ctx, &redis.XAddArgs{
+			Stream: taskLogStreamPrefix + entry.TaskId,
+			MaxLen: taskMaxLogLen,
+			Approx: true,
+			Values: map[string]interface{}{"data": string(data)},
+		})
+		pipe.Publish(l.ctx, taskLogChannelPrefix+entry.TaskId, string(data))
+	}
+	_, err := pipe.Exec(l.ctx)
+	return err
+}
@@ -0,0 +1,83 @@
+package logic
+
+import (
+	"context"
+
+	"cscan/model"
+	"cscan/rpc/task/internal/svc"
+	"cscan/rpc/task/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SaveDirScanResultLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewSaveDirScanResultLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SaveDirScanResultLogic {
+	return &SaveDirScanResultLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 保存目录扫描结果
+func (l *SaveDirScanResultLogic) SaveDirScanResult(in *pb.SaveDirScanResultReq) (*pb.SaveDirScanResultResp, error) {
+	if len(in.Results) == 0 {
+		return &pb.SaveDirScanResultResp{
+			Success: true,
+			Message: "No results to save",
+			Total:   0,
+		}, nil
+	}
+
+	workspaceId := in.WorkspaceId
+	if workspaceId == "" {
+		workspaceId = "default"
+	}
+	// 使用Worker凭证时只能写入自己持有的任务
+	if err := authorizeTaskResult(l.ctx, l.svcCtx, in.TaskId, workspaceId, in.MainTaskId); err != nil {
+		return nil, err
+	}
+
+	docs := make([]*model.DirScanResult, 0, len(in.Results))
+	for _, r := range in.Results {
+		if r.Authority == "" || r.Path == "" {
+			continue
+		}
+		docs = append(docs, &model.DirScanResult{
+			Authority:   r.Authority,
+			Host:        r.Host,
+			Port:        int(r.Port),
+			Url:         r.Url,
+			Path:        r.Path,
+			Status:      int(r.Status),
+			Length:      int(r.Length),
+			Title:       r.Title,
+			ContentType: r.ContentType,
+			Location:    r.Location,
+			TaskId:      in.MainTaskId,
+		})
+	}
+
+	newCount, err := l.svcCtx.GetDirScanModel(workspaceId).BulkUpsert(l.ctx, docs)
+	if err != nil {
+		l.Logger.Errorf("SaveDirScanResult: bulk upsert failed: %v", err)
+		return &pb.SaveDirScanResultResp{
+			Success: false,
+			Message: "Failed to save results: " + err.Error(),
+		}, nil
+	}
+
+	l.Logger.Infof("SaveDirScanResult: saved %d paths, %d new", len(docs), newCount)
+
+	return &pb.SaveDirScanResultResp{
+		Success:  true,
+		Message:  "Results saved successfully",
+		Total:    int32(len(docs)),
+		NewCount: int32(newCount),
+	}, nil
+}
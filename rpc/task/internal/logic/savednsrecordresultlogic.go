@@ -0,0 +1,87 @@
+package logic
+
+import (
+	"context"
+
+	"cscan/model"
+	"cscan/rpc/task/internal/svc"
+	"cscan/rpc/task/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SaveDnsRecordResultLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewSaveDnsRecordResultLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SaveDnsRecordResultLogic {
+	return &SaveDnsRecordResultLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 保存域名DNS记录
+func (l *SaveDnsRecordResultLogic) SaveDnsRecordResult(in *pb.SaveDnsRecordResultReq) (*pb.SaveDnsRecordResultResp, error) {
+	if len(in.Domains) == 0 {
+		return &pb.SaveDnsRecordResultResp{
+			Success: true,
+			Message: "No records to save",
+			Total:   0,
+		}, nil
+	}
+
+	workspaceId := in.WorkspaceId
+	if workspaceId == "" {
+		workspaceId = "default"
+	}
+	// 使用Worker凭证时只能写入自己持有的任务
+	if err := authorizeTaskResult(l.ctx, l.svcCtx, in.TaskId, workspaceId, in.MainTaskId); err != nil {
+		return nil, err
+	}
+
+	docs := make([]*model.DNSRecord, 0, len(in.Domains))
+	for _, d := range in.Domains {
+		if d.Domain == "" {
+			continue
+		}
+		doc := &model.DNSRecord{
+			Domain:       d.Domain,
+			Zone:         d.Zone,
+			Records:      make([]model.DNSRecordItem, 0, len(d.Records)),
+			SPF:          d.Spf,
+			DMARC:        d.Dmarc,
+			ZoneTransfer: d.ZoneTransfer,
+			Issues:       make([]model.DNSIssue, 0, len(d.Issues)),
+			TaskId:       in.MainTaskId,
+		}
+		for _, r := range d.Records {
+			doc.Records = append(doc.Records, model.DNSRecordItem{Type: r.Type, Name: r.Name, Value: r.Value, TTL: r.Ttl})
+		}
+		for _, i := range d.Issues {
+			doc.Issues = append(doc.Issues, model.DNSIssue{Type: i.Type, Severity: i.Severity, Detail: i.Detail})
+		}
+		docs = append(docs, doc)
+	}
+
+	newCount, err := l.svcCtx.GetDNSRecordModel(workspaceId).BulkUpsert(l.ctx, docs)
+	if err != nil {
+		l.Logger.Errorf("SaveDnsRecordResult: bulk upsert failed: %v", err)
+		return &pb.SaveDnsRecordResultResp{
+			Success: false,
+			Message: "Failed to save records: " + err.Error(),
+		}, nil
+	}
+
+	l.Logger.Infof("SaveDnsRecordResult: saved %d domains, %d new", len(docs), newCount)
+
+	return &pb.SaveDnsRecordResultResp{
+		Success:  true,
+		Message:  "Records saved successfully",
+		Total:    int32(len(docs)),
+		NewCount: int32(newCount),
+	}, nil
+}
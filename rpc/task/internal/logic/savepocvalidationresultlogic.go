@@ -0,0 +1,76 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"cscan/rpc/task/internal/svc"
+	"cscan/rpc/task/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SavePocValidationResultLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewSavePocValidationResultLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SavePocValidationResultLogic {
+	return &SavePocValidationResultLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 保存POC验证结果，供 GetPocValidationResult 查询，并更新任务信息中的状态
+func (l *SavePocValidationResultLogic) SavePocValidationResult(in *pb.SavePocValidationResultReq) (*pb.SavePocValidationResultResp, error) {
+	if in.TaskId == "" {
+		return &pb.SavePocValidationResultResp{Success: false, Message: "taskId不能为空"}, nil
+	}
+	// 使用Worker凭证时只能上报自己持有的任务
+	if _, err := authorizeTask(l.ctx, l.svcCtx, in.TaskId); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Local().Format("2006-01-02 15:04:05")
+	status := "SUCCESS"
+	resultData := map[string]interface{}{
+		"taskId":     in.TaskId,
+		"batchId":    in.BatchId,
+		"status":     status,
+		"results":    in.Results,
+		"updateTime": now,
+	}
+	if in.Error != "" {
+		status = "FAILURE"
+		resultData["status"] = status
+		resultData["error"] = in.Error
+	}
+
+	resultJson, err := json.Marshal(resultData)
+	if err != nil {
+		return &pb.SavePocValidationResultResp{Success: false, Message: err.Error()}, nil
+	}
+	if err := l.svcCtx.RedisClient.Set(l.ctx, "cscan:task:result:"+in.TaskId, resultJson, 24*time.Hour).Err(); err != nil {
+		l.Logger.Errorf("SavePocValidationResult: save result failed, taskId=%s, error=%v", in.TaskId, err)
+		return &pb.SavePocValidationResultResp{Success: false, Message: err.Error()}, nil
+	}
+
+	// 更新任务信息状态
+	taskInfoKey := "cscan:task:info:" + in.TaskId
+	taskInfoData, err := l.svcCtx.RedisClient.Get(l.ctx, taskInfoKey).Result()
+	if err == nil && taskInfoData != "" {
+		var taskInfo map[string]string
+		if json.Unmarshal([]byte(taskInfoData), &taskInfo) == nil {
+			taskInfo["status"] = status
+			taskInfo["updateTime"] = now
+			updatedInfo, _ := json.Marshal(taskInfo)
+			l.svcCtx.RedisClient.Set(l.ctx, taskInfoKey, updatedInfo, 24*time.Hour)
+		}
+	}
+
+	return &pb.SavePocValidationResultResp{Success: true, Message: "success"}, nil
+}
@@ -0,0 +1,83 @@
+package logic
+
+import (
+	"context"
+
+	"cscan/model"
+	"cscan/rpc/task/internal/svc"
+	"cscan/rpc/task/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SaveSecretResultLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewSaveSecretResultLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SaveSecretResultLogic {
+	return &SaveSecretResultLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 保存敏感信息检测结果
+func (l *SaveSecretResultLogic) SaveSecretResult(in *pb.SaveSecretResultReq) (*pb.SaveSecretResultResp, error) {
+	if len(in.Findings) == 0 {
+		return &pb.SaveSecretResultResp{
+			Success: true,
+			Message: "No findings to save",
+			Total:   0,
+		}, nil
+	}
+
+	workspaceId := in.WorkspaceId
+	if workspaceId == "" {
+		workspaceId = "default"
+	}
+	// 使用Worker凭证时只能写入自己持有的任务
+	if err := authorizeTaskResult(l.ctx, l.svcCtx, in.TaskId, workspaceId, in.MainTaskId); err != nil {
+		return nil, err
+	}
+
+	docs := make([]*model.SecretFinding, 0, len(in.Findings))
+	for _, f := range in.Findings {
+		if f.Url == "" || f.Rule == "" || f.Hash == "" {
+			continue
+		}
+		docs = append(docs, &model.SecretFinding{
+			Authority: f.Authority,
+			Host:      f.Host,
+			Port:      int(f.Port),
+			Url:       f.Url,
+			Location:  f.Location,
+			Rule:      f.Rule,
+			Category:  f.Category,
+			Severity:  f.Severity,
+			Evidence:  f.Evidence,
+			Hash:      f.Hash,
+			TaskId:    in.MainTaskId,
+		})
+	}
+
+	newCount, err := l.svcCtx.GetSecretFindingModel(workspaceId).BulkUpsert(l.ctx, docs)
+	if err != nil {
+		l.Logger.Errorf("SaveSecretResult: bulk upsert failed: %v", err)
+		return &pb.SaveSecretResultResp{
+			Success: false,
+			Message: "Failed to save findings: " + err.Error(),
+		}, nil
+	}
+
+	l.Logger.Infof("SaveSecretResult: saved %d findings, %d new", len(docs), newCount)
+
+	return &pb.SaveSecretResultResp{
+		Success:  true,
+		Message:  "Findings saved successfully",
+		Total:    int32(len(docs)),
+		NewCount: int32(newCount),
+	}, nil
+}
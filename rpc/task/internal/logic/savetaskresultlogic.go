@@ -2,6 +2,10 @@ package logic
 
 import (
 	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net"
+	"strings"
 	"time"
 
 	"cscan/model"
@@ -40,34 +44,43 @@ func (l *SaveTaskResultLogic) SaveTaskResult(in *pb.SaveTaskResultReq) (*pb.Save
 	if workspaceId == "" {
 		workspaceId = "default"
 	}
+	// 使用Worker凭证时只能写入自己持有的任务
+	if err := authorizeTaskResult(l.ctx, l.svcCtx, in.TaskId, workspaceId, in.MainTaskId); err != nil {
+		return nil, err
+	}
 
 	assetModel := l.svcCtx.GetAssetModel(workspaceId)
 
 	var totalAsset, newAsset, updateAsset int32
+	var diffItems []assetDiffItem
+	certDomains := make(map[string]bool)
 	now := time.Now()
 
 	for _, pbAsset := range in.Assets {
 		// 转换为model.Asset
 		asset := &model.Asset{
-			Authority:     pbAsset.Authority,
-			Host:          pbAsset.Host,
-			Port:          int(pbAsset.Port),
-			Category:      pbAsset.Category,
-			Service:       pbAsset.Service,
-			Title:         pbAsset.Title,
-			App:           pbAsset.App,
-			HttpStatus:    pbAsset.HttpStatus,
-			HttpHeader:    pbAsset.HttpHeader,
-			HttpBody:      pbAsset.HttpBody,
-			IconHash:      pbAsset.IconHash,
-			IconHashBytes: pbAsset.IconData,
-			Screenshot:    pbAsset.Screenshot,
-			Server:        pbAsset.Server,
-			Banner:        pbAsset.Banner,
-			IsHTTP:        pbAsset.IsHttp,
-			TaskId:        in.MainTaskId,
-			Source:        pbAsset.Source,
-			OrgId:         in.OrgId,
+			Authority:      pbAsset.Authority,
+			Host:           pbAsset.Host,
+			Port:           int(pbAsset.Port),
+			Category:       pbAsset.Category,
+			Service:        pbAsset.Service,
+			Title:          pbAsset.Title,
+			App:            pbAsset.App,
+			HttpStatus:     pbAsset.HttpStatus,
+			HttpHeader:     pbAsset.HttpHeader,
+			HttpBody:       pbAsset.HttpBody,
+			IconHash:       pbAsset.IconHash,
+			IconHashBytes:  pbAsset.IconData,
+			Screenshot:     pbAsset.Screenshot,
+			ScreenshotHash: pbAsset.ScreenshotHash,
+			Server:         pbAsset.Server,
+			Banner:         pbAsset.Banner,
+			IsHTTP:         pbAsset.IsHttp,
+			IsCDN:          pbAsset.IsCdn,
+			IsCloud:        pbAsset.IsCloud,
+			TaskId:         in.MainTaskId,
+			Source:         pbAsset.Source,
+			OrgId:          in.OrgId,
 		}
 
 		// 如果Source为空，设置默认值
@@ -93,11 +106,36 @@ func (l *SaveTaskResultLogic) SaveTaskResult(in *pb.SaveTaskResultReq) (*pb.Save
 			}
 		}
 
+		// Host本身为IP且未携带IP信息时补充
+		if len(asset.Ip.IpV4) == 0 && len(asset.Ip.IpV6) == 0 && utils.IsIPAddress(asset.Host) {
+			if strings.Contains(asset.Host, ":") {
+				asset.Ip.IpV6 = append(asset.Ip.IpV6, model.IPV6{IPName: asset.Host})
+			} else {
+				asset.Ip.IpV4 = append(asset.Ip.IpV4, model.IPV4{IPName: asset.Host})
+			}
+		}
+		l.enrichIP(&asset.Ip)
+
+		// 处理TLS证书，cert字段为JSON格式的证书信息，入库时保存叶子证书指纹
+		if pbAsset.Cert != "" {
+			var tlsInfo model.AssetTLS
+			if err := json.Unmarshal([]byte(pbAsset.Cert), &tlsInfo); err == nil && tlsInfo.SHA256 != "" {
+				asset.TLS = &tlsInfo
+				asset.Cert = tlsInfo.SHA256
+				for _, domain := range certDomainCandidates(asset.Host, &tlsInfo) {
+					certDomains[domain] = true
+				}
+			}
+		}
+
 		// 处理CName
 		if pbAsset.Cname != "" {
 			asset.CName = pbAsset.Cname
 		}
 
+		// 截图、图标和超长响应体转存到对象存储
+		offloadBlobs(l.ctx, l.svcCtx.Blob, l.svcCtx.Config.Blob.InlineLimit(), asset)
+
 		// 设置Domain字段 - 如果Host不是IP地址，则设置为Domain
 		if asset.Category == "domain" || !utils.IsIPAddress(asset.Host) {
 			asset.Domain = asset.Host
@@ -128,9 +166,11 @@ func (l *SaveTaskResultLogic) SaveTaskResult(in *pb.SaveTaskResultReq) (*pb.Save
 				continue
 			}
 			newAsset++
+			diffItems = append(diffItems, assetDiffItem{assetId: asset.Id.Hex(), asset: asset})
 		} else {
 			// 更新已存在的资产
 			// 只有当任务ID不同时才保存历史记录（表示是新一轮扫描，需要记录上一次的状态）
+			var baseline *model.AssetHistory
 			if existing.TaskId != "" && existing.TaskId != in.MainTaskId {
 				historyModel := l.svcCtx.GetAssetHistoryModel(workspaceId)
 				
@@ -139,52 +179,64 @@ func (l *SaveTaskResultLogic) SaveTaskResult(in *pb.SaveTaskResultReq) (*pb.Save
 				if !exists {
 					// 保存上一次扫描的状态作为历史记录
 					history := &model.AssetHistory{
-						AssetId:    existing.Id.Hex(),
-						Authority:  existing.Authority,
-						Host:       existing.Host,
-						Port:       existing.Port,
-						Service:    existing.Service,
-						Title:      existing.Title,
-						App:        existing.App,
-						HttpStatus: existing.HttpStatus,
-						HttpHeader: existing.HttpHeader,
-						HttpBody:   existing.HttpBody,
-						IconHash:   existing.IconHash,
-						Screenshot: existing.Screenshot,
-						Banner:     existing.Banner,
-						TaskId:     existing.TaskId, // 使用旧的任务ID
-						CreateTime: existing.UpdateTime, // 使用旧的更新时间
+						AssetId:        existing.Id.Hex(),
+						Authority:      existing.Authority,
+						Host:           existing.Host,
+						Port:           existing.Port,
+						Service:        existing.Service,
+						Title:          existing.Title,
+						App:            existing.App,
+						HttpStatus:     existing.HttpStatus,
+						HttpHeader:     existing.HttpHeader,
+						HttpBody:       existing.HttpBody,
+						BodyRef:        existing.BodyRef,
+						IconHash:       existing.IconHash,
+						Screenshot:     existing.Screenshot,
+						ScreenshotRef:  existing.ScreenshotRef,
+						ScreenshotHash: existing.ScreenshotHash,
+						Banner:         existing.Banner,
+						Server:         existing.Server,
+						Cert:           existing.Cert,
+						TaskId:         existing.TaskId,     // 使用旧的任务ID
+						CreateTime:     existing.UpdateTime, // 使用旧的更新时间
 					}
 					if err := historyModel.Insert(l.ctx, history); err != nil {
 						l.Logger.Errorf("Insert asset history failed: %v", err)
 						// 继续更新资产，不中断
 					}
+					baseline = history
 				}
 			}
 
 			// 更新资产
 			updateFields := map[string]interface{}{
-				"authority":   asset.Authority,
-				"service":     asset.Service,
-				"title":       asset.Title,
-				"app":         asset.App,
-				"status":      asset.HttpStatus,
-				"header":      asset.HttpHeader,
-				"body":        asset.HttpBody,
-				"icon_hash":   asset.IconHash,
-				"screenshot":  asset.Screenshot,
-				"server":      asset.Server,
-				"banner":      asset.Banner,
-				"is_http":     asset.IsHTTP,
-				"taskId":      asset.TaskId,
-				"update_time": now,
-				"update":      true,
-				"new":         false,
+				"authority":       asset.Authority,
+				"service":         asset.Service,
+				"title":           asset.Title,
+				"app":             asset.App,
+				"status":          asset.HttpStatus,
+				"header":          asset.HttpHeader,
+				"body":            asset.HttpBody,
+				"body_ref":        asset.BodyRef,
+				"icon_hash":       asset.IconHash,
+				"screenshot":      asset.Screenshot,
+				"screenshot_ref":  asset.ScreenshotRef,
+				"screenshot_hash": asset.ScreenshotHash,
+				"server":          asset.Server,
+				"banner":          asset.Banner,
+				"is_http":         asset.IsHTTP,
+				"taskId":          asset.TaskId,
+				"update_time":     now,
+				"update":          true,
+				"new":             false,
 			}
 
 			// 更新 IconData
 			if len(asset.IconHashBytes) > 0 {
 				updateFields["icon_hash_bytes"] = asset.IconHashBytes
+			} else if asset.IconRef != "" {
+				updateFields["icon_ref"] = asset.IconRef
+				updateFields["icon_hash_bytes"] = nil
 			}
 
 			// 更新IP信息
@@ -192,11 +244,25 @@ func (l *SaveTaskResultLogic) SaveTaskResult(in *pb.SaveTaskResultReq) (*pb.Save
 				updateFields["ip"] = asset.Ip
 			}
 
+			// 更新证书信息
+			if asset.TLS != nil {
+				updateFields["tls"] = asset.TLS
+				updateFields["cert"] = asset.Cert
+			}
+
 			// 更新CName
 			if asset.CName != "" {
 				updateFields["cname"] = asset.CName
 			}
 
+			// 更新CDN/云标记（仅在本次检测命中时更新，避免未检测的阶段覆盖）
+			if asset.IsCDN {
+				updateFields["cdn"] = true
+			}
+			if asset.IsCloud {
+				updateFields["cloud"] = true
+			}
+
 			// 更新Domain
 			if asset.Domain != "" {
 				updateFields["domain"] = asset.Domain
@@ -222,12 +288,24 @@ func (l *SaveTaskResultLogic) SaveTaskResult(in *pb.SaveTaskResultReq) (*pb.Save
 				continue
 			}
 			updateAsset++
+			diffItems = append(diffItems, assetDiffItem{assetId: existing.Id.Hex(), asset: asset, existing: existing, baseline: baseline})
 		}
 		totalAsset++
 	}
 
+	// 证书SAN中发现的域名作为新的子域名加入工作空间
+	if len(certDomains) > 0 {
+		items := l.saveCertDomains(assetModel, in, certDomains, now)
+		newAsset += int32(len(items))
+		totalAsset += int32(len(items))
+		diffItems = append(diffItems, items...)
+	}
+
 	l.Logger.Infof("SaveTaskResult: total=%d, new=%d, update=%d", totalAsset, newAsset, updateAsset)
 
+	// 与上一轮扫描结果对比，记录资产变更
+	l.diffAssets(workspaceId, in.MainTaskId, diffItems)
+
 	return &pb.SaveTaskResultResp{
 		Success:     true,
 		Message:     "Assets saved successfully",
@@ -236,3 +314,34 @@ func (l *SaveTaskResultLogic) SaveTaskResult(in *pb.SaveTaskResultReq) (*pb.Save
 		UpdateAsset: updateAsset,
 	}, nil
 }
+
+// enrichIP 使用离线IP库补全地理位置、ISP和ASN信息
+func (l *SaveTaskResultLogic) enrichIP(ip *model.IP) {
+	for i := range ip.IpV4 {
+		v4 := &ip.IpV4[i]
+		if parsed := net.ParseIP(v4.IPName).To4(); parsed != nil {
+			v4.IPInt = binary.BigEndian.Uint32(parsed)
+		}
+		info := l.svcCtx.IPGeo.Lookup(v4.IPName)
+		if info == nil {
+			continue
+		}
+		v4.Country, v4.Region, v4.City, v4.ISP = info.Country, info.Region, info.City, info.ISP
+		v4.ASN, v4.Org = int(info.ASN), info.Org
+		if v4.Location == "" {
+			v4.Location = info.Location()
+		}
+	}
+	for i := range ip.IpV6 {
+		v6 := &ip.IpV6[i]
+		info := l.svcCtx.IPGeo.Lookup(v6.IPName)
+		if info == nil {
+			continue
+		}
+		v6.Country, v6.Region, v6.City, v6.ISP = info.Country, info.Region, info.City, info.ISP
+		v6.ASN, v6.Org = int(info.ASN), info.Org
+		if v6.Location == "" {
+			v6.Location = info.Location()
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package logic
+
+import (
+	"context"
+
+	"cscan/model"
+	"cscan/rpc/task/internal/svc"
+	"cscan/rpc/task/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SaveUrlResultLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewSaveUrlResultLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SaveUrlResultLogic {
+	return &SaveUrlResultLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 保存爬虫发现的URL
+func (l *SaveUrlResultLogic) SaveUrlResult(in *pb.SaveUrlResultReq) (*pb.SaveUrlResultResp, error) {
+	if len(in.Urls) == 0 {
+		return &pb.SaveUrlResultResp{
+			Success: true,
+			Message: "No urls to save",
+			Total:   0,
+		}, nil
+	}
+
+	workspaceId := in.WorkspaceId
+	if workspaceId == "" {
+		workspaceId = "default"
+	}
+	// 使用Worker凭证时只能写入自己持有的任务
+	if err := authorizeTaskResult(l.ctx, l.svcCtx, in.TaskId, workspaceId, in.MainTaskId); err != nil {
+		return nil, err
+	}
+
+	docs := make([]*model.Url, 0, len(in.Urls))
+	for _, pbUrl := range in.Urls {
+		if pbUrl.Endpoint == "" {
+			continue
+		}
+		method := pbUrl.Method
+		if method == "" {
+			method = "GET"
+		}
+		docs = append(docs, &model.Url{
+			Authority:   pbUrl.Authority,
+			Host:        pbUrl.Host,
+			Port:        int(pbUrl.Port),
+			Url:         pbUrl.Url,
+			Endpoint:    pbUrl.Endpoint,
+			Method:      method,
+			Path:        pbUrl.Path,
+			Params:      pbUrl.Params,
+			Source:      pbUrl.Source,
+			StatusCode:  int(pbUrl.StatusCode),
+			ContentType: pbUrl.ContentType,
+			Length:      int(pbUrl.Length),
+			Title:       pbUrl.Title,
+			Referer:     pbUrl.Referer,
+			TaskId:      in.MainTaskId,
+		})
+	}
+
+	newCount, err := l.svcCtx.GetUrlModel(workspaceId).BulkUpsert(l.ctx, docs)
+	if err != nil {
+		l.Logger.Errorf("SaveUrlResult: bulk upsert failed: %v", err)
+		return &pb.SaveUrlResultResp{
+			Success: false,
+			Message: "Failed to save urls: " + err.Error(),
+		}, nil
+	}
+
+	l.Logger.Infof("SaveUrlResult: saved %d urls, %d new", len(docs), newCount)
+
+	return &pb.SaveUrlResultResp{
+		Success:  true,
+		Message:  "Urls saved successfully",
+		Total:    int32(len(docs)),
+		NewCount: int32(newCount),
+	}, nil
+}
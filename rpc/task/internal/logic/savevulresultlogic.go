@@ -38,6 +38,10 @@ func (l *SaveVulResultLogic) SaveVulResult(in *pb.SaveVulResultReq) (*pb.SaveVul
 	if workspaceId == "" {
 		workspaceId = "default"
 	}
+	// 使用Worker凭证时只能写入自己持有的任务
+	if err := authorizeTaskResult(l.ctx, l.svcCtx, in.TaskId, workspaceId, in.MainTaskId); err != nil {
+		return nil, err
+	}
 
 	vulModel := l.svcCtx.GetVulModel(workspaceId)
 	var savedCount int32
@@ -0,0 +1,122 @@
+package logic
+
+import (
+	"context"
+	"strings"
+
+	"cscan/model"
+	"cscan/pkg/utils"
+	"cscan/rpc/task/internal/svc"
+	"cscan/rpc/task/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SyncSubdomainsLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewSyncSubdomainsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SyncSubdomainsLogic {
+	return &SyncSubdomainsLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 子域名监控：与已知子域名比对，返回新增和解析变化的子域名；confirm 时确认扫描完成的子域名
+func (l *SyncSubdomainsLogic) SyncSubdomains(in *pb.SyncSubdomainsReq) (*pb.SyncSubdomainsResp, error) {
+	if len(in.Subdomains) == 0 {
+		return &pb.SyncSubdomainsResp{
+			Success: true,
+			Message: "No subdomains to sync",
+		}, nil
+	}
+
+	workspaceId := in.WorkspaceId
+	if workspaceId == "" {
+		workspaceId = "default"
+	}
+	// 使用Worker凭证时只能写入自己持有的任务
+	if err := authorizeTaskResult(l.ctx, l.svcCtx, in.TaskId, workspaceId, in.MainTaskId); err != nil {
+		return nil, err
+	}
+
+	if in.Confirm {
+		return l.confirm(in, workspaceId)
+	}
+
+	docs := make([]*model.Subdomain, 0, len(in.Subdomains))
+	for _, s := range in.Subdomains {
+		domain := strings.ToLower(strings.TrimSuffix(s.Domain, "."))
+		if domain == "" {
+			continue
+		}
+		docs = append(docs, &model.Subdomain{
+			Domain:     domain,
+			RootDomain: utils.GetRootDomain(domain),
+			IPs:        s.Ips,
+			CName:      s.Cname,
+		})
+	}
+
+	status, err := l.svcCtx.GetSubdomainModel(workspaceId).Sync(l.ctx, docs, in.MainTaskId)
+	if err != nil {
+		l.Logger.Errorf("SyncSubdomains: sync failed: %v", err)
+		return &pb.SyncSubdomainsResp{
+			Success: false,
+			Message: "Failed to sync subdomains: " + err.Error(),
+		}, nil
+	}
+
+	resp := &pb.SyncSubdomainsResp{
+		Success: true,
+		Message: "Subdomains synced successfully",
+		Total:   int32(len(status)),
+	}
+	for domain, st := range status {
+		switch st {
+		case model.SubdomainStatusNew:
+			resp.NewDomains = append(resp.NewDomains, domain)
+		case model.SubdomainStatusChanged:
+			resp.ChangedDomains = append(resp.ChangedDomains, domain)
+		}
+	}
+
+	l.Logger.Infof("SyncSubdomains: %d subdomains, %d new, %d changed", resp.Total, len(resp.NewDomains), len(resp.ChangedDomains))
+
+	return resp, nil
+}
+
+// confirm 扫描完成后将待确认的子域名转为已知，确认的新增子域名记录到主任务
+func (l *SyncSubdomainsLogic) confirm(in *pb.SyncSubdomainsReq, workspaceId string) (*pb.SyncSubdomainsResp, error) {
+	domains := make([]string, 0, len(in.Subdomains))
+	for _, s := range in.Subdomains {
+		if domain := strings.ToLower(strings.TrimSuffix(s.Domain, ".")); domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+
+	newDomains, err := l.svcCtx.GetSubdomainModel(workspaceId).Confirm(l.ctx, domains, in.MainTaskId)
+	if err != nil {
+		l.Logger.Errorf("SyncSubdomains: confirm failed: %v", err)
+		return &pb.SyncSubdomainsResp{
+			Success: false,
+			Message: "Failed to confirm subdomains: " + err.Error(),
+		}, nil
+	}
+	if err := l.svcCtx.GetMainTaskModel(workspaceId).AddNewSubdomains(l.ctx, in.MainTaskId, newDomains); err != nil {
+		l.Logger.Errorf("SyncSubdomains: record new subdomains for task %s failed: %v", in.MainTaskId, err)
+	}
+
+	l.Logger.Infof("SyncSubdomains: confirmed %d subdomains, %d new", len(domains), len(newDomains))
+
+	return &pb.SyncSubdomainsResp{
+		Success:    true,
+		Message:    "Subdomains confirmed successfully",
+		Total:      int32(len(domains)),
+		NewDomains: newDomains,
+	}, nil
+}
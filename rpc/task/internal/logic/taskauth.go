@@ -0,0 +1,70 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+
+	"cscan/rpc/task/internal/auth"
+	"cscan/rpc/task/internal/svc"
+	"cscan/scheduler"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// authorizeTask 校验使用Worker凭证的调用方持有任务的租约，返回租约中的任务；
+// 未开启认证或API服务调用时不校验，返回nil
+func authorizeTask(ctx context.Context, svcCtx *svc.ServiceContext, taskId string) (*scheduler.TaskInfo, error) {
+	identity := auth.IdentityFromContext(ctx)
+	if identity == nil || identity.Credential == nil {
+		return nil, nil
+	}
+	if taskId == "" {
+		return nil, status.Error(codes.PermissionDenied, "task id is required")
+	}
+
+	lease, err := svcCtx.TaskLeases.Get(ctx, taskId)
+	if err != nil {
+		logx.WithContext(ctx).Errorf("Get lease of task %s failed: %v", taskId, err)
+		return nil, status.Error(codes.Unavailable, "verify task lease failed")
+	}
+	if lease == nil || lease.Worker == "" {
+		return nil, status.Errorf(codes.PermissionDenied, "task %s is not leased", taskId)
+	}
+	// 名称不符时 WorkerName 会重新读取凭证，兼容刚被重命名的Worker
+	if _, err := auth.WorkerName(ctx, lease.Worker); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "task %s is leased to another worker", taskId)
+	}
+
+	var task scheduler.TaskInfo
+	if err := json.Unmarshal([]byte(lease.Task), &task); err != nil {
+		logx.WithContext(ctx).Errorf("Parse leased task %s failed: %v", taskId, err)
+		return nil, status.Error(codes.Internal, "invalid task lease")
+	}
+	return &task, nil
+}
+
+// authorizeTaskResult 校验Worker写入结果的工作空间和主任务属于其持有的任务
+func authorizeTaskResult(ctx context.Context, svcCtx *svc.ServiceContext, taskId, workspaceId, mainTaskId string) error {
+	task, err := authorizeTask(ctx, svcCtx, taskId)
+	if err != nil || task == nil {
+		return err
+	}
+	if defaultWorkspace(workspaceId) != defaultWorkspace(task.WorkspaceId) {
+		return status.Errorf(codes.PermissionDenied, "workspace %s does not match task %s", workspaceId, taskId)
+	}
+	// POC验证任务以自身的任务ID作为主任务ID
+	if mainTaskId != task.MainTaskId && mainTaskId != task.TaskId {
+		return status.Errorf(codes.PermissionDenied, "main task %s does not match task %s", mainTaskId, taskId)
+	}
+	return nil
+}
+
+// defaultWorkspace 未指定工作空间时使用 default
+func defaultWorkspace(workspaceId string) string {
+	if workspaceId == "" {
+		return "default"
+	}
+	return workspaceId
+}
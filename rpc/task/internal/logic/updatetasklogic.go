@@ -3,9 +3,14 @@ package logic
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 
+	"cscan/model"
+	"cscan/pkg/notify"
+	"cscan/pkg/risk"
+	"cscan/rpc/task/internal/auth"
 	"cscan/rpc/task/internal/svc"
 	"cscan/rpc/task/pb"
 	"cscan/scheduler"
@@ -33,8 +38,24 @@ func (l *UpdateTaskLogic) UpdateTask(in *pb.UpdateTaskReq) (*pb.UpdateTaskResp,
 	taskId := in.TaskId
 	state := in.State
 
+	// 使用凭证的Worker只能以绑定的名称上报，避免释放其他Worker的租约
+	worker, err := auth.WorkerName(l.ctx, in.Worker)
+	if err != nil {
+		return nil, err
+	}
+	in.Worker = worker
+
 	l.Logger.Infof("UpdateTask: taskId=%s, state=%s", taskId, state)
 
+	// 租约已被回收的任务可能已重新分配，忽略原Worker的迟到上报，避免重复计数
+	if stale, reason := l.checkLease(taskId, state, in.Worker); stale {
+		l.Logger.Infof("UpdateTask: ignore stale update, taskId=%s, state=%s, worker=%s: %s", taskId, state, in.Worker, reason)
+		return &pb.UpdateTaskResp{
+			Success: false,
+			Message: reason,
+		}, nil
+	}
+
 	// 从处理中集合移除
 	processingKey := "cscan:task:processing"
 	l.svcCtx.RedisClient.SRem(l.ctx, processingKey, taskId)
@@ -69,6 +90,38 @@ func (l *UpdateTaskLogic) UpdateTask(in *pb.UpdateTaskReq) (*pb.UpdateTaskResp,
 	}, nil
 }
 
+// checkLease 校验上报的Worker是否仍持有任务租约，任务结束时释放租约；
+// 没有租约记录的任务（升级前分配的任务）不做限制
+func (l *UpdateTaskLogic) checkLease(taskId, state, worker string) (bool, string) {
+	leases := l.svcCtx.TaskLeases
+	switch state {
+	case "STARTED":
+		holder, exists, err := leases.Holder(l.ctx, taskId)
+		if err != nil {
+			l.Logger.Errorf("UpdateTask: get lease failed, taskId=%s: %v", taskId, err)
+			return false, ""
+		}
+		if exists && holder != worker {
+			return true, "task lease is held by another worker"
+		}
+	case "SUCCESS", "FAILURE", "COMPLETED", "STOPPED", "PAUSED":
+		released, err := leases.Release(l.ctx, taskId, worker)
+		if err != nil {
+			l.Logger.Errorf("UpdateTask: release lease failed, taskId=%s: %v", taskId, err)
+			return false, ""
+		}
+		if released < 0 {
+			return true, "task lease is held by another worker"
+		}
+		if released == 0 {
+			if dead, _ := leases.IsDeadLetter(l.ctx, taskId); dead {
+				return true, "task has been moved to dead letter"
+			}
+		}
+	}
+	return false, ""
+}
+
 // updateTaskInDB 更新数据库中的任务状态
 func (l *UpdateTaskLogic) updateTaskInDB(taskId, state, result string) {
 	// 从Redis获取任务信息（workspaceId）
@@ -134,43 +187,33 @@ func (l *UpdateTaskLogic) updateTaskInDB(taskId, state, result string) {
 		// 任务开始时设置开始时间
 		update["start_time"] = now
 		update["progress"] = 10 // 开始时进度设为10%
-	case "SUCCESS", "COMPLETED":
+	case "SUCCESS", "COMPLETED", "FAILURE":
 		if isSubTask {
-			// 子任务完成，递增 sub_task_done
-			// 使用 $inc 操作符，mainTaskId 是 MongoDB ObjectID
-			if err := taskModel.IncrSubTaskDone(l.ctx, mainTaskId); err != nil {
+			// 子任务结束（成功或失败），递增 sub_task_done，失败时同时递增 sub_task_failed
+			// 使用 $inc 操作符并返回更新后的主任务，mainTaskId 是 MongoDB ObjectID
+			task, err := taskModel.IncrSubTaskDone(l.ctx, mainTaskId, state == "FAILURE")
+			if err != nil {
 				l.Logger.Errorf("UpdateTask: failed to incr sub_task_done, mainTaskId=%s, error=%v", mainTaskId, err)
-			} else {
-				l.Logger.Infof("UpdateTask: sub_task_done incremented, mainTaskId=%s, taskId=%s", mainTaskId, taskId)
+				return
 			}
-			// 检查是否所有子任务都完成了
-			// 使用 FindById 而不是 FindByTaskId，因为 mainTaskId 是 MongoDB ObjectID
-			task, err := taskModel.FindById(l.ctx, mainTaskId)
-			if err == nil && task != nil && task.SubTaskDone+1 >= task.SubTaskCount {
-				// 所有子任务完成，更新主任务状态
-				update["end_time"] = now
-				update["progress"] = 100
-				update["result"] = result
-				l.Logger.Infof("UpdateTask: all sub-tasks completed, mainTaskId=%s, done=%d, total=%d", mainTaskId, task.SubTaskDone+1, task.SubTaskCount)
-			} else {
+			if task.SubTaskDone < task.SubTaskCount {
 				// 还有子任务未完成，只更新 sub_task_done，不更新主任务状态
-				if err != nil {
-					l.Logger.Errorf("UpdateTask: failed to find task by id, mainTaskId=%s, error=%v", mainTaskId, err)
-				} else if task != nil {
-					l.Logger.Infof("UpdateTask: sub-task completed, mainTaskId=%s, done=%d, total=%d", mainTaskId, task.SubTaskDone+1, task.SubTaskCount)
-				}
+				l.Logger.Infof("UpdateTask: sub-task %s finished with %s, mainTaskId=%s, done=%d, failed=%d, total=%d", taskId, state, mainTaskId, task.SubTaskDone, task.SubTaskFailed, task.SubTaskCount)
 				return
 			}
-		} else {
-			// 单任务或最后一个子任务完成
-			update["end_time"] = now
-			update["progress"] = 100
-			update["result"] = result
+			// 所有子任务结束，有子任务失败时主任务为失败
+			if task.SubTaskFailed > 0 {
+				state = "FAILURE"
+				update["status"] = state
+			}
+			l.Logger.Infof("UpdateTask: all sub-tasks finished, mainTaskId=%s, failed=%d, total=%d", mainTaskId, task.SubTaskFailed, task.SubTaskCount)
 		}
-	case "FAILURE":
-		// 任务失败时设置结束时间
+		// 单任务或最后一个子任务结束
 		update["end_time"] = now
 		update["result"] = result
+		if state != "FAILURE" {
+			update["progress"] = 100
+		}
 	case "STOPPED":
 		// 任务停止时设置结束时间
 		update["end_time"] = now
@@ -183,6 +226,152 @@ func (l *UpdateTaskLogic) updateTaskInDB(taskId, state, result string) {
 			l.Logger.Errorf("UpdateTask: failed to update task in DB, mainTaskId=%s, error=%v", mainTaskId, err)
 		} else {
 			l.Logger.Infof("UpdateTask: task updated in DB, mainTaskId=%s, state=%s", mainTaskId, state)
+
+			// 主任务结束（单任务结束或全部子任务结束）时发送通知
+			if _, finished := update["end_time"]; finished && state != "STOPPED" {
+				go l.sendTaskNotify(workspaceId, mainTaskId, state, result)
+			}
 		}
 	}
 }
+
+// sendTaskNotify 根据主任务关联的通知渠道发送任务结果通知
+func (l *UpdateTaskLogic) sendTaskNotify(workspaceId, mainTaskId, state, result string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	task, err := l.svcCtx.GetMainTaskModel(workspaceId).FindById(ctx, mainTaskId)
+	if err != nil || task.NotifyId == "" {
+		return
+	}
+
+	cfg, err := l.svcCtx.NotifyConfigModel.FindById(ctx, task.NotifyId)
+	if err != nil {
+		logx.Errorf("TaskNotify: notify config not found, notifyId=%s, error=%v", task.NotifyId, err)
+		return
+	}
+	if cfg.Status != model.StatusEnable {
+		return
+	}
+
+	// 统计本次任务的资产和漏洞（资产/漏洞中的任务ID为主任务UUID）
+	assetCount, _ := l.svcCtx.GetAssetModel(workspaceId).Count(ctx, bson.M{"taskId": task.TaskId})
+	vulModel := l.svcCtx.GetVulModel(workspaceId)
+	vulCount, _ := vulModel.Count(ctx, bson.M{"task_id": task.TaskId})
+
+	var severeVulCount int64
+	if cfg.MinSeverity != "" {
+		severeVulCount, _ = vulModel.Count(ctx, bson.M{
+			"task_id":  task.TaskId,
+			"severity": bson.M{"$in": severitiesAtLeast(cfg.MinSeverity)},
+		})
+	}
+
+	changeCount, _ := l.svcCtx.GetAssetChangeModel(workspaceId).Count(ctx, bson.M{"taskId": task.TaskId})
+
+	status := scheduler.TaskStatusSuccess
+	if state == "FAILURE" {
+		status = scheduler.TaskStatusFailure
+	}
+
+	// 判断是否命中订阅的事件
+	hit := (status == scheduler.TaskStatusSuccess && cfg.HasEvent(model.NotifyEventSuccess)) ||
+		(status == scheduler.TaskStatusFailure && cfg.HasEvent(model.NotifyEventFailure))
+	if cfg.HasEvent(model.NotifyEventVul) && cfg.MinSeverity != "" && severeVulCount > 0 {
+		hit = true
+	}
+	if cfg.HasEvent(model.NotifyEventChange) && changeCount > 0 {
+		hit = true
+	}
+	if !hit {
+		return
+	}
+
+	// 同一次执行只通知一次，定时任务每次执行的开始时间不同；
+	// 发送前原子占用，并发结束的子任务只有一个能发送，发送失败也不再重试
+	notifiedKey := "cscan:task:notified:" + mainTaskId
+	if task.StartTime != nil {
+		notifiedKey += fmt.Sprintf(":%d", task.StartTime.Unix())
+	}
+	if ok, err := l.svcCtx.RedisClient.SetNX(ctx, notifiedKey, status, 24*time.Hour).Result(); err != nil || !ok {
+		return
+	}
+
+	var duration int64
+	if task.StartTime != nil {
+		duration = int64(time.Since(*task.StartTime).Seconds())
+	}
+
+	data := &notify.TemplateData{
+		TaskResult: scheduler.TaskResult{
+			TaskId:     task.TaskId,
+			Status:     status,
+			Message:    result,
+			AssetCount: int(assetCount),
+			VulCount:   int(vulCount),
+			Duration:   duration,
+		},
+		TaskName:       task.Name,
+		Target:         task.Target,
+		WorkspaceId:    workspaceId,
+		MinSeverity:    cfg.MinSeverity,
+		SevereVulCount: int(severeVulCount),
+		ChangeCount:    int(changeCount),
+		FinishTime:     time.Now().Local().Format("2006-01-02 15:04:05"),
+	}
+
+	content, err := notify.Render(cfg.Template, data)
+	if err != nil {
+		logx.Errorf("TaskNotify: render template failed, notifyId=%s, error=%v", cfg.Id.Hex(), err)
+		return
+	}
+
+	notifier, err := notify.New(toNotifyConfig(cfg))
+	if err != nil {
+		logx.Errorf("TaskNotify: invalid notify config, notifyId=%s, error=%v", cfg.Id.Hex(), err)
+		return
+	}
+
+	title := fmt.Sprintf("[CSCAN] 任务%s: %s", statusText(status), task.Name)
+	if err := notifier.Send(ctx, &notify.Message{Title: title, Content: content, Data: data}); err != nil {
+		logx.Errorf("TaskNotify: send failed, type=%s, mainTaskId=%s, error=%v", cfg.Type, mainTaskId, err)
+		return
+	}
+	logx.Infof("TaskNotify: sent, type=%s, mainTaskId=%s, status=%s", cfg.Type, mainTaskId, status)
+}
+
+// severitiesAtLeast 返回不低于指定等级的漏洞等级列表
+func severitiesAtLeast(minSeverity string) []string {
+	minWeight, ok := risk.SeverityWeight[strings.ToLower(minSeverity)]
+	if !ok {
+		return []string{minSeverity}
+	}
+	var levels []string
+	for level, weight := range risk.SeverityWeight {
+		if weight >= minWeight {
+			levels = append(levels, level)
+		}
+	}
+	return levels
+}
+
+func statusText(status string) string {
+	if status == scheduler.TaskStatusFailure {
+		return "失败"
+	}
+	return "完成"
+}
+
+func toNotifyConfig(cfg *model.NotifyConfig) *notify.Config {
+	return &notify.Config{
+		Type:       cfg.Type,
+		WebhookUrl: cfg.WebhookUrl,
+		Secret:     cfg.Secret,
+		SmtpHost:   cfg.SmtpHost,
+		SmtpPort:   cfg.SmtpPort,
+		SmtpUser:   cfg.SmtpUser,
+		SmtpPass:   cfg.SmtpPass,
+		SmtpFrom:   cfg.SmtpFrom,
+		SmtpTo:     cfg.SmtpTo,
+	}
+}
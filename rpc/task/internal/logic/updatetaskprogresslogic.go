@@ -0,0 +1,61 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"cscan/rpc/task/internal/svc"
+	"cscan/rpc/task/pb"
+	"cscan/scheduler"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UpdateTaskProgressLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewUpdateTaskProgressLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateTaskProgressLogic {
+	return &UpdateTaskProgressLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 更新任务进度：每个子任务独立保存进度，同时更新主任务的当前阶段
+func (l *UpdateTaskProgressLogic) UpdateTaskProgress(in *pb.UpdateTaskProgressReq) (*pb.UpdateTaskProgressResp, error) {
+	if in.TaskId == "" {
+		return &pb.UpdateTaskProgressResp{Success: false, Message: "taskId不能为空"}, nil
+	}
+	// 使用Worker凭证时只能上报自己持有的任务
+	if _, err := authorizeTask(l.ctx, l.svcCtx, in.TaskId); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Local().Format("2006-01-02 15:04:05")
+	subData, _ := json.Marshal(map[string]interface{}{
+		"taskId":       in.TaskId,
+		"progress":     in.Progress,
+		"message":      in.Message,
+		"currentPhase": in.CurrentPhase,
+		"updateTime":   now,
+	})
+	mainData, _ := json.Marshal(map[string]interface{}{
+		"currentPhase": in.CurrentPhase,
+		"updateTime":   now,
+	})
+
+	pipe := l.svcCtx.RedisClient.Pipeline()
+	pipe.Set(l.ctx, "cscan:task:progress:sub:"+in.TaskId, subData, 30*time.Minute)
+	pipe.Set(l.ctx, "cscan:task:progress:"+scheduler.ParentTaskId(in.TaskId), mainData, 30*time.Minute)
+	if _, err := pipe.Exec(l.ctx); err != nil {
+		l.Logger.Errorf("UpdateTaskProgress: save progress failed, taskId=%s, error=%v", in.TaskId, err)
+		return &pb.UpdateTaskProgressResp{Success: false, Message: err.Error()}, nil
+	}
+
+	return &pb.UpdateTaskProgressResp{Success: true, Message: "success"}, nil
+}
@@ -10,7 +10,6 @@ import (
 	"cscan/scheduler"
 
 	"github.com/google/uuid"
-	"github.com/redis/go-redis/v9"
 	"github.com/zeromicro/go-zero/core/logx"
 )
 
@@ -79,31 +78,14 @@ func (l *ValidatePocLogic) ValidatePoc(in *pb.ValidatePocReq) (*pb.ValidatePocRe
 		Priority:    2, // 高优先级
 	}
 
-	// 推送任务到队列（使用 Sorted Set，时间戳作为分数实现 FIFO）
-	taskJson, _ := json.Marshal(task)
-	queueKey := "cscan:task:queue"
-	score := float64(time.Now().UnixNano())
-	err := l.svcCtx.RedisClient.ZAdd(l.ctx, queueKey, redis.Z{
-		Score:  score,
-		Member: taskJson,
-	}).Err()
-	if err != nil {
-		// 如果是类型错误，尝试删除旧 key 后重试
-		if err.Error() == "WRONGTYPE Operation against a key holding the wrong kind of value" {
-			l.svcCtx.RedisClient.Del(l.ctx, queueKey)
-			err = l.svcCtx.RedisClient.ZAdd(l.ctx, queueKey, redis.Z{
-				Score:  score,
-				Member: taskJson,
-			}).Err()
-		}
-		if err != nil {
-			l.Logger.Errorf("ValidatePoc: failed to push task to queue, error=%v", err)
-			return &pb.ValidatePocResp{
-				Success: false,
-				Message: "任务入队失败: " + err.Error(),
-				Matched: false,
-			}, nil
-		}
+	// 推送任务到队列，与其他任务使用相同的队列结构
+	if err := l.svcCtx.Scheduler.PushTask(l.ctx, task); err != nil {
+		l.Logger.Errorf("ValidatePoc: failed to push task to queue, error=%v", err)
+		return &pb.ValidatePocResp{
+			Success: false,
+			Message: "任务入队失败: " + err.Error(),
+			Matched: false,
+		}, nil
 	}
 
 	// 保存任务信息到Redis（用于结果查询）
@@ -0,0 +1,79 @@
+package logic
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"cscan/rpc/task/internal/auth"
+	"cscan/rpc/task/internal/svc"
+	"cscan/rpc/task/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type WorkerOnlineLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewWorkerOnlineLogic(ctx context.Context, svcCtx *svc.ServiceContext) *WorkerOnlineLogic {
+	return &WorkerOnlineLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// Worker上线：已注册的Worker使用凭证绑定的名称，其他Worker与在线Worker重名时自动改名
+func (l *WorkerOnlineLogic) WorkerOnline(in *pb.WorkerOnlineReq) (*pb.WorkerOnlineResp, error) {
+	if identity := auth.IdentityFromContext(l.ctx); identity != nil && identity.Credential != nil {
+		return &pb.WorkerOnlineResp{
+			Success:    true,
+			Message:    "success",
+			WorkerName: identity.Credential.WorkerName,
+		}, nil
+	}
+
+	if in.WorkerName == "" {
+		return &pb.WorkerOnlineResp{Success: false, Message: "workerName不能为空"}, nil
+	}
+
+	name := in.WorkerName
+	if l.nameInUse(name) {
+		name = ""
+		for i := 0; i < 100; i++ {
+			candidate := fmt.Sprintf("%s-%s", in.WorkerName, randomHex(2))
+			if !l.nameInUse(candidate) {
+				name = candidate
+				break
+			}
+		}
+		// 极端情况：100次都冲突，使用时间戳
+		if name == "" {
+			name = fmt.Sprintf("%s-%d", in.WorkerName, time.Now().UnixNano())
+		}
+		l.Logger.Infof("Worker name conflict detected, renamed from '%s' to '%s' (ip=%s)", in.WorkerName, name, in.Ip)
+	}
+
+	return &pb.WorkerOnlineResp{
+		Success:    true,
+		Message:    "success",
+		WorkerName: name,
+	}, nil
+}
+
+// nameInUse 是否有同名Worker在线（会话心跳未过期）
+func (l *WorkerOnlineLogic) nameInUse(name string) bool {
+	exists, err := l.svcCtx.RedisClient.Exists(l.ctx, workerHeartbeatKeyPrefix+name).Result()
+	return err == nil && exists > 0
+}
+
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,186 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"cscan/rpc/task/internal/auth"
+	"cscan/rpc/task/internal/svc"
+	"cscan/rpc/task/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// API服务发布控制命令和状态查询的频道
+	workerControlChannel = "cscan:worker:control"
+	workerQueryChannel   = "cscan:worker:query"
+	// Worker状态，API服务列出Worker时读取
+	workerStatusKeyPrefix = "worker:"
+	workerStatusTTL       = 2 * time.Minute // 离线Worker会更快从列表消失
+	// 会话心跳，用于判断同名Worker是否在线
+	workerHeartbeatKeyPrefix = "cscan:worker:heartbeat:"
+	workerHeartbeatTTL       = 90 * time.Second
+	workerHeartbeatInterval  = 30 * time.Second
+)
+
+type WorkerSessionLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewWorkerSessionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *WorkerSessionLogic {
+	return &WorkerSessionLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// Worker会话：保存Worker上报的状态，把API服务发布的控制命令和状态查询转发给对应Worker
+func (l *WorkerSessionLogic) WorkerSession(stream pb.TaskService_WorkerSessionServer) error {
+	pubsub := l.svcCtx.RedisClient.Subscribe(l.ctx, workerControlChannel, workerQueryChannel)
+	defer pubsub.Close()
+	if _, err := pubsub.Receive(l.ctx); err != nil {
+		l.Logger.Errorf("Subscribe worker control channel failed: %v", err)
+		return status.Error(codes.Unavailable, "subscribe worker control channel failed")
+	}
+	messages := pubsub.Channel()
+
+	reports := make(chan *pb.WorkerStatusReport)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			report, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case reports <- report:
+			case <-l.ctx.Done():
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(workerHeartbeatInterval)
+	defer ticker.Stop()
+
+	// 当前会话的Worker名称，以最近一次上报为准，重命名后随之更新
+	workerName := ""
+	for {
+		select {
+		case <-l.ctx.Done():
+			return l.ctx.Err()
+		case err := <-recvErr:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case report := <-reports:
+			// 使用凭证的Worker只能以绑定的名称上报状态和订阅控制命令
+			name, err := auth.WorkerName(l.ctx, report.WorkerName)
+			if err != nil {
+				return err
+			}
+			report.WorkerName = name
+			if report.Offline {
+				l.removeWorker(report.WorkerName)
+				workerName = ""
+				continue
+			}
+			if report.WorkerName == "" {
+				continue
+			}
+			if workerName != "" && workerName != report.WorkerName {
+				l.svcCtx.RedisClient.Del(l.ctx, workerHeartbeatKeyPrefix+workerName)
+			}
+			workerName = report.WorkerName
+			l.saveStatus(report)
+		case <-ticker.C:
+			if workerName != "" {
+				l.svcCtx.RedisClient.Set(l.ctx, workerHeartbeatKeyPrefix+workerName, time.Now().Unix(), workerHeartbeatTTL)
+			}
+		case msg, ok := <-messages:
+			if !ok {
+				return status.Error(codes.Unavailable, "worker control channel closed")
+			}
+			cmd := parseWorkerCommand(msg.Channel, msg.Payload)
+			if cmd == nil {
+				l.Logger.Errorf("Invalid worker control command: %s", msg.Payload)
+				continue
+			}
+			// 只转发广播命令和发给当前Worker的命令
+			if workerName == "" || (cmd.WorkerName != "" && cmd.WorkerName != workerName) {
+				continue
+			}
+			if err := stream.Send(cmd); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// saveStatus 保存Worker状态并刷新会话心跳
+func (l *WorkerSessionLogic) saveStatus(report *pb.WorkerStatusReport) {
+	data, _ := json.Marshal(map[string]interface{}{
+		"workerName":         report.WorkerName,
+		"ip":                 report.Ip,
+		"cpuLoad":            report.CpuLoad,
+		"memUsed":            report.MemUsed,
+		"taskStartedNumber":  report.TaskStartedNumber,
+		"taskExecutedNumber": report.TaskExecutedNumber,
+		"isDaemon":           false,
+		"healthStatus":       report.HealthStatus,
+		"isThrottled":        report.IsThrottled,
+		"cpuOverloadCount":   report.CpuOverloadCount,
+		"concurrency":        report.Concurrency,
+		"runningTasks":       report.RunningTasks,
+		"updateTime":         time.Now().Local().Format("2006-01-02 15:04:05"),
+		"tools":              report.Tools,
+		"labels":             report.Labels,
+		"capabilities":       report.Capabilities,
+	})
+	pipe := l.svcCtx.RedisClient.Pipeline()
+	pipe.Set(l.ctx, workerStatusKeyPrefix+report.WorkerName, data, workerStatusTTL)
+	pipe.Set(l.ctx, workerHeartbeatKeyPrefix+report.WorkerName, time.Now().Unix(), workerHeartbeatTTL)
+	if _, err := pipe.Exec(l.ctx); err != nil {
+		l.Logger.Errorf("Save worker status failed, worker=%s: %v", report.WorkerName, err)
+	}
+}
+
+// removeWorker Worker正常退出时清理状态，让Worker立即从列表中消失
+func (l *WorkerSessionLogic) removeWorker(workerName string) {
+	if workerName == "" {
+		return
+	}
+	l.svcCtx.RedisClient.Del(l.ctx, workerStatusKeyPrefix+workerName, workerHeartbeatKeyPrefix+workerName)
+}
+
+// parseWorkerCommand 将频道消息转换为Worker命令，状态查询转换为query命令
+func parseWorkerCommand(channel, payload string) *pb.WorkerCommand {
+	if channel == workerQueryChannel {
+		return &pb.WorkerCommand{Action: "query"}
+	}
+	var cmd struct {
+		Action      string `json:"action"`
+		WorkerName  string `json:"workerName"`
+		NewName     string `json:"newName"`
+		Concurrency int    `json:"concurrency"`
+	}
+	if err := json.Unmarshal([]byte(payload), &cmd); err != nil || cmd.Action == "" {
+		return nil
+	}
+	return &pb.WorkerCommand{
+		Action:      cmd.Action,
+		WorkerName:  cmd.WorkerName,
+		NewName:     cmd.NewName,
+		Concurrency: int32(cmd.Concurrency),
+	}
+}
@@ -130,3 +130,87 @@ func (s *TaskServiceServer) GetSubfinderProviders(ctx context.Context, in *pb.Ge
 	l := logic.NewGetSubfinderProvidersLogic(ctx, s.svcCtx)
 	return l.GetSubfinderProviders(in)
 }
+
+// 保存爬虫发现的URL
+func (s *TaskServiceServer) SaveUrlResult(ctx context.Context, in *pb.SaveUrlResultReq) (*pb.SaveUrlResultResp, error) {
+	l := logic.NewSaveUrlResultLogic(ctx, s.svcCtx)
+	return l.SaveUrlResult(in)
+}
+
+// 获取爆破字典内容
+func (s *TaskServiceServer) GetWordlists(ctx context.Context, in *pb.GetWordlistsReq) (*pb.GetWordlistsResp, error) {
+	l := logic.NewGetWordlistsLogic(ctx, s.svcCtx)
+	return l.GetWordlists(in)
+}
+
+// 保存目录扫描结果
+func (s *TaskServiceServer) SaveDirScanResult(ctx context.Context, in *pb.SaveDirScanResultReq) (*pb.SaveDirScanResultResp, error) {
+	l := logic.NewSaveDirScanResultLogic(ctx, s.svcCtx)
+	return l.SaveDirScanResult(in)
+}
+
+// 获取敏感信息检测规则
+func (s *TaskServiceServer) GetSecretRules(ctx context.Context, in *pb.GetSecretRulesReq) (*pb.GetSecretRulesResp, error) {
+	l := logic.NewGetSecretRulesLogic(ctx, s.svcCtx)
+	return l.GetSecretRules(in)
+}
+
+// 保存敏感信息检测结果
+func (s *TaskServiceServer) SaveSecretResult(ctx context.Context, in *pb.SaveSecretResultReq) (*pb.SaveSecretResultResp, error) {
+	l := logic.NewSaveSecretResultLogic(ctx, s.svcCtx)
+	return l.SaveSecretResult(in)
+}
+
+// 保存域名DNS记录
+func (s *TaskServiceServer) SaveDnsRecordResult(ctx context.Context, in *pb.SaveDnsRecordResultReq) (*pb.SaveDnsRecordResultResp, error) {
+	l := logic.NewSaveDnsRecordResultLogic(ctx, s.svcCtx)
+	return l.SaveDnsRecordResult(in)
+}
+
+// 子域名监控：与已知子域名比对，返回新增和解析变化的子域名
+func (s *TaskServiceServer) SyncSubdomains(ctx context.Context, in *pb.SyncSubdomainsReq) (*pb.SyncSubdomainsResp, error) {
+	l := logic.NewSyncSubdomainsLogic(ctx, s.svcCtx)
+	return l.SyncSubdomains(in)
+}
+
+// Worker使用注册令牌换取凭证，无需认证
+func (s *TaskServiceServer) EnrollWorker(ctx context.Context, in *pb.EnrollWorkerReq) (*pb.EnrollWorkerResp, error) {
+	l := logic.NewEnrollWorkerLogic(ctx, s.svcCtx)
+	return l.EnrollWorker(in)
+}
+
+// Worker上线：校验凭证并确认使用的名称
+func (s *TaskServiceServer) WorkerOnline(ctx context.Context, in *pb.WorkerOnlineReq) (*pb.WorkerOnlineResp, error) {
+	l := logic.NewWorkerOnlineLogic(ctx, s.svcCtx)
+	return l.WorkerOnline(in)
+}
+
+// Worker日志上报（客户端流）
+func (s *TaskServiceServer) PushWorkerLogs(stream pb.TaskService_PushWorkerLogsServer) error {
+	l := logic.NewPushWorkerLogsLogic(stream.Context(), s.svcCtx)
+	return l.PushWorkerLogs(stream)
+}
+
+// Worker会话（双向流）：Worker上报状态，服务端下发控制命令和状态查询
+func (s *TaskServiceServer) WorkerSession(stream pb.TaskService_WorkerSessionServer) error {
+	l := logic.NewWorkerSessionLogic(stream.Context(), s.svcCtx)
+	return l.WorkerSession(stream)
+}
+
+// 获取任务控制信号
+func (s *TaskServiceServer) GetTaskControl(ctx context.Context, in *pb.GetTaskControlReq) (*pb.GetTaskControlResp, error) {
+	l := logic.NewGetTaskControlLogic(ctx, s.svcCtx)
+	return l.GetTaskControl(in)
+}
+
+// 更新任务进度
+func (s *TaskServiceServer) UpdateTaskProgress(ctx context.Context, in *pb.UpdateTaskProgressReq) (*pb.UpdateTaskProgressResp, error) {
+	l := logic.NewUpdateTaskProgressLogic(ctx, s.svcCtx)
+	return l.UpdateTaskProgress(in)
+}
+
+// 保存POC验证结果
+func (s *TaskServiceServer) SavePocValidationResult(ctx context.Context, in *pb.SavePocValidationResultReq) (*pb.SavePocValidationResultResp, error) {
+	l := logic.NewSavePocValidationResultLogic(ctx, s.svcCtx)
+	return l.SavePocValidationResult(in)
+}
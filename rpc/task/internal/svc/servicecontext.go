@@ -5,9 +5,13 @@ import (
 	"time"
 
 	"cscan/model"
+	"cscan/pkg/blob"
+	"cscan/pkg/ipgeo"
 	"cscan/rpc/task/internal/config"
+	"cscan/scheduler"
 
 	"github.com/redis/go-redis/v9"
+	"github.com/zeromicro/go-zero/core/logx"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -23,6 +27,15 @@ type ServiceContext struct {
 	HttpServiceMappingModel *model.HttpServiceMappingModel
 	WorkspaceModel          *model.WorkspaceModel
 	SubfinderProviderModel  *model.SubfinderProviderModel
+	NotifyConfigModel       *model.NotifyConfigModel
+	WordlistModel           *model.WordlistModel
+	SecretRuleModel         *model.SecretRuleModel
+	WorkerEnrollTokenModel  *model.WorkerEnrollTokenModel
+	WorkerCredentialModel   *model.WorkerCredentialModel
+	IPGeo                   *ipgeo.Enricher
+	Blob                    blob.Store // 为nil时截图等数据仍内联保存在Mongo中
+	TaskLeases              *scheduler.TaskLeases
+	Scheduler               *scheduler.Scheduler
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
@@ -43,6 +56,22 @@ func NewServiceContext(c config.Config) *ServiceContext {
 		DB:       0,
 	})
 
+	// 加载离线IP库，失败时不影响服务启动
+	geo, err := ipgeo.New(ipgeo.Config{
+		CityDB:      c.IPGeo.CityDB,
+		ASNDB:       c.IPGeo.ASNDB,
+		Ip2regionDB: c.IPGeo.Ip2regionDB,
+	})
+	if err != nil {
+		logx.Errorf("Load ip geo database failed: %v", err)
+	}
+
+	blobStore, err := blob.New(c.Blob)
+	if err != nil {
+		logx.Errorf("Init blob store failed, screenshots will be stored inline: %v", err)
+		blobStore = nil
+	}
+
 	return &ServiceContext{
 		Config:                  c,
 		MongoClient:             mongoClient,
@@ -54,7 +83,24 @@ func NewServiceContext(c config.Config) *ServiceContext {
 		HttpServiceMappingModel: model.NewHttpServiceMappingModel(mongoDB),
 		WorkspaceModel:          model.NewWorkspaceModel(mongoDB),
 		SubfinderProviderModel:  model.NewSubfinderProviderModel(mongoDB),
+		NotifyConfigModel:       model.NewNotifyConfigModel(mongoDB),
+		WordlistModel:           model.NewWordlistModel(mongoDB),
+		SecretRuleModel:         model.NewSecretRuleModel(mongoDB),
+		WorkerEnrollTokenModel:  model.NewWorkerEnrollTokenModel(mongoDB),
+		WorkerCredentialModel:   model.NewWorkerCredentialModel(mongoDB),
+		IPGeo:                   geo,
+		Blob:                    blobStore,
+		TaskLeases:              scheduler.NewTaskLeases(rdb),
+		Scheduler:               scheduler.NewScheduler(rdb),
+	}
+}
+
+// LeaseTTL 任务租约有效期
+func (s *ServiceContext) LeaseTTL() time.Duration {
+	if s.Config.TaskLease.TTL <= 0 {
+		return 180 * time.Second
 	}
+	return time.Duration(s.Config.TaskLease.TTL) * time.Second
 }
 
 func (s *ServiceContext) GetAssetModel(workspaceId string) *model.AssetModel {
@@ -91,3 +137,45 @@ func (s *ServiceContext) GetAssetHistoryModel(workspaceId string) *model.AssetHi
 	}
 	return model.NewAssetHistoryModel(s.MongoDB, workspaceId)
 }
+
+func (s *ServiceContext) GetAssetChangeModel(workspaceId string) *model.AssetChangeModel {
+	if workspaceId == "" {
+		workspaceId = "default"
+	}
+	return model.NewAssetChangeModel(s.MongoDB, workspaceId)
+}
+
+func (s *ServiceContext) GetUrlModel(workspaceId string) *model.UrlModel {
+	if workspaceId == "" {
+		workspaceId = "default"
+	}
+	return model.NewUrlModel(s.MongoDB, workspaceId)
+}
+
+func (s *ServiceContext) GetDirScanModel(workspaceId string) *model.DirScanModel {
+	if workspaceId == "" {
+		workspaceId = "default"
+	}
+	return model.NewDirScanModel(s.MongoDB, workspaceId)
+}
+
+func (s *ServiceContext) GetSecretFindingModel(workspaceId string) *model.SecretFindingModel {
+	if workspaceId == "" {
+		workspaceId = "default"
+	}
+	return model.NewSecretFindingModel(s.MongoDB, workspaceId)
+}
+
+func (s *ServiceContext) GetDNSRecordModel(workspaceId string) *model.DNSRecordModel {
+	if workspaceId == "" {
+		workspaceId = "default"
+	}
+	return model.NewDNSRecordModel(s.MongoDB, workspaceId)
+}
+
+func (s *ServiceContext) GetSubdomainModel(workspaceId string) *model.SubdomainModel {
+	if workspaceId == "" {
+		workspaceId = "default"
+	}
+	return model.NewSubdomainModel(s.MongoDB, workspaceId)
+}
@@ -422,32 +422,33 @@ func (x *NewTaskResp) GetMessage() string {
 }
 
 type AssetDocument struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Authority     string                 `protobuf:"bytes,1,opt,name=authority,proto3" json:"authority,omitempty"`
-	Host          string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
-	Port          int32                  `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
-	Category      string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
-	Service       string                 `protobuf:"bytes,5,opt,name=service,proto3" json:"service,omitempty"`
-	Server        string                 `protobuf:"bytes,6,opt,name=server,proto3" json:"server,omitempty"`
-	Banner        string                 `protobuf:"bytes,7,opt,name=banner,proto3" json:"banner,omitempty"`
-	Title         string                 `protobuf:"bytes,8,opt,name=title,proto3" json:"title,omitempty"`
-	App           []string               `protobuf:"bytes,9,rep,name=app,proto3" json:"app,omitempty"`
-	HttpStatus    string                 `protobuf:"bytes,10,opt,name=httpStatus,proto3" json:"httpStatus,omitempty"`
-	HttpHeader    string                 `protobuf:"bytes,11,opt,name=httpHeader,proto3" json:"httpHeader,omitempty"`
-	HttpBody      string                 `protobuf:"bytes,12,opt,name=httpBody,proto3" json:"httpBody,omitempty"`
-	Cert          string                 `protobuf:"bytes,13,opt,name=cert,proto3" json:"cert,omitempty"`
-	IconHash      string                 `protobuf:"bytes,14,opt,name=iconHash,proto3" json:"iconHash,omitempty"`
-	IsCdn         bool                   `protobuf:"varint,15,opt,name=isCdn,proto3" json:"isCdn,omitempty"`
-	Cname         string                 `protobuf:"bytes,16,opt,name=cname,proto3" json:"cname,omitempty"`
-	IsCloud       bool                   `protobuf:"varint,17,opt,name=isCloud,proto3" json:"isCloud,omitempty"`
-	Ipv4          []*IPV4                `protobuf:"bytes,18,rep,name=ipv4,proto3" json:"ipv4,omitempty"`
-	Ipv6          []*IPV6                `protobuf:"bytes,19,rep,name=ipv6,proto3" json:"ipv6,omitempty"`
-	Screenshot    string                 `protobuf:"bytes,20,opt,name=screenshot,proto3" json:"screenshot,omitempty"`
-	IsHttp        bool                   `protobuf:"varint,21,opt,name=isHttp,proto3" json:"isHttp,omitempty"`
-	Source        string                 `protobuf:"bytes,22,opt,name=source,proto3" json:"source,omitempty"`     // 资产来源: subfinder, portscan, etc.
-	IconData      []byte                 `protobuf:"bytes,23,opt,name=iconData,proto3" json:"iconData,omitempty"` // favicon 图片原始数据
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Authority      string                 `protobuf:"bytes,1,opt,name=authority,proto3" json:"authority,omitempty"`
+	Host           string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	Port           int32                  `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	Category       string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	Service        string                 `protobuf:"bytes,5,opt,name=service,proto3" json:"service,omitempty"`
+	Server         string                 `protobuf:"bytes,6,opt,name=server,proto3" json:"server,omitempty"`
+	Banner         string                 `protobuf:"bytes,7,opt,name=banner,proto3" json:"banner,omitempty"`
+	Title          string                 `protobuf:"bytes,8,opt,name=title,proto3" json:"title,omitempty"`
+	App            []string               `protobuf:"bytes,9,rep,name=app,proto3" json:"app,omitempty"`
+	HttpStatus     string                 `protobuf:"bytes,10,opt,name=httpStatus,proto3" json:"httpStatus,omitempty"`
+	HttpHeader     string                 `protobuf:"bytes,11,opt,name=httpHeader,proto3" json:"httpHeader,omitempty"`
+	HttpBody       string                 `protobuf:"bytes,12,opt,name=httpBody,proto3" json:"httpBody,omitempty"`
+	Cert           string                 `protobuf:"bytes,13,opt,name=cert,proto3" json:"cert,omitempty"`
+	IconHash       string                 `protobuf:"bytes,14,opt,name=iconHash,proto3" json:"iconHash,omitempty"`
+	IsCdn          bool                   `protobuf:"varint,15,opt,name=isCdn,proto3" json:"isCdn,omitempty"`
+	Cname          string                 `protobuf:"bytes,16,opt,name=cname,proto3" json:"cname,omitempty"`
+	IsCloud        bool                   `protobuf:"varint,17,opt,name=isCloud,proto3" json:"isCloud,omitempty"`
+	Ipv4           []*IPV4                `protobuf:"bytes,18,rep,name=ipv4,proto3" json:"ipv4,omitempty"`
+	Ipv6           []*IPV6                `protobuf:"bytes,19,rep,name=ipv6,proto3" json:"ipv6,omitempty"`
+	Screenshot     string                 `protobuf:"bytes,20,opt,name=screenshot,proto3" json:"screenshot,omitempty"`
+	IsHttp         bool                   `protobuf:"varint,21,opt,name=isHttp,proto3" json:"isHttp,omitempty"`
+	Source         string                 `protobuf:"bytes,22,opt,name=source,proto3" json:"source,omitempty"`                 // 资产来源: subfinder, portscan, etc.
+	IconData       []byte                 `protobuf:"bytes,23,opt,name=iconData,proto3" json:"iconData,omitempty"`             // favicon 图片原始数据
+	ScreenshotHash string                 `protobuf:"bytes,24,opt,name=screenshotHash,proto3" json:"screenshotHash,omitempty"` // 截图感知哈希(dHash)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *AssetDocument) Reset() {
@@ -641,6 +642,13 @@ func (x *AssetDocument) GetIconData() []byte {
 	return nil
 }
 
+func (x *AssetDocument) GetScreenshotHash() string {
+	if x != nil {
+		return x.ScreenshotHash
+	}
+	return ""
+}
+
 type IPV4 struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Ip            string                 `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
@@ -759,6 +767,7 @@ type SaveTaskResultReq struct {
 	MainTaskId    string                 `protobuf:"bytes,2,opt,name=mainTaskId,proto3" json:"mainTaskId,omitempty"`
 	Assets        []*AssetDocument       `protobuf:"bytes,3,rep,name=assets,proto3" json:"assets,omitempty"`
 	OrgId         string                 `protobuf:"bytes,4,opt,name=orgId,proto3" json:"orgId,omitempty"`
+	TaskId        string                 `protobuf:"bytes,5,opt,name=taskId,proto3" json:"taskId,omitempty"` // 执行的任务ID，使用Worker凭证时校验租约
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -821,6 +830,13 @@ func (x *SaveTaskResultReq) GetOrgId() string {
 	return ""
 }
 
+func (x *SaveTaskResultReq) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
 type SaveTaskResultResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -1108,6 +1124,7 @@ type SaveVulResultReq struct {
 	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspaceId,proto3" json:"workspaceId,omitempty"`
 	MainTaskId    string                 `protobuf:"bytes,2,opt,name=mainTaskId,proto3" json:"mainTaskId,omitempty"`
 	Vuls          []*VulDocument         `protobuf:"bytes,3,rep,name=vuls,proto3" json:"vuls,omitempty"`
+	TaskId        string                 `protobuf:"bytes,4,opt,name=taskId,proto3" json:"taskId,omitempty"` // 执行的任务ID，使用Worker凭证时校验租约
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1163,6 +1180,13 @@ func (x *SaveVulResultReq) GetVuls() []*VulDocument {
 	return nil
 }
 
+func (x *SaveVulResultReq) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
 type SaveVulResultResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -1232,6 +1256,9 @@ type KeepAliveReq struct {
 	TaskExecutedNumber int32                  `protobuf:"varint,5,opt,name=taskExecutedNumber,proto3" json:"taskExecutedNumber,omitempty"`
 	IsDaemon           bool                   `protobuf:"varint,6,opt,name=isDaemon,proto3" json:"isDaemon,omitempty"`
 	Ip                 string                 `protobuf:"bytes,7,opt,name=ip,proto3" json:"ip,omitempty"`
+	TaskIds            []string               `protobuf:"bytes,8,rep,name=taskIds,proto3" json:"taskIds,omitempty"`                                                                         // 已领取未结束的任务，用于续约
+	Labels             map[string]string      `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Worker标签，如 region=cn
+	Capabilities       []string               `protobuf:"bytes,10,rep,name=capabilities,proto3" json:"capabilities,omitempty"`                                                              // 自动检测的能力，如 masscan、chrome
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
@@ -1315,6 +1342,27 @@ func (x *KeepAliveReq) GetIp() string {
 	return ""
 }
 
+func (x *KeepAliveReq) GetTaskIds() []string {
+	if x != nil {
+		return x.TaskIds
+	}
+	return nil
+}
+
+func (x *KeepAliveReq) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *KeepAliveReq) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
 type KeepAliveResp struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	Status            string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
@@ -1322,6 +1370,7 @@ type KeepAliveResp struct {
 	ManualReloadFlag  bool                   `protobuf:"varint,3,opt,name=manualReloadFlag,proto3" json:"manualReloadFlag,omitempty"`
 	ManualInitEnvFlag bool                   `protobuf:"varint,4,opt,name=manualInitEnvFlag,proto3" json:"manualInitEnvFlag,omitempty"`
 	ManualSyncFlag    bool                   `protobuf:"varint,5,opt,name=manualSyncFlag,proto3" json:"manualSyncFlag,omitempty"`
+	LostTaskIds       []string               `protobuf:"bytes,6,rep,name=lostTaskIds,proto3" json:"lostTaskIds,omitempty"` // 租约已被回收的任务，Worker应停止执行
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -1391,6 +1440,13 @@ func (x *KeepAliveResp) GetManualSyncFlag() bool {
 	return false
 }
 
+func (x *KeepAliveResp) GetLostTaskIds() []string {
+	if x != nil {
+		return x.LostTaskIds
+	}
+	return nil
+}
+
 type GetWorkerConfigReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	WorkerName    string                 `protobuf:"bytes,1,opt,name=workerName,proto3" json:"workerName,omitempty"`
@@ -3604,218 +3660,2873 @@ func (x *GetSubfinderProvidersResp) GetCount() int32 {
 	return 0
 }
 
-var File_rpc_task_task_proto protoreflect.FileDescriptor
+// 爬虫发现的URL
+type UrlDocument struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Authority     string                 `protobuf:"bytes,1,opt,name=authority,proto3" json:"authority,omitempty"`
+	Host          string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	Port          int32                  `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	Url           string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	Endpoint      string                 `protobuf:"bytes,5,opt,name=endpoint,proto3" json:"endpoint,omitempty"` // 不含查询参数的URL，与method一起去重
+	Method        string                 `protobuf:"bytes,6,opt,name=method,proto3" json:"method,omitempty"`
+	Path          string                 `protobuf:"bytes,7,opt,name=path,proto3" json:"path,omitempty"`
+	Params        []string               `protobuf:"bytes,8,rep,name=params,proto3" json:"params,omitempty"` // 查询参数和表单字段名
+	Source        string                 `protobuf:"bytes,9,opt,name=source,proto3" json:"source,omitempty"` // page, form, js, xhr, robots, sitemap
+	StatusCode    int32                  `protobuf:"varint,10,opt,name=statusCode,proto3" json:"statusCode,omitempty"`
+	ContentType   string                 `protobuf:"bytes,11,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	Length        int32                  `protobuf:"varint,12,opt,name=length,proto3" json:"length,omitempty"`
+	Title         string                 `protobuf:"bytes,13,opt,name=title,proto3" json:"title,omitempty"`
+	Referer       string                 `protobuf:"bytes,14,opt,name=referer,proto3" json:"referer,omitempty"` // 发现该URL的页面
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_rpc_task_task_proto_rawDesc = "" +
-	"\n" +
-	"\x13rpc/task/task.proto\x12\x04task\"F\n" +
-	"\fCheckTaskReq\x12\x16\n" +
-	"\x06taskId\x18\x01 \x01(\tR\x06taskId\x12\x1e\n" +
-	"\n" +
-	"mainTaskId\x18\x02 \x01(\tR\n" +
-	"mainTaskId\"\xe1\x01\n" +
-	"\rCheckTaskResp\x12\x18\n" +
-	"\aisExist\x18\x01 \x01(\bR\aisExist\x12\x1e\n" +
-	"\n" +
-	"isFinished\x18\x02 \x01(\bR\n" +
-	"isFinished\x12\x16\n" +
-	"\x06taskId\x18\x03 \x01(\tR\x06taskId\x12\x14\n" +
-	"\x05state\x18\x04 \x01(\tR\x05state\x12\x16\n" +
-	"\x06worker\x18\x05 \x01(\tR\x06worker\x12\x16\n" +
-	"\x06result\x18\x06 \x01(\tR\x06result\x12 \n" +
-	"\vworkspaceId\x18\a \x01(\tR\vworkspaceId\x12\x16\n" +
-	"\x06config\x18\b \x01(\tR\x06config\"m\n" +
-	"\rUpdateTaskReq\x12\x16\n" +
-	"\x06taskId\x18\x01 \x01(\tR\x06taskId\x12\x14\n" +
-	"\x05state\x18\x02 \x01(\tR\x05state\x12\x16\n" +
-	"\x06worker\x18\x03 \x01(\tR\x06worker\x12\x16\n" +
-	"\x06result\x18\x04 \x01(\tR\x06result\"D\n" +
-	"\x0eUpdateTaskResp\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\x9a\x01\n" +
-	"\n" +
-	"NewTaskReq\x12\x16\n" +
-	"\x06taskId\x18\x01 \x01(\tR\x06taskId\x12\x1e\n" +
-	"\n" +
-	"mainTaskId\x18\x02 \x01(\tR\n" +
-	"mainTaskId\x12\x1a\n" +
-	"\btaskName\x18\x03 \x01(\tR\btaskName\x12\x16\n" +
-	"\x06config\x18\x04 \x01(\tR\x06config\x12 \n" +
-	"\vworkspaceId\x18\x05 \x01(\tR\vworkspaceId\"A\n" +
-	"\vNewTaskResp\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\xe1\x04\n" +
-	"\rAssetDocument\x12\x1c\n" +
-	"\tauthority\x18\x01 \x01(\tR\tauthority\x12\x12\n" +
-	"\x04host\x18\x02 \x01(\tR\x04host\x12\x12\n" +
-	"\x04port\x18\x03 \x01(\x05R\x04port\x12\x1a\n" +
-	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x18\n" +
-	"\aservice\x18\x05 \x01(\tR\aservice\x12\x16\n" +
-	"\x06server\x18\x06 \x01(\tR\x06server\x12\x16\n" +
-	"\x06banner\x18\a \x01(\tR\x06banner\x12\x14\n" +
-	"\x05title\x18\b \x01(\tR\x05title\x12\x10\n" +
-	"\x03app\x18\t \x03(\tR\x03app\x12\x1e\n" +
-	"\n" +
-	"httpStatus\x18\n" +
-	" \x01(\tR\n" +
-	"httpStatus\x12\x1e\n" +
-	"\n" +
-	"httpHeader\x18\v \x01(\tR\n" +
-	"httpHeader\x12\x1a\n" +
-	"\bhttpBody\x18\f \x01(\tR\bhttpBody\x12\x12\n" +
-	"\x04cert\x18\r \x01(\tR\x04cert\x12\x1a\n" +
-	"\biconHash\x18\x0e \x01(\tR\biconHash\x12\x14\n" +
-	"\x05isCdn\x18\x0f \x01(\bR\x05isCdn\x12\x14\n" +
-	"\x05cname\x18\x10 \x01(\tR\x05cname\x12\x18\n" +
-	"\aisCloud\x18\x11 \x01(\bR\aisCloud\x12\x1e\n" +
-	"\x04ipv4\x18\x12 \x03(\v2\n" +
-	".task.IPV4R\x04ipv4\x12\x1e\n" +
-	"\x04ipv6\x18\x13 \x03(\v2\n" +
-	".task.IPV6R\x04ipv6\x12\x1e\n" +
-	"\n" +
-	"screenshot\x18\x14 \x01(\tR\n" +
-	"screenshot\x12\x16\n" +
-	"\x06isHttp\x18\x15 \x01(\bR\x06isHttp\x12\x16\n" +
-	"\x06source\x18\x16 \x01(\tR\x06source\x12\x1a\n" +
-	"\biconData\x18\x17 \x01(\fR\biconData\"H\n" +
-	"\x04IPV4\x12\x0e\n" +
-	"\x02ip\x18\x01 \x01(\tR\x02ip\x12\x14\n" +
-	"\x05ipInt\x18\x02 \x01(\rR\x05ipInt\x12\x1a\n" +
-	"\blocation\x18\x03 \x01(\tR\blocation\"2\n" +
-	"\x04IPV6\x12\x0e\n" +
-	"\x02ip\x18\x01 \x01(\tR\x02ip\x12\x1a\n" +
-	"\blocation\x18\x02 \x01(\tR\blocation\"\x98\x01\n" +
-	"\x11SaveTaskResultReq\x12 \n" +
-	"\vworkspaceId\x18\x01 \x01(\tR\vworkspaceId\x12\x1e\n" +
-	"\n" +
-	"mainTaskId\x18\x02 \x01(\tR\n" +
-	"mainTaskId\x12+\n" +
-	"\x06assets\x18\x03 \x03(\v2\x13.task.AssetDocumentR\x06assets\x12\x14\n" +
-	"\x05orgId\x18\x04 \x01(\tR\x05orgId\"\xa6\x01\n" +
-	"\x12SaveTaskResultResp\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1e\n" +
-	"\n" +
-	"totalAsset\x18\x03 \x01(\x05R\n" +
-	"totalAsset\x12\x1a\n" +
-	"\bnewAsset\x18\x04 \x01(\x05R\bnewAsset\x12 \n" +
-	"\vupdateAsset\x18\x05 \x01(\x05R\vupdateAsset\"\x87\x06\n" +
-	"\vVulDocument\x12\x1c\n" +
-	"\tauthority\x18\x01 \x01(\tR\tauthority\x12\x12\n" +
-	"\x04host\x18\x02 \x01(\tR\x04host\x12\x12\n" +
-	"\x04port\x18\x03 \x01(\x05R\x04port\x12\x10\n" +
-	"\x03url\x18\x04 \x01(\tR\x03url\x12\x18\n" +
-	"\apocFile\x18\x05 \x01(\tR\apocFile\x12\x16\n" +
-	"\x06source\x18\x06 \x01(\tR\x06source\x12\x1a\n" +
-	"\bseverity\x18\a \x01(\tR\bseverity\x12\x14\n" +
-	"\x05extra\x18\b \x01(\tR\x05extra\x12\x16\n" +
-	"\x06result\x18\t \x01(\tR\x06result\x12\x16\n" +
-	"\x06taskId\x18\n" +
-	" \x01(\tR\x06taskId\x12!\n" +
-	"\tcvssScore\x18\v \x01(\x01H\x00R\tcvssScore\x88\x01\x01\x12\x19\n" +
-	"\x05cveId\x18\f \x01(\tH\x01R\x05cveId\x88\x01\x01\x12\x19\n" +
-	"\x05cweId\x18\r \x01(\tH\x02R\x05cweId\x88\x01\x01\x12%\n" +
-	"\vremediation\x18\x0e \x01(\tH\x03R\vremediation\x88\x01\x01\x12\x1e\n" +
-	"\n" +
-	"references\x18\x0f \x03(\tR\n" +
-	"references\x12%\n" +
-	"\vmatcherName\x18\x10 \x01(\tH\x04R\vmatcherName\x88\x01\x01\x12*\n" +
-	"\x10extractedResults\x18\x11 \x03(\tR\x10extractedResults\x12%\n" +
-	"\vcurlCommand\x18\x12 \x01(\tH\x05R\vcurlCommand\x88\x01\x01\x12\x1d\n" +
-	"\arequest\x18\x13 \x01(\tH\x06R\arequest\x88\x01\x01\x12\x1f\n" +
-	"\bresponse\x18\x14 \x01(\tH\aR\bresponse\x88\x01\x01\x121\n" +
-	"\x11responseTruncated\x18\x15 \x01(\bH\bR\x11responseTruncated\x88\x01\x01B\f\n" +
-	"\n" +
-	"_cvssScoreB\b\n" +
-	"\x06_cveIdB\b\n" +
-	"\x06_cweIdB\x0e\n" +
-	"\f_remediationB\x0e\n" +
-	"\f_matcherNameB\x0e\n" +
-	"\f_curlCommandB\n" +
-	"\n" +
-	"\b_requestB\v\n" +
-	"\t_responseB\x14\n" +
-	"\x12_responseTruncated\"{\n" +
-	"\x10SaveVulResultReq\x12 \n" +
-	"\vworkspaceId\x18\x01 \x01(\tR\vworkspaceId\x12\x1e\n" +
-	"\n" +
-	"mainTaskId\x18\x02 \x01(\tR\n" +
-	"mainTaskId\x12%\n" +
-	"\x04vuls\x18\x03 \x03(\v2\x11.task.VulDocumentR\x04vuls\"]\n" +
-	"\x11SaveVulResultResp\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
-	"\x05total\x18\x03 \x01(\x05R\x05total\"\xec\x01\n" +
-	"\fKeepAliveReq\x12\x1e\n" +
-	"\n" +
-	"workerName\x18\x01 \x01(\tR\n" +
-	"workerName\x12\x18\n" +
-	"\acpuLoad\x18\x02 \x01(\x01R\acpuLoad\x12\x18\n" +
-	"\amemUsed\x18\x03 \x01(\x01R\amemUsed\x12,\n" +
-	"\x11taskStartedNumber\x18\x04 \x01(\x05R\x11taskStartedNumber\x12.\n" +
-	"\x12taskExecutedNumber\x18\x05 \x01(\x05R\x12taskExecutedNumber\x12\x1a\n" +
-	"\bisDaemon\x18\x06 \x01(\bR\bisDaemon\x12\x0e\n" +
-	"\x02ip\x18\a \x01(\tR\x02ip\"\xd1\x01\n" +
-	"\rKeepAliveResp\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\x12&\n" +
-	"\x0emanualStopFlag\x18\x02 \x01(\bR\x0emanualStopFlag\x12*\n" +
-	"\x10manualReloadFlag\x18\x03 \x01(\bR\x10manualReloadFlag\x12,\n" +
-	"\x11manualInitEnvFlag\x18\x04 \x01(\bR\x11manualInitEnvFlag\x12&\n" +
-	"\x0emanualSyncFlag\x18\x05 \x01(\bR\x0emanualSyncFlag\"4\n" +
-	"\x12GetWorkerConfigReq\x12\x1e\n" +
-	"\n" +
-	"workerName\x18\x01 \x01(\tR\n" +
-	"workerName\"-\n" +
-	"\x13GetWorkerConfigResp\x12\x16\n" +
-	"\x06config\x18\x01 \x01(\tR\x06config\"D\n" +
-	"\x12RequestResourceReq\x12\x1a\n" +
-	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"Q\n" +
-	"\x13RequestResourceResp\x12\x12\n" +
-	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
-	"\x04hash\x18\x02 \x01(\tR\x04hash\x12\x12\n" +
-	"\x04data\x18\x03 \x01(\fR\x04data\"K\n" +
-	"\x15GetTemplatesByTagsReq\x12\x12\n" +
-	"\x04tags\x18\x01 \x03(\tR\x04tags\x12\x1e\n" +
-	"\n" +
-	"severities\x18\x02 \x03(\tR\n" +
-	"severities\"\x80\x01\n" +
-	"\x16GetTemplatesByTagsResp\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1c\n" +
-	"\ttemplates\x18\x03 \x03(\tR\ttemplates\x12\x14\n" +
-	"\x05count\x18\x04 \x01(\x05R\x05count\"<\n" +
-	"\x18GetCustomFingerprintsReq\x12 \n" +
-	"\venabledOnly\x18\x01 \x01(\bR\venabledOnly\"\x97\x05\n" +
-	"\x13FingerprintDocument\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
-	"\bcategory\x18\x03 \x01(\tR\bcategory\x12\x12\n" +
-	"\x04rule\x18\x04 \x01(\tR\x04rule\x12\x16\n" +
-	"\x06source\x18\x05 \x01(\tR\x06source\x12@\n" +
-	"\aheaders\x18\x06 \x03(\v2&.task.FingerprintDocument.HeadersEntryR\aheaders\x12@\n" +
-	"\acookies\x18\a \x03(\v2&.task.FingerprintDocument.CookiesEntryR\acookies\x12\x12\n" +
-	"\x04html\x18\b \x03(\tR\x04html\x12\x18\n" +
-	"\ascripts\x18\t \x03(\tR\ascripts\x12\x1c\n" +
-	"\tscriptSrc\x18\n" +
-	" \x03(\tR\tscriptSrc\x127\n" +
-	"\x04meta\x18\v \x03(\v2#.task.FingerprintDocument.MetaEntryR\x04meta\x12\x10\n" +
-	"\x03css\x18\f \x03(\tR\x03css\x12\x10\n" +
-	"\x03url\x18\r \x03(\tR\x03url\x12\x1c\n" +
-	"\tisBuiltin\x18\x0e \x01(\bR\tisBuiltin\x12\x18\n" +
-	"\aenabled\x18\x0f \x01(\bR\aenabled\x1a:\n" +
-	"\fHeadersEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a:\n" +
-	"\fCookiesEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a7\n" +
-	"\tMetaEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa4\x01\n" +
-	"\x19GetCustomFingerprintsResp\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12=\n" +
+func (x *UrlDocument) Reset() {
+	*x = UrlDocument{}
+	mi := &file_rpc_task_task_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UrlDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UrlDocument) ProtoMessage() {}
+
+func (x *UrlDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UrlDocument.ProtoReflect.Descriptor instead.
+func (*UrlDocument) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *UrlDocument) GetAuthority() string {
+	if x != nil {
+		return x.Authority
+	}
+	return ""
+}
+
+func (x *UrlDocument) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *UrlDocument) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *UrlDocument) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *UrlDocument) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *UrlDocument) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *UrlDocument) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *UrlDocument) GetParams() []string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *UrlDocument) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *UrlDocument) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *UrlDocument) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *UrlDocument) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *UrlDocument) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UrlDocument) GetReferer() string {
+	if x != nil {
+		return x.Referer
+	}
+	return ""
+}
+
+// 保存爬虫结果请求
+type SaveUrlResultReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspaceId,proto3" json:"workspaceId,omitempty"`
+	MainTaskId    string                 `protobuf:"bytes,2,opt,name=mainTaskId,proto3" json:"mainTaskId,omitempty"`
+	Urls          []*UrlDocument         `protobuf:"bytes,3,rep,name=urls,proto3" json:"urls,omitempty"`
+	TaskId        string                 `protobuf:"bytes,4,opt,name=taskId,proto3" json:"taskId,omitempty"` // 执行的任务ID，使用Worker凭证时校验租约
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveUrlResultReq) Reset() {
+	*x = SaveUrlResultReq{}
+	mi := &file_rpc_task_task_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveUrlResultReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveUrlResultReq) ProtoMessage() {}
+
+func (x *SaveUrlResultReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveUrlResultReq.ProtoReflect.Descriptor instead.
+func (*SaveUrlResultReq) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *SaveUrlResultReq) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *SaveUrlResultReq) GetMainTaskId() string {
+	if x != nil {
+		return x.MainTaskId
+	}
+	return ""
+}
+
+func (x *SaveUrlResultReq) GetUrls() []*UrlDocument {
+	if x != nil {
+		return x.Urls
+	}
+	return nil
+}
+
+func (x *SaveUrlResultReq) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// 保存爬虫结果响应
+type SaveUrlResultResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	NewCount      int32                  `protobuf:"varint,4,opt,name=newCount,proto3" json:"newCount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveUrlResultResp) Reset() {
+	*x = SaveUrlResultResp{}
+	mi := &file_rpc_task_task_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveUrlResultResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveUrlResultResp) ProtoMessage() {}
+
+func (x *SaveUrlResultResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveUrlResultResp.ProtoReflect.Descriptor instead.
+func (*SaveUrlResultResp) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SaveUrlResultResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SaveUrlResultResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SaveUrlResultResp) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SaveUrlResultResp) GetNewCount() int32 {
+	if x != nil {
+		return x.NewCount
+	}
+	return 0
+}
+
+// 获取爆破字典请求
+type GetWordlistsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // dir, subdomain
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWordlistsReq) Reset() {
+	*x = GetWordlistsReq{}
+	mi := &file_rpc_task_task_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWordlistsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWordlistsReq) ProtoMessage() {}
+
+func (x *GetWordlistsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWordlistsReq.ProtoReflect.Descriptor instead.
+func (*GetWordlistsReq) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetWordlistsReq) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *GetWordlistsReq) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+// 获取爆破字典响应，多个字典合并去重
+type GetWordlistsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Words         []string               `protobuf:"bytes,3,rep,name=words,proto3" json:"words,omitempty"`
+	Count         int32                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWordlistsResp) Reset() {
+	*x = GetWordlistsResp{}
+	mi := &file_rpc_task_task_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWordlistsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWordlistsResp) ProtoMessage() {}
+
+func (x *GetWordlistsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWordlistsResp.ProtoReflect.Descriptor instead.
+func (*GetWordlistsResp) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetWordlistsResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetWordlistsResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetWordlistsResp) GetWords() []string {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+func (x *GetWordlistsResp) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// 目录扫描结果
+type DirScanDocument struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Authority     string                 `protobuf:"bytes,1,opt,name=authority,proto3" json:"authority,omitempty"`
+	Host          string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	Port          int32                  `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	Url           string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	Path          string                 `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
+	Status        int32                  `protobuf:"varint,6,opt,name=status,proto3" json:"status,omitempty"`
+	Length        int32                  `protobuf:"varint,7,opt,name=length,proto3" json:"length,omitempty"`
+	Title         string                 `protobuf:"bytes,8,opt,name=title,proto3" json:"title,omitempty"`
+	ContentType   string                 `protobuf:"bytes,9,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	Location      string                 `protobuf:"bytes,10,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DirScanDocument) Reset() {
+	*x = DirScanDocument{}
+	mi := &file_rpc_task_task_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DirScanDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DirScanDocument) ProtoMessage() {}
+
+func (x *DirScanDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DirScanDocument.ProtoReflect.Descriptor instead.
+func (*DirScanDocument) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *DirScanDocument) GetAuthority() string {
+	if x != nil {
+		return x.Authority
+	}
+	return ""
+}
+
+func (x *DirScanDocument) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *DirScanDocument) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *DirScanDocument) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *DirScanDocument) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *DirScanDocument) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *DirScanDocument) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *DirScanDocument) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *DirScanDocument) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *DirScanDocument) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+// 保存目录扫描结果请求
+type SaveDirScanResultReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspaceId,proto3" json:"workspaceId,omitempty"`
+	MainTaskId    string                 `protobuf:"bytes,2,opt,name=mainTaskId,proto3" json:"mainTaskId,omitempty"`
+	Results       []*DirScanDocument     `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	TaskId        string                 `protobuf:"bytes,4,opt,name=taskId,proto3" json:"taskId,omitempty"` // 执行的任务ID，使用Worker凭证时校验租约
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveDirScanResultReq) Reset() {
+	*x = SaveDirScanResultReq{}
+	mi := &file_rpc_task_task_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveDirScanResultReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveDirScanResultReq) ProtoMessage() {}
+
+func (x *SaveDirScanResultReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveDirScanResultReq.ProtoReflect.Descriptor instead.
+func (*SaveDirScanResultReq) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *SaveDirScanResultReq) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *SaveDirScanResultReq) GetMainTaskId() string {
+	if x != nil {
+		return x.MainTaskId
+	}
+	return ""
+}
+
+func (x *SaveDirScanResultReq) GetResults() []*DirScanDocument {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SaveDirScanResultReq) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// 保存目录扫描结果响应
+type SaveDirScanResultResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	NewCount      int32                  `protobuf:"varint,4,opt,name=newCount,proto3" json:"newCount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveDirScanResultResp) Reset() {
+	*x = SaveDirScanResultResp{}
+	mi := &file_rpc_task_task_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveDirScanResultResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveDirScanResultResp) ProtoMessage() {}
+
+func (x *SaveDirScanResultResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveDirScanResultResp.ProtoReflect.Descriptor instead.
+func (*SaveDirScanResultResp) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *SaveDirScanResultResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SaveDirScanResultResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SaveDirScanResultResp) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SaveDirScanResultResp) GetNewCount() int32 {
+	if x != nil {
+		return x.NewCount
+	}
+	return 0
+}
+
+// 获取敏感信息检测规则请求
+type GetSecretRulesReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EnabledOnly   bool                   `protobuf:"varint,1,opt,name=enabledOnly,proto3" json:"enabledOnly,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSecretRulesReq) Reset() {
+	*x = GetSecretRulesReq{}
+	mi := &file_rpc_task_task_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSecretRulesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSecretRulesReq) ProtoMessage() {}
+
+func (x *GetSecretRulesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSecretRulesReq.ProtoReflect.Descriptor instead.
+func (*GetSecretRulesReq) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetSecretRulesReq) GetEnabledOnly() bool {
+	if x != nil {
+		return x.EnabledOnly
+	}
+	return false
+}
+
+// 敏感信息检测规则
+type SecretRuleDocument struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Severity      string                 `protobuf:"bytes,3,opt,name=severity,proto3" json:"severity,omitempty"`
+	Pattern       string                 `protobuf:"bytes,4,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	MinEntropy    float64                `protobuf:"fixed64,5,opt,name=minEntropy,proto3" json:"minEntropy,omitempty"`
+	Validator     string                 `protobuf:"bytes,6,opt,name=validator,proto3" json:"validator,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretRuleDocument) Reset() {
+	*x = SecretRuleDocument{}
+	mi := &file_rpc_task_task_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretRuleDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretRuleDocument) ProtoMessage() {}
+
+func (x *SecretRuleDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretRuleDocument.ProtoReflect.Descriptor instead.
+func (*SecretRuleDocument) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *SecretRuleDocument) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SecretRuleDocument) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *SecretRuleDocument) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *SecretRuleDocument) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *SecretRuleDocument) GetMinEntropy() float64 {
+	if x != nil {
+		return x.MinEntropy
+	}
+	return 0
+}
+
+func (x *SecretRuleDocument) GetValidator() string {
+	if x != nil {
+		return x.Validator
+	}
+	return ""
+}
+
+// 获取敏感信息检测规则响应
+type GetSecretRulesResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Rules         []*SecretRuleDocument  `protobuf:"bytes,3,rep,name=rules,proto3" json:"rules,omitempty"`
+	Count         int32                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSecretRulesResp) Reset() {
+	*x = GetSecretRulesResp{}
+	mi := &file_rpc_task_task_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSecretRulesResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSecretRulesResp) ProtoMessage() {}
+
+func (x *GetSecretRulesResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSecretRulesResp.ProtoReflect.Descriptor instead.
+func (*GetSecretRulesResp) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetSecretRulesResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetSecretRulesResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetSecretRulesResp) GetRules() []*SecretRuleDocument {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *GetSecretRulesResp) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// 敏感信息检测结果，evidence已脱敏，hash为敏感值的SHA256
+type SecretDocument struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Authority     string                 `protobuf:"bytes,1,opt,name=authority,proto3" json:"authority,omitempty"`
+	Host          string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	Port          int32                  `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	Url           string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	Location      string                 `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	Rule          string                 `protobuf:"bytes,6,opt,name=rule,proto3" json:"rule,omitempty"`
+	Category      string                 `protobuf:"bytes,7,opt,name=category,proto3" json:"category,omitempty"`
+	Severity      string                 `protobuf:"bytes,8,opt,name=severity,proto3" json:"severity,omitempty"`
+	Evidence      string                 `protobuf:"bytes,9,opt,name=evidence,proto3" json:"evidence,omitempty"`
+	Hash          string                 `protobuf:"bytes,10,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretDocument) Reset() {
+	*x = SecretDocument{}
+	mi := &file_rpc_task_task_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretDocument) ProtoMessage() {}
+
+func (x *SecretDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretDocument.ProtoReflect.Descriptor instead.
+func (*SecretDocument) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *SecretDocument) GetAuthority() string {
+	if x != nil {
+		return x.Authority
+	}
+	return ""
+}
+
+func (x *SecretDocument) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *SecretDocument) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *SecretDocument) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *SecretDocument) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *SecretDocument) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *SecretDocument) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *SecretDocument) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *SecretDocument) GetEvidence() string {
+	if x != nil {
+		return x.Evidence
+	}
+	return ""
+}
+
+func (x *SecretDocument) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+// 保存敏感信息检测结果请求
+type SaveSecretResultReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspaceId,proto3" json:"workspaceId,omitempty"`
+	MainTaskId    string                 `protobuf:"bytes,2,opt,name=mainTaskId,proto3" json:"mainTaskId,omitempty"`
+	Findings      []*SecretDocument      `protobuf:"bytes,3,rep,name=findings,proto3" json:"findings,omitempty"`
+	TaskId        string                 `protobuf:"bytes,4,opt,name=taskId,proto3" json:"taskId,omitempty"` // 执行的任务ID，使用Worker凭证时校验租约
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveSecretResultReq) Reset() {
+	*x = SaveSecretResultReq{}
+	mi := &file_rpc_task_task_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveSecretResultReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveSecretResultReq) ProtoMessage() {}
+
+func (x *SaveSecretResultReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveSecretResultReq.ProtoReflect.Descriptor instead.
+func (*SaveSecretResultReq) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *SaveSecretResultReq) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *SaveSecretResultReq) GetMainTaskId() string {
+	if x != nil {
+		return x.MainTaskId
+	}
+	return ""
+}
+
+func (x *SaveSecretResultReq) GetFindings() []*SecretDocument {
+	if x != nil {
+		return x.Findings
+	}
+	return nil
+}
+
+func (x *SaveSecretResultReq) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// 保存敏感信息检测结果响应
+type SaveSecretResultResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	NewCount      int32                  `protobuf:"varint,4,opt,name=newCount,proto3" json:"newCount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveSecretResultResp) Reset() {
+	*x = SaveSecretResultResp{}
+	mi := &file_rpc_task_task_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveSecretResultResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveSecretResultResp) ProtoMessage() {}
+
+func (x *SaveSecretResultResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveSecretResultResp.ProtoReflect.Descriptor instead.
+func (*SaveSecretResultResp) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *SaveSecretResultResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SaveSecretResultResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SaveSecretResultResp) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SaveSecretResultResp) GetNewCount() int32 {
+	if x != nil {
+		return x.NewCount
+	}
+	return 0
+}
+
+// DNS记录
+type DnsRecordItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Ttl           uint32                 `protobuf:"varint,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DnsRecordItem) Reset() {
+	*x = DnsRecordItem{}
+	mi := &file_rpc_task_task_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DnsRecordItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DnsRecordItem) ProtoMessage() {}
+
+func (x *DnsRecordItem) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DnsRecordItem.ProtoReflect.Descriptor instead.
+func (*DnsRecordItem) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *DnsRecordItem) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DnsRecordItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DnsRecordItem) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *DnsRecordItem) GetTtl() uint32 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+// DNS配置问题
+type DnsIssueItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Severity      string                 `protobuf:"bytes,2,opt,name=severity,proto3" json:"severity,omitempty"`
+	Detail        string                 `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DnsIssueItem) Reset() {
+	*x = DnsIssueItem{}
+	mi := &file_rpc_task_task_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DnsIssueItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DnsIssueItem) ProtoMessage() {}
+
+func (x *DnsIssueItem) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DnsIssueItem.ProtoReflect.Descriptor instead.
+func (*DnsIssueItem) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *DnsIssueItem) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DnsIssueItem) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *DnsIssueItem) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+// 域名的完整DNS记录
+type DnsRecordDocument struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domain        string                 `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	Zone          string                 `protobuf:"bytes,2,opt,name=zone,proto3" json:"zone,omitempty"`
+	Records       []*DnsRecordItem       `protobuf:"bytes,3,rep,name=records,proto3" json:"records,omitempty"`
+	Spf           string                 `protobuf:"bytes,4,opt,name=spf,proto3" json:"spf,omitempty"`
+	Dmarc         string                 `protobuf:"bytes,5,opt,name=dmarc,proto3" json:"dmarc,omitempty"`
+	ZoneTransfer  []string               `protobuf:"bytes,6,rep,name=zoneTransfer,proto3" json:"zoneTransfer,omitempty"`
+	Issues        []*DnsIssueItem        `protobuf:"bytes,7,rep,name=issues,proto3" json:"issues,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DnsRecordDocument) Reset() {
+	*x = DnsRecordDocument{}
+	mi := &file_rpc_task_task_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DnsRecordDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DnsRecordDocument) ProtoMessage() {}
+
+func (x *DnsRecordDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DnsRecordDocument.ProtoReflect.Descriptor instead.
+func (*DnsRecordDocument) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *DnsRecordDocument) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *DnsRecordDocument) GetZone() string {
+	if x != nil {
+		return x.Zone
+	}
+	return ""
+}
+
+func (x *DnsRecordDocument) GetRecords() []*DnsRecordItem {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *DnsRecordDocument) GetSpf() string {
+	if x != nil {
+		return x.Spf
+	}
+	return ""
+}
+
+func (x *DnsRecordDocument) GetDmarc() string {
+	if x != nil {
+		return x.Dmarc
+	}
+	return ""
+}
+
+func (x *DnsRecordDocument) GetZoneTransfer() []string {
+	if x != nil {
+		return x.ZoneTransfer
+	}
+	return nil
+}
+
+func (x *DnsRecordDocument) GetIssues() []*DnsIssueItem {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+// 保存DNS记录请求
+type SaveDnsRecordResultReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspaceId,proto3" json:"workspaceId,omitempty"`
+	MainTaskId    string                 `protobuf:"bytes,2,opt,name=mainTaskId,proto3" json:"mainTaskId,omitempty"`
+	Domains       []*DnsRecordDocument   `protobuf:"bytes,3,rep,name=domains,proto3" json:"domains,omitempty"`
+	TaskId        string                 `protobuf:"bytes,4,opt,name=taskId,proto3" json:"taskId,omitempty"` // 执行的任务ID，使用Worker凭证时校验租约
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveDnsRecordResultReq) Reset() {
+	*x = SaveDnsRecordResultReq{}
+	mi := &file_rpc_task_task_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveDnsRecordResultReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveDnsRecordResultReq) ProtoMessage() {}
+
+func (x *SaveDnsRecordResultReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveDnsRecordResultReq.ProtoReflect.Descriptor instead.
+func (*SaveDnsRecordResultReq) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *SaveDnsRecordResultReq) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *SaveDnsRecordResultReq) GetMainTaskId() string {
+	if x != nil {
+		return x.MainTaskId
+	}
+	return ""
+}
+
+func (x *SaveDnsRecordResultReq) GetDomains() []*DnsRecordDocument {
+	if x != nil {
+		return x.Domains
+	}
+	return nil
+}
+
+func (x *SaveDnsRecordResultReq) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// 保存DNS记录响应
+type SaveDnsRecordResultResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	NewCount      int32                  `protobuf:"varint,4,opt,name=newCount,proto3" json:"newCount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveDnsRecordResultResp) Reset() {
+	*x = SaveDnsRecordResultResp{}
+	mi := &file_rpc_task_task_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveDnsRecordResultResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveDnsRecordResultResp) ProtoMessage() {}
+
+func (x *SaveDnsRecordResultResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveDnsRecordResultResp.ProtoReflect.Descriptor instead.
+func (*SaveDnsRecordResultResp) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *SaveDnsRecordResultResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SaveDnsRecordResultResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SaveDnsRecordResultResp) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SaveDnsRecordResultResp) GetNewCount() int32 {
+	if x != nil {
+		return x.NewCount
+	}
+	return 0
+}
+
+// 子域名及其解析结果
+type SubdomainDocument struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domain        string                 `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	Ips           []string               `protobuf:"bytes,2,rep,name=ips,proto3" json:"ips,omitempty"`
+	Cname         string                 `protobuf:"bytes,3,opt,name=cname,proto3" json:"cname,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubdomainDocument) Reset() {
+	*x = SubdomainDocument{}
+	mi := &file_rpc_task_task_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubdomainDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubdomainDocument) ProtoMessage() {}
+
+func (x *SubdomainDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubdomainDocument.ProtoReflect.Descriptor instead.
+func (*SubdomainDocument) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *SubdomainDocument) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *SubdomainDocument) GetIps() []string {
+	if x != nil {
+		return x.Ips
+	}
+	return nil
+}
+
+func (x *SubdomainDocument) GetCname() string {
+	if x != nil {
+		return x.Cname
+	}
+	return ""
+}
+
+// 子域名监控同步请求
+type SyncSubdomainsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspaceId,proto3" json:"workspaceId,omitempty"`
+	MainTaskId    string                 `protobuf:"bytes,2,opt,name=mainTaskId,proto3" json:"mainTaskId,omitempty"`
+	Subdomains    []*SubdomainDocument   `protobuf:"bytes,3,rep,name=subdomains,proto3" json:"subdomains,omitempty"`
+	Confirm       bool                   `protobuf:"varint,4,opt,name=confirm,proto3" json:"confirm,omitempty"` // 扫描完成后确认：待确认的子域名转为已知，新增子域名记录到主任务
+	TaskId        string                 `protobuf:"bytes,5,opt,name=taskId,proto3" json:"taskId,omitempty"`    // 执行的任务ID，使用Worker凭证时校验租约
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncSubdomainsReq) Reset() {
+	*x = SyncSubdomainsReq{}
+	mi := &file_rpc_task_task_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncSubdomainsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncSubdomainsReq) ProtoMessage() {}
+
+func (x *SyncSubdomainsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncSubdomainsReq.ProtoReflect.Descriptor instead.
+func (*SyncSubdomainsReq) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *SyncSubdomainsReq) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *SyncSubdomainsReq) GetMainTaskId() string {
+	if x != nil {
+		return x.MainTaskId
+	}
+	return ""
+}
+
+func (x *SyncSubdomainsReq) GetSubdomains() []*SubdomainDocument {
+	if x != nil {
+		return x.Subdomains
+	}
+	return nil
+}
+
+func (x *SyncSubdomainsReq) GetConfirm() bool {
+	if x != nil {
+		return x.Confirm
+	}
+	return false
+}
+
+func (x *SyncSubdomainsReq) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// 子域名监控同步响应
+type SyncSubdomainsResp struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Total          int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	NewDomains     []string               `protobuf:"bytes,4,rep,name=newDomains,proto3" json:"newDomains,omitempty"`
+	ChangedDomains []string               `protobuf:"bytes,5,rep,name=changedDomains,proto3" json:"changedDomains,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SyncSubdomainsResp) Reset() {
+	*x = SyncSubdomainsResp{}
+	mi := &file_rpc_task_task_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncSubdomainsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncSubdomainsResp) ProtoMessage() {}
+
+func (x *SyncSubdomainsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncSubdomainsResp.ProtoReflect.Descriptor instead.
+func (*SyncSubdomainsResp) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *SyncSubdomainsResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SyncSubdomainsResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SyncSubdomainsResp) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SyncSubdomainsResp) GetNewDomains() []string {
+	if x != nil {
+		return x.NewDomains
+	}
+	return nil
+}
+
+func (x *SyncSubdomainsResp) GetChangedDomains() []string {
+	if x != nil {
+		return x.ChangedDomains
+	}
+	return nil
+}
+
+// Worker注册请求
+type EnrollWorkerReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	WorkerName    string                 `protobuf:"bytes,2,opt,name=workerName,proto3" json:"workerName,omitempty"`
+	Ip            string                 `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollWorkerReq) Reset() {
+	*x = EnrollWorkerReq{}
+	mi := &file_rpc_task_task_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollWorkerReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollWorkerReq) ProtoMessage() {}
+
+func (x *EnrollWorkerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollWorkerReq.ProtoReflect.Descriptor instead.
+func (*EnrollWorkerReq) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *EnrollWorkerReq) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *EnrollWorkerReq) GetWorkerName() string {
+	if x != nil {
+		return x.WorkerName
+	}
+	return ""
+}
+
+func (x *EnrollWorkerReq) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+// Worker注册响应，凭证明文只在此时返回
+type EnrollWorkerResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	WorkerId      string                 `protobuf:"bytes,3,opt,name=workerId,proto3" json:"workerId,omitempty"`
+	WorkerName    string                 `protobuf:"bytes,4,opt,name=workerName,proto3" json:"workerName,omitempty"`
+	Credential    string                 `protobuf:"bytes,5,opt,name=credential,proto3" json:"credential,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollWorkerResp) Reset() {
+	*x = EnrollWorkerResp{}
+	mi := &file_rpc_task_task_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollWorkerResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollWorkerResp) ProtoMessage() {}
+
+func (x *EnrollWorkerResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollWorkerResp.ProtoReflect.Descriptor instead.
+func (*EnrollWorkerResp) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *EnrollWorkerResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *EnrollWorkerResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *EnrollWorkerResp) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+func (x *EnrollWorkerResp) GetWorkerName() string {
+	if x != nil {
+		return x.WorkerName
+	}
+	return ""
+}
+
+func (x *EnrollWorkerResp) GetCredential() string {
+	if x != nil {
+		return x.Credential
+	}
+	return ""
+}
+
+// Worker上线请求
+type WorkerOnlineReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkerName    string                 `protobuf:"bytes,1,opt,name=workerName,proto3" json:"workerName,omitempty"`
+	Ip            string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerOnlineReq) Reset() {
+	*x = WorkerOnlineReq{}
+	mi := &file_rpc_task_task_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerOnlineReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerOnlineReq) ProtoMessage() {}
+
+func (x *WorkerOnlineReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerOnlineReq.ProtoReflect.Descriptor instead.
+func (*WorkerOnlineReq) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *WorkerOnlineReq) GetWorkerName() string {
+	if x != nil {
+		return x.WorkerName
+	}
+	return ""
+}
+
+func (x *WorkerOnlineReq) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+// Worker上线响应，workerName为Worker最终使用的名称
+type WorkerOnlineResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	WorkerName    string                 `protobuf:"bytes,3,opt,name=workerName,proto3" json:"workerName,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerOnlineResp) Reset() {
+	*x = WorkerOnlineResp{}
+	mi := &file_rpc_task_task_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerOnlineResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerOnlineResp) ProtoMessage() {}
+
+func (x *WorkerOnlineResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerOnlineResp.ProtoReflect.Descriptor instead.
+func (*WorkerOnlineResp) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *WorkerOnlineResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *WorkerOnlineResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *WorkerOnlineResp) GetWorkerName() string {
+	if x != nil {
+		return x.WorkerName
+	}
+	return ""
+}
+
+// Worker日志条目
+type WorkerLogEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     string                 `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Level         string                 `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	WorkerName    string                 `protobuf:"bytes,3,opt,name=workerName,proto3" json:"workerName,omitempty"`
+	TaskId        string                 `protobuf:"bytes,4,opt,name=taskId,proto3" json:"taskId,omitempty"` // 任务日志时不为空
+	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerLogEntry) Reset() {
+	*x = WorkerLogEntry{}
+	mi := &file_rpc_task_task_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerLogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerLogEntry) ProtoMessage() {}
+
+func (x *WorkerLogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerLogEntry.ProtoReflect.Descriptor instead.
+func (*WorkerLogEntry) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *WorkerLogEntry) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *WorkerLogEntry) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *WorkerLogEntry) GetWorkerName() string {
+	if x != nil {
+		return x.WorkerName
+	}
+	return ""
+}
+
+func (x *WorkerLogEntry) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *WorkerLogEntry) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Worker日志上报响应
+type PushWorkerLogsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushWorkerLogsResp) Reset() {
+	*x = PushWorkerLogsResp{}
+	mi := &file_rpc_task_task_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushWorkerLogsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushWorkerLogsResp) ProtoMessage() {}
+
+func (x *PushWorkerLogsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushWorkerLogsResp.ProtoReflect.Descriptor instead.
+func (*PushWorkerLogsResp) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *PushWorkerLogsResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PushWorkerLogsResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PushWorkerLogsResp) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Worker状态上报，offline为true表示Worker正常退出
+type WorkerStatusReport struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	WorkerName         string                 `protobuf:"bytes,1,opt,name=workerName,proto3" json:"workerName,omitempty"`
+	Ip                 string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	CpuLoad            float64                `protobuf:"fixed64,3,opt,name=cpuLoad,proto3" json:"cpuLoad,omitempty"`
+	MemUsed            float64                `protobuf:"fixed64,4,opt,name=memUsed,proto3" json:"memUsed,omitempty"`
+	TaskStartedNumber  int32                  `protobuf:"varint,5,opt,name=taskStartedNumber,proto3" json:"taskStartedNumber,omitempty"`
+	TaskExecutedNumber int32                  `protobuf:"varint,6,opt,name=taskExecutedNumber,proto3" json:"taskExecutedNumber,omitempty"`
+	HealthStatus       string                 `protobuf:"bytes,7,opt,name=healthStatus,proto3" json:"healthStatus,omitempty"`
+	IsThrottled        bool                   `protobuf:"varint,8,opt,name=isThrottled,proto3" json:"isThrottled,omitempty"`
+	CpuOverloadCount   int32                  `protobuf:"varint,9,opt,name=cpuOverloadCount,proto3" json:"cpuOverloadCount,omitempty"`
+	Concurrency        int32                  `protobuf:"varint,10,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	RunningTasks       int32                  `protobuf:"varint,11,opt,name=runningTasks,proto3" json:"runningTasks,omitempty"`
+	Tools              map[string]bool        `protobuf:"bytes,12,rep,name=tools,proto3" json:"tools,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // 工具安装状态
+	Offline            bool                   `protobuf:"varint,13,opt,name=offline,proto3" json:"offline,omitempty"`
+	Labels             map[string]string      `protobuf:"bytes,14,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Worker标签
+	Capabilities       []string               `protobuf:"bytes,15,rep,name=capabilities,proto3" json:"capabilities,omitempty"`                                                               // Worker能力
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *WorkerStatusReport) Reset() {
+	*x = WorkerStatusReport{}
+	mi := &file_rpc_task_task_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerStatusReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerStatusReport) ProtoMessage() {}
+
+func (x *WorkerStatusReport) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerStatusReport.ProtoReflect.Descriptor instead.
+func (*WorkerStatusReport) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *WorkerStatusReport) GetWorkerName() string {
+	if x != nil {
+		return x.WorkerName
+	}
+	return ""
+}
+
+func (x *WorkerStatusReport) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *WorkerStatusReport) GetCpuLoad() float64 {
+	if x != nil {
+		return x.CpuLoad
+	}
+	return 0
+}
+
+func (x *WorkerStatusReport) GetMemUsed() float64 {
+	if x != nil {
+		return x.MemUsed
+	}
+	return 0
+}
+
+func (x *WorkerStatusReport) GetTaskStartedNumber() int32 {
+	if x != nil {
+		return x.TaskStartedNumber
+	}
+	return 0
+}
+
+func (x *WorkerStatusReport) GetTaskExecutedNumber() int32 {
+	if x != nil {
+		return x.TaskExecutedNumber
+	}
+	return 0
+}
+
+func (x *WorkerStatusReport) GetHealthStatus() string {
+	if x != nil {
+		return x.HealthStatus
+	}
+	return ""
+}
+
+func (x *WorkerStatusReport) GetIsThrottled() bool {
+	if x != nil {
+		return x.IsThrottled
+	}
+	return false
+}
+
+func (x *WorkerStatusReport) GetCpuOverloadCount() int32 {
+	if x != nil {
+		return x.CpuOverloadCount
+	}
+	return 0
+}
+
+func (x *WorkerStatusReport) GetConcurrency() int32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+func (x *WorkerStatusReport) GetRunningTasks() int32 {
+	if x != nil {
+		return x.RunningTasks
+	}
+	return 0
+}
+
+func (x *WorkerStatusReport) GetTools() map[string]bool {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+func (x *WorkerStatusReport) GetOffline() bool {
+	if x != nil {
+		return x.Offline
+	}
+	return false
+}
+
+func (x *WorkerStatusReport) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *WorkerStatusReport) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+// 服务端下发的命令: stop, restart, rename, setConcurrency, query(立即上报状态)
+type WorkerCommand struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        string                 `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	WorkerName    string                 `protobuf:"bytes,2,opt,name=workerName,proto3" json:"workerName,omitempty"`
+	NewName       string                 `protobuf:"bytes,3,opt,name=newName,proto3" json:"newName,omitempty"`
+	Concurrency   int32                  `protobuf:"varint,4,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerCommand) Reset() {
+	*x = WorkerCommand{}
+	mi := &file_rpc_task_task_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerCommand) ProtoMessage() {}
+
+func (x *WorkerCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerCommand.ProtoReflect.Descriptor instead.
+func (*WorkerCommand) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *WorkerCommand) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *WorkerCommand) GetWorkerName() string {
+	if x != nil {
+		return x.WorkerName
+	}
+	return ""
+}
+
+func (x *WorkerCommand) GetNewName() string {
+	if x != nil {
+		return x.NewName
+	}
+	return ""
+}
+
+func (x *WorkerCommand) GetConcurrency() int32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+// 任务控制信号请求
+type GetTaskControlReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskControlReq) Reset() {
+	*x = GetTaskControlReq{}
+	mi := &file_rpc_task_task_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskControlReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskControlReq) ProtoMessage() {}
+
+func (x *GetTaskControlReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskControlReq.ProtoReflect.Descriptor instead.
+func (*GetTaskControlReq) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *GetTaskControlReq) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// 任务控制信号响应: PAUSE, STOP，为空表示继续执行
+type GetTaskControlResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Control       string                 `protobuf:"bytes,1,opt,name=control,proto3" json:"control,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskControlResp) Reset() {
+	*x = GetTaskControlResp{}
+	mi := &file_rpc_task_task_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskControlResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskControlResp) ProtoMessage() {}
+
+func (x *GetTaskControlResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskControlResp.ProtoReflect.Descriptor instead.
+func (*GetTaskControlResp) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *GetTaskControlResp) GetControl() string {
+	if x != nil {
+		return x.Control
+	}
+	return ""
+}
+
+// 任务进度更新请求
+type UpdateTaskProgressReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	Progress      int32                  `protobuf:"varint,2,opt,name=progress,proto3" json:"progress,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	CurrentPhase  string                 `protobuf:"bytes,4,opt,name=currentPhase,proto3" json:"currentPhase,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskProgressReq) Reset() {
+	*x = UpdateTaskProgressReq{}
+	mi := &file_rpc_task_task_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskProgressReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskProgressReq) ProtoMessage() {}
+
+func (x *UpdateTaskProgressReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskProgressReq.ProtoReflect.Descriptor instead.
+func (*UpdateTaskProgressReq) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *UpdateTaskProgressReq) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *UpdateTaskProgressReq) GetProgress() int32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *UpdateTaskProgressReq) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *UpdateTaskProgressReq) GetCurrentPhase() string {
+	if x != nil {
+		return x.CurrentPhase
+	}
+	return ""
+}
+
+// 任务进度更新响应
+type UpdateTaskProgressResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskProgressResp) Reset() {
+	*x = UpdateTaskProgressResp{}
+	mi := &file_rpc_task_task_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskProgressResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskProgressResp) ProtoMessage() {}
+
+func (x *UpdateTaskProgressResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskProgressResp.ProtoReflect.Descriptor instead.
+func (*UpdateTaskProgressResp) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *UpdateTaskProgressResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateTaskProgressResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// 保存POC验证结果请求，error不为空表示验证失败
+type SavePocValidationResultReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	BatchId       string                 `protobuf:"bytes,2,opt,name=batchId,proto3" json:"batchId,omitempty"`
+	Results       []*PocValidationResult `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SavePocValidationResultReq) Reset() {
+	*x = SavePocValidationResultReq{}
+	mi := &file_rpc_task_task_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavePocValidationResultReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavePocValidationResultReq) ProtoMessage() {}
+
+func (x *SavePocValidationResultReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavePocValidationResultReq.ProtoReflect.Descriptor instead.
+func (*SavePocValidationResultReq) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *SavePocValidationResultReq) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *SavePocValidationResultReq) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *SavePocValidationResultReq) GetResults() []*PocValidationResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SavePocValidationResultReq) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// 保存POC验证结果响应
+type SavePocValidationResultResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SavePocValidationResultResp) Reset() {
+	*x = SavePocValidationResultResp{}
+	mi := &file_rpc_task_task_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavePocValidationResultResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavePocValidationResultResp) ProtoMessage() {}
+
+func (x *SavePocValidationResultResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_task_task_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavePocValidationResultResp.ProtoReflect.Descriptor instead.
+func (*SavePocValidationResultResp) Descriptor() ([]byte, []int) {
+	return file_rpc_task_task_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *SavePocValidationResultResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SavePocValidationResultResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_rpc_task_task_proto protoreflect.FileDescriptor
+
+const file_rpc_task_task_proto_rawDesc = "" +
+	"\n" +
+	"\x13rpc/task/task.proto\x12\x04task\"F\n" +
+	"\fCheckTaskReq\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\tR\x06taskId\x12\x1e\n" +
+	"\n" +
+	"mainTaskId\x18\x02 \x01(\tR\n" +
+	"mainTaskId\"\xe1\x01\n" +
+	"\rCheckTaskResp\x12\x18\n" +
+	"\aisExist\x18\x01 \x01(\bR\aisExist\x12\x1e\n" +
+	"\n" +
+	"isFinished\x18\x02 \x01(\bR\n" +
+	"isFinished\x12\x16\n" +
+	"\x06taskId\x18\x03 \x01(\tR\x06taskId\x12\x14\n" +
+	"\x05state\x18\x04 \x01(\tR\x05state\x12\x16\n" +
+	"\x06worker\x18\x05 \x01(\tR\x06worker\x12\x16\n" +
+	"\x06result\x18\x06 \x01(\tR\x06result\x12 \n" +
+	"\vworkspaceId\x18\a \x01(\tR\vworkspaceId\x12\x16\n" +
+	"\x06config\x18\b \x01(\tR\x06config\"m\n" +
+	"\rUpdateTaskReq\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\tR\x06taskId\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x16\n" +
+	"\x06worker\x18\x03 \x01(\tR\x06worker\x12\x16\n" +
+	"\x06result\x18\x04 \x01(\tR\x06result\"D\n" +
+	"\x0eUpdateTaskResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x9a\x01\n" +
+	"\n" +
+	"NewTaskReq\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\tR\x06taskId\x12\x1e\n" +
+	"\n" +
+	"mainTaskId\x18\x02 \x01(\tR\n" +
+	"mainTaskId\x12\x1a\n" +
+	"\btaskName\x18\x03 \x01(\tR\btaskName\x12\x16\n" +
+	"\x06config\x18\x04 \x01(\tR\x06config\x12 \n" +
+	"\vworkspaceId\x18\x05 \x01(\tR\vworkspaceId\"A\n" +
+	"\vNewTaskResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x89\x05\n" +
+	"\rAssetDocument\x12\x1c\n" +
+	"\tauthority\x18\x01 \x01(\tR\tauthority\x12\x12\n" +
+	"\x04host\x18\x02 \x01(\tR\x04host\x12\x12\n" +
+	"\x04port\x18\x03 \x01(\x05R\x04port\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x18\n" +
+	"\aservice\x18\x05 \x01(\tR\aservice\x12\x16\n" +
+	"\x06server\x18\x06 \x01(\tR\x06server\x12\x16\n" +
+	"\x06banner\x18\a \x01(\tR\x06banner\x12\x14\n" +
+	"\x05title\x18\b \x01(\tR\x05title\x12\x10\n" +
+	"\x03app\x18\t \x03(\tR\x03app\x12\x1e\n" +
+	"\n" +
+	"httpStatus\x18\n" +
+	" \x01(\tR\n" +
+	"httpStatus\x12\x1e\n" +
+	"\n" +
+	"httpHeader\x18\v \x01(\tR\n" +
+	"httpHeader\x12\x1a\n" +
+	"\bhttpBody\x18\f \x01(\tR\bhttpBody\x12\x12\n" +
+	"\x04cert\x18\r \x01(\tR\x04cert\x12\x1a\n" +
+	"\biconHash\x18\x0e \x01(\tR\biconHash\x12\x14\n" +
+	"\x05isCdn\x18\x0f \x01(\bR\x05isCdn\x12\x14\n" +
+	"\x05cname\x18\x10 \x01(\tR\x05cname\x12\x18\n" +
+	"\aisCloud\x18\x11 \x01(\bR\aisCloud\x12\x1e\n" +
+	"\x04ipv4\x18\x12 \x03(\v2\n" +
+	".task.IPV4R\x04ipv4\x12\x1e\n" +
+	"\x04ipv6\x18\x13 \x03(\v2\n" +
+	".task.IPV6R\x04ipv6\x12\x1e\n" +
+	"\n" +
+	"screenshot\x18\x14 \x01(\tR\n" +
+	"screenshot\x12\x16\n" +
+	"\x06isHttp\x18\x15 \x01(\bR\x06isHttp\x12\x16\n" +
+	"\x06source\x18\x16 \x01(\tR\x06source\x12\x1a\n" +
+	"\biconData\x18\x17 \x01(\fR\biconData\x12&\n" +
+	"\x0escreenshotHash\x18\x18 \x01(\tR\x0escreenshotHash\"H\n" +
+	"\x04IPV4\x12\x0e\n" +
+	"\x02ip\x18\x01 \x01(\tR\x02ip\x12\x14\n" +
+	"\x05ipInt\x18\x02 \x01(\rR\x05ipInt\x12\x1a\n" +
+	"\blocation\x18\x03 \x01(\tR\blocation\"2\n" +
+	"\x04IPV6\x12\x0e\n" +
+	"\x02ip\x18\x01 \x01(\tR\x02ip\x12\x1a\n" +
+	"\blocation\x18\x02 \x01(\tR\blocation\"\xb0\x01\n" +
+	"\x11SaveTaskResultReq\x12 \n" +
+	"\vworkspaceId\x18\x01 \x01(\tR\vworkspaceId\x12\x1e\n" +
+	"\n" +
+	"mainTaskId\x18\x02 \x01(\tR\n" +
+	"mainTaskId\x12+\n" +
+	"\x06assets\x18\x03 \x03(\v2\x13.task.AssetDocumentR\x06assets\x12\x14\n" +
+	"\x05orgId\x18\x04 \x01(\tR\x05orgId\x12\x16\n" +
+	"\x06taskId\x18\x05 \x01(\tR\x06taskId\"\xa6\x01\n" +
+	"\x12SaveTaskResultResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1e\n" +
+	"\n" +
+	"totalAsset\x18\x03 \x01(\x05R\n" +
+	"totalAsset\x12\x1a\n" +
+	"\bnewAsset\x18\x04 \x01(\x05R\bnewAsset\x12 \n" +
+	"\vupdateAsset\x18\x05 \x01(\x05R\vupdateAsset\"\x87\x06\n" +
+	"\vVulDocument\x12\x1c\n" +
+	"\tauthority\x18\x01 \x01(\tR\tauthority\x12\x12\n" +
+	"\x04host\x18\x02 \x01(\tR\x04host\x12\x12\n" +
+	"\x04port\x18\x03 \x01(\x05R\x04port\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12\x18\n" +
+	"\apocFile\x18\x05 \x01(\tR\apocFile\x12\x16\n" +
+	"\x06source\x18\x06 \x01(\tR\x06source\x12\x1a\n" +
+	"\bseverity\x18\a \x01(\tR\bseverity\x12\x14\n" +
+	"\x05extra\x18\b \x01(\tR\x05extra\x12\x16\n" +
+	"\x06result\x18\t \x01(\tR\x06result\x12\x16\n" +
+	"\x06taskId\x18\n" +
+	" \x01(\tR\x06taskId\x12!\n" +
+	"\tcvssScore\x18\v \x01(\x01H\x00R\tcvssScore\x88\x01\x01\x12\x19\n" +
+	"\x05cveId\x18\f \x01(\tH\x01R\x05cveId\x88\x01\x01\x12\x19\n" +
+	"\x05cweId\x18\r \x01(\tH\x02R\x05cweId\x88\x01\x01\x12%\n" +
+	"\vremediation\x18\x0e \x01(\tH\x03R\vremediation\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"references\x18\x0f \x03(\tR\n" +
+	"references\x12%\n" +
+	"\vmatcherName\x18\x10 \x01(\tH\x04R\vmatcherName\x88\x01\x01\x12*\n" +
+	"\x10extractedResults\x18\x11 \x03(\tR\x10extractedResults\x12%\n" +
+	"\vcurlCommand\x18\x12 \x01(\tH\x05R\vcurlCommand\x88\x01\x01\x12\x1d\n" +
+	"\arequest\x18\x13 \x01(\tH\x06R\arequest\x88\x01\x01\x12\x1f\n" +
+	"\bresponse\x18\x14 \x01(\tH\aR\bresponse\x88\x01\x01\x121\n" +
+	"\x11responseTruncated\x18\x15 \x01(\bH\bR\x11responseTruncated\x88\x01\x01B\f\n" +
+	"\n" +
+	"_cvssScoreB\b\n" +
+	"\x06_cveIdB\b\n" +
+	"\x06_cweIdB\x0e\n" +
+	"\f_remediationB\x0e\n" +
+	"\f_matcherNameB\x0e\n" +
+	"\f_curlCommandB\n" +
+	"\n" +
+	"\b_requestB\v\n" +
+	"\t_responseB\x14\n" +
+	"\x12_responseTruncated\"\x93\x01\n" +
+	"\x10SaveVulResultReq\x12 \n" +
+	"\vworkspaceId\x18\x01 \x01(\tR\vworkspaceId\x12\x1e\n" +
+	"\n" +
+	"mainTaskId\x18\x02 \x01(\tR\n" +
+	"mainTaskId\x12%\n" +
+	"\x04vuls\x18\x03 \x03(\v2\x11.task.VulDocumentR\x04vuls\x12\x16\n" +
+	"\x06taskId\x18\x04 \x01(\tR\x06taskId\"]\n" +
+	"\x11SaveVulResultResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\"\x9d\x03\n" +
+	"\fKeepAliveReq\x12\x1e\n" +
+	"\n" +
+	"workerName\x18\x01 \x01(\tR\n" +
+	"workerName\x12\x18\n" +
+	"\acpuLoad\x18\x02 \x01(\x01R\acpuLoad\x12\x18\n" +
+	"\amemUsed\x18\x03 \x01(\x01R\amemUsed\x12,\n" +
+	"\x11taskStartedNumber\x18\x04 \x01(\x05R\x11taskStartedNumber\x12.\n" +
+	"\x12taskExecutedNumber\x18\x05 \x01(\x05R\x12taskExecutedNumber\x12\x1a\n" +
+	"\bisDaemon\x18\x06 \x01(\bR\bisDaemon\x12\x0e\n" +
+	"\x02ip\x18\a \x01(\tR\x02ip\x12\x18\n" +
+	"\ataskIds\x18\b \x03(\tR\ataskIds\x126\n" +
+	"\x06labels\x18\t \x03(\v2\x1e.task.KeepAliveReq.LabelsEntryR\x06labels\x12\"\n" +
+	"\fcapabilities\x18\n" +
+	" \x03(\tR\fcapabilities\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf3\x01\n" +
+	"\rKeepAliveResp\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12&\n" +
+	"\x0emanualStopFlag\x18\x02 \x01(\bR\x0emanualStopFlag\x12*\n" +
+	"\x10manualReloadFlag\x18\x03 \x01(\bR\x10manualReloadFlag\x12,\n" +
+	"\x11manualInitEnvFlag\x18\x04 \x01(\bR\x11manualInitEnvFlag\x12&\n" +
+	"\x0emanualSyncFlag\x18\x05 \x01(\bR\x0emanualSyncFlag\x12 \n" +
+	"\vlostTaskIds\x18\x06 \x03(\tR\vlostTaskIds\"4\n" +
+	"\x12GetWorkerConfigReq\x12\x1e\n" +
+	"\n" +
+	"workerName\x18\x01 \x01(\tR\n" +
+	"workerName\"-\n" +
+	"\x13GetWorkerConfigResp\x12\x16\n" +
+	"\x06config\x18\x01 \x01(\tR\x06config\"D\n" +
+	"\x12RequestResourceReq\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"Q\n" +
+	"\x13RequestResourceResp\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04hash\x18\x02 \x01(\tR\x04hash\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"K\n" +
+	"\x15GetTemplatesByTagsReq\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\x12\x1e\n" +
+	"\n" +
+	"severities\x18\x02 \x03(\tR\n" +
+	"severities\"\x80\x01\n" +
+	"\x16GetTemplatesByTagsResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1c\n" +
+	"\ttemplates\x18\x03 \x03(\tR\ttemplates\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count\"<\n" +
+	"\x18GetCustomFingerprintsReq\x12 \n" +
+	"\venabledOnly\x18\x01 \x01(\bR\venabledOnly\"\x97\x05\n" +
+	"\x13FingerprintDocument\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04rule\x18\x04 \x01(\tR\x04rule\x12\x16\n" +
+	"\x06source\x18\x05 \x01(\tR\x06source\x12@\n" +
+	"\aheaders\x18\x06 \x03(\v2&.task.FingerprintDocument.HeadersEntryR\aheaders\x12@\n" +
+	"\acookies\x18\a \x03(\v2&.task.FingerprintDocument.CookiesEntryR\acookies\x12\x12\n" +
+	"\x04html\x18\b \x03(\tR\x04html\x12\x18\n" +
+	"\ascripts\x18\t \x03(\tR\ascripts\x12\x1c\n" +
+	"\tscriptSrc\x18\n" +
+	" \x03(\tR\tscriptSrc\x127\n" +
+	"\x04meta\x18\v \x03(\v2#.task.FingerprintDocument.MetaEntryR\x04meta\x12\x10\n" +
+	"\x03css\x18\f \x03(\tR\x03css\x12\x10\n" +
+	"\x03url\x18\r \x03(\tR\x03url\x12\x1c\n" +
+	"\tisBuiltin\x18\x0e \x01(\bR\tisBuiltin\x12\x18\n" +
+	"\aenabled\x18\x0f \x01(\bR\aenabled\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a:\n" +
+	"\fCookiesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a7\n" +
+	"\tMetaEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa4\x01\n" +
+	"\x19GetCustomFingerprintsResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12=\n" +
 	"\ffingerprints\x18\x03 \x03(\v2\x19.task.FingerprintDocumentR\ffingerprints\x12\x14\n" +
 	"\x05count\x18\x04 \x01(\x05R\x05count\"f\n" +
 	"\x16ValidateFingerprintReq\x12\x10\n" +
@@ -3970,8 +6681,252 @@ const file_rpc_task_task_proto_rawDesc = "" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12=\n" +
 	"\tproviders\x18\x03 \x03(\v2\x1f.task.SubfinderProviderDocumentR\tproviders\x12\x14\n" +
-	"\x05count\x18\x04 \x01(\x05R\x05count2\x9a\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count\"\xe7\x02\n" +
+	"\vUrlDocument\x12\x1c\n" +
+	"\tauthority\x18\x01 \x01(\tR\tauthority\x12\x12\n" +
+	"\x04host\x18\x02 \x01(\tR\x04host\x12\x12\n" +
+	"\x04port\x18\x03 \x01(\x05R\x04port\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12\x1a\n" +
+	"\bendpoint\x18\x05 \x01(\tR\bendpoint\x12\x16\n" +
+	"\x06method\x18\x06 \x01(\tR\x06method\x12\x12\n" +
+	"\x04path\x18\a \x01(\tR\x04path\x12\x16\n" +
+	"\x06params\x18\b \x03(\tR\x06params\x12\x16\n" +
+	"\x06source\x18\t \x01(\tR\x06source\x12\x1e\n" +
+	"\n" +
+	"statusCode\x18\n" +
+	" \x01(\x05R\n" +
+	"statusCode\x12 \n" +
+	"\vcontentType\x18\v \x01(\tR\vcontentType\x12\x16\n" +
+	"\x06length\x18\f \x01(\x05R\x06length\x12\x14\n" +
+	"\x05title\x18\r \x01(\tR\x05title\x12\x18\n" +
+	"\areferer\x18\x0e \x01(\tR\areferer\"\x93\x01\n" +
+	"\x10SaveUrlResultReq\x12 \n" +
+	"\vworkspaceId\x18\x01 \x01(\tR\vworkspaceId\x12\x1e\n" +
+	"\n" +
+	"mainTaskId\x18\x02 \x01(\tR\n" +
+	"mainTaskId\x12%\n" +
+	"\x04urls\x18\x03 \x03(\v2\x11.task.UrlDocumentR\x04urls\x12\x16\n" +
+	"\x06taskId\x18\x04 \x01(\tR\x06taskId\"y\n" +
+	"\x11SaveUrlResultResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x1a\n" +
+	"\bnewCount\x18\x04 \x01(\x05R\bnewCount\"7\n" +
+	"\x0fGetWordlistsReq\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\"r\n" +
+	"\x10GetWordlistsResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05words\x18\x03 \x03(\tR\x05words\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count\"\x81\x02\n" +
+	"\x0fDirScanDocument\x12\x1c\n" +
+	"\tauthority\x18\x01 \x01(\tR\tauthority\x12\x12\n" +
+	"\x04host\x18\x02 \x01(\tR\x04host\x12\x12\n" +
+	"\x04port\x18\x03 \x01(\x05R\x04port\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12\x12\n" +
+	"\x04path\x18\x05 \x01(\tR\x04path\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\x05R\x06status\x12\x16\n" +
+	"\x06length\x18\a \x01(\x05R\x06length\x12\x14\n" +
+	"\x05title\x18\b \x01(\tR\x05title\x12 \n" +
+	"\vcontentType\x18\t \x01(\tR\vcontentType\x12\x1a\n" +
+	"\blocation\x18\n" +
+	" \x01(\tR\blocation\"\xa1\x01\n" +
+	"\x14SaveDirScanResultReq\x12 \n" +
+	"\vworkspaceId\x18\x01 \x01(\tR\vworkspaceId\x12\x1e\n" +
+	"\n" +
+	"mainTaskId\x18\x02 \x01(\tR\n" +
+	"mainTaskId\x12/\n" +
+	"\aresults\x18\x03 \x03(\v2\x15.task.DirScanDocumentR\aresults\x12\x16\n" +
+	"\x06taskId\x18\x04 \x01(\tR\x06taskId\"}\n" +
+	"\x15SaveDirScanResultResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x1a\n" +
+	"\bnewCount\x18\x04 \x01(\x05R\bnewCount\"5\n" +
+	"\x11GetSecretRulesReq\x12 \n" +
+	"\venabledOnly\x18\x01 \x01(\bR\venabledOnly\"\xb8\x01\n" +
+	"\x12SecretRuleDocument\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x1a\n" +
+	"\bseverity\x18\x03 \x01(\tR\bseverity\x12\x18\n" +
+	"\apattern\x18\x04 \x01(\tR\apattern\x12\x1e\n" +
+	"\n" +
+	"minEntropy\x18\x05 \x01(\x01R\n" +
+	"minEntropy\x12\x1c\n" +
+	"\tvalidator\x18\x06 \x01(\tR\tvalidator\"\x8e\x01\n" +
+	"\x12GetSecretRulesResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12.\n" +
+	"\x05rules\x18\x03 \x03(\v2\x18.task.SecretRuleDocumentR\x05rules\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count\"\x80\x02\n" +
+	"\x0eSecretDocument\x12\x1c\n" +
+	"\tauthority\x18\x01 \x01(\tR\tauthority\x12\x12\n" +
+	"\x04host\x18\x02 \x01(\tR\x04host\x12\x12\n" +
+	"\x04port\x18\x03 \x01(\x05R\x04port\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12\x1a\n" +
+	"\blocation\x18\x05 \x01(\tR\blocation\x12\x12\n" +
+	"\x04rule\x18\x06 \x01(\tR\x04rule\x12\x1a\n" +
+	"\bcategory\x18\a \x01(\tR\bcategory\x12\x1a\n" +
+	"\bseverity\x18\b \x01(\tR\bseverity\x12\x1a\n" +
+	"\bevidence\x18\t \x01(\tR\bevidence\x12\x12\n" +
+	"\x04hash\x18\n" +
+	" \x01(\tR\x04hash\"\xa1\x01\n" +
+	"\x13SaveSecretResultReq\x12 \n" +
+	"\vworkspaceId\x18\x01 \x01(\tR\vworkspaceId\x12\x1e\n" +
+	"\n" +
+	"mainTaskId\x18\x02 \x01(\tR\n" +
+	"mainTaskId\x120\n" +
+	"\bfindings\x18\x03 \x03(\v2\x14.task.SecretDocumentR\bfindings\x12\x16\n" +
+	"\x06taskId\x18\x04 \x01(\tR\x06taskId\"|\n" +
+	"\x14SaveSecretResultResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x1a\n" +
+	"\bnewCount\x18\x04 \x01(\x05R\bnewCount\"_\n" +
+	"\rDnsRecordItem\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x10\n" +
+	"\x03ttl\x18\x04 \x01(\rR\x03ttl\"V\n" +
+	"\fDnsIssueItem\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1a\n" +
+	"\bseverity\x18\x02 \x01(\tR\bseverity\x12\x16\n" +
+	"\x06detail\x18\x03 \x01(\tR\x06detail\"\xe6\x01\n" +
+	"\x11DnsRecordDocument\x12\x16\n" +
+	"\x06domain\x18\x01 \x01(\tR\x06domain\x12\x12\n" +
+	"\x04zone\x18\x02 \x01(\tR\x04zone\x12-\n" +
+	"\arecords\x18\x03 \x03(\v2\x13.task.DnsRecordItemR\arecords\x12\x10\n" +
+	"\x03spf\x18\x04 \x01(\tR\x03spf\x12\x14\n" +
+	"\x05dmarc\x18\x05 \x01(\tR\x05dmarc\x12\"\n" +
+	"\fzoneTransfer\x18\x06 \x03(\tR\fzoneTransfer\x12*\n" +
+	"\x06issues\x18\a \x03(\v2\x12.task.DnsIssueItemR\x06issues\"\xa5\x01\n" +
+	"\x16SaveDnsRecordResultReq\x12 \n" +
+	"\vworkspaceId\x18\x01 \x01(\tR\vworkspaceId\x12\x1e\n" +
 	"\n" +
+	"mainTaskId\x18\x02 \x01(\tR\n" +
+	"mainTaskId\x121\n" +
+	"\adomains\x18\x03 \x03(\v2\x17.task.DnsRecordDocumentR\adomains\x12\x16\n" +
+	"\x06taskId\x18\x04 \x01(\tR\x06taskId\"\x7f\n" +
+	"\x17SaveDnsRecordResultResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x1a\n" +
+	"\bnewCount\x18\x04 \x01(\x05R\bnewCount\"S\n" +
+	"\x11SubdomainDocument\x12\x16\n" +
+	"\x06domain\x18\x01 \x01(\tR\x06domain\x12\x10\n" +
+	"\x03ips\x18\x02 \x03(\tR\x03ips\x12\x14\n" +
+	"\x05cname\x18\x03 \x01(\tR\x05cname\"\xc0\x01\n" +
+	"\x11SyncSubdomainsReq\x12 \n" +
+	"\vworkspaceId\x18\x01 \x01(\tR\vworkspaceId\x12\x1e\n" +
+	"\n" +
+	"mainTaskId\x18\x02 \x01(\tR\n" +
+	"mainTaskId\x127\n" +
+	"\n" +
+	"subdomains\x18\x03 \x03(\v2\x17.task.SubdomainDocumentR\n" +
+	"subdomains\x12\x18\n" +
+	"\aconfirm\x18\x04 \x01(\bR\aconfirm\x12\x16\n" +
+	"\x06taskId\x18\x05 \x01(\tR\x06taskId\"\xa6\x01\n" +
+	"\x12SyncSubdomainsResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x1e\n" +
+	"\n" +
+	"newDomains\x18\x04 \x03(\tR\n" +
+	"newDomains\x12&\n" +
+	"\x0echangedDomains\x18\x05 \x03(\tR\x0echangedDomains\"W\n" +
+	"\x0fEnrollWorkerReq\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1e\n" +
+	"\n" +
+	"workerName\x18\x02 \x01(\tR\n" +
+	"workerName\x12\x0e\n" +
+	"\x02ip\x18\x03 \x01(\tR\x02ip\"\xa2\x01\n" +
+	"\x10EnrollWorkerResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1a\n" +
+	"\bworkerId\x18\x03 \x01(\tR\bworkerId\x12\x1e\n" +
+	"\n" +
+	"workerName\x18\x04 \x01(\tR\n" +
+	"workerName\x12\x1e\n" +
+	"\n" +
+	"credential\x18\x05 \x01(\tR\n" +
+	"credential\"A\n" +
+	"\x0fWorkerOnlineReq\x12\x1e\n" +
+	"\n" +
+	"workerName\x18\x01 \x01(\tR\n" +
+	"workerName\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02ip\"f\n" +
+	"\x10WorkerOnlineResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1e\n" +
+	"\n" +
+	"workerName\x18\x03 \x01(\tR\n" +
+	"workerName\"\x96\x01\n" +
+	"\x0eWorkerLogEntry\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\tR\ttimestamp\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\tR\x05level\x12\x1e\n" +
+	"\n" +
+	"workerName\x18\x03 \x01(\tR\n" +
+	"workerName\x12\x16\n" +
+	"\x06taskId\x18\x04 \x01(\tR\x06taskId\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\"^\n" +
+	"\x12PushWorkerLogsResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"\xba\x05\n" +
+	"\x12WorkerStatusReport\x12\x1e\n" +
+	"\n" +
+	"workerName\x18\x01 \x01(\tR\n" +
+	"workerName\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02ip\x12\x18\n" +
+	"\acpuLoad\x18\x03 \x01(\x01R\acpuLoad\x12\x18\n" +
+	"\amemUsed\x18\x04 \x01(\x01R\amemUsed\x12,\n" +
+	"\x11taskStartedNumber\x18\x05 \x01(\x05R\x11taskStartedNumber\x12.\n" +
+	"\x12taskExecutedNumber\x18\x06 \x01(\x05R\x12taskExecutedNumber\x12\"\n" +
+	"\fhealthStatus\x18\a \x01(\tR\fhealthStatus\x12 \n" +
+	"\visThrottled\x18\b \x01(\bR\visThrottled\x12*\n" +
+	"\x10cpuOverloadCount\x18\t \x01(\x05R\x10cpuOverloadCount\x12 \n" +
+	"\vconcurrency\x18\n" +
+	" \x01(\x05R\vconcurrency\x12\"\n" +
+	"\frunningTasks\x18\v \x01(\x05R\frunningTasks\x129\n" +
+	"\x05tools\x18\f \x03(\v2#.task.WorkerStatusReport.ToolsEntryR\x05tools\x12\x18\n" +
+	"\aoffline\x18\r \x01(\bR\aoffline\x12<\n" +
+	"\x06labels\x18\x0e \x03(\v2$.task.WorkerStatusReport.LabelsEntryR\x06labels\x12\"\n" +
+	"\fcapabilities\x18\x0f \x03(\tR\fcapabilities\x1a8\n" +
+	"\n" +
+	"ToolsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x83\x01\n" +
+	"\rWorkerCommand\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\tR\x06action\x12\x1e\n" +
+	"\n" +
+	"workerName\x18\x02 \x01(\tR\n" +
+	"workerName\x12\x18\n" +
+	"\anewName\x18\x03 \x01(\tR\anewName\x12 \n" +
+	"\vconcurrency\x18\x04 \x01(\x05R\vconcurrency\"+\n" +
+	"\x11GetTaskControlReq\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\tR\x06taskId\".\n" +
+	"\x12GetTaskControlResp\x12\x18\n" +
+	"\acontrol\x18\x01 \x01(\tR\acontrol\"\x89\x01\n" +
+	"\x15UpdateTaskProgressReq\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\tR\x06taskId\x12\x1a\n" +
+	"\bprogress\x18\x02 \x01(\x05R\bprogress\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\"\n" +
+	"\fcurrentPhase\x18\x04 \x01(\tR\fcurrentPhase\"L\n" +
+	"\x16UpdateTaskProgressResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x99\x01\n" +
+	"\x1aSavePocValidationResultReq\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\tR\x06taskId\x12\x18\n" +
+	"\abatchId\x18\x02 \x01(\tR\abatchId\x123\n" +
+	"\aresults\x18\x03 \x03(\v2\x19.task.PocValidationResultR\aresults\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"Q\n" +
+	"\x1bSavePocValidationResultResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage2\x8e\x12\n" +
 	"\vTaskService\x124\n" +
 	"\tCheckTask\x12\x12.task.CheckTaskReq\x1a\x13.task.CheckTaskResp\x127\n" +
 	"\n" +
@@ -3992,7 +6947,21 @@ const file_rpc_task_task_proto_rawDesc = "" +
 	"GetPocById\x12\x13.task.GetPocByIdReq\x1a\x14.task.GetPocByIdResp\x12L\n" +
 	"\x11GetTemplatesByIds\x12\x1a.task.GetTemplatesByIdsReq\x1a\x1b.task.GetTemplatesByIdsResp\x12[\n" +
 	"\x16GetHttpServiceMappings\x12\x1f.task.GetHttpServiceMappingsReq\x1a .task.GetHttpServiceMappingsResp\x12X\n" +
-	"\x15GetSubfinderProviders\x12\x1e.task.GetSubfinderProvidersReq\x1a\x1f.task.GetSubfinderProvidersRespB\x06Z\x04./pbb\x06proto3"
+	"\x15GetSubfinderProviders\x12\x1e.task.GetSubfinderProvidersReq\x1a\x1f.task.GetSubfinderProvidersResp\x12@\n" +
+	"\rSaveUrlResult\x12\x16.task.SaveUrlResultReq\x1a\x17.task.SaveUrlResultResp\x12=\n" +
+	"\fGetWordlists\x12\x15.task.GetWordlistsReq\x1a\x16.task.GetWordlistsResp\x12L\n" +
+	"\x11SaveDirScanResult\x12\x1a.task.SaveDirScanResultReq\x1a\x1b.task.SaveDirScanResultResp\x12C\n" +
+	"\x0eGetSecretRules\x12\x17.task.GetSecretRulesReq\x1a\x18.task.GetSecretRulesResp\x12I\n" +
+	"\x10SaveSecretResult\x12\x19.task.SaveSecretResultReq\x1a\x1a.task.SaveSecretResultResp\x12R\n" +
+	"\x13SaveDnsRecordResult\x12\x1c.task.SaveDnsRecordResultReq\x1a\x1d.task.SaveDnsRecordResultResp\x12C\n" +
+	"\x0eSyncSubdomains\x12\x17.task.SyncSubdomainsReq\x1a\x18.task.SyncSubdomainsResp\x12=\n" +
+	"\fEnrollWorker\x12\x15.task.EnrollWorkerReq\x1a\x16.task.EnrollWorkerResp\x12=\n" +
+	"\fWorkerOnline\x12\x15.task.WorkerOnlineReq\x1a\x16.task.WorkerOnlineResp\x12B\n" +
+	"\x0ePushWorkerLogs\x12\x14.task.WorkerLogEntry\x1a\x18.task.PushWorkerLogsResp(\x01\x12B\n" +
+	"\rWorkerSession\x12\x18.task.WorkerStatusReport\x1a\x13.task.WorkerCommand(\x010\x01\x12C\n" +
+	"\x0eGetTaskControl\x12\x17.task.GetTaskControlReq\x1a\x18.task.GetTaskControlResp\x12O\n" +
+	"\x12UpdateTaskProgress\x12\x1b.task.UpdateTaskProgressReq\x1a\x1c.task.UpdateTaskProgressResp\x12^\n" +
+	"\x17SavePocValidationResult\x12 .task.SavePocValidationResultReq\x1a!.task.SavePocValidationResultRespB\x06Z\x04./pbb\x06proto3"
 
 var (
 	file_rpc_task_task_proto_rawDescOnce sync.Once
@@ -4006,115 +6975,194 @@ func file_rpc_task_task_proto_rawDescGZIP() []byte {
 	return file_rpc_task_task_proto_rawDescData
 }
 
-var file_rpc_task_task_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
+var file_rpc_task_task_proto_msgTypes = make([]protoimpl.MessageInfo, 88)
 var file_rpc_task_task_proto_goTypes = []any{
-	(*CheckTaskReq)(nil),               // 0: task.CheckTaskReq
-	(*CheckTaskResp)(nil),              // 1: task.CheckTaskResp
-	(*UpdateTaskReq)(nil),              // 2: task.UpdateTaskReq
-	(*UpdateTaskResp)(nil),             // 3: task.UpdateTaskResp
-	(*NewTaskReq)(nil),                 // 4: task.NewTaskReq
-	(*NewTaskResp)(nil),                // 5: task.NewTaskResp
-	(*AssetDocument)(nil),              // 6: task.AssetDocument
-	(*IPV4)(nil),                       // 7: task.IPV4
-	(*IPV6)(nil),                       // 8: task.IPV6
-	(*SaveTaskResultReq)(nil),          // 9: task.SaveTaskResultReq
-	(*SaveTaskResultResp)(nil),         // 10: task.SaveTaskResultResp
-	(*VulDocument)(nil),                // 11: task.VulDocument
-	(*SaveVulResultReq)(nil),           // 12: task.SaveVulResultReq
-	(*SaveVulResultResp)(nil),          // 13: task.SaveVulResultResp
-	(*KeepAliveReq)(nil),               // 14: task.KeepAliveReq
-	(*KeepAliveResp)(nil),              // 15: task.KeepAliveResp
-	(*GetWorkerConfigReq)(nil),         // 16: task.GetWorkerConfigReq
-	(*GetWorkerConfigResp)(nil),        // 17: task.GetWorkerConfigResp
-	(*RequestResourceReq)(nil),         // 18: task.RequestResourceReq
-	(*RequestResourceResp)(nil),        // 19: task.RequestResourceResp
-	(*GetTemplatesByTagsReq)(nil),      // 20: task.GetTemplatesByTagsReq
-	(*GetTemplatesByTagsResp)(nil),     // 21: task.GetTemplatesByTagsResp
-	(*GetCustomFingerprintsReq)(nil),   // 22: task.GetCustomFingerprintsReq
-	(*FingerprintDocument)(nil),        // 23: task.FingerprintDocument
-	(*GetCustomFingerprintsResp)(nil),  // 24: task.GetCustomFingerprintsResp
-	(*ValidateFingerprintReq)(nil),     // 25: task.ValidateFingerprintReq
-	(*MatchedFingerprintInfo)(nil),     // 26: task.MatchedFingerprintInfo
-	(*ValidateFingerprintResp)(nil),    // 27: task.ValidateFingerprintResp
-	(*ValidatePocReq)(nil),             // 28: task.ValidatePocReq
-	(*PocValidationResult)(nil),        // 29: task.PocValidationResult
-	(*ValidatePocResp)(nil),            // 30: task.ValidatePocResp
-	(*BatchValidatePocReq)(nil),        // 31: task.BatchValidatePocReq
-	(*BatchValidatePocResp)(nil),       // 32: task.BatchValidatePocResp
-	(*GetPocValidationResultReq)(nil),  // 33: task.GetPocValidationResultReq
-	(*GetPocValidationResultResp)(nil), // 34: task.GetPocValidationResultResp
-	(*GetPocByIdReq)(nil),              // 35: task.GetPocByIdReq
-	(*GetPocByIdResp)(nil),             // 36: task.GetPocByIdResp
-	(*GetTemplatesByIdsReq)(nil),       // 37: task.GetTemplatesByIdsReq
-	(*GetTemplatesByIdsResp)(nil),      // 38: task.GetTemplatesByIdsResp
-	(*GetHttpServiceMappingsReq)(nil),  // 39: task.GetHttpServiceMappingsReq
-	(*HttpServiceMappingDocument)(nil), // 40: task.HttpServiceMappingDocument
-	(*GetHttpServiceMappingsResp)(nil), // 41: task.GetHttpServiceMappingsResp
-	(*GetSubfinderProvidersReq)(nil),   // 42: task.GetSubfinderProvidersReq
-	(*SubfinderProviderDocument)(nil),  // 43: task.SubfinderProviderDocument
-	(*GetSubfinderProvidersResp)(nil),  // 44: task.GetSubfinderProvidersResp
-	nil,                                // 45: task.FingerprintDocument.HeadersEntry
-	nil,                                // 46: task.FingerprintDocument.CookiesEntry
-	nil,                                // 47: task.FingerprintDocument.MetaEntry
-	nil,                                // 48: task.BatchValidatePocResp.UrlStatsEntry
+	(*CheckTaskReq)(nil),                // 0: task.CheckTaskReq
+	(*CheckTaskResp)(nil),               // 1: task.CheckTaskResp
+	(*UpdateTaskReq)(nil),               // 2: task.UpdateTaskReq
+	(*UpdateTaskResp)(nil),              // 3: task.UpdateTaskResp
+	(*NewTaskReq)(nil),                  // 4: task.NewTaskReq
+	(*NewTaskResp)(nil),                 // 5: task.NewTaskResp
+	(*AssetDocument)(nil),               // 6: task.AssetDocument
+	(*IPV4)(nil),                        // 7: task.IPV4
+	(*IPV6)(nil),                        // 8: task.IPV6
+	(*SaveTaskResultReq)(nil),           // 9: task.SaveTaskResultReq
+	(*SaveTaskResultResp)(nil),          // 10: task.SaveTaskResultResp
+	(*VulDocument)(nil),                 // 11: task.VulDocument
+	(*SaveVulResultReq)(nil),            // 12: task.SaveVulResultReq
+	(*SaveVulResultResp)(nil),           // 13: task.SaveVulResultResp
+	(*KeepAliveReq)(nil),                // 14: task.KeepAliveReq
+	(*KeepAliveResp)(nil),               // 15: task.KeepAliveResp
+	(*GetWorkerConfigReq)(nil),          // 16: task.GetWorkerConfigReq
+	(*GetWorkerConfigResp)(nil),         // 17: task.GetWorkerConfigResp
+	(*RequestResourceReq)(nil),          // 18: task.RequestResourceReq
+	(*RequestResourceResp)(nil),         // 19: task.RequestResourceResp
+	(*GetTemplatesByTagsReq)(nil),       // 20: task.GetTemplatesByTagsReq
+	(*GetTemplatesByTagsResp)(nil),      // 21: task.GetTemplatesByTagsResp
+	(*GetCustomFingerprintsReq)(nil),    // 22: task.GetCustomFingerprintsReq
+	(*FingerprintDocument)(nil),         // 23: task.FingerprintDocument
+	(*GetCustomFingerprintsResp)(nil),   // 24: task.GetCustomFingerprintsResp
+	(*ValidateFingerprintReq)(nil),      // 25: task.ValidateFingerprintReq
+	(*MatchedFingerprintInfo)(nil),      // 26: task.MatchedFingerprintInfo
+	(*ValidateFingerprintResp)(nil),     // 27: task.ValidateFingerprintResp
+	(*ValidatePocReq)(nil),              // 28: task.ValidatePocReq
+	(*PocValidationResult)(nil),         // 29: task.PocValidationResult
+	(*ValidatePocResp)(nil),             // 30: task.ValidatePocResp
+	(*BatchValidatePocReq)(nil),         // 31: task.BatchValidatePocReq
+	(*BatchValidatePocResp)(nil),        // 32: task.BatchValidatePocResp
+	(*GetPocValidationResultReq)(nil),   // 33: task.GetPocValidationResultReq
+	(*GetPocValidationResultResp)(nil),  // 34: task.GetPocValidationResultResp
+	(*GetPocByIdReq)(nil),               // 35: task.GetPocByIdReq
+	(*GetPocByIdResp)(nil),              // 36: task.GetPocByIdResp
+	(*GetTemplatesByIdsReq)(nil),        // 37: task.GetTemplatesByIdsReq
+	(*GetTemplatesByIdsResp)(nil),       // 38: task.GetTemplatesByIdsResp
+	(*GetHttpServiceMappingsReq)(nil),   // 39: task.GetHttpServiceMappingsReq
+	(*HttpServiceMappingDocument)(nil),  // 40: task.HttpServiceMappingDocument
+	(*GetHttpServiceMappingsResp)(nil),  // 41: task.GetHttpServiceMappingsResp
+	(*GetSubfinderProvidersReq)(nil),    // 42: task.GetSubfinderProvidersReq
+	(*SubfinderProviderDocument)(nil),   // 43: task.SubfinderProviderDocument
+	(*GetSubfinderProvidersResp)(nil),   // 44: task.GetSubfinderProvidersResp
+	(*UrlDocument)(nil),                 // 45: task.UrlDocument
+	(*SaveUrlResultReq)(nil),            // 46: task.SaveUrlResultReq
+	(*SaveUrlResultResp)(nil),           // 47: task.SaveUrlResultResp
+	(*GetWordlistsReq)(nil),             // 48: task.GetWordlistsReq
+	(*GetWordlistsResp)(nil),            // 49: task.GetWordlistsResp
+	(*DirScanDocument)(nil),             // 50: task.DirScanDocument
+	(*SaveDirScanResultReq)(nil),        // 51: task.SaveDirScanResultReq
+	(*SaveDirScanResultResp)(nil),       // 52: task.SaveDirScanResultResp
+	(*GetSecretRulesReq)(nil),           // 53: task.GetSecretRulesReq
+	(*SecretRuleDocument)(nil),          // 54: task.SecretRuleDocument
+	(*GetSecretRulesResp)(nil),          // 55: task.GetSecretRulesResp
+	(*SecretDocument)(nil),              // 56: task.SecretDocument
+	(*SaveSecretResultReq)(nil),         // 57: task.SaveSecretResultReq
+	(*SaveSecretResultResp)(nil),        // 58: task.SaveSecretResultResp
+	(*DnsRecordItem)(nil),               // 59: task.DnsRecordItem
+	(*DnsIssueItem)(nil),                // 60: task.DnsIssueItem
+	(*DnsRecordDocument)(nil),           // 61: task.DnsRecordDocument
+	(*SaveDnsRecordResultReq)(nil),      // 62: task.SaveDnsRecordResultReq
+	(*SaveDnsRecordResultResp)(nil),     // 63: task.SaveDnsRecordResultResp
+	(*SubdomainDocument)(nil),           // 64: task.SubdomainDocument
+	(*SyncSubdomainsReq)(nil),           // 65: task.SyncSubdomainsReq
+	(*SyncSubdomainsResp)(nil),          // 66: task.SyncSubdomainsResp
+	(*EnrollWorkerReq)(nil),             // 67: task.EnrollWorkerReq
+	(*EnrollWorkerResp)(nil),            // 68: task.EnrollWorkerResp
+	(*WorkerOnlineReq)(nil),             // 69: task.WorkerOnlineReq
+	(*WorkerOnlineResp)(nil),            // 70: task.WorkerOnlineResp
+	(*WorkerLogEntry)(nil),              // 71: task.WorkerLogEntry
+	(*PushWorkerLogsResp)(nil),          // 72: task.PushWorkerLogsResp
+	(*WorkerStatusReport)(nil),          // 73: task.WorkerStatusReport
+	(*WorkerCommand)(nil),               // 74: task.WorkerCommand
+	(*GetTaskControlReq)(nil),           // 75: task.GetTaskControlReq
+	(*GetTaskControlResp)(nil),          // 76: task.GetTaskControlResp
+	(*UpdateTaskProgressReq)(nil),       // 77: task.UpdateTaskProgressReq
+	(*UpdateTaskProgressResp)(nil),      // 78: task.UpdateTaskProgressResp
+	(*SavePocValidationResultReq)(nil),  // 79: task.SavePocValidationResultReq
+	(*SavePocValidationResultResp)(nil), // 80: task.SavePocValidationResultResp
+	nil,                                 // 81: task.KeepAliveReq.LabelsEntry
+	nil,                                 // 82: task.FingerprintDocument.HeadersEntry
+	nil,                                 // 83: task.FingerprintDocument.CookiesEntry
+	nil,                                 // 84: task.FingerprintDocument.MetaEntry
+	nil,                                 // 85: task.BatchValidatePocResp.UrlStatsEntry
+	nil,                                 // 86: task.WorkerStatusReport.ToolsEntry
+	nil,                                 // 87: task.WorkerStatusReport.LabelsEntry
 }
 var file_rpc_task_task_proto_depIdxs = []int32{
 	7,  // 0: task.AssetDocument.ipv4:type_name -> task.IPV4
 	8,  // 1: task.AssetDocument.ipv6:type_name -> task.IPV6
 	6,  // 2: task.SaveTaskResultReq.assets:type_name -> task.AssetDocument
 	11, // 3: task.SaveVulResultReq.vuls:type_name -> task.VulDocument
-	45, // 4: task.FingerprintDocument.headers:type_name -> task.FingerprintDocument.HeadersEntry
-	46, // 5: task.FingerprintDocument.cookies:type_name -> task.FingerprintDocument.CookiesEntry
-	47, // 6: task.FingerprintDocument.meta:type_name -> task.FingerprintDocument.MetaEntry
-	23, // 7: task.GetCustomFingerprintsResp.fingerprints:type_name -> task.FingerprintDocument
-	26, // 8: task.ValidateFingerprintResp.matchedList:type_name -> task.MatchedFingerprintInfo
-	29, // 9: task.ValidatePocResp.results:type_name -> task.PocValidationResult
-	29, // 10: task.BatchValidatePocResp.results:type_name -> task.PocValidationResult
-	48, // 11: task.BatchValidatePocResp.urlStats:type_name -> task.BatchValidatePocResp.UrlStatsEntry
-	29, // 12: task.GetPocValidationResultResp.results:type_name -> task.PocValidationResult
-	40, // 13: task.GetHttpServiceMappingsResp.mappings:type_name -> task.HttpServiceMappingDocument
-	43, // 14: task.GetSubfinderProvidersResp.providers:type_name -> task.SubfinderProviderDocument
-	0,  // 15: task.TaskService.CheckTask:input_type -> task.CheckTaskReq
-	2,  // 16: task.TaskService.UpdateTask:input_type -> task.UpdateTaskReq
-	4,  // 17: task.TaskService.NewTask:input_type -> task.NewTaskReq
-	9,  // 18: task.TaskService.SaveTaskResult:input_type -> task.SaveTaskResultReq
-	12, // 19: task.TaskService.SaveVulResult:input_type -> task.SaveVulResultReq
-	14, // 20: task.TaskService.KeepAlive:input_type -> task.KeepAliveReq
-	16, // 21: task.TaskService.GetWorkerConfig:input_type -> task.GetWorkerConfigReq
-	18, // 22: task.TaskService.RequestResource:input_type -> task.RequestResourceReq
-	20, // 23: task.TaskService.GetTemplatesByTags:input_type -> task.GetTemplatesByTagsReq
-	22, // 24: task.TaskService.GetCustomFingerprints:input_type -> task.GetCustomFingerprintsReq
-	25, // 25: task.TaskService.ValidateFingerprint:input_type -> task.ValidateFingerprintReq
-	28, // 26: task.TaskService.ValidatePoc:input_type -> task.ValidatePocReq
-	31, // 27: task.TaskService.BatchValidatePoc:input_type -> task.BatchValidatePocReq
-	33, // 28: task.TaskService.GetPocValidationResult:input_type -> task.GetPocValidationResultReq
-	35, // 29: task.TaskService.GetPocById:input_type -> task.GetPocByIdReq
-	37, // 30: task.TaskService.GetTemplatesByIds:input_type -> task.GetTemplatesByIdsReq
-	39, // 31: task.TaskService.GetHttpServiceMappings:input_type -> task.GetHttpServiceMappingsReq
-	42, // 32: task.TaskService.GetSubfinderProviders:input_type -> task.GetSubfinderProvidersReq
-	1,  // 33: task.TaskService.CheckTask:output_type -> task.CheckTaskResp
-	3,  // 34: task.TaskService.UpdateTask:output_type -> task.UpdateTaskResp
-	5,  // 35: task.TaskService.NewTask:output_type -> task.NewTaskResp
-	10, // 36: task.TaskService.SaveTaskResult:output_type -> task.SaveTaskResultResp
-	13, // 37: task.TaskService.SaveVulResult:output_type -> task.SaveVulResultResp
-	15, // 38: task.TaskService.KeepAlive:output_type -> task.KeepAliveResp
-	17, // 39: task.TaskService.GetWorkerConfig:output_type -> task.GetWorkerConfigResp
-	19, // 40: task.TaskService.RequestResource:output_type -> task.RequestResourceResp
-	21, // 41: task.TaskService.GetTemplatesByTags:output_type -> task.GetTemplatesByTagsResp
-	24, // 42: task.TaskService.GetCustomFingerprints:output_type -> task.GetCustomFingerprintsResp
-	27, // 43: task.TaskService.ValidateFingerprint:output_type -> task.ValidateFingerprintResp
-	30, // 44: task.TaskService.ValidatePoc:output_type -> task.ValidatePocResp
-	32, // 45: task.TaskService.BatchValidatePoc:output_type -> task.BatchValidatePocResp
-	34, // 46: task.TaskService.GetPocValidationResult:output_type -> task.GetPocValidationResultResp
-	36, // 47: task.TaskService.GetPocById:output_type -> task.GetPocByIdResp
-	38, // 48: task.TaskService.GetTemplatesByIds:output_type -> task.GetTemplatesByIdsResp
-	41, // 49: task.TaskService.GetHttpServiceMappings:output_type -> task.GetHttpServiceMappingsResp
-	44, // 50: task.TaskService.GetSubfinderProviders:output_type -> task.GetSubfinderProvidersResp
-	33, // [33:51] is the sub-list for method output_type
-	15, // [15:33] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	81, // 4: task.KeepAliveReq.labels:type_name -> task.KeepAliveReq.LabelsEntry
+	82, // 5: task.FingerprintDocument.headers:type_name -> task.FingerprintDocument.HeadersEntry
+	83, // 6: task.FingerprintDocument.cookies:type_name -> task.FingerprintDocument.CookiesEntry
+	84, // 7: task.FingerprintDocument.meta:type_name -> task.FingerprintDocument.MetaEntry
+	23, // 8: task.GetCustomFingerprintsResp.fingerprints:type_name -> task.FingerprintDocument
+	26, // 9: task.ValidateFingerprintResp.matchedList:type_name -> task.MatchedFingerprintInfo
+	29, // 10: task.ValidatePocResp.results:type_name -> task.PocValidationResult
+	29, // 11: task.BatchValidatePocResp.results:type_name -> task.PocValidationResult
+	85, // 12: task.BatchValidatePocResp.urlStats:type_name -> task.BatchValidatePocResp.UrlStatsEntry
+	29, // 13: task.GetPocValidationResultResp.results:type_name -> task.PocValidationResult
+	40, // 14: task.GetHttpServiceMappingsResp.mappings:type_name -> task.HttpServiceMappingDocument
+	43, // 15: task.GetSubfinderProvidersResp.providers:type_name -> task.SubfinderProviderDocument
+	45, // 16: task.SaveUrlResultReq.urls:type_name -> task.UrlDocument
+	50, // 17: task.SaveDirScanResultReq.results:type_name -> task.DirScanDocument
+	54, // 18: task.GetSecretRulesResp.rules:type_name -> task.SecretRuleDocument
+	56, // 19: task.SaveSecretResultReq.findings:type_name -> task.SecretDocument
+	59, // 20: task.DnsRecordDocument.records:type_name -> task.DnsRecordItem
+	60, // 21: task.DnsRecordDocument.issues:type_name -> task.DnsIssueItem
+	61, // 22: task.SaveDnsRecordResultReq.domains:type_name -> task.DnsRecordDocument
+	64, // 23: task.SyncSubdomainsReq.subdomains:type_name -> task.SubdomainDocument
+	86, // 24: task.WorkerStatusReport.tools:type_name -> task.WorkerStatusReport.ToolsEntry
+	87, // 25: task.WorkerStatusReport.labels:type_name -> task.WorkerStatusReport.LabelsEntry
+	29, // 26: task.SavePocValidationResultReq.results:type_name -> task.PocValidationResult
+	0,  // 27: task.TaskService.CheckTask:input_type -> task.CheckTaskReq
+	2,  // 28: task.TaskService.UpdateTask:input_type -> task.UpdateTaskReq
+	4,  // 29: task.TaskService.NewTask:input_type -> task.NewTaskReq
+	9,  // 30: task.TaskService.SaveTaskResult:input_type -> task.SaveTaskResultReq
+	12, // 31: task.TaskService.SaveVulResult:input_type -> task.SaveVulResultReq
+	14, // 32: task.TaskService.KeepAlive:input_type -> task.KeepAliveReq
+	16, // 33: task.TaskService.GetWorkerConfig:input_type -> task.GetWorkerConfigReq
+	18, // 34: task.TaskService.RequestResource:input_type -> task.RequestResourceReq
+	20, // 35: task.TaskService.GetTemplatesByTags:input_type -> task.GetTemplatesByTagsReq
+	22, // 36: task.TaskService.GetCustomFingerprints:input_type -> task.GetCustomFingerprintsReq
+	25, // 37: task.TaskService.ValidateFingerprint:input_type -> task.ValidateFingerprintReq
+	28, // 38: task.TaskService.ValidatePoc:input_type -> task.ValidatePocReq
+	31, // 39: task.TaskService.BatchValidatePoc:input_type -> task.BatchValidatePocReq
+	33, // 40: task.TaskService.GetPocValidationResult:input_type -> task.GetPocValidationResultReq
+	35, // 41: task.TaskService.GetPocById:input_type -> task.GetPocByIdReq
+	37, // 42: task.TaskService.GetTemplatesByIds:input_type -> task.GetTemplatesByIdsReq
+	39, // 43: task.TaskService.GetHttpServiceMappings:input_type -> task.GetHttpServiceMappingsReq
+	42, // 44: task.TaskService.GetSubfinderProviders:input_type -> task.GetSubfinderProvidersReq
+	46, // 45: task.TaskService.SaveUrlResult:input_type -> task.SaveUrlResultReq
+	48, // 46: task.TaskService.GetWordlists:input_type -> task.GetWordlistsReq
+	51, // 47: task.TaskService.SaveDirScanResult:input_type -> task.SaveDirScanResultReq
+	53, // 48: task.TaskService.GetSecretRules:input_type -> task.GetSecretRulesReq
+	57, // 49: task.TaskService.SaveSecretResult:input_type -> task.SaveSecretResultReq
+	62, // 50: task.TaskService.SaveDnsRecordResult:input_type -> task.SaveDnsRecordResultReq
+	65, // 51: task.TaskService.SyncSubdomains:input_type -> task.SyncSubdomainsReq
+	67, // 52: task.TaskService.EnrollWorker:input_type -> task.EnrollWorkerReq
+	69, // 53: task.TaskService.WorkerOnline:input_type -> task.WorkerOnlineReq
+	71, // 54: task.TaskService.PushWorkerLogs:input_type -> task.WorkerLogEntry
+	73, // 55: task.TaskService.WorkerSession:input_type -> task.WorkerStatusReport
+	75, // 56: task.TaskService.GetTaskControl:input_type -> task.GetTaskControlReq
+	77, // 57: task.TaskService.UpdateTaskProgress:input_type -> task.UpdateTaskProgressReq
+	79, // 58: task.TaskService.SavePocValidationResult:input_type -> task.SavePocValidationResultReq
+	1,  // 59: task.TaskService.CheckTask:output_type -> task.CheckTaskResp
+	3,  // 60: task.TaskService.UpdateTask:output_type -> task.UpdateTaskResp
+	5,  // 61: task.TaskService.NewTask:output_type -> task.NewTaskResp
+	10, // 62: task.TaskService.SaveTaskResult:output_type -> task.SaveTaskResultResp
+	13, // 63: task.TaskService.SaveVulResult:output_type -> task.SaveVulResultResp
+	15, // 64: task.TaskService.KeepAlive:output_type -> task.KeepAliveResp
+	17, // 65: task.TaskService.GetWorkerConfig:output_type -> task.GetWorkerConfigResp
+	19, // 66: task.TaskService.RequestResource:output_type -> task.RequestResourceResp
+	21, // 67: task.TaskService.GetTemplatesByTags:output_type -> task.GetTemplatesByTagsResp
+	24, // 68: task.TaskService.GetCustomFingerprints:output_type -> task.GetCustomFingerprintsResp
+	27, // 69: task.TaskService.ValidateFingerprint:output_type -> task.ValidateFingerprintResp
+	30, // 70: task.TaskService.ValidatePoc:output_type -> task.ValidatePocResp
+	32, // 71: task.TaskService.BatchValidatePoc:output_type -> task.BatchValidatePocResp
+	34, // 72: task.TaskService.GetPocValidationResult:output_type -> task.GetPocValidationResultResp
+	36, // 73: task.TaskService.GetPocById:output_type -> task.GetPocByIdResp
+	38, // 74: task.TaskService.GetTemplatesByIds:output_type -> task.GetTemplatesByIdsResp
+	41, // 75: task.TaskService.GetHttpServiceMappings:output_type -> task.GetHttpServiceMappingsResp
+	44, // 76: task.TaskService.GetSubfinderProviders:output_type -> task.GetSubfinderProvidersResp
+	47, // 77: task.TaskService.SaveUrlResult:output_type -> task.SaveUrlResultResp
+	49, // 78: task.TaskService.GetWordlists:output_type -> task.GetWordlistsResp
+	52, // 79: task.TaskService.SaveDirScanResult:output_type -> task.SaveDirScanResultResp
+	55, // 80: task.TaskService.GetSecretRules:output_type -> task.GetSecretRulesResp
+	58, // 81: task.TaskService.SaveSecretResult:output_type -> task.SaveSecretResultResp
+	63, // 82: task.TaskService.SaveDnsRecordResult:output_type -> task.SaveDnsRecordResultResp
+	66, // 83: task.TaskService.SyncSubdomains:output_type -> task.SyncSubdomainsResp
+	68, // 84: task.TaskService.EnrollWorker:output_type -> task.EnrollWorkerResp
+	70, // 85: task.TaskService.WorkerOnline:output_type -> task.WorkerOnlineResp
+	72, // 86: task.TaskService.PushWorkerLogs:output_type -> task.PushWorkerLogsResp
+	74, // 87: task.TaskService.WorkerSession:output_type -> task.WorkerCommand
+	76, // 88: task.TaskService.GetTaskControl:output_type -> task.GetTaskControlResp
+	78, // 89: task.TaskService.UpdateTaskProgress:output_type -> task.UpdateTaskProgressResp
+	80, // 90: task.TaskService.SavePocValidationResult:output_type -> task.SavePocValidationResultResp
+	59, // [59:91] is the sub-list for method output_type
+	27, // [27:59] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_rpc_task_task_proto_init() }
@@ -4129,7 +7177,7 @@ func file_rpc_task_task_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_task_task_proto_rawDesc), len(file_rpc_task_task_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   49,
+			NumMessages:   88,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,24 +19,38 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TaskService_CheckTask_FullMethodName              = "/task.TaskService/CheckTask"
-	TaskService_UpdateTask_FullMethodName             = "/task.TaskService/UpdateTask"
-	TaskService_NewTask_FullMethodName                = "/task.TaskService/NewTask"
-	TaskService_SaveTaskResult_FullMethodName         = "/task.TaskService/SaveTaskResult"
-	TaskService_SaveVulResult_FullMethodName          = "/task.TaskService/SaveVulResult"
-	TaskService_KeepAlive_FullMethodName              = "/task.TaskService/KeepAlive"
-	TaskService_GetWorkerConfig_FullMethodName        = "/task.TaskService/GetWorkerConfig"
-	TaskService_RequestResource_FullMethodName        = "/task.TaskService/RequestResource"
-	TaskService_GetTemplatesByTags_FullMethodName     = "/task.TaskService/GetTemplatesByTags"
-	TaskService_GetCustomFingerprints_FullMethodName  = "/task.TaskService/GetCustomFingerprints"
-	TaskService_ValidateFingerprint_FullMethodName    = "/task.TaskService/ValidateFingerprint"
-	TaskService_ValidatePoc_FullMethodName            = "/task.TaskService/ValidatePoc"
-	TaskService_BatchValidatePoc_FullMethodName       = "/task.TaskService/BatchValidatePoc"
-	TaskService_GetPocValidationResult_FullMethodName = "/task.TaskService/GetPocValidationResult"
-	TaskService_GetPocById_FullMethodName             = "/task.TaskService/GetPocById"
-	TaskService_GetTemplatesByIds_FullMethodName      = "/task.TaskService/GetTemplatesByIds"
-	TaskService_GetHttpServiceMappings_FullMethodName = "/task.TaskService/GetHttpServiceMappings"
-	TaskService_GetSubfinderProviders_FullMethodName  = "/task.TaskService/GetSubfinderProviders"
+	TaskService_CheckTask_FullMethodName               = "/task.TaskService/CheckTask"
+	TaskService_UpdateTask_FullMethodName              = "/task.TaskService/UpdateTask"
+	TaskService_NewTask_FullMethodName                 = "/task.TaskService/NewTask"
+	TaskService_SaveTaskResult_FullMethodName          = "/task.TaskService/SaveTaskResult"
+	TaskService_SaveVulResult_FullMethodName           = "/task.TaskService/SaveVulResult"
+	TaskService_KeepAlive_FullMethodName               = "/task.TaskService/KeepAlive"
+	TaskService_GetWorkerConfig_FullMethodName         = "/task.TaskService/GetWorkerConfig"
+	TaskService_RequestResource_FullMethodName         = "/task.TaskService/RequestResource"
+	TaskService_GetTemplatesByTags_FullMethodName      = "/task.TaskService/GetTemplatesByTags"
+	TaskService_GetCustomFingerprints_FullMethodName   = "/task.TaskService/GetCustomFingerprints"
+	TaskService_ValidateFingerprint_FullMethodName     = "/task.TaskService/ValidateFingerprint"
+	TaskService_ValidatePoc_FullMethodName             = "/task.TaskService/ValidatePoc"
+	TaskService_BatchValidatePoc_FullMethodName        = "/task.TaskService/BatchValidatePoc"
+	TaskService_GetPocValidationResult_FullMethodName  = "/task.TaskService/GetPocValidationResult"
+	TaskService_GetPocById_FullMethodName              = "/task.TaskService/GetPocById"
+	TaskService_GetTemplatesByIds_FullMethodName       = "/task.TaskService/GetTemplatesByIds"
+	TaskService_GetHttpServiceMappings_FullMethodName  = "/task.TaskService/GetHttpServiceMappings"
+	TaskService_GetSubfinderProviders_FullMethodName   = "/task.TaskService/GetSubfinderProviders"
+	TaskService_SaveUrlResult_FullMethodName           = "/task.TaskService/SaveUrlResult"
+	TaskService_GetWordlists_FullMethodName            = "/task.TaskService/GetWordlists"
+	TaskService_SaveDirScanResult_FullMethodName       = "/task.TaskService/SaveDirScanResult"
+	TaskService_GetSecretRules_FullMethodName          = "/task.TaskService/GetSecretRules"
+	TaskService_SaveSecretResult_FullMethodName        = "/task.TaskService/SaveSecretResult"
+	TaskService_SaveDnsRecordResult_FullMethodName     = "/task.TaskService/SaveDnsRecordResult"
+	TaskService_SyncSubdomains_FullMethodName          = "/task.TaskService/SyncSubdomains"
+	TaskService_EnrollWorker_FullMethodName            = "/task.TaskService/EnrollWorker"
+	TaskService_WorkerOnline_FullMethodName            = "/task.TaskService/WorkerOnline"
+	TaskService_PushWorkerLogs_FullMethodName          = "/task.TaskService/PushWorkerLogs"
+	TaskService_WorkerSession_FullMethodName           = "/task.TaskService/WorkerSession"
+	TaskService_GetTaskControl_FullMethodName          = "/task.TaskService/GetTaskControl"
+	TaskService_UpdateTaskProgress_FullMethodName      = "/task.TaskService/UpdateTaskProgress"
+	TaskService_SavePocValidationResult_FullMethodName = "/task.TaskService/SavePocValidationResult"
 )
 
 // TaskServiceClient is the client API for TaskService service.
@@ -81,6 +95,34 @@ type TaskServiceClient interface {
 	GetHttpServiceMappings(ctx context.Context, in *GetHttpServiceMappingsReq, opts ...grpc.CallOption) (*GetHttpServiceMappingsResp, error)
 	// 获取Subfinder数据源配置
 	GetSubfinderProviders(ctx context.Context, in *GetSubfinderProvidersReq, opts ...grpc.CallOption) (*GetSubfinderProvidersResp, error)
+	// 保存爬虫发现的URL
+	SaveUrlResult(ctx context.Context, in *SaveUrlResultReq, opts ...grpc.CallOption) (*SaveUrlResultResp, error)
+	// 获取爆破字典内容
+	GetWordlists(ctx context.Context, in *GetWordlistsReq, opts ...grpc.CallOption) (*GetWordlistsResp, error)
+	// 保存目录扫描结果
+	SaveDirScanResult(ctx context.Context, in *SaveDirScanResultReq, opts ...grpc.CallOption) (*SaveDirScanResultResp, error)
+	// 获取敏感信息检测规则
+	GetSecretRules(ctx context.Context, in *GetSecretRulesReq, opts ...grpc.CallOption) (*GetSecretRulesResp, error)
+	// 保存敏感信息检测结果
+	SaveSecretResult(ctx context.Context, in *SaveSecretResultReq, opts ...grpc.CallOption) (*SaveSecretResultResp, error)
+	// 保存域名DNS记录
+	SaveDnsRecordResult(ctx context.Context, in *SaveDnsRecordResultReq, opts ...grpc.CallOption) (*SaveDnsRecordResultResp, error)
+	// 子域名监控：与已知子域名比对，返回新增和解析变化的子域名
+	SyncSubdomains(ctx context.Context, in *SyncSubdomainsReq, opts ...grpc.CallOption) (*SyncSubdomainsResp, error)
+	// Worker使用注册令牌换取凭证，无需认证
+	EnrollWorker(ctx context.Context, in *EnrollWorkerReq, opts ...grpc.CallOption) (*EnrollWorkerResp, error)
+	// Worker上线：校验凭证并确认使用的名称
+	WorkerOnline(ctx context.Context, in *WorkerOnlineReq, opts ...grpc.CallOption) (*WorkerOnlineResp, error)
+	// Worker日志上报（客户端流）
+	PushWorkerLogs(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[WorkerLogEntry, PushWorkerLogsResp], error)
+	// Worker会话（双向流）：Worker上报状态，服务端下发控制命令和状态查询
+	WorkerSession(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[WorkerStatusReport, WorkerCommand], error)
+	// 获取任务控制信号
+	GetTaskControl(ctx context.Context, in *GetTaskControlReq, opts ...grpc.CallOption) (*GetTaskControlResp, error)
+	// 更新任务进度
+	UpdateTaskProgress(ctx context.Context, in *UpdateTaskProgressReq, opts ...grpc.CallOption) (*UpdateTaskProgressResp, error)
+	// 保存POC验证结果
+	SavePocValidationResult(ctx context.Context, in *SavePocValidationResultReq, opts ...grpc.CallOption) (*SavePocValidationResultResp, error)
 }
 
 type taskServiceClient struct {
@@ -271,6 +313,152 @@ func (c *taskServiceClient) GetSubfinderProviders(ctx context.Context, in *GetSu
 	return out, nil
 }
 
+func (c *taskServiceClient) SaveUrlResult(ctx context.Context, in *SaveUrlResultReq, opts ...grpc.CallOption) (*SaveUrlResultResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SaveUrlResultResp)
+	err := c.cc.Invoke(ctx, TaskService_SaveUrlResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) GetWordlists(ctx context.Context, in *GetWordlistsReq, opts ...grpc.CallOption) (*GetWordlistsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWordlistsResp)
+	err := c.cc.Invoke(ctx, TaskService_GetWordlists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) SaveDirScanResult(ctx context.Context, in *SaveDirScanResultReq, opts ...grpc.CallOption) (*SaveDirScanResultResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SaveDirScanResultResp)
+	err := c.cc.Invoke(ctx, TaskService_SaveDirScanResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) GetSecretRules(ctx context.Context, in *GetSecretRulesReq, opts ...grpc.CallOption) (*GetSecretRulesResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSecretRulesResp)
+	err := c.cc.Invoke(ctx, TaskService_GetSecretRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) SaveSecretResult(ctx context.Context, in *SaveSecretResultReq, opts ...grpc.CallOption) (*SaveSecretResultResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SaveSecretResultResp)
+	err := c.cc.Invoke(ctx, TaskService_SaveSecretResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) SaveDnsRecordResult(ctx context.Context, in *SaveDnsRecordResultReq, opts ...grpc.CallOption) (*SaveDnsRecordResultResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SaveDnsRecordResultResp)
+	err := c.cc.Invoke(ctx, TaskService_SaveDnsRecordResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) SyncSubdomains(ctx context.Context, in *SyncSubdomainsReq, opts ...grpc.CallOption) (*SyncSubdomainsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncSubdomainsResp)
+	err := c.cc.Invoke(ctx, TaskService_SyncSubdomains_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) EnrollWorker(ctx context.Context, in *EnrollWorkerReq, opts ...grpc.CallOption) (*EnrollWorkerResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrollWorkerResp)
+	err := c.cc.Invoke(ctx, TaskService_EnrollWorker_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) WorkerOnline(ctx context.Context, in *WorkerOnlineReq, opts ...grpc.CallOption) (*WorkerOnlineResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkerOnlineResp)
+	err := c.cc.Invoke(ctx, TaskService_WorkerOnline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) PushWorkerLogs(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[WorkerLogEntry, PushWorkerLogsResp], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TaskService_ServiceDesc.Streams[0], TaskService_PushWorkerLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WorkerLogEntry, PushWorkerLogsResp]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskService_PushWorkerLogsClient = grpc.ClientStreamingClient[WorkerLogEntry, PushWorkerLogsResp]
+
+func (c *taskServiceClient) WorkerSession(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[WorkerStatusReport, WorkerCommand], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TaskService_ServiceDesc.Streams[1], TaskService_WorkerSession_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WorkerStatusReport, WorkerCommand]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskService_WorkerSessionClient = grpc.BidiStreamingClient[WorkerStatusReport, WorkerCommand]
+
+func (c *taskServiceClient) GetTaskControl(ctx context.Context, in *GetTaskControlReq, opts ...grpc.CallOption) (*GetTaskControlResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTaskControlResp)
+	err := c.cc.Invoke(ctx, TaskService_GetTaskControl_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) UpdateTaskProgress(ctx context.Context, in *UpdateTaskProgressReq, opts ...grpc.CallOption) (*UpdateTaskProgressResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateTaskProgressResp)
+	err := c.cc.Invoke(ctx, TaskService_UpdateTaskProgress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) SavePocValidationResult(ctx context.Context, in *SavePocValidationResultReq, opts ...grpc.CallOption) (*SavePocValidationResultResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SavePocValidationResultResp)
+	err := c.cc.Invoke(ctx, TaskService_SavePocValidationResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TaskServiceServer is the server API for TaskService service.
 // All implementations must embed UnimplementedTaskServiceServer
 // for forward compatibility.
@@ -313,6 +501,34 @@ type TaskServiceServer interface {
 	GetHttpServiceMappings(context.Context, *GetHttpServiceMappingsReq) (*GetHttpServiceMappingsResp, error)
 	// 获取Subfinder数据源配置
 	GetSubfinderProviders(context.Context, *GetSubfinderProvidersReq) (*GetSubfinderProvidersResp, error)
+	// 保存爬虫发现的URL
+	SaveUrlResult(context.Context, *SaveUrlResultReq) (*SaveUrlResultResp, error)
+	// 获取爆破字典内容
+	GetWordlists(context.Context, *GetWordlistsReq) (*GetWordlistsResp, error)
+	// 保存目录扫描结果
+	SaveDirScanResult(context.Context, *SaveDirScanResultReq) (*SaveDirScanResultResp, error)
+	// 获取敏感信息检测规则
+	GetSecretRules(context.Context, *GetSecretRulesReq) (*GetSecretRulesResp, error)
+	// 保存敏感信息检测结果
+	SaveSecretResult(context.Context, *SaveSecretResultReq) (*SaveSecretResultResp, error)
+	// 保存域名DNS记录
+	SaveDnsRecordResult(context.Context, *SaveDnsRecordResultReq) (*SaveDnsRecordResultResp, error)
+	// 子域名监控：与已知子域名比对，返回新增和解析变化的子域名
+	SyncSubdomains(context.Context, *SyncSubdomainsReq) (*SyncSubdomainsResp, error)
+	// Worker使用注册令牌换取凭证，无需认证
+	EnrollWorker(context.Context, *EnrollWorkerReq) (*EnrollWorkerResp, error)
+	// Worker上线：校验凭证并确认使用的名称
+	WorkerOnline(context.Context, *WorkerOnlineReq) (*WorkerOnlineResp, error)
+	// Worker日志上报（客户端流）
+	PushWorkerLogs(grpc.ClientStreamingServer[WorkerLogEntry, PushWorkerLogsResp]) error
+	// Worker会话（双向流）：Worker上报状态，服务端下发控制命令和状态查询
+	WorkerSession(grpc.BidiStreamingServer[WorkerStatusReport, WorkerCommand]) error
+	// 获取任务控制信号
+	GetTaskControl(context.Context, *GetTaskControlReq) (*GetTaskControlResp, error)
+	// 更新任务进度
+	UpdateTaskProgress(context.Context, *UpdateTaskProgressReq) (*UpdateTaskProgressResp, error)
+	// 保存POC验证结果
+	SavePocValidationResult(context.Context, *SavePocValidationResultReq) (*SavePocValidationResultResp, error)
 	mustEmbedUnimplementedTaskServiceServer()
 }
 
@@ -377,6 +593,48 @@ func (UnimplementedTaskServiceServer) GetHttpServiceMappings(context.Context, *G
 func (UnimplementedTaskServiceServer) GetSubfinderProviders(context.Context, *GetSubfinderProvidersReq) (*GetSubfinderProvidersResp, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetSubfinderProviders not implemented")
 }
+func (UnimplementedTaskServiceServer) SaveUrlResult(context.Context, *SaveUrlResultReq) (*SaveUrlResultResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method SaveUrlResult not implemented")
+}
+func (UnimplementedTaskServiceServer) GetWordlists(context.Context, *GetWordlistsReq) (*GetWordlistsResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWordlists not implemented")
+}
+func (UnimplementedTaskServiceServer) SaveDirScanResult(context.Context, *SaveDirScanResultReq) (*SaveDirScanResultResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method SaveDirScanResult not implemented")
+}
+func (UnimplementedTaskServiceServer) GetSecretRules(context.Context, *GetSecretRulesReq) (*GetSecretRulesResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSecretRules not implemented")
+}
+func (UnimplementedTaskServiceServer) SaveSecretResult(context.Context, *SaveSecretResultReq) (*SaveSecretResultResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method SaveSecretResult not implemented")
+}
+func (UnimplementedTaskServiceServer) SaveDnsRecordResult(context.Context, *SaveDnsRecordResultReq) (*SaveDnsRecordResultResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method SaveDnsRecordResult not implemented")
+}
+func (UnimplementedTaskServiceServer) SyncSubdomains(context.Context, *SyncSubdomainsReq) (*SyncSubdomainsResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method SyncSubdomains not implemented")
+}
+func (UnimplementedTaskServiceServer) EnrollWorker(context.Context, *EnrollWorkerReq) (*EnrollWorkerResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method EnrollWorker not implemented")
+}
+func (UnimplementedTaskServiceServer) WorkerOnline(context.Context, *WorkerOnlineReq) (*WorkerOnlineResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method WorkerOnline not implemented")
+}
+func (UnimplementedTaskServiceServer) PushWorkerLogs(grpc.ClientStreamingServer[WorkerLogEntry, PushWorkerLogsResp]) error {
+	return status.Error(codes.Unimplemented, "method PushWorkerLogs not implemented")
+}
+func (UnimplementedTaskServiceServer) WorkerSession(grpc.BidiStreamingServer[WorkerStatusReport, WorkerCommand]) error {
+	return status.Error(codes.Unimplemented, "method WorkerSession not implemented")
+}
+func (UnimplementedTaskServiceServer) GetTaskControl(context.Context, *GetTaskControlReq) (*GetTaskControlResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTaskControl not implemented")
+}
+func (UnimplementedTaskServiceServer) UpdateTaskProgress(context.Context, *UpdateTaskProgressReq) (*UpdateTaskProgressResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateTaskProgress not implemented")
+}
+func (UnimplementedTaskServiceServer) SavePocValidationResult(context.Context, *SavePocValidationResultReq) (*SavePocValidationResultResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method SavePocValidationResult not implemented")
+}
 func (UnimplementedTaskServiceServer) mustEmbedUnimplementedTaskServiceServer() {}
 func (UnimplementedTaskServiceServer) testEmbeddedByValue()                     {}
 
@@ -722,6 +980,236 @@ func _TaskService_GetSubfinderProviders_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaskService_SaveUrlResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveUrlResultReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).SaveUrlResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_SaveUrlResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).SaveUrlResult(ctx, req.(*SaveUrlResultReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_GetWordlists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWordlistsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).GetWordlists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_GetWordlists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).GetWordlists(ctx, req.(*GetWordlistsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_SaveDirScanResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveDirScanResultReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).SaveDirScanResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_SaveDirScanResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).SaveDirScanResult(ctx, req.(*SaveDirScanResultReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_GetSecretRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSecretRulesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).GetSecretRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_GetSecretRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).GetSecretRules(ctx, req.(*GetSecretRulesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_SaveSecretResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveSecretResultReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).SaveSecretResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_SaveSecretResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).SaveSecretResult(ctx, req.(*SaveSecretResultReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_SaveDnsRecordResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveDnsRecordResultReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).SaveDnsRecordResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_SaveDnsRecordResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).SaveDnsRecordResult(ctx, req.(*SaveDnsRecordResultReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_SyncSubdomains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncSubdomainsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).SyncSubdomains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_SyncSubdomains_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).SyncSubdomains(ctx, req.(*SyncSubdomainsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_EnrollWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollWorkerReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).EnrollWorker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_EnrollWorker_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).EnrollWorker(ctx, req.(*EnrollWorkerReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_WorkerOnline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkerOnlineReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).WorkerOnline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_WorkerOnline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).WorkerOnline(ctx, req.(*WorkerOnlineReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_PushWorkerLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TaskServiceServer).PushWorkerLogs(&grpc.GenericServerStream[WorkerLogEntry, PushWorkerLogsResp]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskService_PushWorkerLogsServer = grpc.ClientStreamingServer[WorkerLogEntry, PushWorkerLogsResp]
+
+func _TaskService_WorkerSession_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TaskServiceServer).WorkerSession(&grpc.GenericServerStream[WorkerStatusReport, WorkerCommand]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskService_WorkerSessionServer = grpc.BidiStreamingServer[WorkerStatusReport, WorkerCommand]
+
+func _TaskService_GetTaskControl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskControlReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).GetTaskControl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_GetTaskControl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).GetTaskControl(ctx, req.(*GetTaskControlReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_UpdateTaskProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTaskProgressReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).UpdateTaskProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_UpdateTaskProgress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).UpdateTaskProgress(ctx, req.(*UpdateTaskProgressReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_SavePocValidationResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SavePocValidationResultReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).SavePocValidationResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_SavePocValidationResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).SavePocValidationResult(ctx, req.(*SavePocValidationResultReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TaskService_ServiceDesc is the grpc.ServiceDesc for TaskService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -801,7 +1289,67 @@ var TaskService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetSubfinderProviders",
 			Handler:    _TaskService_GetSubfinderProviders_Handler,
 		},
+		{
+			MethodName: "SaveUrlResult",
+			Handler:    _TaskService_SaveUrlResult_Handler,
+		},
+		{
+			MethodName: "GetWordlists",
+			Handler:    _TaskService_GetWordlists_Handler,
+		},
+		{
+			MethodName: "SaveDirScanResult",
+			Handler:    _TaskService_SaveDirScanResult_Handler,
+		},
+		{
+			MethodName: "GetSecretRules",
+			Handler:    _TaskService_GetSecretRules_Handler,
+		},
+		{
+			MethodName: "SaveSecretResult",
+			Handler:    _TaskService_SaveSecretResult_Handler,
+		},
+		{
+			MethodName: "SaveDnsRecordResult",
+			Handler:    _TaskService_SaveDnsRecordResult_Handler,
+		},
+		{
+			MethodName: "SyncSubdomains",
+			Handler:    _TaskService_SyncSubdomains_Handler,
+		},
+		{
+			MethodName: "EnrollWorker",
+			Handler:    _TaskService_EnrollWorker_Handler,
+		},
+		{
+			MethodName: "WorkerOnline",
+			Handler:    _TaskService_WorkerOnline_Handler,
+		},
+		{
+			MethodName: "GetTaskControl",
+			Handler:    _TaskService_GetTaskControl_Handler,
+		},
+		{
+			MethodName: "UpdateTaskProgress",
+			Handler:    _TaskService_UpdateTaskProgress_Handler,
+		},
+		{
+			MethodName: "SavePocValidationResult",
+			Handler:    _TaskService_SavePocValidationResult_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PushWorkerLogs",
+			Handler:       _TaskService_PushWorkerLogs_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WorkerSession",
+			Handler:       _TaskService_WorkerSession_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpc/task/task.proto",
 }
@@ -1,15 +1,21 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 
+	"cscan/pkg/rpcauth"
+	"cscan/rpc/task/internal/auth"
 	"cscan/rpc/task/internal/config"
+	"cscan/rpc/task/internal/logic"
 	"cscan/rpc/task/internal/server"
 	"cscan/rpc/task/internal/svc"
 	"cscan/rpc/task/pb"
+	"cscan/scheduler"
 
 	"github.com/zeromicro/go-zero/core/conf"
+	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/core/service"
 	"github.com/zeromicro/go-zero/zrpc"
 	"google.golang.org/grpc"
@@ -34,6 +40,36 @@ func main() {
 	})
 	defer s.Stop()
 
+	// 传输加密，配置CA时同时校验Worker的客户端证书
+	if c.TLS.Enabled() {
+		creds, err := rpcauth.ServerCredentials(c.TLS)
+		if err != nil {
+			logx.Must(err)
+		}
+		s.AddOptions(grpc.Creds(creds))
+	}
+
+	// Worker认证
+	if c.WorkerAuth.Enable {
+		authenticator := auth.NewWorkerAuthenticator(ctx.WorkerCredentialModel, c.WorkerAuth.ServiceToken)
+		s.AddUnaryInterceptors(authenticator.UnaryInterceptor)
+		s.AddStreamInterceptors(authenticator.StreamInterceptor)
+	} else {
+		logx.Info("Worker auth disabled, anyone who can reach the rpc port can pull tasks and save results")
+	}
+
+	// 旧版本把指定了Worker的任务也放在共享队列中，移到对应Worker的专属队列
+	if moved, err := scheduler.MigrateQueue(context.Background(), ctx.RedisClient); err != nil {
+		logx.Errorf("Migrate task queue failed: %v", err)
+	} else if moved > 0 {
+		logx.Infof("Moved %d worker-specific tasks from shared queue to worker queues", moved)
+	}
+
+	// 回收到期的任务租约，Worker失联后任务自动重新入队
+	reaper := logic.NewLeaseReaper(ctx)
+	reaper.Start()
+	defer reaper.Stop()
+
 	fmt.Printf("Starting rpc server at %s...\n", c.ListenOn)
 	s.Start()
 }
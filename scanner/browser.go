@@ -0,0 +1,423 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const (
+	defaultBrowserTabs         = 4
+	defaultBrowserRecycleAfter = 200
+	defaultBrowserIdleTimeout  = 2 * time.Minute
+	maxFullPageHeight          = 16384 // 整页截图最大高度，防止无限滚动页面生成超大图片
+)
+
+// ScreenshotOptions 截图参数
+type ScreenshotOptions struct {
+	Width    int           // 视口宽度，默认1920
+	Height   int           // 视口高度，默认1080
+	FullPage bool          // 截取整个页面，否则只截取首屏
+	Format   string        // 图片格式: jpeg/webp/png，默认jpeg
+	Quality  int           // jpeg/webp压缩质量(1-100)，默认80
+	Timeout  time.Duration // 单个页面超时，默认30秒
+	Wait     time.Duration // 页面加载后等待渲染的时间，默认2秒
+}
+
+func (o *ScreenshotOptions) setDefaults() {
+	if o.Width <= 0 {
+		o.Width = 1920
+	}
+	if o.Height <= 0 {
+		o.Height = 1080
+	}
+	if o.Quality <= 0 || o.Quality > 100 {
+		o.Quality = 80
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = 30 * time.Second
+	}
+	if o.Wait <= 0 {
+		o.Wait = 2 * time.Second
+	}
+}
+
+func (o *ScreenshotOptions) format() page.CaptureScreenshotFormat {
+	switch o.Format {
+	case "png":
+		return page.CaptureScreenshotFormatPng
+	case "webp":
+		return page.CaptureScreenshotFormatWebp
+	}
+	return page.CaptureScreenshotFormatJpeg
+}
+
+// BrowserPool 共享的无头浏览器，所有截图和爬虫渲染复用同一个Chromium进程，每个页面使用独立的标签页
+// 同时打开的标签页数量有上限；浏览器处理一定数量的页面或崩溃后重启，空闲一段时间后自动关闭
+type BrowserPool struct {
+	mu           sync.Mutex
+	sem          chan struct{}
+	maxTabs      int
+	recycleAfter int
+	idleTimeout  time.Duration
+	current      *browserInstance
+	idleTimer    *time.Timer
+	closed       bool
+}
+
+// browserInstance 一个Chromium进程
+type browserInstance struct {
+	ctx         context.Context
+	cancel      context.CancelFunc
+	allocCancel context.CancelFunc
+	pages       int  // 已处理的页面数
+	active      int  // 正在使用的标签页数
+	retired     bool // 已达到回收条件，不再分配新的标签页
+}
+
+// NewBrowserPool 创建浏览器池，浏览器在第一次使用时启动
+func NewBrowserPool(maxTabs, recycleAfter int) *BrowserPool {
+	p := &BrowserPool{idleTimeout: defaultBrowserIdleTimeout}
+	p.SetLimits(maxTabs, recycleAfter)
+	return p
+}
+
+// SetLimits 调整标签页上限和回收阈值，正在使用的标签页不受影响
+func (p *BrowserPool) SetLimits(maxTabs, recycleAfter int) {
+	if maxTabs <= 0 {
+		maxTabs = defaultBrowserTabs
+	}
+	if recycleAfter <= 0 {
+		recycleAfter = defaultBrowserRecycleAfter
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.sem == nil || maxTabs != p.maxTabs {
+		p.sem = make(chan struct{}, maxTabs)
+		p.maxTabs = maxTabs
+	}
+	p.recycleAfter = recycleAfter
+}
+
+// Screenshot 打开页面并截图，返回图片原始数据
+func (p *BrowserPool) Screenshot(ctx context.Context, targetUrl string, opts ScreenshotOptions) ([]byte, error) {
+	opts.setDefaults()
+
+	var buf []byte
+	err := p.runTab(ctx, opts.Timeout, func(runCtx context.Context) error {
+		err := chromedp.Run(runCtx,
+			chromedp.EmulateViewport(int64(opts.Width), int64(opts.Height)),
+			chromedp.Navigate(targetUrl),
+			chromedp.Sleep(opts.Wait),
+			captureScreenshot(&buf, &opts),
+		)
+		if err != nil && opts.FullPage && runCtx.Err() == nil {
+			// 整页截图失败（如页面过大）时退回首屏截图
+			logx.Debugf("Full page screenshot failed for %s, fallback to viewport: %v", targetUrl, err)
+			opts.FullPage = false
+			err = chromedp.Run(runCtx, captureScreenshot(&buf, &opts))
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// RenderRequest 页面渲染过程中发出的XHR/fetch请求
+type RenderRequest struct {
+	Method string
+	Url    string
+}
+
+// RenderResult 页面渲染结果
+type RenderResult struct {
+	Url      string          // 跳转后的最终地址
+	HTML     string          // 渲染后的DOM
+	Requests []RenderRequest // 页面发出的XHR/fetch请求
+}
+
+// Render 打开页面并等待脚本执行，返回渲染后的DOM和页面发出的XHR/fetch请求
+func (p *BrowserPool) Render(ctx context.Context, targetUrl string, timeout, wait time.Duration) (*RenderResult, error) {
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	if wait <= 0 {
+		wait = 2 * time.Second
+	}
+
+	result := &RenderResult{}
+	var mu sync.Mutex
+	err := p.runTab(ctx, timeout, func(runCtx context.Context) error {
+		chromedp.ListenTarget(runCtx, func(ev interface{}) {
+			e, ok := ev.(*network.EventRequestWillBeSent)
+			if !ok || (e.Type != network.ResourceTypeXHR && e.Type != network.ResourceTypeFetch) {
+				return
+			}
+			mu.Lock()
+			result.Requests = append(result.Requests, RenderRequest{Method: e.Request.Method, Url: e.Request.URL})
+			mu.Unlock()
+		})
+		return chromedp.Run(runCtx,
+			network.Enable(),
+			chromedp.Navigate(targetUrl),
+			chromedp.Sleep(wait),
+			chromedp.Location(&result.Url),
+			chromedp.OuterHTML("html", &result.HTML, chromedp.ByQuery),
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	return result, nil
+}
+
+// runTab 等待空闲标签页，在新标签页中执行fn，超时或调用方取消时关闭标签页
+func (p *BrowserPool) runTab(ctx context.Context, timeout time.Duration, fn func(runCtx context.Context) error) error {
+	p.mu.Lock()
+	sem := p.sem
+	p.mu.Unlock()
+	select {
+	case sem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-sem }()
+
+	inst, err := p.acquire()
+	if err != nil {
+		return err
+	}
+	defer p.release(inst)
+
+	tabCtx, tabCancel := chromedp.NewContext(inst.ctx)
+	defer tabCancel()
+	// 调用方取消时同时关闭标签页
+	stop := context.AfterFunc(ctx, tabCancel)
+	defer stop()
+	runCtx, runCancel := context.WithTimeout(tabCtx, timeout)
+	defer runCancel()
+
+	return fn(runCtx)
+}
+
+// captureScreenshot 按配置截取首屏或整页
+func captureScreenshot(res *[]byte, opts *ScreenshotOptions) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		params := page.CaptureScreenshot().
+			WithFromSurface(true).
+			WithFormat(opts.format())
+		if opts.format() != page.CaptureScreenshotFormatPng {
+			params = params.WithQuality(int64(opts.Quality))
+		}
+
+		if opts.FullPage {
+			_, _, contentSize, _, _, cssContentSize, err := page.GetLayoutMetrics().Do(ctx)
+			if err != nil {
+				return err
+			}
+			if cssContentSize != nil {
+				contentSize = cssContentSize
+			}
+			if contentSize == nil {
+				return fmt.Errorf("layout metrics unavailable")
+			}
+			height := math.Min(math.Max(contentSize.Height, float64(opts.Height)), maxFullPageHeight)
+			params = params.
+				WithCaptureBeyondViewport(true).
+				WithClip(&page.Viewport{
+					X:      0,
+					Y:      0,
+					Width:  float64(opts.Width),
+					Height: math.Ceil(height),
+					Scale:  1,
+				})
+		}
+
+		var err error
+		*res, err = params.Do(ctx)
+		return err
+	})
+}
+
+// acquire 获取当前浏览器，不存在、已回收或已崩溃时启动新的浏览器
+func (p *BrowserPool) acquire() (*browserInstance, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return nil, fmt.Errorf("browser pool closed")
+	}
+	if p.idleTimer != nil {
+		p.idleTimer.Stop()
+		p.idleTimer = nil
+	}
+
+	if p.current != nil && (p.current.retired || p.current.ctx.Err() != nil) {
+		p.retire(p.current)
+		p.current = nil
+	}
+	if p.current == nil {
+		inst, err := startBrowser()
+		if err != nil {
+			return nil, err
+		}
+		p.current = inst
+	}
+
+	inst := p.current
+	inst.active++
+	inst.pages++
+	if inst.pages >= p.recycleAfter {
+		inst.retired = true
+	}
+	return inst, nil
+}
+
+// release 归还标签页，已回收的浏览器在最后一个标签页关闭后退出
+func (p *BrowserPool) release(inst *browserInstance) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	inst.active--
+	if inst.ctx.Err() != nil {
+		// 浏览器崩溃或连接断开
+		logx.Errorf("Browser crashed, will restart on next screenshot")
+		inst.retired = true
+	}
+	if inst.retired {
+		p.retire(inst)
+		if p.current == inst {
+			p.current = nil
+		}
+	}
+
+	if p.current != nil && p.current.active == 0 && !p.closed {
+		idle := p.current
+		p.idleTimer = time.AfterFunc(p.idleTimeout, func() {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			if p.current == idle && idle.active == 0 {
+				idle.retired = true
+				p.retire(idle)
+				p.current = nil
+			}
+		})
+	}
+}
+
+// retire 标记浏览器为已回收，没有正在使用的标签页时关闭进程，调用方需持有锁
+func (p *BrowserPool) retire(inst *browserInstance) {
+	inst.retired = true
+	if inst.active == 0 {
+		inst.cancel()
+		inst.allocCancel()
+	}
+}
+
+// Close 关闭浏览器，正在进行的截图会失败
+func (p *BrowserPool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	if p.idleTimer != nil {
+		p.idleTimer.Stop()
+		p.idleTimer = nil
+	}
+	if p.current != nil {
+		p.current.cancel()
+		p.current.allocCancel()
+		p.current = nil
+	}
+}
+
+// startBrowser 启动Chromium进程
+func startBrowser() (*browserInstance, error) {
+	// 配置chromedp选项，支持 Docker 环境中的 Chromium
+	opts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.Flag("headless", true),
+		chromedp.Flag("no-sandbox", true),
+		chromedp.Flag("disable-dev-shm-usage", true),
+		chromedp.Flag("ignore-certificate-errors", true),
+		chromedp.Flag("disable-web-security", true),
+		chromedp.Flag("disable-features", "VizDisplayCompositor"),
+		chromedp.Flag("disable-background-timer-throttling", true),
+		chromedp.Flag("disable-backgrounding-occluded-windows", true),
+		chromedp.Flag("disable-renderer-backgrounding", true),
+		chromedp.Flag("force-color-profile", "srgb"),
+		chromedp.UserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"),
+	)
+
+	// 检查环境变量中是否指定了 Chrome 路径
+	if chromePath := os.Getenv("CHROME_BIN"); chromePath != "" {
+		opts = append(opts, chromedp.ExecPath(chromePath))
+	}
+
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), opts...)
+	ctx, cancel := chromedp.NewContext(allocCtx)
+	// 第一次Run启动浏览器进程，不能使用带超时的上下文，否则超时后浏览器会被关闭
+	if err := chromedp.Run(ctx); err != nil {
+		cancel()
+		allocCancel()
+		return nil, fmt.Errorf("start browser: %v", err)
+	}
+	return &browserInstance{ctx: ctx, cancel: cancel, allocCancel: allocCancel}, nil
+}
+
+// CheckChromeInstalled 检查本机是否有可用于截图的Chromium，查找位置与chromedp一致，CHROME_BIN优先
+func CheckChromeInstalled() bool {
+	if chromePath := os.Getenv("CHROME_BIN"); chromePath != "" {
+		_, err := exec.LookPath(chromePath)
+		return err == nil
+	}
+	var locations []string
+	switch runtime.GOOS {
+	case "darwin":
+		locations = []string{
+			"/Applications/Chromium.app/Contents/MacOS/Chromium",
+			"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
+		}
+	case "windows":
+		locations = []string{
+			"chrome",
+			"chrome.exe",
+			`C:\Program Files (x86)\Google\Chrome\Application\chrome.exe`,
+			`C:\Program Files\Google\Chrome\Application\chrome.exe`,
+			filepath.Join(os.Getenv("USERPROFILE"), `AppData\Local\Google\Chrome\Application\chrome.exe`),
+			filepath.Join(os.Getenv("USERPROFILE"), `AppData\Local\Chromium\Application\chrome.exe`),
+		}
+	default:
+		locations = []string{
+			"headless_shell",
+			"headless-shell",
+			"chromium",
+			"chromium-browser",
+			"google-chrome",
+			"google-chrome-stable",
+			"google-chrome-beta",
+			"google-chrome-unstable",
+			"/usr/bin/google-chrome",
+			"/usr/local/bin/chrome",
+			"/snap/bin/chromium",
+			"chrome",
+		}
+	}
+	for _, path := range locations {
+		if _, err := exec.LookPath(path); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package scanner
+
+import (
+	"context"
+	"net"
+
+	"cscan/pkg/cdn"
+)
+
+// MarkCdn 仅根据资产已有的CNAME和IP匹配已知CDN/云特征，不额外发起DNS查询
+func MarkCdn(asset *Asset) {
+	detector := cdn.Default()
+
+	if p := detector.MatchCName(asset.CName); p != nil {
+		applyProvider(asset, p)
+	}
+	if ip := net.ParseIP(asset.Host); ip != nil {
+		if p := detector.MatchIP(asset.Host); p != nil {
+			applyProvider(asset, p)
+		}
+	}
+	for _, ip := range assetIPs(asset) {
+		if p := detector.MatchIP(ip); p != nil {
+			applyProvider(asset, p)
+		}
+	}
+}
+
+// DetectCdn 对域名资产执行完整检测：CNAME链、CDN IP段以及多解析器结果差异
+func DetectCdn(ctx context.Context, asset *Asset, resolvers []string) {
+	detector := cdn.Default().WithResolvers(resolvers)
+	result := detector.Detect(ctx, asset.Host, assetIPs(asset))
+
+	asset.IsCDN = asset.IsCDN || result.IsCDN
+	asset.IsCloud = asset.IsCloud || result.IsCloud
+	if cname := result.CName(); cname != "" {
+		asset.CName = cname
+	}
+}
+
+func applyProvider(asset *Asset, p *cdn.Provider) {
+	switch p.Type {
+	case cdn.TypeCDN, cdn.TypeWAF:
+		asset.IsCDN = true
+	case cdn.TypeCloud:
+		asset.IsCloud = true
+	}
+}
+
+func assetIPs(asset *Asset) []string {
+	ips := make([]string, 0, len(asset.IPV4)+len(asset.IPV6))
+	for _, ip := range asset.IPV4 {
+		ips = append(ips, ip.IP)
+	}
+	for _, ip := range asset.IPV6 {
+		ips = append(ips, ip.IP)
+	}
+	return ips
+}
@@ -0,0 +1,741 @@
+package scanner
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"golang.org/x/net/html"
+	"golang.org/x/net/publicsuffix"
+	"golang.org/x/time/rate"
+)
+
+const (
+	crawlerUserAgent   = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+	crawlerMaxBodySize = 2 * 1024 * 1024 // 单个响应最多读取2MB
+)
+
+// URL来源，与 model.UrlSource* 对应
+const (
+	crawlSourcePage    = "page"
+	crawlSourceForm    = "form"
+	crawlSourceJS      = "js"
+	crawlSourceXHR     = "xhr"
+	crawlSourceRobots  = "robots"
+	crawlSourceSitemap = "sitemap"
+)
+
+// crawlerStaticExt 默认不请求也不记录的静态资源扩展名
+var crawlerStaticExt = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".bmp": true, ".ico": true, ".svg": true, ".webp": true,
+	".css": true, ".woff": true, ".woff2": true, ".ttf": true, ".eot": true, ".otf": true,
+	".mp3": true, ".mp4": true, ".avi": true, ".webm": true, ".flv": true, ".wav": true,
+	".pdf": true, ".zip": true, ".rar": true, ".gz": true, ".7z": true, ".tar": true, ".exe": true, ".map": true,
+}
+
+// jsEndpointRegex 从JS代码中提取接口路径，规则参考 LinkFinder
+var jsEndpointRegex = regexp.MustCompile("[\"'`]" +
+	`((?:[a-zA-Z]{1,10}://|//)[^"'/]+\.[a-zA-Z]{2,}[^"']*` +
+	`|(?:/|\.\./|\./)[^"'><,;| *()%$^/\\\[\]][^"'><,;|()]+` +
+	`|[a-zA-Z0-9_\-/]+/[a-zA-Z0-9_\-/.]+\.(?:[a-zA-Z]{1,4}|action)(?:[\?#][^"']*)?` +
+	`|[a-zA-Z0-9_\-/]+/[a-zA-Z0-9_\-/]{3,}(?:[\?#][^"']*)?` +
+	`|[a-zA-Z0-9_\-]+\.(?:php|asp|aspx|jsp|json|action|html|js|txt|xml)(?:[\?#][^"']*)?)` +
+	"[\"'`]")
+
+// mimeTypeRegex 过滤JS中形如 text/html 的MIME类型字符串
+var mimeTypeRegex = regexp.MustCompile(`^(?:text|application|image|audio|video|font|multipart)/[\w.+\-]+$`)
+
+var sitemapLocRegex = regexp.MustCompile(`(?i)<loc>\s*([^<\s]+)\s*</loc>`)
+
+// CrawlerScanner Web爬虫，从HTTP资产首页开始按深度爬取，提取链接、表单和JS中的接口
+type CrawlerScanner struct {
+	BaseScanner
+	client  *http.Client
+	browser *BrowserPool // 无头模式使用的浏览器，与指纹识别截图共享
+}
+
+// NewCrawlerScanner 创建Web爬虫，browser为空时无头模式退化为静态爬取
+func NewCrawlerScanner(browser *BrowserPool) *CrawlerScanner {
+	return &CrawlerScanner{
+		BaseScanner: BaseScanner{name: "crawler"},
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig:     &tls.Config{InsecureSkipVerify: true},
+				MaxIdleConnsPerHost: 4,
+			},
+			// 不自动跳转，跳转地址作为新的链接处理，避免跳出爬取范围
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		browser: browser,
+	}
+}
+
+// CrawlerOptions 爬虫选项
+type CrawlerOptions struct {
+	MaxDepth      int      `json:"maxDepth"`      // 最大爬取深度，默认2
+	MaxPages      int      `json:"maxPages"`      // 每个站点最多请求的页面数，默认200
+	Scope         string   `json:"scope"`         // host: 同主机端口(默认), domain: 同主域名
+	RateLimit     int      `json:"rateLimit"`     // 每个站点每秒请求数，默认10
+	Concurrency   int      `json:"concurrency"`   // 同时爬取的站点数，默认5
+	Headless      bool     `json:"headless"`      // 使用无头浏览器渲染HTML页面
+	Timeout       int      `json:"timeout"`       // 总超时时间(秒)，默认1800秒
+	TargetTimeout int      `json:"targetTimeout"` // 单个站点超时时间(秒)，默认300秒
+	ExcludeExt    []string `json:"excludeExt"`    // 额外排除的文件扩展名
+}
+
+// CrawledUrl 爬虫发现的URL，同一个 Endpoint+Method 只保留一条，参数名合并
+type CrawledUrl struct {
+	Authority   string   `json:"authority"`
+	Host        string   `json:"host"`
+	Port        int      `json:"port"`
+	Url         string   `json:"url"`      // 首次发现时的完整URL
+	Endpoint    string   `json:"endpoint"` // 不含查询参数的URL
+	Method      string   `json:"method"`
+	Path        string   `json:"path"`
+	Params      []string `json:"params,omitempty"` // 查询参数和表单字段名
+	Source      string   `json:"source"`           // page, form, js, xhr, robots, sitemap
+	StatusCode  int      `json:"statusCode,omitempty"`
+	ContentType string   `json:"contentType,omitempty"`
+	Length      int      `json:"length,omitempty"`
+	Title       string   `json:"title,omitempty"`
+	Referer     string   `json:"referer,omitempty"`
+}
+
+// Scan 爬取所有HTTP资产，结果保存在 ScanResult.Urls
+func (s *CrawlerScanner) Scan(ctx context.Context, config *ScanConfig) (*ScanResult, error) {
+	opts := &CrawlerOptions{}
+	if config.Options != nil {
+		switch v := config.Options.(type) {
+		case *CrawlerOptions:
+			opts = v
+		default:
+			if data, err := json.Marshal(config.Options); err == nil {
+				json.Unmarshal(data, opts)
+			}
+		}
+	}
+	opts.setDefaults()
+
+	taskLog := func(level, format string, args ...interface{}) {
+		if config.TaskLogger != nil {
+			config.TaskLogger(level, format, args...)
+		}
+	}
+
+	result := &ScanResult{
+		WorkspaceId: config.WorkspaceId,
+		MainTaskId:  config.MainTaskId,
+		Urls:        make([]*CrawledUrl, 0),
+	}
+
+	// 每个站点只爬取一次
+	var sites []*url.URL
+	seen := make(map[string]bool)
+	for _, asset := range filterHttpAssets(config.Assets) {
+		base, err := httpBaseUrl(asset)
+		if err != nil || seen[base.String()] {
+			continue
+		}
+		seen[base.String()] = true
+		sites = append(sites, base)
+	}
+	if len(sites) == 0 {
+		logx.Info("No HTTP assets found, skipping crawler")
+		return result, nil
+	}
+
+	headless := opts.Headless && s.browser != nil
+	logx.Infof("Crawler: crawling %d sites, depth=%d, maxPages=%d, scope=%s, headless=%v", len(sites), opts.MaxDepth, opts.MaxPages, opts.Scope, headless)
+	taskLog("INFO", "Crawler: crawling %d sites, depth=%d, maxPages=%d, scope=%s, headless=%v", len(sites), opts.MaxDepth, opts.MaxPages, opts.Scope, headless)
+
+	crawlCtx, cancel := context.WithTimeout(ctx, time.Duration(opts.Timeout)*time.Second)
+	defer cancel()
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		done int
+	)
+	sem := make(chan struct{}, opts.Concurrency)
+	for _, base := range sites {
+		select {
+		case sem <- struct{}{}:
+		case <-crawlCtx.Done():
+		}
+		if crawlCtx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(base *url.URL) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			siteCtx, siteCancel := context.WithTimeout(crawlCtx, time.Duration(opts.TargetTimeout)*time.Second)
+			defer siteCancel()
+			c := newSiteCrawler(s, base, opts, headless)
+			urls, pages := c.crawl(siteCtx)
+			if siteCtx.Err() == context.DeadlineExceeded {
+				taskLog("WARN", "Crawler: %s timeout", base.Host)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			result.Urls = append(result.Urls, urls...)
+			done++
+			taskLog("INFO", "Crawler [%d/%d]: %s, %d pages, %d urls", done, len(sites), base.Host, pages, len(urls))
+			if config.OnProgress != nil {
+				config.OnProgress(done*100/len(sites), fmt.Sprintf("Crawler: %d/%d", done, len(sites)))
+			}
+		}(base)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		// 任务被取消，返回已爬取的结果
+		return result, ctx.Err()
+	}
+	if crawlCtx.Err() == context.DeadlineExceeded {
+		taskLog("WARN", "Crawler: total timeout %ds reached", opts.Timeout)
+	}
+	logx.Infof("Crawler: completed, found %d urls", len(result.Urls))
+	taskLog("INFO", "Crawler: completed, found %d urls", len(result.Urls))
+	return result, nil
+}
+
+func (o *CrawlerOptions) setDefaults() {
+	if o.MaxDepth <= 0 {
+		o.MaxDepth = 2
+	}
+	if o.MaxPages <= 0 {
+		o.MaxPages = 200
+	}
+	if o.Scope != "domain" {
+		o.Scope = "host"
+	}
+	if o.RateLimit <= 0 {
+		o.RateLimit = 10
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = 5
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = 1800
+	}
+	if o.TargetTimeout <= 0 {
+		o.TargetTimeout = 300
+	}
+}
+
+// crawlItem 待请求的URL
+type crawlItem struct {
+	u       *url.URL
+	depth   int
+	source  string
+	referer string
+}
+
+// siteCrawler 单个站点的爬取状态
+type siteCrawler struct {
+	s          *CrawlerScanner
+	opts       *CrawlerOptions
+	base       *url.URL
+	baseDomain string // domain范围下的主域名
+	headless   bool
+	excludeExt map[string]bool
+	limiter    *rate.Limiter
+	queue      []crawlItem
+	visited    map[string]bool        // 已入队的请求，按 地址+参数名 去重
+	found      map[string]*CrawledUrl // 已发现的URL，按 方法+地址 去重
+	order      []string
+	pages      int
+}
+
+func newSiteCrawler(s *CrawlerScanner, base *url.URL, opts *CrawlerOptions, headless bool) *siteCrawler {
+	excludeExt := make(map[string]bool, len(crawlerStaticExt)+len(opts.ExcludeExt))
+	for ext := range crawlerStaticExt {
+		excludeExt[ext] = true
+	}
+	for _, ext := range opts.ExcludeExt {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		if ext != "" && !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		excludeExt[ext] = true
+	}
+
+	c := &siteCrawler{
+		s:          s,
+		opts:       opts,
+		base:       base,
+		headless:   headless,
+		excludeExt: excludeExt,
+		limiter:    rate.NewLimiter(rate.Limit(opts.RateLimit), 1),
+		visited:    make(map[string]bool),
+		found:      make(map[string]*CrawledUrl),
+	}
+	if opts.Scope == "domain" {
+		c.baseDomain = registrableDomain(base.Hostname())
+	}
+	return c
+}
+
+// crawl 广度优先爬取，返回发现的URL和请求的页面数
+func (c *siteCrawler) crawl(ctx context.Context) ([]*CrawledUrl, int) {
+	c.enqueue(c.base, 0, crawlSourcePage, "")
+	for _, p := range []string{"/robots.txt", "/sitemap.xml"} {
+		source := crawlSourceRobots
+		if p == "/sitemap.xml" {
+			source = crawlSourceSitemap
+		}
+		c.enqueue(c.base.ResolveReference(&url.URL{Path: p}), 1, source, "")
+	}
+
+	for len(c.queue) > 0 && c.pages < c.opts.MaxPages && ctx.Err() == nil {
+		item := c.queue[0]
+		c.queue = c.queue[1:]
+		if err := c.limiter.Wait(ctx); err != nil {
+			break
+		}
+		c.pages++
+		c.fetch(ctx, item)
+	}
+
+	urls := make([]*CrawledUrl, 0, len(c.order))
+	for _, key := range c.order {
+		urls = append(urls, c.found[key])
+	}
+	return urls, c.pages
+}
+
+// fetch 请求URL，记录响应信息并解析其中的链接
+func (c *siteCrawler) fetch(ctx context.Context, item crawlItem) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, item.u.String(), nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("User-Agent", crawlerUserAgent)
+	if item.referer != "" {
+		req.Header.Set("Referer", item.referer)
+	}
+	resp, err := c.s.client.Do(req)
+	if err != nil {
+		logx.Debugf("Crawler: fetch %s failed: %v", item.u, err)
+		return
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, crawlerMaxBodySize))
+
+	contentType := resp.Header.Get("Content-Type")
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+
+	// 主动探测的 robots.txt 和 sitemap.xml 不存在时不记录
+	isRobots := item.u.Path == "/robots.txt"
+	if item.referer == "" && item.source != crawlSourcePage && resp.StatusCode != http.StatusOK {
+		return
+	}
+
+	cu := c.record(item.u, http.MethodGet, item.source, item.referer, nil)
+	if cu != nil && cu.StatusCode == 0 {
+		cu.StatusCode = resp.StatusCode
+		cu.ContentType = contentType
+		cu.Length = len(body)
+	}
+
+	page := item.u.String()
+	if loc := resp.Header.Get("Location"); loc != "" && resp.StatusCode >= 300 && resp.StatusCode < 400 {
+		// 跳转视为同一深度的链接
+		c.addLink(loc, item.u, item.depth-1, crawlSourcePage, page)
+		return
+	}
+
+	switch {
+	case isRobots:
+		// robots.txt中的地址与robots.txt本身处于同一深度
+		c.parseRobots(string(body), item.depth-1)
+	case strings.Contains(contentType, "xml") && sitemapLocRegex.Match(body):
+		// sitemap中的地址与sitemap本身处于同一深度
+		for _, m := range sitemapLocRegex.FindAllStringSubmatch(string(body), -1) {
+			c.addLink(html.UnescapeString(m[1]), item.u, item.depth-1, crawlSourceSitemap, page)
+		}
+	case strings.Contains(contentType, "javascript") || strings.HasSuffix(strings.ToLower(item.u.Path), ".js"):
+		c.parseJS(string(body), c.base, page)
+	case strings.Contains(contentType, "html") || contentType == "" && looksLikeHTML(body):
+		title := c.parseHTML(string(body), item.u, item.depth)
+		if cu != nil && cu.Title == "" {
+			cu.Title = title
+		}
+		if c.headless && resp.StatusCode < 400 {
+			c.render(ctx, item)
+		}
+	}
+}
+
+// render 使用无头浏览器渲染页面，提取动态生成的链接和页面发出的XHR/fetch请求
+func (c *siteCrawler) render(ctx context.Context, item crawlItem) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return
+	}
+	res, err := c.s.browser.Render(ctx, item.u.String(), 30*time.Second, 2*time.Second)
+	if err != nil {
+		logx.Debugf("Crawler: render %s failed: %v", item.u, err)
+		return
+	}
+	page := item.u.String()
+	pageUrl := item.u
+	if u, err := url.Parse(res.Url); err == nil && res.Url != "" {
+		pageUrl = u
+	}
+	c.parseHTML(res.HTML, pageUrl, item.depth)
+	for _, r := range res.Requests {
+		u, err := pageUrl.Parse(r.Url)
+		if err != nil || !c.inScope(u) || c.excluded(u) {
+			continue
+		}
+		c.record(u, strings.ToUpper(r.Method), crawlSourceXHR, page, nil)
+	}
+}
+
+// parseHTML 解析页面中的链接、脚本、表单，返回页面标题
+func (c *siteCrawler) parseHTML(body string, pageUrl *url.URL, depth int) string {
+	page := pageUrl.String()
+	baseUrl := pageUrl
+	var (
+		title    string
+		inTitle  bool
+		inScript bool
+		form     *crawledForm
+	)
+
+	z := html.NewTokenizer(strings.NewReader(body))
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if form != nil {
+				c.addForm(form, baseUrl, page)
+			}
+			return title
+		case html.TextToken:
+			if inScript {
+				c.parseJS(string(z.Text()), baseUrl, page)
+			} else if inTitle && title == "" {
+				title = strings.TrimSpace(html.UnescapeString(string(z.Text())))
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch string(name) {
+			case "script":
+				inScript = false
+			case "title":
+				inTitle = false
+			case "form":
+				if form != nil {
+					c.addForm(form, baseUrl, page)
+					form = nil
+				}
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			attrs := make(map[string]string)
+			for hasAttr {
+				var k, v []byte
+				k, v, hasAttr = z.TagAttr()
+				attrs[string(k)] = string(v)
+			}
+			switch tag := string(name); tag {
+			case "base":
+				if u, err := pageUrl.Parse(attrs["href"]); err == nil && attrs["href"] != "" {
+					baseUrl = u
+				}
+			case "title":
+				inTitle = tt == html.StartTagToken
+			case "a", "link", "area":
+				c.addLink(attrs["href"], baseUrl, depth, crawlSourcePage, page)
+			case "iframe", "frame", "embed":
+				c.addLink(attrs["src"], baseUrl, depth, crawlSourcePage, page)
+			case "script":
+				if src := attrs["src"]; src != "" {
+					// 脚本不受深度限制，最深一层页面引用的脚本也会被请求解析
+					c.addLink(src, baseUrl, min(depth, c.opts.MaxDepth-1), crawlSourcePage, page)
+				} else {
+					inScript = tt == html.StartTagToken
+				}
+			case "meta":
+				if strings.EqualFold(attrs["http-equiv"], "refresh") {
+					content := attrs["content"]
+					if i := strings.Index(strings.ToLower(content), "url="); i >= 0 {
+						c.addLink(strings.Trim(content[i+4:], `'" `), baseUrl, depth, crawlSourcePage, page)
+					}
+				}
+			case "form":
+				if form != nil {
+					c.addForm(form, baseUrl, page)
+				}
+				form = &crawledForm{action: attrs["action"], method: attrs["method"]}
+			case "input", "select", "textarea", "button":
+				if form != nil && attrs["name"] != "" {
+					form.params = append(form.params, attrs["name"])
+				}
+			}
+		}
+	}
+}
+
+// crawledForm 页面中的表单
+type crawledForm struct {
+	action string
+	method string
+	params []string
+}
+
+// addForm 记录表单提交地址和字段名，表单不会被提交
+func (c *siteCrawler) addForm(form *crawledForm, baseUrl *url.URL, page string) {
+	u, err := baseUrl.Parse(strings.TrimSpace(form.action))
+	if err != nil || !c.inScope(u) || c.excluded(u) {
+		return
+	}
+	method := strings.ToUpper(strings.TrimSpace(form.method))
+	if method != http.MethodPost {
+		method = http.MethodGet
+	}
+	c.record(u, method, crawlSourceForm, page, form.params)
+}
+
+// parseJS 从JS代码中提取接口，接口只记录不请求
+func (c *siteCrawler) parseJS(code string, pageUrl *url.URL, page string) {
+	for _, m := range jsEndpointRegex.FindAllStringSubmatch(code, -1) {
+		endpoint := m[1]
+		if strings.ContainsAny(endpoint, " \t\r\n") || mimeTypeRegex.MatchString(endpoint) {
+			continue
+		}
+		var u *url.URL
+		var err error
+		switch {
+		case strings.Contains(endpoint, "://"), strings.HasPrefix(endpoint, "/"), strings.HasPrefix(endpoint, "./"), strings.HasPrefix(endpoint, "../"):
+			u, err = pageUrl.Parse(endpoint)
+		default:
+			// api/user/list 形式的路径按站点根目录解析
+			u, err = c.base.Parse("/" + endpoint)
+		}
+		if err != nil || !c.inScope(u) || c.excluded(u) {
+			continue
+		}
+		if strings.HasSuffix(strings.ToLower(u.Path), ".js") {
+			// 动态加载的脚本继续请求解析
+			c.enqueue(u, c.opts.MaxDepth, crawlSourceJS, page)
+			continue
+		}
+		c.record(u, http.MethodGet, crawlSourceJS, page, nil)
+	}
+}
+
+// parseRobots 提取robots.txt中的路径和sitemap地址
+func (c *siteCrawler) parseRobots(body string, depth int) {
+	robots := c.base.ResolveReference(&url.URL{Path: "/robots.txt"})
+	for _, line := range strings.Split(body, "\n") {
+		k, v, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		v = strings.TrimSpace(v)
+		if i := strings.Index(v, "#"); i >= 0 {
+			v = strings.TrimSpace(v[:i])
+		}
+		switch strings.ToLower(strings.TrimSpace(k)) {
+		case "allow", "disallow":
+			// 截断通配符之后的部分
+			if i := strings.IndexAny(v, "*$"); i >= 0 {
+				v = v[:i]
+			}
+			if v != "" && v != "/" {
+				c.addLink(v, robots, depth, crawlSourceRobots, robots.String())
+			}
+		case "sitemap":
+			c.addLink(v, robots, depth, crawlSourceSitemap, robots.String())
+		}
+	}
+}
+
+// addLink 解析链接，在范围内时记录，未超过最大深度时加入队列
+func (c *siteCrawler) addLink(link string, baseUrl *url.URL, depth int, source, referer string) {
+	link = strings.TrimSpace(link)
+	if link == "" || strings.HasPrefix(link, "#") {
+		return
+	}
+	lower := strings.ToLower(link)
+	for _, p := range []string{"javascript:", "mailto:", "tel:", "data:"} {
+		if strings.HasPrefix(lower, p) {
+			return
+		}
+	}
+	u, err := baseUrl.Parse(link)
+	if err != nil || !c.inScope(u) || c.excluded(u) {
+		return
+	}
+	c.record(u, http.MethodGet, source, referer, nil)
+	if depth+1 <= c.opts.MaxDepth {
+		c.enqueue(u, depth+1, source, referer)
+	}
+}
+
+// enqueue 加入请求队列，相同地址和参数名的URL只请求一次
+func (c *siteCrawler) enqueue(u *url.URL, depth int, source, referer string) {
+	key := crawlEndpoint(u) + "?" + strings.Join(queryParams(u), "&")
+	if c.visited[key] {
+		return
+	}
+	c.visited[key] = true
+	c.queue = append(c.queue, crawlItem{u: u, depth: depth, source: source, referer: referer})
+}
+
+// record 记录发现的URL，已存在时合并参数名
+func (c *siteCrawler) record(u *url.URL, method, source, referer string, extraParams []string) *CrawledUrl {
+	endpoint := crawlEndpoint(u)
+	key := method + " " + endpoint
+	params := append(queryParams(u), extraParams...)
+	if cu, ok := c.found[key]; ok {
+		cu.Params = mergeParams(cu.Params, params)
+		return cu
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	portNum, _ := strconv.Atoi(port)
+	p := u.EscapedPath()
+	if p == "" {
+		p = "/"
+	}
+	full := *u
+	full.Fragment = ""
+	cu := &CrawledUrl{
+		Authority: net.JoinHostPort(u.Hostname(), port),
+		Host:      u.Hostname(),
+		Port:      portNum,
+		Url:       full.String(),
+		Endpoint:  endpoint,
+		Method:    method,
+		Path:      p,
+		Params:    mergeParams(nil, params),
+		Source:    source,
+		Referer:   referer,
+	}
+	c.found[key] = cu
+	c.order = append(c.order, key)
+	return cu
+}
+
+// inScope 判断URL是否在爬取范围内
+func (c *siteCrawler) inScope(u *url.URL) bool {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+	if c.opts.Scope == "domain" {
+		host := strings.ToLower(u.Hostname())
+		return host == strings.ToLower(c.base.Hostname()) || c.baseDomain != "" && registrableDomain(host) == c.baseDomain
+	}
+	return strings.EqualFold(hostWithPort(u), hostWithPort(c.base))
+}
+
+// excluded 判断URL是否为排除的静态资源
+func (c *siteCrawler) excluded(u *url.URL) bool {
+	return c.excludeExt[strings.ToLower(path.Ext(u.Path))]
+}
+
+// crawlEndpoint 不含查询参数和锚点的URL，默认端口省略
+func crawlEndpoint(u *url.URL) string {
+	p := u.EscapedPath()
+	if p == "" {
+		p = "/"
+	}
+	host := u.Hostname()
+	if port := u.Port(); port != "" && !(u.Scheme == "http" && port == "80") && !(u.Scheme == "https" && port == "443") {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	return u.Scheme + "://" + strings.ToLower(host) + p
+}
+
+// hostWithPort 返回 主机:端口，未指定端口时使用协议默认端口
+func hostWithPort(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// registrableDomain 返回主域名，IP或无法识别时返回主机本身
+func registrableDomain(host string) string {
+	if net.ParseIP(host) != nil {
+		return host
+	}
+	domain, err := publicsuffix.EffectiveTLDPlusOne(strings.ToLower(host))
+	if err != nil {
+		return strings.ToLower(host)
+	}
+	return domain
+}
+
+// queryParams 返回排序后的查询参数名
+func queryParams(u *url.URL) []string {
+	q := u.Query()
+	params := make([]string, 0, len(q))
+	for k := range q {
+		if k != "" {
+			params = append(params, k)
+		}
+	}
+	sort.Strings(params)
+	return params
+}
+
+// mergeParams 合并参数名并排序去重
+func mergeParams(a, b []string) []string {
+	if len(b) == 0 {
+		return a
+	}
+	seen := make(map[string]bool, len(a)+len(b))
+	merged := make([]string, 0, len(a)+len(b))
+	for _, p := range append(append([]string{}, a...), b...) {
+		if p != "" && !seen[p] {
+			seen[p] = true
+			merged = append(merged, p)
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+// looksLikeHTML 未返回Content-Type时根据内容判断是否为HTML
+func looksLikeHTML(body []byte) bool {
+	head := strings.ToLower(strings.TrimSpace(string(body[:min(len(body), 512)])))
+	return strings.HasPrefix(head, "<!doctype html") || strings.HasPrefix(head, "<html") || strings.Contains(head, "<head")
+}
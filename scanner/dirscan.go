@@ -0,0 +1,434 @@
+package scanner
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"golang.org/x/time/rate"
+)
+
+const (
+	dirScanMaxBodySize = 1024 * 1024 // 单个响应最多读取1MB
+	dirScanExtHolder   = "%EXT%"     // 字典中的扩展名占位符，与dirsearch一致
+)
+
+// DirScanner 目录扫描，使用字典爆破HTTP资产的目录和文件
+// 扫描前对每个站点请求几个随机路径，记录其响应特征，之后与之相同的响应视为软404页面
+type DirScanner struct {
+	BaseScanner
+	client *http.Client
+}
+
+// NewDirScanner 创建目录扫描器
+func NewDirScanner() *DirScanner {
+	return &DirScanner{
+		BaseScanner: BaseScanner{name: "dirscan"},
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig:     &tls.Config{InsecureSkipVerify: true},
+				MaxIdleConnsPerHost: 20,
+			},
+			// 不跟随跳转，跳转地址作为结果的一部分保存
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// DirScanOptions 目录扫描选项
+type DirScanOptions struct {
+	Words         []string `json:"words"`         // 字典内容
+	Extensions    []string `json:"extensions"`    // 替换 %EXT% 的扩展名
+	Concurrency   int      `json:"concurrency"`   // 同时扫描的站点数，默认5
+	Threads       int      `json:"threads"`       // 每个站点的并发请求数，默认10
+	RateLimit     int      `json:"rateLimit"`     // 每个站点每秒请求数，默认50
+	ExcludeStatus []int    `json:"excludeStatus"` // 排除的状态码，默认404
+	MaxResults    int      `json:"maxResults"`    // 单个站点结果数上限，默认200
+	Timeout       int      `json:"timeout"`       // 总超时时间(秒)，默认1800秒
+	TargetTimeout int      `json:"targetTimeout"` // 单个站点超时时间(秒)，默认600秒
+}
+
+func (o *DirScanOptions) setDefaults() {
+	if o.Concurrency <= 0 {
+		o.Concurrency = 5
+	}
+	if o.Threads <= 0 {
+		o.Threads = 10
+	}
+	if o.RateLimit <= 0 {
+		o.RateLimit = 50
+	}
+	if len(o.ExcludeStatus) == 0 {
+		o.ExcludeStatus = []int{404}
+	}
+	if o.MaxResults <= 0 {
+		o.MaxResults = 200
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = 1800
+	}
+	if o.TargetTimeout <= 0 {
+		o.TargetTimeout = 600
+	}
+}
+
+// DirEntry 目录扫描发现的路径
+type DirEntry struct {
+	Authority   string `json:"authority"`
+	Host        string `json:"host"`
+	Port        int    `json:"port"`
+	Url         string `json:"url"`
+	Path        string `json:"path"`
+	Status      int    `json:"status"`
+	Length      int    `json:"length"`
+	Title       string `json:"title,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	Location    string `json:"location,omitempty"`
+}
+
+// Scan 对所有HTTP资产执行目录扫描，结果保存在 ScanResult.DirEntries
+func (s *DirScanner) Scan(ctx context.Context, config *ScanConfig) (*ScanResult, error) {
+	opts := &DirScanOptions{}
+	if config.Options != nil {
+		switch v := config.Options.(type) {
+		case *DirScanOptions:
+			opts = v
+		default:
+			if data, err := json.Marshal(config.Options); err == nil {
+				json.Unmarshal(data, opts)
+			}
+		}
+	}
+	opts.setDefaults()
+
+	taskLog := func(level, format string, args ...interface{}) {
+		if config.TaskLogger != nil {
+			config.TaskLogger(level, format, args...)
+		}
+	}
+
+	result := &ScanResult{
+		WorkspaceId: config.WorkspaceId,
+		MainTaskId:  config.MainTaskId,
+		DirEntries:  make([]*DirEntry, 0),
+	}
+
+	paths := expandDirWords(opts.Words, opts.Extensions)
+	if len(paths) == 0 {
+		taskLog("WARN", "DirScan: wordlist is empty, skipping")
+		return result, nil
+	}
+
+	// 每个站点只扫描一次
+	var sites []*Asset
+	seen := make(map[string]bool)
+	for _, asset := range filterHttpAssets(config.Assets) {
+		key := fmt.Sprintf("%s:%d", asset.Host, asset.Port)
+		if !seen[key] {
+			seen[key] = true
+			sites = append(sites, asset)
+		}
+	}
+	if len(sites) == 0 {
+		logx.Info("No HTTP assets found, skipping dir scan")
+		return result, nil
+	}
+
+	logx.Infof("DirScan: scanning %d sites with %d paths, threads=%d, rate=%d/s", len(sites), len(paths), opts.Threads, opts.RateLimit)
+	taskLog("INFO", "DirScan: scanning %d sites with %d paths, threads=%d, rate=%d/s", len(sites), len(paths), opts.Threads, opts.RateLimit)
+
+	scanCtx, cancel := context.WithTimeout(ctx, time.Duration(opts.Timeout)*time.Second)
+	defer cancel()
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		done int
+	)
+	sem := make(chan struct{}, opts.Concurrency)
+	for _, asset := range sites {
+		select {
+		case sem <- struct{}{}:
+		case <-scanCtx.Done():
+		}
+		if scanCtx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(asset *Asset) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			siteCtx, siteCancel := context.WithTimeout(scanCtx, time.Duration(opts.TargetTimeout)*time.Second)
+			defer siteCancel()
+			entries, err := s.scanSite(siteCtx, asset, paths, opts)
+			if err != nil {
+				taskLog("WARN", "DirScan: %s:%d %v", asset.Host, asset.Port, err)
+			} else if siteCtx.Err() == context.DeadlineExceeded {
+				taskLog("WARN", "DirScan: %s:%d timeout", asset.Host, asset.Port)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			result.DirEntries = append(result.DirEntries, entries...)
+			done++
+			taskLog("INFO", "DirScan [%d/%d]: %s:%d, %d paths found", done, len(sites), asset.Host, asset.Port, len(entries))
+			if config.OnProgress != nil {
+				config.OnProgress(done*100/len(sites), fmt.Sprintf("DirScan: %d/%d", done, len(sites)))
+			}
+		}(asset)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		// 任务被取消，返回已扫描的结果
+		return result, ctx.Err()
+	}
+	if scanCtx.Err() == context.DeadlineExceeded {
+		taskLog("WARN", "DirScan: total timeout %ds reached", opts.Timeout)
+	}
+	logx.Infof("DirScan: completed, found %d paths", len(result.DirEntries))
+	taskLog("INFO", "DirScan: completed, found %d paths", len(result.DirEntries))
+	return result, nil
+}
+
+// scanSite 校准软404特征后并发请求字典中的路径
+func (s *DirScanner) scanSite(ctx context.Context, asset *Asset, paths []string, opts *DirScanOptions) ([]*DirEntry, error) {
+	base, err := httpBaseUrl(asset)
+	if err != nil {
+		return nil, err
+	}
+	limiter := rate.NewLimiter(rate.Limit(opts.RateLimit), 1)
+
+	baselines := s.calibrate(ctx, base, limiter, opts.Extensions)
+	if len(baselines) == 0 {
+		return nil, fmt.Errorf("site unreachable, skipped")
+	}
+
+	excluded := make(map[int]bool, len(opts.ExcludeStatus))
+	for _, code := range opts.ExcludeStatus {
+		excluded[code] = true
+	}
+
+	siteCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		entries  []*DirEntry
+		overflow bool
+	)
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range jobs {
+				if limiter.Wait(siteCtx) != nil {
+					continue
+				}
+				resp := s.probe(siteCtx, base, p)
+				if resp == nil || excluded[resp.status] || isSoftNotFound(baselines, resp) {
+					continue
+				}
+				mu.Lock()
+				if len(entries) >= opts.MaxResults {
+					// 结果过多通常是校准未能识别的软404页面，停止扫描该站点
+					overflow = true
+					cancel()
+				} else {
+					entries = append(entries, resp.entry(asset, base, p))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, p := range paths {
+		select {
+		case jobs <- p:
+			continue
+		case <-siteCtx.Done():
+		}
+		break
+	}
+	close(jobs)
+	wg.Wait()
+
+	if overflow {
+		return entries, fmt.Errorf("more than %d results, possibly unrecognized soft-404 pages, stopped", opts.MaxResults)
+	}
+	return entries, nil
+}
+
+// dirResponse 响应特征
+type dirResponse struct {
+	status      int
+	length      int // 原始响应体长度
+	normLength  int // 去掉响应中回显的请求路径后的长度
+	title       string
+	contentType string
+	location    string // 跳转地址
+	normLoc     string // 请求路径替换为占位符后的跳转地址
+}
+
+func (r *dirResponse) entry(asset *Asset, base *url.URL, p string) *DirEntry {
+	u := base.ResolveReference(&url.URL{Path: "/" + p})
+	return &DirEntry{
+		Authority:   net.JoinHostPort(asset.Host, strconv.Itoa(asset.Port)),
+		Host:        asset.Host,
+		Port:        asset.Port,
+		Url:         u.String(),
+		Path:        u.EscapedPath(),
+		Status:      r.status,
+		Length:      r.length,
+		Title:       r.title,
+		ContentType: r.contentType,
+		Location:    r.location,
+	}
+}
+
+// probe 请求路径并提取响应特征，请求失败返回nil
+func (s *DirScanner) probe(ctx context.Context, base *url.URL, p string) *dirResponse {
+	u := base.ResolveReference(&url.URL{Path: "/" + p})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil
+	}
+	req.Header.Set("User-Agent", crawlerUserAgent)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, dirScanMaxBodySize))
+
+	contentType := resp.Header.Get("Content-Type")
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+
+	// 很多404页面会回显请求的路径，比较前去掉
+	text := string(body)
+	name := strings.TrimSuffix(p, "/")
+	if name != "" {
+		text = strings.ReplaceAll(text, name, "")
+		text = strings.ReplaceAll(text, url.PathEscape(name), "")
+	}
+	location := resp.Header.Get("Location")
+	normLoc := location
+	if name != "" {
+		normLoc = strings.ReplaceAll(normLoc, name, "{path}")
+	}
+
+	return &dirResponse{
+		status:      resp.StatusCode,
+		length:      len(body),
+		normLength:  len(text),
+		title:       extractTitle(string(body)),
+		contentType: strings.TrimSpace(contentType),
+		location:    location,
+		normLoc:     normLoc,
+	}
+}
+
+// calibrate 请求几个不存在的随机路径，记录站点对不存在路径的响应特征
+func (s *DirScanner) calibrate(ctx context.Context, base *url.URL, limiter *rate.Limiter, extensions []string) []*dirResponse {
+	probes := []string{randomToken(12), randomToken(12) + "/", randomToken(12) + ".html", "." + randomToken(8)}
+	for i, ext := range extensions {
+		if i >= 3 {
+			break
+		}
+		probes = append(probes, randomToken(12)+"."+strings.TrimPrefix(ext, "."))
+	}
+
+	var baselines []*dirResponse
+	for _, p := range probes {
+		if limiter.Wait(ctx) != nil {
+			break
+		}
+		if resp := s.probe(ctx, base, p); resp != nil {
+			baselines = append(baselines, resp)
+		}
+	}
+	return baselines
+}
+
+// isSoftNotFound 与任意一个随机路径的响应特征相同时视为软404
+func isSoftNotFound(baselines []*dirResponse, r *dirResponse) bool {
+	for _, b := range baselines {
+		if b.status != r.status {
+			continue
+		}
+		if b.location != "" || r.location != "" {
+			if b.normLoc == r.normLoc {
+				return true
+			}
+			continue
+		}
+		if b.title != r.title {
+			continue
+		}
+		// 页面中可能包含时间戳、随机token等动态内容，长度允许少量差异
+		diff := b.normLength - r.normLength
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff <= max(32, b.normLength/50) {
+			return true
+		}
+	}
+	return false
+}
+
+// expandDirWords 整理字典：去掉空行和注释，替换 %EXT% 占位符，去重
+func expandDirWords(words, extensions []string) []string {
+	seen := make(map[string]bool, len(words))
+	paths := make([]string, 0, len(words))
+	add := func(p string) {
+		if p != "" && !seen[p] {
+			seen[p] = true
+			paths = append(paths, p)
+		}
+	}
+	for _, w := range words {
+		w = strings.TrimLeft(strings.TrimSpace(w), "/")
+		if w == "" || strings.HasPrefix(w, "#") {
+			continue
+		}
+		if !strings.Contains(w, dirScanExtHolder) {
+			add(w)
+			continue
+		}
+		// 未配置扩展名时跳过带占位符的条目
+		for _, ext := range extensions {
+			ext = strings.TrimPrefix(strings.TrimSpace(ext), ".")
+			if ext != "" {
+				add(strings.ReplaceAll(w, dirScanExtHolder, ext))
+			}
+		}
+	}
+	return paths
+}
+
+// randomToken 生成随机路径名
+func randomToken(n int) string {
+	b := make([]byte, (n+1)/2)
+	rand.Read(b)
+	return hex.EncodeToString(b)[:n]
+}
@@ -0,0 +1,368 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"cscan/pkg/cdn"
+
+	"github.com/miekg/dns"
+	"golang.org/x/time/rate"
+)
+
+// DefaultSubdomainWords 未选择子域名字典时使用的内置字典
+var DefaultSubdomainWords = []string{
+	"www", "mail", "ftp", "admin", "api", "dev", "test", "staging",
+	"blog", "shop", "store", "app", "m", "mobile", "cdn", "static",
+	"img", "images", "assets", "media", "video", "download", "docs",
+	"portal", "vpn", "remote", "gateway", "proxy", "ns1", "ns2",
+	"mx", "smtp", "pop", "imap", "webmail", "owa", "exchange",
+	"git", "gitlab", "github", "jenkins", "ci", "cd", "build",
+	"monitor", "grafana", "prometheus", "kibana", "elastic",
+	"db", "database", "mysql", "postgres", "redis", "mongo",
+	"backup", "bak", "old", "new", "beta", "alpha", "demo",
+}
+
+// permutationWords 生成子域名变形使用的常见环境/用途词
+var permutationWords = []string{
+	"dev", "test", "qa", "uat", "stage", "staging", "pre", "prod",
+	"beta", "demo", "new", "old", "bak", "internal", "admin", "api",
+	"v1", "v2", "m", "web",
+}
+
+// DNSBruteOptions 子域名字典爆破选项
+type DNSBruteOptions struct {
+	Words           []string `json:"words"`           // 爆破字典
+	Resolvers       []string `json:"resolvers"`       // DNS服务器，为空时使用 cdn.DefaultResolvers
+	RateLimit       int      `json:"rateLimit"`       // 每秒DNS查询数，默认200
+	Threads         int      `json:"threads"`         // 并发查询数，默认50
+	Permutation     bool     `json:"permutation"`     // 对已发现的子域名生成变形再解析
+	MaxPermutations int      `json:"maxPermutations"` // 单个根域名的变形数量上限，默认20000
+}
+
+func (o *DNSBruteOptions) setDefaults() {
+	if len(o.Resolvers) == 0 {
+		o.Resolvers = cdn.DefaultResolvers
+	}
+	if o.RateLimit <= 0 {
+		o.RateLimit = 200
+	}
+	if o.Threads <= 0 {
+		o.Threads = 50
+	}
+	if o.MaxPermutations <= 0 {
+		o.MaxPermutations = 20000
+	}
+}
+
+// dnsAnswer A记录查询结果
+type dnsAnswer struct {
+	ips    []string
+	cnames []string
+}
+
+// wildcardInfo 泛解析检测结果，记录随机子域名解析到的地址和CNAME
+type wildcardInfo struct {
+	once    sync.Once
+	enabled bool
+	ips     map[string]bool
+	cnames  map[string]bool
+}
+
+// dnsBruter 通过DNS查询验证候选子域名，过滤泛解析
+type dnsBruter struct {
+	opts      DNSBruteOptions
+	resolvers []string
+	next      atomic.Uint64
+	limiter   *rate.Limiter
+	timeout   time.Duration
+
+	mu        sync.Mutex
+	wildcards map[string]*wildcardInfo
+}
+
+func newDNSBruter(opts DNSBruteOptions) *dnsBruter {
+	opts.setDefaults()
+	resolvers := make([]string, 0, len(opts.Resolvers))
+	for _, r := range opts.Resolvers {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(r); err != nil {
+			r = net.JoinHostPort(r, "53")
+		}
+		resolvers = append(resolvers, r)
+	}
+	return &dnsBruter{
+		opts:      opts,
+		resolvers: resolvers,
+		limiter:   rate.NewLimiter(rate.Limit(opts.RateLimit), 1),
+		timeout:   2 * time.Second,
+		wildcards: make(map[string]*wildcardInfo),
+	}
+}
+
+// Bruteforce 使用字典爆破根域名的子域名
+func (b *dnsBruter) Bruteforce(ctx context.Context, domain string) []string {
+	candidates := make([]string, 0, len(b.opts.Words))
+	seen := make(map[string]bool)
+	for _, word := range b.opts.Words {
+		word = strings.Trim(strings.ToLower(strings.TrimSpace(word)), ".")
+		if word == "" || !validSubdomainLabel(word) {
+			continue
+		}
+		name := word + "." + domain
+		if !seen[name] {
+			seen[name] = true
+			candidates = append(candidates, name)
+		}
+	}
+	return b.resolveAll(ctx, candidates)
+}
+
+// Permute 对已发现的子域名生成变形并解析，返回新发现的子域名
+func (b *dnsBruter) Permute(ctx context.Context, domain string, known []string) []string {
+	return b.resolveAll(ctx, generatePermutations(domain, known, permutationWords, b.opts.MaxPermutations))
+}
+
+// resolveAll 并发解析候选域名，返回存在且不是泛解析的域名
+func (b *dnsBruter) resolveAll(ctx context.Context, names []string) []string {
+	var (
+		found []string
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+	)
+	taskChan := make(chan string, b.opts.Threads)
+	for i := 0; i < b.opts.Threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range taskChan {
+				answer, ok := b.lookup(ctx, name)
+				if !ok || b.isWildcard(ctx, name, answer) {
+					continue
+				}
+				mu.Lock()
+				found = append(found, name)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, name := range names {
+		select {
+		case taskChan <- name:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(taskChan)
+	wg.Wait()
+	return found
+}
+
+// isWildcard 上级域名存在泛解析且应答与随机子域名一致时认为是泛解析结果
+func (b *dnsBruter) isWildcard(ctx context.Context, name string, answer *dnsAnswer) bool {
+	_, parent, ok := strings.Cut(name, ".")
+	if !ok {
+		return false
+	}
+	info := b.wildcard(ctx, parent)
+	if !info.enabled {
+		return false
+	}
+	for _, cname := range answer.cnames {
+		if info.cnames[cname] {
+			return true
+		}
+	}
+	if len(answer.ips) == 0 {
+		return false
+	}
+	for _, ip := range answer.ips {
+		if !info.ips[ip] {
+			return false
+		}
+	}
+	return true
+}
+
+// wildcard 检测上级域名的泛解析，每个上级域名只检测一次
+func (b *dnsBruter) wildcard(ctx context.Context, parent string) *wildcardInfo {
+	b.mu.Lock()
+	info, ok := b.wildcards[parent]
+	if !ok {
+		info = &wildcardInfo{ips: make(map[string]bool), cnames: make(map[string]bool)}
+		b.wildcards[parent] = info
+	}
+	b.mu.Unlock()
+
+	info.once.Do(func() {
+		for i := 0; i < 3; i++ {
+			answer, ok := b.lookup(ctx, randomToken(12)+"."+parent)
+			if !ok {
+				continue
+			}
+			info.enabled = true
+			for _, ip := range answer.ips {
+				info.ips[ip] = true
+			}
+			for _, cname := range answer.cnames {
+				info.cnames[cname] = true
+			}
+		}
+	})
+	return info
+}
+
+// IsWildcardZone 上级域名是否存在泛解析，检测结果会缓存
+func (b *dnsBruter) IsWildcardZone(ctx context.Context, parent string) bool {
+	return b.wildcard(ctx, parent).enabled
+}
+
+// lookup 查询A记录，出错时换下一个DNS服务器重试
+func (b *dnsBruter) lookup(ctx context.Context, name string) (*dnsAnswer, bool) {
+	if len(b.resolvers) == 0 {
+		return nil, false
+	}
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(name), dns.TypeA)
+	m.RecursionDesired = true
+	client := &dns.Client{Timeout: b.timeout}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		if err := b.limiter.Wait(ctx); err != nil {
+			return nil, false
+		}
+		server := b.resolvers[b.next.Add(1)%uint64(len(b.resolvers))]
+		resp, _, err := client.ExchangeContext(ctx, m, server)
+		if err != nil || (resp.Rcode != dns.RcodeSuccess && resp.Rcode != dns.RcodeNameError) {
+			continue
+		}
+		if resp.Rcode == dns.RcodeNameError {
+			return nil, false
+		}
+		answer := &dnsAnswer{}
+		for _, rr := range resp.Answer {
+			switch v := rr.(type) {
+			case *dns.A:
+				answer.ips = append(answer.ips, v.A.String())
+			case *dns.CNAME:
+				answer.cnames = append(answer.cnames, strings.ToLower(strings.TrimSuffix(v.Target, ".")))
+			}
+		}
+		return answer, len(answer.ips) > 0 || len(answer.cnames) > 0
+	}
+	return nil, false
+}
+
+// generatePermutations 根据已发现的子域名生成变形：
+// 增加一级(dev.api.example.com)、连接词(api-dev、dev-api、apidev)、替换词(dev-api→test-api)、数字增减(api1→api2)
+func generatePermutations(domain string, known []string, words []string, limit int) []string {
+	knownSet := make(map[string]bool, len(known))
+	for _, name := range known {
+		knownSet[name] = true
+	}
+	wordSet := make(map[string]bool, len(words))
+	for _, w := range words {
+		wordSet[w] = true
+	}
+
+	seen := make(map[string]bool)
+	var result []string
+	add := func(name string) bool {
+		if len(result) >= limit {
+			return false
+		}
+		if !knownSet[name] && !seen[name] {
+			seen[name] = true
+			result = append(result, name)
+		}
+		return true
+	}
+
+	for _, name := range known {
+		if !strings.HasSuffix(name, "."+domain) {
+			continue
+		}
+		first, parent, _ := strings.Cut(name, ".")
+		if first == "" || first == "*" {
+			continue
+		}
+
+		var variants []string
+		for _, w := range words {
+			variants = append(variants, w+"."+name, w+"-"+first+"."+parent, first+"-"+w+"."+parent, first+w+"."+parent)
+		}
+		// 替换连接符分隔的已知词
+		parts := strings.Split(first, "-")
+		for i, part := range parts {
+			if !wordSet[part] {
+				continue
+			}
+			for _, w := range words {
+				if w == part {
+					continue
+				}
+				replaced := append([]string{}, parts...)
+				replaced[i] = w
+				variants = append(variants, strings.Join(replaced, "-")+"."+parent)
+			}
+		}
+		// 数字增减
+		for _, n := range numberVariants(first) {
+			variants = append(variants, n+"."+parent)
+		}
+
+		for _, v := range variants {
+			if !add(v) {
+				return result
+			}
+		}
+	}
+	return result
+}
+
+// numberVariants 将标签中最后一个数字加减1，如 web01 → web00、web02
+func numberVariants(label string) []string {
+	end := strings.LastIndexFunc(label, func(r rune) bool { return r >= '0' && r <= '9' })
+	if end < 0 {
+		return nil
+	}
+	start := end
+	for start > 0 && label[start-1] >= '0' && label[start-1] <= '9' {
+		start--
+	}
+	digits := label[start : end+1]
+	n, err := strconv.Atoi(digits)
+	if err != nil {
+		return nil
+	}
+	var variants []string
+	for _, v := range []int{n - 1, n + 1} {
+		if v < 0 {
+			continue
+		}
+		variants = append(variants, label[:start]+fmt.Sprintf("%0*d", len(digits), v)+label[end+1:])
+	}
+	return variants
+}
+
+// validSubdomainLabel 字典词只允许字母、数字、连字符、下划线和点
+func validSubdomainLabel(word string) bool {
+	for _, r := range word {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return false
+		}
+	}
+	return !strings.Contains(word, "..")
+}
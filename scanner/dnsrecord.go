@@ -0,0 +1,83 @@
+package scanner
+
+import (
+	"context"
+	"sync"
+
+	"cscan/pkg/dnsrecord"
+)
+
+// dnsRecordConcurrency 收集DNS记录的默认并发数，每个域名需要多次查询
+const dnsRecordConcurrency = 20
+
+// collectDNSRecords 收集域名的完整DNS记录，并补充所属区域顶点的记录以检查区域传送和SPF/DMARC
+func collectDNSRecords(ctx context.Context, domains []string, resolvers []string, concurrent int, taskLog func(level, format string, args ...interface{})) []*dnsrecord.Result {
+	if concurrent <= 0 || concurrent > dnsRecordConcurrency {
+		concurrent = dnsRecordConcurrency
+	}
+	collector := dnsrecord.NewCollector(dnsrecord.Options{
+		Resolvers:     resolvers,
+		CheckTransfer: true,
+	})
+
+	var (
+		results []*dnsrecord.Result
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+	)
+	collected := make(map[string]bool)
+	run := func(names []string) {
+		taskChan := make(chan string, concurrent)
+		for i := 0; i < concurrent; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for domain := range taskChan {
+					if r := collector.Collect(ctx, domain); r != nil {
+						mu.Lock()
+						results = append(results, r)
+						mu.Unlock()
+					}
+				}
+			}()
+		}
+		for _, name := range names {
+			if collected[name] {
+				continue
+			}
+			collected[name] = true
+			select {
+			case taskChan <- name:
+			case <-ctx.Done():
+			}
+		}
+		close(taskChan)
+		wg.Wait()
+	}
+
+	run(domains)
+
+	// 目标中不一定包含区域顶点，补充收集以检查区域传送和SPF/DMARC
+	var zones []string
+	for _, r := range results {
+		if r.Zone != "" && !collected[r.Zone] {
+			zones = append(zones, r.Zone)
+		}
+	}
+	if len(zones) > 0 && ctx.Err() == nil {
+		run(zones)
+	}
+
+	issues, transfers := 0, 0
+	for _, r := range results {
+		issues += len(r.Issues)
+		transfers += len(r.ZoneTransfer)
+	}
+	if taskLog != nil {
+		taskLog("INFO", "DNS records: collected %d domains, %d issues", len(results), issues)
+		if transfers > 0 {
+			taskLog("WARN", "DNS records: %d nameservers allow zone transfer", transfers)
+		}
+	}
+	return results
+}
@@ -21,9 +21,12 @@ func NewDomainScanner() *DomainScanner {
 
 // DomainScanOptions 域名扫描选项
 type DomainScanOptions struct {
-	Subfinder  bool `json:"subfinder"`
-	Massdns    bool `json:"massdns"`
-	Concurrent int  `json:"concurrent"`
+	Subfinder  bool     `json:"subfinder"`
+	Massdns    bool     `json:"massdns"`
+	Concurrent int      `json:"concurrent"`
+	CdnCheck   bool     `json:"cdnCheck"`   // 多解析器CDN/WAF检测
+	Resolvers  []string `json:"resolvers"`  // CDN检测和DNS记录收集使用的DNS服务器
+	DNSRecords bool     `json:"dnsRecords"` // 收集完整DNS记录，检查区域传送和SPF/DMARC
 }
 
 // Scan 执行域名扫描
@@ -46,13 +49,24 @@ func (s *DomainScanner) Scan(ctx context.Context, config *ScanConfig) (*ScanResu
 	}
 
 	// DNS解析
-	assets := s.resolveDomains(ctx, subdomains, opts.Concurrent)
+	assets := s.resolveDomains(ctx, subdomains, opts)
 
-	return &ScanResult{
+	result := &ScanResult{
 		WorkspaceId: config.WorkspaceId,
 		MainTaskId:  config.MainTaskId,
 		Assets:      assets,
-	}, nil
+	}
+
+	// 完整DNS记录，只收集目标域名和解析成功的子域名
+	if opts.DNSRecords {
+		names := append([]string{}, domains...)
+		for _, asset := range assets {
+			names = append(names, asset.Host)
+		}
+		result.DNSRecords = collectDNSRecords(ctx, names, opts.Resolvers, opts.Concurrent, config.TaskLogger)
+	}
+
+	return result, nil
 }
 
 // parseDomains 解析域名
@@ -77,20 +91,7 @@ func parseDomains(target string) []string {
 func (s *DomainScanner) enumerateSubdomains(ctx context.Context, domain string, opts *DomainScanOptions) []string {
 	var subdomains []string
 
-	// 常见子域名前缀
-	prefixes := []string{
-		"www", "mail", "ftp", "admin", "api", "dev", "test", "staging",
-		"blog", "shop", "store", "app", "m", "mobile", "cdn", "static",
-		"img", "images", "assets", "media", "video", "download", "docs",
-		"portal", "vpn", "remote", "gateway", "proxy", "ns1", "ns2",
-		"mx", "smtp", "pop", "imap", "webmail", "owa", "exchange",
-		"git", "gitlab", "github", "jenkins", "ci", "cd", "build",
-		"monitor", "grafana", "prometheus", "kibana", "elastic",
-		"db", "database", "mysql", "postgres", "redis", "mongo",
-		"backup", "bak", "old", "new", "beta", "alpha", "demo",
-	}
-
-	for _, prefix := range prefixes {
+	for _, prefix := range DefaultSubdomainWords {
 		subdomain := prefix + "." + domain
 		subdomains = append(subdomains, subdomain)
 	}
@@ -102,11 +103,16 @@ func (s *DomainScanner) enumerateSubdomains(ctx context.Context, domain string,
 }
 
 // resolveDomains DNS解析
-func (s *DomainScanner) resolveDomains(ctx context.Context, domains []string, concurrent int) []*Asset {
+func (s *DomainScanner) resolveDomains(ctx context.Context, domains []string, opts *DomainScanOptions) []*Asset {
 	var assets []*Asset
 	var mu sync.Mutex
 	var wg sync.WaitGroup
 
+	concurrent := opts.Concurrent
+	if concurrent <= 0 {
+		concurrent = 50
+	}
+
 	// 创建任务通道
 	taskChan := make(chan string, concurrent)
 
@@ -146,6 +152,13 @@ func (s *DomainScanner) resolveDomains(ctx context.Context, domains []string, co
 						asset.CName = strings.TrimSuffix(cname, ".")
 					}
 
+					// CDN/WAF/云检测
+					if opts.CdnCheck {
+						DetectCdn(ctx, asset, opts.Resolvers)
+					} else {
+						MarkCdn(asset)
+					}
+
 					mu.Lock()
 					assets = append(assets, asset)
 					mu.Unlock()
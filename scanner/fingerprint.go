@@ -17,9 +17,9 @@ import (
 	"strings"
 	"time"
 
+	"cscan/pkg/imghash"
 	"cscan/pkg/utils"
 
-	"github.com/chromedp/chromedp"
 	wappalyzer "github.com/projectdiscovery/wappalyzergo"
 	"github.com/zeromicro/go-zero/core/logx"
 )
@@ -30,6 +30,7 @@ type FingerprintScanner struct {
 	client               *http.Client
 	wappalyzerClient     *wappalyzer.Wappalyze
 	customFingerprintEngine *CustomFingerprintEngine
+	browser              *BrowserPool // 内置截图共享的浏览器
 }
 
 // AppDetectionResult 应用检测结果，用于合并多个来源的识别结果
@@ -58,9 +59,20 @@ func NewFingerprintScanner() *FingerprintScanner {
 			},
 		},
 		wappalyzerClient: wappalyzerClient,
+		browser:          NewBrowserPool(0, 0),
 	}
 }
 
+// Close 关闭截图使用的浏览器
+func (s *FingerprintScanner) Close() {
+	s.browser.Close()
+}
+
+// Browser 返回截图使用的浏览器，供爬虫无头模式共享
+func (s *FingerprintScanner) Browser() *BrowserPool {
+	return s.browser
+}
+
 // SetCustomFingerprintEngine 设置自定义指纹引擎
 func (s *FingerprintScanner) SetCustomFingerprintEngine(engine *CustomFingerprintEngine) {
 	s.customFingerprintEngine = engine
@@ -78,6 +90,16 @@ type FingerprintOptions struct {
 	Timeout      int    `json:"timeout"`      // 总超时时间(秒)，默认300秒
 	TargetTimeout int   `json:"targetTimeout"` // 单个目标超时时间(秒)，默认30秒
 	Concurrency  int    `json:"concurrency"`  // 并发数，默认10
+	Jarm         bool   `json:"jarm"`         // 计算TLS服务端JARM指纹（额外10次握手）
+	// 内置截图参数
+	ScreenshotWidth    int    `json:"screenshotWidth"`    // 视口宽度，默认1920
+	ScreenshotHeight   int    `json:"screenshotHeight"`   // 视口高度，默认1080
+	ScreenshotFullPage bool   `json:"screenshotFullPage"` // 截取整个页面，默认只截取首屏
+	ScreenshotFormat   string `json:"screenshotFormat"`   // jpeg/webp/png，默认jpeg
+	ScreenshotQuality  int    `json:"screenshotQuality"`  // jpeg/webp压缩质量(1-100)，默认80
+	ScreenshotTimeout  int    `json:"screenshotTimeout"`  // 单个页面截图超时(秒)，默认30秒
+	ScreenshotTabs     int    `json:"screenshotTabs"`     // 同时打开的标签页上限，默认4
+	ScreenshotRecycle  int    `json:"screenshotRecycle"`  // 浏览器处理多少个页面后重启，默认200
 }
 
 
@@ -124,6 +146,9 @@ func (s *FingerprintScanner) Scan(ctx context.Context, config *ScanConfig) (*Sca
 	if opts.TargetTimeout <= 0 {
 		opts.TargetTimeout = 30
 	}
+	if opts.Screenshot {
+		s.browser.SetLimits(opts.ScreenshotTabs, opts.ScreenshotRecycle)
+	}
 
 	// 日志辅助函数
 	taskLog := func(level, format string, args ...interface{}) {
@@ -142,7 +167,10 @@ func (s *FingerprintScanner) Scan(ctx context.Context, config *ScanConfig) (*Sca
 	httpAssets := filterHttpAssets(config.Assets)
 	if len(httpAssets) == 0 {
 		logx.Info("No HTTP/HTTPS assets found, skipping fingerprint detection")
-		// 返回所有原始资产，但不进行指纹识别
+		// 返回所有原始资产，但不进行指纹识别，仅采集TLS证书
+		for _, asset := range config.Assets {
+			s.collectTLSCert(ctx, asset, opts)
+		}
 		result.Assets = config.Assets
 		return result, nil
 	}
@@ -180,6 +208,7 @@ func (s *FingerprintScanner) Scan(ctx context.Context, config *ScanConfig) (*Sca
 				taskLog("INFO", "Fingerprint [%d/%d]: %s:%d", i+1, len(httpAssets), asset.Host, asset.Port)
 				targetCtx, targetCancel := context.WithTimeout(ctx, time.Duration(opts.TargetTimeout)*time.Second)
 				s.runAdditionalFingerprint(targetCtx, asset, opts)
+				s.collectTLSCert(targetCtx, asset, opts)
 				if targetCtx.Err() == context.DeadlineExceeded {
 					taskLog("WARN", "Fingerprint: %s:%d timeout", asset.Host, asset.Port)
 				}
@@ -190,18 +219,21 @@ func (s *FingerprintScanner) Scan(ctx context.Context, config *ScanConfig) (*Sca
 				taskLog("INFO", "Fingerprint [%d/%d]: %s:%d", i+1, len(httpAssets), asset.Host, asset.Port)
 				targetCtx, targetCancel := context.WithTimeout(ctx, time.Duration(opts.TargetTimeout)*time.Second)
 				s.fingerprint(targetCtx, asset, opts)
+				s.collectTLSCert(targetCtx, asset, opts)
 				if targetCtx.Err() == context.DeadlineExceeded {
 					taskLog("WARN", "Fingerprint: %s:%d timeout", asset.Host, asset.Port)
 				}
 				targetCancel()
 			}
+			hashScreenshot(asset)
 			result.Assets = append(result.Assets, asset)
 		}
 	}
 
-	// 添加非HTTP资产到结果中（不进行指纹识别）
+	// 添加非HTTP资产到结果中（不进行指纹识别，仅采集TLS证书）
 	for _, asset := range config.Assets {
 		if !isHttpAsset(asset) {
+			s.collectTLSCert(ctx, asset, opts)
 			result.Assets = append(result.Assets, asset)
 		}
 	}
@@ -474,7 +506,7 @@ func (s *FingerprintScanner) runAdditionalFingerprint(ctx context.Context, asset
 
 	// 截图功能：如果 httpx 没有获取到截图，使用内置方法补充
 	if opts.Screenshot && asset.Screenshot == "" {
-		screenshot := s.takeScreenshot(ctx, targetUrl)
+		screenshot := s.takeScreenshot(ctx, targetUrl, opts)
 		if screenshot != "" {
 			asset.Screenshot = screenshot
 			logx.Debugf("Screenshot captured for %s:%d using builtin method", asset.Host, asset.Port)
@@ -482,6 +514,22 @@ func (s *FingerprintScanner) runAdditionalFingerprint(ctx context.Context, asset
 	}
 }
 
+// collectTLSCert 采集TLS服务的证书链信息
+func (s *FingerprintScanner) collectTLSCert(ctx context.Context, asset *Asset, opts *FingerprintOptions) {
+	if asset.TLS != nil || ctx.Err() != nil || !isTLSAsset(asset) {
+		return
+	}
+	cert, err := GrabTLSCert(ctx, asset.Host, asset.Port, 10*time.Second)
+	if err != nil {
+		logx.Debugf("Grab tls cert failed for %s:%d: %v", asset.Host, asset.Port, err)
+		return
+	}
+	if opts.Jarm {
+		cert.Jarm = JarmHash(ctx, asset.Host, asset.Port, 5*time.Second)
+	}
+	asset.TLS = cert
+}
+
 // getIconHashWithData 获取favicon的hash值和原始数据
 func (s *FingerprintScanner) getIconHashWithData(baseUrl string) (string, []byte) {
 	// 尝试常见的favicon路径
@@ -640,7 +688,7 @@ func (s *FingerprintScanner) fingerprint(ctx context.Context, asset *Asset, opts
 
 		// 截图
 		if opts.Screenshot {
-			screenshot := s.takeScreenshot(ctx, targetUrl)
+			screenshot := s.takeScreenshot(ctx, targetUrl, opts)
 			logx.Infof("takeScreenshot截图: targetUrl:%s ->screenshot)", targetUrl)
 			if screenshot != "" {
 				asset.Screenshot = screenshot
@@ -851,6 +899,11 @@ type HttpxResult struct {
 	Response       string            `json:"response"` // httpx -include-response 输出的字段名
 }
 
+// CheckHttpxInstalled 检查httpx是否安装
+func CheckHttpxInstalled() bool {
+	return checkHttpxInstalled()
+}
+
 // checkHttpxInstalled 检查httpx是否安装
 func checkHttpxInstalled() bool {
 	cmd := exec.Command("httpx", "-version")
@@ -915,87 +968,45 @@ func calculateIconHash(data []byte) string {
 	return hex.EncodeToString(sum[:])
 }
 
-// takeScreenshot 使用chromedp截图
-func (s *FingerprintScanner) takeScreenshot(ctx context.Context, targetUrl string) string {
-	// 创建chromedp上下文，设置超时
-	screenshotCtx, cancel := context.WithTimeout(ctx, 45*time.Second)
-	defer cancel()
-
-	// 配置chromedp选项，支持 Docker 环境中的 Chromium
-	opts := append(chromedp.DefaultExecAllocatorOptions[:],
-		chromedp.Flag("headless", true),
-		chromedp.Flag("disable-gpu", false), // 启用GPU渲染
-		chromedp.Flag("no-sandbox", true),
-		chromedp.Flag("disable-dev-shm-usage", true),
-		chromedp.Flag("ignore-certificate-errors", true),
-		chromedp.Flag("disable-web-security", true),
-		chromedp.Flag("disable-features", "VizDisplayCompositor"),
-		chromedp.Flag("disable-background-timer-throttling", true),
-		chromedp.Flag("disable-backgrounding-occluded-windows", true),
-		chromedp.Flag("disable-renderer-backgrounding", true),
-		chromedp.Flag("force-color-profile", "srgb"),
-		chromedp.WindowSize(1920, 1080),
-		chromedp.UserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"),
-	)
-
-	// 检查环境变量中是否指定了 Chrome 路径
-	if chromePath := os.Getenv("CHROME_BIN"); chromePath != "" {
-		opts = append(opts, chromedp.ExecPath(chromePath))
-	}
-
-	allocCtx, allocCancel := chromedp.NewExecAllocator(screenshotCtx, opts...)
-	defer allocCancel()
-
-	taskCtx, taskCancel := chromedp.NewContext(allocCtx)
-	defer taskCancel()
-
-	var buf []byte
-	var pageHeight int64
-	
-	err := chromedp.Run(taskCtx,
-		// 导航到目标URL
-		chromedp.Navigate(targetUrl),
-		// 等待页面基本加载完成
-		chromedp.WaitReady("body", chromedp.ByQuery),
-		// 等待网络空闲
-		chromedp.ActionFunc(func(ctx context.Context) error {
-			time.Sleep(3 * time.Second)
-			return nil
-		}),
-		// 获取页面高度
-		chromedp.Evaluate(`document.body.scrollHeight`, &pageHeight),
-		// 设置视口大小以适应页面内容
-		chromedp.EmulateViewport(1920, pageHeight),
-		// 滚动到页面顶部确保完整截图
-		chromedp.Evaluate(`window.scrollTo(0, 0)`, nil),
-		// 再次等待渲染完成
-		chromedp.Sleep(2*time.Second),
-		// 执行全屏截图
-		chromedp.FullScreenshot(&buf, 90),
-	)
-
+// takeScreenshot 使用共享浏览器截图，返回base64编码的图片
+func (s *FingerprintScanner) takeScreenshot(ctx context.Context, targetUrl string, opts *FingerprintOptions) string {
+	buf, err := s.browser.Screenshot(ctx, targetUrl, ScreenshotOptions{
+		Width:    opts.ScreenshotWidth,
+		Height:   opts.ScreenshotHeight,
+		FullPage: opts.ScreenshotFullPage,
+		Format:   opts.ScreenshotFormat,
+		Quality:  opts.ScreenshotQuality,
+		Timeout:  time.Duration(opts.ScreenshotTimeout) * time.Second,
+	})
 	if err != nil {
 		logx.Errorf("Screenshot failed for %s: %v", targetUrl, err)
-		// 如果全屏截图失败，尝试普通截图
-		err = chromedp.Run(taskCtx,
-			chromedp.Navigate(targetUrl),
-			chromedp.Sleep(5*time.Second),
-			chromedp.CaptureScreenshot(&buf),
-		)
-		if err != nil {
-			logx.Errorf("Fallback screenshot also failed for %s: %v", targetUrl, err)
-			return ""
-		}
+		return ""
 	}
-	
+
 	logx.Infof("完成使用chromedp截图: %s", targetUrl)
-	// 返回base64编码的截图
 	if len(buf) > 0 {
 		return base64.StdEncoding.EncodeToString(buf)
 	}
 	return ""
 }
 
+// hashScreenshot 计算截图的感知哈希，用于按外观聚类相似页面
+func hashScreenshot(asset *Asset) {
+	if asset.Screenshot == "" || asset.ScreenshotHash != "" {
+		return
+	}
+	data, err := base64.StdEncoding.DecodeString(asset.Screenshot)
+	if err != nil {
+		logx.Debugf("Decode screenshot failed for %s:%d: %v", asset.Host, asset.Port, err)
+		return
+	}
+	hash, err := imghash.DHash(data)
+	if err != nil {
+		logx.Debugf("Hash screenshot failed for %s:%d: %v", asset.Host, asset.Port, err)
+		return
+	}
+	asset.ScreenshotHash = hash
+}
 
 // extractTitle 提取网页标题
 func extractTitle(body string) string {
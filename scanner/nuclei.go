@@ -86,6 +86,7 @@ type NucleiOptions struct {
 	CustomTemplates      []string                      `json:"customTemplates"`      // 自定义模板内容(YAML)
 	CustomPocOnly        bool                          `json:"customPocOnly"`        // 只使用自定义POC
 	NucleiTemplates      []string                      `json:"nucleiTemplates"`      // 从数据库加载的Nuclei模板内容
+	ExtraTargets         []string                      `json:"extraTargets"`         // 额外扫描的URL（如爬虫发现的带参数URL）
 	OnVulnerabilityFound func(vul *Vulnerability)      `json:"-"`                    // 发现漏洞时的回调函数
 }
 
@@ -148,6 +149,10 @@ func (s *NucleiScanner) Scan(ctx context.Context, config *ScanConfig) (*ScanResu
 	} else {
 		// 从资产列表构建目标URL
 		targets = s.prepareTargets(config.Assets)
+		if len(opts.ExtraTargets) > 0 {
+			targets = utils.UniqueStrings(append(targets, opts.ExtraTargets...))
+			logx.Infof("Nuclei: added %d extra targets", len(opts.ExtraTargets))
+		}
 	}
 	if len(targets) == 0 {
 		logx.Info("No targets for nuclei scan")
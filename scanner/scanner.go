@@ -2,6 +2,8 @@ package scanner
 
 import (
 	"context"
+
+	"cscan/pkg/dnsrecord"
 )
 
 // Scanner 扫描器接口
@@ -28,37 +30,43 @@ type ScanConfig struct {
 
 // ScanResult 扫描结果
 type ScanResult struct {
-	WorkspaceId     string           `json:"workspaceId"`
-	MainTaskId      string           `json:"mainTaskId"`
-	Assets          []*Asset         `json:"assets"`
-	Vulnerabilities []*Vulnerability `json:"vulnerabilities"`
+	WorkspaceId     string              `json:"workspaceId"`
+	MainTaskId      string              `json:"mainTaskId"`
+	Assets          []*Asset            `json:"assets"`
+	Vulnerabilities []*Vulnerability    `json:"vulnerabilities"`
+	Urls            []*CrawledUrl       `json:"urls,omitempty"`       // 爬虫发现的URL
+	DirEntries      []*DirEntry         `json:"dirEntries,omitempty"` // 目录扫描发现的路径
+	Secrets         []*SecretFinding    `json:"secrets,omitempty"`    // 敏感信息检测结果
+	DNSRecords      []*dnsrecord.Result `json:"dnsRecords,omitempty"` // 域名的完整DNS记录
 }
 
 // Asset 资产
 type Asset struct {
-	Authority  string   `json:"authority"`
-	Host       string   `json:"host"`
-	Port       int      `json:"port"`
-	Category   string   `json:"category"` // ipv4/ipv6/domain
-	Service    string   `json:"service"`
-	Server     string   `json:"server"`
-	Banner     string   `json:"banner"`
-	Title      string   `json:"title"`
-	App        []string `json:"app"`
-	HttpStatus string   `json:"httpStatus"`
-	HttpHeader string   `json:"httpHeader"`
-	HttpBody   string   `json:"httpBody"`
-	Cert       string   `json:"cert"`
-	IconHash   string   `json:"iconHash"`
-	IconData   []byte   `json:"iconData,omitempty"` // favicon 图片原始数据
-	Screenshot string   `json:"screenshot"`
-	IsCDN      bool     `json:"isCdn"`
-	CName      string   `json:"cname"`
-	IsCloud    bool     `json:"isCloud"`
-	IsHTTP     bool     `json:"isHttp"`   // 是否为HTTP服务
-	IPV4       []IPInfo `json:"ipv4"`
-	IPV6       []IPInfo `json:"ipv6"`
-	Source     string   `json:"source"`   // 资产来源: subfinder, portscan, etc.
+	Authority      string   `json:"authority"`
+	Host           string   `json:"host"`
+	Port           int      `json:"port"`
+	Category       string   `json:"category"` // ipv4/ipv6/domain
+	Service        string   `json:"service"`
+	Server         string   `json:"server"`
+	Banner         string   `json:"banner"`
+	Title          string   `json:"title"`
+	App            []string `json:"app"`
+	HttpStatus     string   `json:"httpStatus"`
+	HttpHeader     string   `json:"httpHeader"`
+	HttpBody       string   `json:"httpBody"`
+	Cert           string   `json:"cert"`
+	TLS            *TLSCert `json:"tls,omitempty"` // TLS证书信息
+	IconHash       string   `json:"iconHash"`
+	IconData       []byte   `json:"iconData,omitempty"` // favicon 图片原始数据
+	Screenshot     string   `json:"screenshot"`
+	ScreenshotHash string   `json:"screenshotHash,omitempty"` // 截图感知哈希(dHash)
+	IsCDN          bool     `json:"isCdn"`
+	CName          string   `json:"cname"`
+	IsCloud        bool     `json:"isCloud"`
+	IsHTTP         bool     `json:"isHttp"` // 是否为HTTP服务
+	IPV4           []IPInfo `json:"ipv4"`
+	IPV6           []IPInfo `json:"ipv6"`
+	Source         string   `json:"source"` // 资产来源: subfinder, portscan, etc.
 }
 
 // IPInfo IP信息
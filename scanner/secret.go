@@ -0,0 +1,239 @@
+package scanner
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"cscan/model"
+	"cscan/pkg/secret"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const secretMaxJSSize = 2 * 1024 * 1024 // 单个JS文件最多读取2MB
+
+// SecretScanner 敏感信息检测，检测资产的响应头、响应体和爬虫发现的JS文件
+type SecretScanner struct {
+	BaseScanner
+	client *http.Client
+}
+
+// NewSecretScanner 创建敏感信息检测器
+func NewSecretScanner() *SecretScanner {
+	return &SecretScanner{
+		BaseScanner: BaseScanner{name: "secret"},
+		client: &http.Client{
+			Timeout: 15 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		},
+	}
+}
+
+// SecretScanOptions 敏感信息检测选项
+type SecretScanOptions struct {
+	Rules       []secret.Rule `json:"rules"`
+	JSFiles     []*CrawledUrl `json:"jsFiles"`     // 爬虫发现的JS文件
+	MaxJSFiles  int           `json:"maxJsFiles"`  // 最多检测的JS文件数，默认500
+	Concurrency int           `json:"concurrency"` // 下载JS文件的并发数，默认10
+	Timeout     int           `json:"timeout"`     // 总超时时间(秒)，默认600秒
+}
+
+func (o *SecretScanOptions) setDefaults() {
+	if o.MaxJSFiles <= 0 {
+		o.MaxJSFiles = 500
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = 10
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = 600
+	}
+}
+
+// SecretFinding 敏感信息检测结果，关联到发现该信息的资产
+type SecretFinding struct {
+	Authority string `json:"authority"`
+	Host      string `json:"host"`
+	Port      int    `json:"port"`
+	Url       string `json:"url"`
+	Location  string `json:"location"` // header, body, js
+	Rule      string `json:"rule"`
+	Category  string `json:"category"`
+	Severity  string `json:"severity"`
+	Evidence  string `json:"evidence"` // 已脱敏
+	Hash      string `json:"hash"`     // 敏感值的SHA256
+}
+
+// Scan 检测资产中保存的响应内容，并下载检测JS文件，结果保存在 ScanResult.Secrets
+func (s *SecretScanner) Scan(ctx context.Context, config *ScanConfig) (*ScanResult, error) {
+	opts := &SecretScanOptions{}
+	if config.Options != nil {
+		switch v := config.Options.(type) {
+		case *SecretScanOptions:
+			opts = v
+		default:
+			if data, err := json.Marshal(config.Options); err == nil {
+				json.Unmarshal(data, opts)
+			}
+		}
+	}
+	opts.setDefaults()
+
+	taskLog := func(level, format string, args ...interface{}) {
+		if config.TaskLogger != nil {
+			config.TaskLogger(level, format, args...)
+		}
+	}
+
+	result := &ScanResult{
+		WorkspaceId: config.WorkspaceId,
+		MainTaskId:  config.MainTaskId,
+		Secrets:     make([]*SecretFinding, 0),
+	}
+
+	engine, errs := secret.NewEngine(opts.Rules)
+	for _, err := range errs {
+		taskLog("WARN", "SecretScan: %v", err)
+	}
+	if engine.RuleCount() == 0 {
+		taskLog("WARN", "SecretScan: no usable rules, skipping")
+		return result, nil
+	}
+
+	// 资产的响应头和响应体在指纹识别阶段已获取，直接检测
+	assets := filterHttpAssets(config.Assets)
+	for _, asset := range assets {
+		base, err := httpBaseUrl(asset)
+		if err != nil {
+			continue
+		}
+		target := &SecretFinding{Authority: asset.Authority, Host: asset.Host, Port: asset.Port, Url: base.String()}
+		if target.Authority == "" {
+			target.Authority = base.Host
+		}
+		result.Secrets = append(result.Secrets, collectSecrets(engine, target, model.SecretLocationHeader, asset.HttpHeader)...)
+		result.Secrets = append(result.Secrets, collectSecrets(engine, target, model.SecretLocationBody, asset.HttpBody)...)
+	}
+	taskLog("INFO", "SecretScan: checked %d assets with %d rules, %d findings", len(assets), engine.RuleCount(), len(result.Secrets))
+
+	jsFiles := opts.JSFiles
+	if len(jsFiles) > opts.MaxJSFiles {
+		taskLog("WARN", "SecretScan: %d js files found, only the first %d are checked", len(jsFiles), opts.MaxJSFiles)
+		jsFiles = jsFiles[:opts.MaxJSFiles]
+	}
+	if len(jsFiles) == 0 {
+		return result, nil
+	}
+
+	scanCtx, cancel := context.WithTimeout(ctx, time.Duration(opts.Timeout)*time.Second)
+	defer cancel()
+
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		done  int
+		found int
+	)
+	sem := make(chan struct{}, opts.Concurrency)
+	for _, js := range jsFiles {
+		select {
+		case sem <- struct{}{}:
+		case <-scanCtx.Done():
+		}
+		if scanCtx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(js *CrawledUrl) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			body, err := s.fetchJS(scanCtx, js.Url)
+			if err != nil {
+				logx.Debugf("SecretScan: fetch %s failed: %v", js.Url, err)
+			}
+			target := &SecretFinding{Authority: js.Authority, Host: js.Host, Port: js.Port, Url: js.Url}
+			findings := collectSecrets(engine, target, model.SecretLocationJS, body)
+
+			mu.Lock()
+			defer mu.Unlock()
+			result.Secrets = append(result.Secrets, findings...)
+			found += len(findings)
+			done++
+			if config.OnProgress != nil {
+				config.OnProgress(done*100/len(jsFiles), fmt.Sprintf("SecretScan: %d/%d", done, len(jsFiles)))
+			}
+		}(js)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return result, ctx.Err()
+	}
+	if scanCtx.Err() == context.DeadlineExceeded {
+		taskLog("WARN", "SecretScan: timeout %ds reached, %d/%d js files checked", opts.Timeout, done, len(jsFiles))
+	}
+	taskLog("INFO", "SecretScan: checked %d js files, %d findings", done, found)
+	return result, nil
+}
+
+// fetchJS 下载JS文件内容
+func (s *SecretScanner) fetchJS(ctx context.Context, jsUrl string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jsUrl, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", crawlerUserAgent)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, secretMaxJSSize))
+	return string(body), err
+}
+
+// collectSecrets 检测内容并补充资产信息
+func collectSecrets(engine *secret.Engine, target *SecretFinding, location, content string) []*SecretFinding {
+	if content == "" {
+		return nil
+	}
+	var findings []*SecretFinding
+	for _, f := range engine.Scan(content) {
+		finding := *target
+		finding.Location = location
+		finding.Rule = f.Rule
+		finding.Category = f.Category
+		finding.Severity = f.Severity
+		finding.Evidence = f.Evidence
+		finding.Hash = f.Hash
+		findings = append(findings, &finding)
+	}
+	return findings
+}
+
+// SelectJSFiles 从爬虫结果中选出JS文件
+func SelectJSFiles(urls []*CrawledUrl) []*CrawledUrl {
+	var files []*CrawledUrl
+	for _, u := range urls {
+		if u.StatusCode != 0 && u.StatusCode != http.StatusOK {
+			continue
+		}
+		if strings.HasSuffix(strings.ToLower(u.Path), ".js") || strings.Contains(u.ContentType, "javascript") {
+			files = append(files, u)
+		}
+	}
+	return files
+}
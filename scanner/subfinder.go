@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"cscan/pkg/utils"
 
@@ -42,6 +43,15 @@ type SubfinderOptions struct {
 	ProviderConfig     map[string][]string `json:"providerConfig"`     // API配置 (从数据库加载)
 	ResolveDNS         bool                `json:"resolveDNS"`         // 是否解析DNS
 	Concurrent         int                 `json:"concurrent"`         // DNS解析并发数
+	CdnCheck           bool                `json:"cdnCheck"`           // 多解析器CDN/WAF检测
+	Resolvers          []string            `json:"resolvers"`          // CDN检测和DNS记录收集使用的DNS服务器
+	DNSRecords         bool                `json:"dnsRecords"`         // 收集完整DNS记录，检查区域传送和SPF/DMARC
+	SkipPassive        bool                `json:"skipPassive"`        // 不使用被动数据源，只进行爆破
+	Bruteforce         bool                `json:"bruteforce"`         // 字典爆破子域名
+	Wordlist           []string            `json:"wordlist"`           // 子域名爆破字典，为空时使用内置字典
+	BruteRateLimit     int                 `json:"bruteRateLimit"`     // 爆破每秒DNS查询数
+	Permutation        bool                `json:"permutation"`        // 对已发现的子域名生成变形再解析
+	Takeover           bool                `json:"takeover"`           // 子域名接管检测
 }
 
 // Scan 执行Subfinder子域名扫描
@@ -89,6 +99,9 @@ func (s *SubfinderScanner) Scan(ctx context.Context, config *ScanConfig) (*ScanR
 	var mu sync.Mutex
 
 	for _, domain := range domains {
+		if opts.SkipPassive {
+			break
+		}
 		select {
 		case <-ctx.Done():
 			logx.Info("Subfinder scan cancelled by context")
@@ -111,6 +124,11 @@ func (s *SubfinderScanner) Scan(ctx context.Context, config *ScanConfig) (*ScanR
 		taskLog("INFO", "Subfinder: found %d subdomains for %s", len(subdomains), domain)
 	}
 
+	// 字典爆破和变形（可选），与被动数据源的结果合并
+	if opts.Bruteforce || opts.Permutation {
+		allSubdomains = append(allSubdomains, s.bruteforceDomains(ctx, domains, utils.UniqueStrings(allSubdomains), opts, taskLog)...)
+	}
+
 	// 去重
 	allSubdomains = utils.UniqueStrings(allSubdomains)
 	taskLog("INFO", "Subfinder: total %d unique subdomains", len(allSubdomains))
@@ -118,13 +136,23 @@ func (s *SubfinderScanner) Scan(ctx context.Context, config *ScanConfig) (*ScanR
 	// DNS解析（可选）
 	if opts.ResolveDNS && len(allSubdomains) > 0 {
 		taskLog("INFO", "Resolving DNS for %d subdomains", len(allSubdomains))
-		assets := s.resolveDomains(ctx, allSubdomains, opts.Concurrent, taskLog)
+		assets := s.resolveDomains(ctx, allSubdomains, opts, taskLog)
 		// 设置Source字段
 		for _, asset := range assets {
 			asset.Source = "subfinder"
 		}
 		result.Assets = assets
 		taskLog("INFO", "Subfinder: resolved %d assets", len(assets))
+
+		cdnCount := 0
+		for _, asset := range assets {
+			if asset.IsCDN {
+				cdnCount++
+			}
+		}
+		if cdnCount > 0 {
+			taskLog("INFO", "Subfinder: %d subdomains behind CDN/WAF", cdnCount)
+		}
 	} else {
 		// 不解析DNS，直接返回域名作为资产
 		for _, subdomain := range allSubdomains {
@@ -137,9 +165,73 @@ func (s *SubfinderScanner) Scan(ctx context.Context, config *ScanConfig) (*ScanR
 		}
 	}
 
+	// 完整DNS记录（可选），包含目标域名本身
+	if opts.DNSRecords {
+		names := utils.UniqueStrings(append(append([]string{}, domains...), allSubdomains...))
+		taskLog("INFO", "Collecting DNS records for %d domains", len(names))
+		result.DNSRecords = collectDNSRecords(ctx, names, opts.Resolvers, opts.Concurrent, taskLog)
+	}
+
+	// 子域名接管检测（可选），包含无法解析的悬空CNAME
+	if opts.Takeover && len(allSubdomains) > 0 {
+		taskLog("INFO", "Checking subdomain takeover for %d subdomains", len(allSubdomains))
+		result.Vulnerabilities = checkTakeover(ctx, allSubdomains, opts.Resolvers, opts.Concurrent, taskLog)
+	}
+
 	return result, nil
 }
 
+// bruteforceDomains 使用字典爆破根域名，并对已发现的子域名生成变形，过滤泛解析结果
+func (s *SubfinderScanner) bruteforceDomains(ctx context.Context, domains, known []string, opts *SubfinderOptions, taskLog func(level, format string, args ...interface{})) []string {
+	words := opts.Wordlist
+	if opts.Bruteforce && len(words) == 0 {
+		words = DefaultSubdomainWords
+		taskLog("INFO", "DNS brute: no wordlist selected, using %d builtin words", len(words))
+	}
+
+	// 爆破与被动枚举共用最大枚举时间
+	if opts.MaxEnumerationTime > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(opts.MaxEnumerationTime)*time.Minute)
+		defer cancel()
+	}
+
+	bruter := newDNSBruter(DNSBruteOptions{
+		Words:       words,
+		Resolvers:   opts.Resolvers,
+		RateLimit:   opts.BruteRateLimit,
+		Threads:     opts.Concurrent,
+		Permutation: opts.Permutation,
+	})
+
+	var found []string
+	for _, domain := range domains {
+		if ctx.Err() != nil {
+			break
+		}
+		if bruter.IsWildcardZone(ctx, domain) {
+			taskLog("INFO", "DNS brute: %s has wildcard records, matching answers are filtered", domain)
+		}
+
+		var names []string
+		if opts.Bruteforce {
+			names = bruter.Bruteforce(ctx, domain)
+			taskLog("INFO", "DNS brute: found %d subdomains for %s with %d words", len(names), domain, len(words))
+		}
+		if opts.Permutation {
+			seeds := utils.UniqueStrings(append(append([]string{}, known...), names...))
+			permuted := bruter.Permute(ctx, domain, seeds)
+			taskLog("INFO", "DNS brute: found %d subdomains for %s by permutation", len(permuted), domain)
+			names = append(names, permuted...)
+		}
+		found = append(found, names...)
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		taskLog("WARN", "DNS brute: max enumeration time reached, results may be incomplete")
+	}
+	return found
+}
+
 // parseDomains 解析目标中的域名（跳过IP地址）
 func (s *SubfinderScanner) parseDomains(target string) []string {
 	var domains []string
@@ -280,11 +372,12 @@ func (s *SubfinderScanner) enumerateDomain(ctx context.Context, domain string, o
 }
 
 // resolveDomains DNS解析子域名
-func (s *SubfinderScanner) resolveDomains(ctx context.Context, domains []string, concurrent int, taskLog func(level, format string, args ...interface{})) []*Asset {
+func (s *SubfinderScanner) resolveDomains(ctx context.Context, domains []string, opts *SubfinderOptions, taskLog func(level, format string, args ...interface{})) []*Asset {
 	var assets []*Asset
 	var mu sync.Mutex
 	var wg sync.WaitGroup
 
+	concurrent := opts.Concurrent
 	if concurrent <= 0 {
 		concurrent = 50
 	}
@@ -324,6 +417,13 @@ func (s *SubfinderScanner) resolveDomains(ctx context.Context, domains []string,
 						asset.CName = strings.TrimSuffix(cname, ".")
 					}
 
+					// CDN/WAF/云检测
+					if opts.CdnCheck {
+						DetectCdn(ctx, asset, opts.Resolvers)
+					} else {
+						MarkCdn(asset)
+					}
+
 					mu.Lock()
 					assets = append(assets, asset)
 					mu.Unlock()
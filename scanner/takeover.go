@@ -0,0 +1,104 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"cscan/pkg/takeover"
+)
+
+// takeoverConcurrency 子域名接管检测的默认并发数
+const takeoverConcurrency = 20
+
+// checkTakeover 检测子域名接管，结果转换为漏洞
+func checkTakeover(ctx context.Context, domains []string, resolvers []string, concurrent int, taskLog func(level, format string, args ...interface{})) []*Vulnerability {
+	if concurrent <= 0 || concurrent > takeoverConcurrency {
+		concurrent = takeoverConcurrency
+	}
+	checker := takeover.NewChecker(takeover.DefaultData(), takeover.Options{Resolvers: resolvers})
+
+	var (
+		vuls []*Vulnerability
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+	)
+	taskChan := make(chan string, concurrent)
+	for i := 0; i < concurrent; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for domain := range taskChan {
+				finding := checker.Check(ctx, domain)
+				if finding == nil {
+					continue
+				}
+				mu.Lock()
+				vuls = append(vuls, takeoverToVul(finding))
+				mu.Unlock()
+				if taskLog != nil {
+					taskLog("WARN", "Takeover: %s -> %s (%s, %s)", finding.Domain, strings.Join(finding.CNames, " -> "), finding.Service, finding.Confidence)
+				}
+			}
+		}()
+	}
+	for _, domain := range domains {
+		select {
+		case taskChan <- domain:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(taskChan)
+	wg.Wait()
+
+	if taskLog != nil {
+		taskLog("INFO", "Takeover: checked %d domains with fingerprints %s, %d findings", len(domains), checker.Version(), len(vuls))
+	}
+	return vuls
+}
+
+// takeoverToVul 转换为漏洞，CNAME链和命中的特征作为证据
+func takeoverToVul(f *takeover.Finding) *Vulnerability {
+	port := 80
+	if strings.HasPrefix(f.Url, "https://") {
+		port = 443
+	}
+	service := f.Service
+	if service == "" {
+		service = "未注册域名"
+	}
+
+	var reason string
+	switch f.Reason {
+	case takeover.ReasonFingerprint:
+		reason = fmt.Sprintf("响应包含服务商未认领页面特征: %s", f.Fingerprint)
+	case takeover.ReasonNXDomain:
+		reason = "CNAME指向的服务商主机不存在"
+	case takeover.ReasonUnregistered:
+		reason = "CNAME目标的注册域名不存在，可直接注册"
+	}
+
+	vul := &Vulnerability{
+		Authority:        f.Domain,
+		Host:             f.Domain,
+		Port:             port,
+		Url:              f.Url,
+		PocFile:          "subdomain-takeover",
+		Source:           "takeover",
+		Severity:         f.Severity,
+		Result:           fmt.Sprintf("子域名接管(%s): %s CNAME %s [%s] %s", f.Confidence, f.Domain, strings.Join(f.CNames, " -> "), service, reason),
+		Remediation:      "删除指向已释放服务的DNS记录，或在服务商处重新认领该域名",
+		References:       []string{"https://github.com/EdOverflow/can-i-take-over-xyz"},
+		MatcherName:      f.Confidence + ":" + f.Reason,
+		ExtractedResults: f.CNames,
+	}
+	if f.StatusCode > 0 {
+		vul.Request = fmt.Sprintf("GET / HTTP/1.1\r\nHost: %s\r\n\r\n", f.Domain)
+		vul.Response = fmt.Sprintf("HTTP status %d\n\n%s", f.StatusCode, f.Response)
+	}
+	return vul
+}
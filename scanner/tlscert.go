@@ -0,0 +1,299 @@
+package scanner
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TLSCert TLS证书信息
+type TLSCert struct {
+	Subject     string         `json:"subject"`   // 主题CN
+	SubjectDN   string         `json:"subjectDn"` // 完整主题
+	Issuer      string         `json:"issuer"`    // 颁发者CN
+	IssuerDN    string         `json:"issuerDn"`  // 完整颁发者
+	SANs        []string       `json:"sans"`      // DNS SAN
+	IPs         []string       `json:"ips"`       // IP SAN
+	NotBefore   time.Time      `json:"notBefore"`
+	NotAfter    time.Time      `json:"notAfter"`
+	Serial      string         `json:"serial"`
+	SHA256      string         `json:"sha256"` // 叶子证书SHA256指纹
+	SHA1        string         `json:"sha1"`
+	SigAlg      string         `json:"sigAlg"`
+	KeyAlg      string         `json:"keyAlg"`
+	KeyBits     int            `json:"keyBits"`
+	SelfSigned  bool           `json:"selfSigned"`
+	Trusted     bool           `json:"trusted"` // 是否通过系统根证书校验
+	TLSVersion  string         `json:"tlsVersion"`
+	CipherSuite string         `json:"cipherSuite"`
+	Jarm        string         `json:"jarm,omitempty"`  // JARM风格的TLS服务端指纹
+	Chain       []TLSChainCert `json:"chain,omitempty"` // 证书链（不含叶子证书）
+}
+
+// TLSChainCert 证书链中的中间/根证书
+type TLSChainCert struct {
+	Subject  string    `json:"subject"`
+	Issuer   string    `json:"issuer"`
+	NotAfter time.Time `json:"notAfter"`
+	SHA256   string    `json:"sha256"`
+	IsCA     bool      `json:"isCa"`
+}
+
+// tlsServices 明确为TLS的服务名
+var tlsServices = map[string]bool{
+	"https": true, "https-alt": true, "ssl": true, "tls": true,
+	"imaps": true, "pop3s": true, "smtps": true, "submissions": true,
+	"ldaps": true, "ftps": true, "ircs": true, "nntps": true, "xmpps": true,
+	"sips": true, "mqtts": true,
+}
+
+// tlsPorts 常见TLS端口，服务未识别时使用
+var tlsPorts = map[int]bool{
+	443: true, 465: true, 636: true, 853: true, 989: true, 990: true,
+	992: true, 993: true, 994: true, 995: true, 2376: true, 2484: true,
+	3269: true, 5061: true, 5986: true, 6443: true, 8443: true, 8883: true,
+	9443: true, 10443: true,
+}
+
+// isTLSAsset 判断资产是否可能为TLS服务
+func isTLSAsset(asset *Asset) bool {
+	service := strings.ToLower(asset.Service)
+	if tlsServices[service] || strings.HasPrefix(service, "ssl/") || strings.HasPrefix(service, "tls/") {
+		return true
+	}
+	if service == "http" {
+		return false
+	}
+	return tlsPorts[asset.Port]
+}
+
+// GrabTLSCert 与目标进行TLS握手并采集证书信息
+func GrabTLSCert(ctx context.Context, host string, port int, timeout time.Duration) (*TLSCert, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: true,
+		MinVersion:         tls.VersionTLS10,
+	}
+	if net.ParseIP(host) == nil {
+		config.ServerName = host
+	}
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: timeout},
+		Config:    config,
+	}
+	dialCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn, err := dialer.DialContext(dialCtx, "tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	state := conn.(*tls.Conn).ConnectionState()
+	cert := ParseTLSState(&state, host)
+	if cert == nil {
+		return nil, fmt.Errorf("no peer certificate")
+	}
+	return cert, nil
+}
+
+// ParseTLSState 从TLS连接状态中解析证书信息
+func ParseTLSState(state *tls.ConnectionState, host string) *TLSCert {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return nil
+	}
+	leaf := state.PeerCertificates[0]
+	sha256Sum := sha256.Sum256(leaf.Raw)
+	sha1Sum := sha1.Sum(leaf.Raw)
+
+	cert := &TLSCert{
+		Subject:     leaf.Subject.CommonName,
+		SubjectDN:   leaf.Subject.String(),
+		Issuer:      leaf.Issuer.CommonName,
+		IssuerDN:    leaf.Issuer.String(),
+		SANs:        leaf.DNSNames,
+		NotBefore:   leaf.NotBefore,
+		NotAfter:    leaf.NotAfter,
+		Serial:      leaf.SerialNumber.Text(16),
+		SHA256:      hex.EncodeToString(sha256Sum[:]),
+		SHA1:        hex.EncodeToString(sha1Sum[:]),
+		SigAlg:      leaf.SignatureAlgorithm.String(),
+		KeyAlg:      leaf.PublicKeyAlgorithm.String(),
+		KeyBits:     publicKeyBits(leaf),
+		TLSVersion:  tls.VersionName(state.Version),
+		CipherSuite: tls.CipherSuiteName(state.CipherSuite),
+	}
+	for _, ip := range leaf.IPAddresses {
+		cert.IPs = append(cert.IPs, ip.String())
+	}
+	cert.SelfSigned = leaf.Subject.String() == leaf.Issuer.String() && leaf.CheckSignatureFrom(leaf) == nil
+
+	// 使用系统根证书校验证书链
+	intermediates := x509.NewCertPool()
+	for _, c := range state.PeerCertificates[1:] {
+		intermediates.AddCert(c)
+		sum := sha256.Sum256(c.Raw)
+		cert.Chain = append(cert.Chain, TLSChainCert{
+			Subject:  c.Subject.CommonName,
+			Issuer:   c.Issuer.CommonName,
+			NotAfter: c.NotAfter,
+			SHA256:   hex.EncodeToString(sum[:]),
+			IsCA:     c.IsCA,
+		})
+	}
+	opts := x509.VerifyOptions{Intermediates: intermediates}
+	if net.ParseIP(host) == nil {
+		opts.DNSName = host
+	}
+	_, err := leaf.Verify(opts)
+	cert.Trusted = err == nil
+
+	return cert
+}
+
+func publicKeyBits(cert *x509.Certificate) int {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return key.N.BitLen()
+	case *ecdsa.PublicKey:
+		return key.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		return 256
+	}
+	return 0
+}
+
+// jarmProbe 一次握手探测的参数
+type jarmProbe struct {
+	minVersion uint16
+	maxVersion uint16
+	ciphers    []uint16
+	alpn       []string
+}
+
+var (
+	gcmCiphers = []uint16{
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	}
+	cbcCiphers = []uint16{
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA,
+		tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA,
+		tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA,
+	}
+	chachaCiphers = []uint16{
+		tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+	}
+	rsaKxCiphers = []uint16{
+		tls.TLS_RSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_RSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_RSA_WITH_AES_128_CBC_SHA,
+		tls.TLS_RSA_WITH_AES_256_CBC_SHA,
+	}
+
+	// jarmProbes 10次握手，覆盖不同协议版本、密码套件和ALPN组合
+	jarmProbes = []jarmProbe{
+		{tls.VersionTLS12, tls.VersionTLS12, nil, []string{"h2", "http/1.1"}},
+		{tls.VersionTLS12, tls.VersionTLS12, gcmCiphers, nil},
+		{tls.VersionTLS12, tls.VersionTLS12, cbcCiphers, nil},
+		{tls.VersionTLS12, tls.VersionTLS12, chachaCiphers, []string{"http/1.1"}},
+		{tls.VersionTLS12, tls.VersionTLS12, rsaKxCiphers, nil},
+		{tls.VersionTLS10, tls.VersionTLS11, append(append([]uint16{}, cbcCiphers...), rsaKxCiphers...), nil},
+		{tls.VersionTLS13, tls.VersionTLS13, nil, []string{"h2"}},
+		{tls.VersionTLS13, tls.VersionTLS13, nil, []string{"http/1.1"}},
+		{tls.VersionTLS10, tls.VersionTLS13, nil, nil},
+		{tls.VersionTLS10, tls.VersionTLS13, nil, []string{"h2", "http/1.1", "h3"}},
+	}
+)
+
+// cipherIndex 密码套件在固定列表中的序号，用于生成稳定的指纹
+var cipherIndex = func() map[uint16]int {
+	var ids []int
+	for _, c := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		ids = append(ids, int(c.ID))
+	}
+	sort.Ints(ids)
+	m := make(map[uint16]int, len(ids))
+	for i, id := range ids {
+		m[uint16(id)] = i + 1
+	}
+	return m
+}()
+
+// JarmHash 计算JARM风格的TLS服务端指纹
+// 使用标准库完成10次不同参数的握手，按协商结果生成62位指纹：
+// 前30位为每次握手协商的密码套件序号和协议版本，后32位为ALPN协商结果的SHA256截断。
+// 由于握手报文由标准库构造，结果与官方JARM值不兼容，仅用于同类服务的聚类比对。
+func JarmHash(ctx context.Context, host string, port int, timeout time.Duration) string {
+	var fuzzy strings.Builder
+	var alpns []string
+	succeeded := false
+
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	for _, probe := range jarmProbes {
+		if ctx.Err() != nil {
+			return ""
+		}
+		config := &tls.Config{
+			InsecureSkipVerify: true,
+			MinVersion:         probe.minVersion,
+			MaxVersion:         probe.maxVersion,
+			CipherSuites:       probe.ciphers,
+			NextProtos:         probe.alpn,
+		}
+		if net.ParseIP(host) == nil {
+			config.ServerName = host
+		}
+		dialer := &tls.Dialer{NetDialer: &net.Dialer{Timeout: timeout}, Config: config}
+		dialCtx, cancel := context.WithTimeout(ctx, timeout)
+		conn, err := dialer.DialContext(dialCtx, "tcp", addr)
+		cancel()
+		if err != nil {
+			fuzzy.WriteString("000")
+			alpns = append(alpns, "")
+			continue
+		}
+		state := conn.(*tls.Conn).ConnectionState()
+		conn.Close()
+		succeeded = true
+
+		fmt.Fprintf(&fuzzy, "%02x%c", cipherIndex[state.CipherSuite]&0xff, versionChar(state.Version))
+		alpns = append(alpns, state.NegotiatedProtocol)
+	}
+	if !succeeded {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(alpns, ",")))
+	return fuzzy.String() + hex.EncodeToString(sum[:])[:32]
+}
+
+func versionChar(version uint16) byte {
+	switch version {
+	case tls.VersionTLS10:
+		return 'a'
+	case tls.VersionTLS11:
+		return 'b'
+	case tls.VersionTLS12:
+		return 'c'
+	case tls.VersionTLS13:
+		return 'd'
+	}
+	return '0'
+}
@@ -1,7 +1,9 @@
 package scanner
 
 import (
+	"fmt"
 	"net"
+	"net/url"
 	"strconv"
 	"strings"
 )
@@ -327,3 +329,12 @@ func IsHTTPService(service string, port int) bool {
 
 	return false
 }
+
+// httpBaseUrl 返回HTTP资产的根地址，如 https://example.com:8443/
+func httpBaseUrl(asset *Asset) (*url.URL, error) {
+	scheme := "http"
+	if asset.Service == "https" || asset.Port == 443 || asset.Port == 8443 {
+		scheme = "https"
+	}
+	return url.Parse(fmt.Sprintf("%s://%s/", scheme, net.JoinHostPort(asset.Host, strconv.Itoa(asset.Port))))
+}
@@ -0,0 +1,427 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 租约状态
+const (
+	LeaseStateLeased     = "leased"     // Worker持有中
+	LeaseStateDeadLetter = "deadletter" // 重试次数用尽
+)
+
+const (
+	// 按到期时间排序的租约，成员为taskId
+	leaseIndexKey = "cscan:task:leases"
+	// 单个任务的租约详情，重新入队后保留记录但清空持有者，用于拒绝旧Worker的迟到上报
+	leaseKeyPrefix = "cscan:task:lease:"
+	// Worker持有的租约
+	workerLeasesKeyPrefix = "cscan:task:leases:worker:"
+	// 死信，按主任务分组，字段为子任务ID
+	deadLetterKeyPrefix = "cscan:task:deadletter:"
+
+	leaseRecordTTL     = 7 * 24 * time.Hour
+	deadLetterTTL      = 7 * 24 * time.Hour
+	leaseAssignedGrace = time.Minute // 刚分配的任务可能还未出现在Worker的心跳中
+)
+
+// claimScript 从队列取出任务并创建租约，任务已被其他Worker取走时返回0；
+// KEYS[6]之后为任务所在的其他队列，一并移除
+var claimScript = redis.NewScript(`
+if redis.call('ZREM', KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+for i = 6, #KEYS do
+	redis.call('ZREM', KEYS[i], ARGV[1])
+end
+redis.call('HSET', KEYS[3], 'worker', ARGV[3], 'task', ARGV[1], 'retry', ARGV[6],
+	'assign_time', ARGV[4], 'renew_time', ARGV[4], 'expire_time', ARGV[5])
+redis.call('EXPIRE', KEYS[3], ARGV[7])
+redis.call('ZADD', KEYS[2], ARGV[5], ARGV[2])
+redis.call('SADD', KEYS[4], ARGV[2])
+redis.call('SADD', KEYS[5], ARGV[2])
+return 1
+`)
+
+// releaseScript 持有者释放租约，返回1已释放，0没有租约，-1租约属于其他Worker或已重新入队
+var releaseScript = redis.NewScript(`
+local holder = redis.call('HGET', KEYS[2], 'worker')
+if not holder then
+	return 0
+end
+if holder ~= ARGV[2] then
+	return -1
+end
+redis.call('DEL', KEYS[2])
+redis.call('ZREM', KEYS[1], ARGV[1])
+redis.call('SREM', KEYS[3], ARGV[1])
+return 1
+`)
+
+// reclaimScript 取走已到期的租约，多个任务服务实例同时回收时只有一个成功
+var reclaimScript = redis.NewScript(`
+local score = redis.call('ZSCORE', KEYS[1], ARGV[1])
+if not score or tonumber(score) > tonumber(ARGV[2]) then
+	return false
+end
+redis.call('ZREM', KEYS[1], ARGV[1])
+return redis.call('HGETALL', KEYS[2])
+`)
+
+// Lease 任务租约
+type Lease struct {
+	TaskId     string `json:"taskId"`
+	Worker     string `json:"worker"`
+	Retry      int    `json:"retry"`
+	AssignTime int64  `json:"assignTime"`
+	RenewTime  int64  `json:"renewTime"`
+	ExpireTime int64  `json:"expireTime"`
+	Task       string `json:"-"` // 队列中的原始任务数据，重新入队时使用
+}
+
+// DeadLetter 重试次数用尽的任务
+type DeadLetter struct {
+	TaskId string `json:"taskId"`
+	Worker string `json:"worker"` // 最后持有租约的Worker
+	Retry  int    `json:"retry"`
+	Reason string `json:"reason"`
+	Time   int64  `json:"time"`
+}
+
+// TaskLeases 任务租约：Worker取走任务时创建，心跳续约，到期未续约的任务由回收方重新入队
+type TaskLeases struct {
+	rdb           *redis.Client
+	processingKey string
+}
+
+// NewTaskLeases 创建任务租约管理
+func NewTaskLeases(rdb *redis.Client) *TaskLeases {
+	return &TaskLeases{
+		rdb:           rdb,
+		processingKey: "cscan:task:processing",
+	}
+}
+
+// ClaimNext 领取Worker可以执行的优先级最高的任务并创建租约，没有可领取的任务时返回nil；
+// profile 为Worker上报的标签和能力
+func (l *TaskLeases) ClaimNext(ctx context.Context, worker string, profile *WorkerProfile, ttl time.Duration) (*TaskInfo, error) {
+	queues, err := claimQueues(ctx, l.rdb, worker, profile)
+	if err != nil {
+		return nil, err
+	}
+	// 每个队列中跳过的不可领取任务数，下一页从其后开始读
+	offsets := make(map[string]int64, len(queues))
+	for page := 0; page < claimMaxPages; page++ {
+		candidates, full, err := peekQueues(ctx, l.rdb, queues, offsets)
+		if err != nil {
+			return nil, err
+		}
+		skipped := make(map[string]int64)
+		for i := range candidates {
+			c := &candidates[i]
+			if !c.task.Claimable(worker, profile) {
+				skipped[c.queue]++
+				continue
+			}
+			claimed, err := l.claim(ctx, c, worker, ttl)
+			if err != nil {
+				return nil, err
+			}
+			if claimed {
+				return &c.task, nil
+			}
+			// 已被其他Worker取走，尝试下一个
+		}
+		// 本页没有领取到任务，读满一页的队列继续往后读
+		more := false
+		for _, key := range queues {
+			if full[key] {
+				offsets[key] += skipped[key]
+				more = true
+			}
+		}
+		if !more {
+			break
+		}
+	}
+	return nil, nil
+}
+
+func (l *TaskLeases) claim(ctx context.Context, c *queuedTask, worker string, ttl time.Duration) (bool, error) {
+	now := time.Now().Unix()
+	keys := []string{c.queue, leaseIndexKey, leaseKeyPrefix + c.task.TaskId, workerLeasesKeyPrefix + worker, l.processingKey}
+	for _, key := range QueueKeys(&c.task) {
+		if key != c.queue {
+			keys = append(keys, key)
+		}
+	}
+	n, err := claimScript.Run(ctx, l.rdb, keys,
+		c.member, c.task.TaskId, worker, now, now+int64(ttl/time.Second), c.task.Retry, int64(leaseRecordTTL/time.Second)).Int()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// Renew 续约Worker正在执行的任务，返回已不属于该Worker的任务；
+// Worker持有但未上报的租约（如Worker重启后丢失的任务）立即置为到期，交给回收处理
+func (l *TaskLeases) Renew(ctx context.Context, worker string, taskIds []string, ttl time.Duration) ([]string, error) {
+	now := time.Now().Unix()
+	expire := now + int64(ttl/time.Second)
+	reported := make(map[string]bool, len(taskIds))
+	var lost []string
+
+	for _, taskId := range taskIds {
+		reported[taskId] = true
+		holder, err := l.rdb.HGet(ctx, leaseKeyPrefix+taskId, "worker").Result()
+		if err == redis.Nil {
+			// 没有租约：升级前分配的任务，或任务已被停止
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if holder != worker {
+			lost = append(lost, taskId)
+			continue
+		}
+		pipe := l.rdb.Pipeline()
+		pipe.ZAddXX(ctx, leaseIndexKey, redis.Z{Score: float64(expire), Member: taskId})
+		pipe.HSet(ctx, leaseKeyPrefix+taskId, "renew_time", now, "expire_time", expire)
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	held, err := l.rdb.SMembers(ctx, workerLeasesKeyPrefix+worker).Result()
+	if err != nil {
+		return lost, err
+	}
+	for _, taskId := range held {
+		if reported[taskId] {
+			continue
+		}
+		values, err := l.rdb.HMGet(ctx, leaseKeyPrefix+taskId, "worker", "assign_time").Result()
+		if err != nil {
+			return lost, err
+		}
+		if holder, _ := values[0].(string); holder != worker {
+			l.rdb.SRem(ctx, workerLeasesKeyPrefix+worker, taskId)
+			continue
+		}
+		if assignTime, _ := values[1].(string); parseInt64(assignTime) > now-int64(leaseAssignedGrace/time.Second) {
+			continue
+		}
+		l.rdb.ZAddXX(ctx, leaseIndexKey, redis.Z{Score: float64(now), Member: taskId})
+	}
+	return lost, nil
+}
+
+// Holder 返回任务租约的持有者，没有租约时exists为false，已重新入队等待分配时holder为空
+func (l *TaskLeases) Holder(ctx context.Context, taskId string) (holder string, exists bool, err error) {
+	holder, err = l.rdb.HGet(ctx, leaseKeyPrefix+taskId, "worker").Result()
+	if err == redis.Nil {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return holder, true, nil
+}
+
+// Get 返回任务的租约，没有租约时返回nil
+func (l *TaskLeases) Get(ctx context.Context, taskId string) (*Lease, error) {
+	fields, err := l.rdb.HGetAll(ctx, leaseKeyPrefix+taskId).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return leaseFromFields(taskId, fields), nil
+}
+
+// Release 任务结束时由持有者释放租约，返回值同 releaseScript
+func (l *TaskLeases) Release(ctx context.Context, taskId, worker string) (int, error) {
+	keys := []string{leaseIndexKey, leaseKeyPrefix + taskId, workerLeasesKeyPrefix + worker}
+	return releaseScript.Run(ctx, l.rdb, keys, taskId, worker).Int()
+}
+
+// Expired 返回已到期的租约
+func (l *TaskLeases) Expired(ctx context.Context, now time.Time, limit int64) ([]string, error) {
+	return l.rdb.ZRangeByScore(ctx, leaseIndexKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(now.Unix(), 10),
+		Count: limit,
+	}).Result()
+}
+
+// Reclaim 取走已到期的租约，租约已续约或已被其他实例取走时返回nil
+func (l *TaskLeases) Reclaim(ctx context.Context, taskId string, now time.Time) (*Lease, error) {
+	keys := []string{leaseIndexKey, leaseKeyPrefix + taskId}
+	values, err := reclaimScript.Run(ctx, l.rdb, keys, taskId, now.Unix()).StringSlice()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]string, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		fields[values[i]] = values[i+1]
+	}
+	lease := leaseFromFields(taskId, fields)
+	if lease.Worker != "" {
+		l.rdb.SRem(ctx, workerLeasesKeyPrefix+lease.Worker, taskId)
+	}
+	return lease, nil
+}
+
+// Requeue 将回收的任务重新放回队列，重试次数加一，保留租约记录以拒绝原Worker的迟到上报
+func (l *TaskLeases) Requeue(ctx context.Context, lease *Lease) error {
+	var task TaskInfo
+	if err := json.Unmarshal([]byte(lease.Task), &task); err != nil {
+		return fmt.Errorf("parse leased task: %v", err)
+	}
+	task.Retry = lease.Retry + 1
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+
+	// 与 PushTask 相同的优先级计算
+	score := float64(time.Now().Unix()) - float64(task.Priority*1000)
+	pipe := l.rdb.TxPipeline()
+	pipe.HSet(ctx, leaseKeyPrefix+lease.TaskId, "worker", "", "retry", task.Retry)
+	pipe.SRem(ctx, l.processingKey, lease.TaskId)
+	enqueue(ctx, pipe, &task, data, score)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// Drop 丢弃回收的任务，用于已停止或暂停的任务
+func (l *TaskLeases) Drop(ctx context.Context, lease *Lease) error {
+	pipe := l.rdb.TxPipeline()
+	pipe.Del(ctx, leaseKeyPrefix+lease.TaskId)
+	pipe.SRem(ctx, l.processingKey, lease.TaskId)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// DeadLetter 记录重试次数用尽的任务并删除租约
+func (l *TaskLeases) DeadLetter(ctx context.Context, lease *Lease, reason string) error {
+	data, err := json.Marshal(DeadLetter{
+		TaskId: lease.TaskId,
+		Worker: lease.Worker,
+		Retry:  lease.Retry,
+		Reason: reason,
+		Time:   time.Now().Unix(),
+	})
+	if err != nil {
+		return err
+	}
+	key := deadLetterKeyPrefix + ParentTaskId(lease.TaskId)
+	pipe := l.rdb.TxPipeline()
+	pipe.HSet(ctx, key, lease.TaskId, data)
+	pipe.Expire(ctx, key, deadLetterTTL)
+	pipe.Del(ctx, leaseKeyPrefix+lease.TaskId)
+	pipe.SRem(ctx, l.processingKey, lease.TaskId)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// IsDeadLetter 任务是否已进入死信
+func (l *TaskLeases) IsDeadLetter(ctx context.Context, taskId string) (bool, error) {
+	return l.rdb.HExists(ctx, deadLetterKeyPrefix+ParentTaskId(taskId), taskId).Result()
+}
+
+// List 返回主任务及其子任务当前持有的租约，按到期时间排序
+func (l *TaskLeases) List(ctx context.Context, taskId string) ([]Lease, error) {
+	taskIds, err := l.rdb.ZRange(ctx, leaseIndexKey, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	var matched []string
+	for _, id := range taskIds {
+		if id == taskId || strings.HasPrefix(id, taskId+"-") {
+			matched = append(matched, id)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, nil
+	}
+
+	pipe := l.rdb.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(matched))
+	for i, id := range matched {
+		cmds[i] = pipe.HGetAll(ctx, leaseKeyPrefix+id)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	leases := make([]Lease, 0, len(matched))
+	for i, id := range matched {
+		fields := cmds[i].Val()
+		if len(fields) == 0 {
+			continue
+		}
+		leases = append(leases, *leaseFromFields(id, fields))
+	}
+	return leases, nil
+}
+
+// ListDeadLetters 返回主任务的死信
+func (l *TaskLeases) ListDeadLetters(ctx context.Context, taskId string) ([]DeadLetter, error) {
+	values, err := l.rdb.HGetAll(ctx, deadLetterKeyPrefix+taskId).Result()
+	if err != nil {
+		return nil, err
+	}
+	letters := make([]DeadLetter, 0, len(values))
+	for _, v := range values {
+		var letter DeadLetter
+		if json.Unmarshal([]byte(v), &letter) == nil {
+			letters = append(letters, letter)
+		}
+	}
+	return letters, nil
+}
+
+func leaseFromFields(taskId string, fields map[string]string) *Lease {
+	retry, _ := strconv.Atoi(fields["retry"])
+	return &Lease{
+		TaskId:     taskId,
+		Worker:     fields["worker"],
+		Retry:      retry,
+		AssignTime: parseInt64(fields["assign_time"]),
+		RenewTime:  parseInt64(fields["renew_time"]),
+		ExpireTime: parseInt64(fields["expire_time"]),
+		Task:       fields["task"],
+	}
+}
+
+func parseInt64(s string) int64 {
+	n, _ := strconv.ParseInt(s, 10, 64)
+	return n
+}
+
+// ParentTaskId 从子任务ID中提取主任务ID，子任务格式: {mainTaskId}-{index}
+func ParentTaskId(taskId string) string {
+	lastDash := strings.LastIndex(taskId, "-")
+	if lastDash <= 0 || lastDash == len(taskId)-1 {
+		return taskId
+	}
+	for _, c := range taskId[lastDash+1:] {
+		if c < '0' || c > '9' {
+			return taskId
+		}
+	}
+	return taskId[:lastDash]
+}
@@ -0,0 +1,183 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// 共享队列，未指定Worker的任务，所有Worker都可以领取
+	sharedQueueKey = "cscan:task:queue"
+	// Worker专属队列，指定了Worker的任务放入每个指定Worker的队列，Worker名称不区分大小写
+	workerQueueKeyPrefix = "cscan:task:queue:worker:"
+	// 已创建的Worker专属队列
+	workerQueuesKey = "cscan:task:queues"
+
+	// claimPeekSize 每个队列每次预读的任务数，并发领取时预读的任务可能已被其他Worker取走
+	claimPeekSize = 16
+	// claimMaxPages 一次领取最多预读的页数，避免队列中大量不可领取的任务拖慢领取
+	claimMaxPages = 64
+)
+
+// migrateScript 将共享队列中指定了Worker的任务移到专属队列，任务已被取走时跳过
+var migrateScript = redis.NewScript(`
+if redis.call('ZREM', KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+for i = 2, #KEYS do
+	redis.call('ZADD', KEYS[i], ARGV[2], ARGV[1])
+	redis.call('SADD', ARGV[3], KEYS[i])
+end
+return 1
+`)
+
+// WorkerQueueKey Worker专属队列
+func WorkerQueueKey(worker string) string {
+	return workerQueueKeyPrefix + strings.ToLower(worker)
+}
+
+// QueueKeys 任务所在的队列：指定了Worker的任务在每个指定Worker的专属队列中，
+// 只指定了选择器的任务在选择器队列中，否则在共享队列中
+func QueueKeys(task *TaskInfo) []string {
+	if len(task.Workers) == 0 {
+		if !task.Selector.IsEmpty() {
+			return []string{SelectorQueueKey(task.Selector)}
+		}
+		return []string{sharedQueueKey}
+	}
+	keys := make([]string, 0, len(task.Workers))
+	seen := make(map[string]bool, len(task.Workers))
+	for _, w := range task.Workers {
+		key := WorkerQueueKey(w)
+		if w == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return QueueKeys(&TaskInfo{Selector: task.Selector})
+	}
+	return keys
+}
+
+// enqueue 将任务放入所在的全部队列
+func enqueue(ctx context.Context, pipe redis.Pipeliner, task *TaskInfo, data []byte, score float64) {
+	for _, key := range QueueKeys(task) {
+		pipe.ZAdd(ctx, key, redis.Z{Score: score, Member: data})
+		switch {
+		case strings.HasPrefix(key, workerQueueKeyPrefix):
+			pipe.SAdd(ctx, workerQueuesKey, key)
+		case strings.HasPrefix(key, selectorQueueKeyPrefix):
+			selector, _ := json.Marshal(task.Selector)
+			pipe.HSet(ctx, selectorQueuesKey, key, selector)
+		}
+	}
+}
+
+// queuedTask 预读的队列任务
+type queuedTask struct {
+	member string
+	score  float64
+	queue  string
+	task   TaskInfo
+}
+
+// claimQueues Worker领取任务的队列：Worker专属队列、共享队列和Worker满足的选择器队列
+func claimQueues(ctx context.Context, rdb *redis.Client, worker string, profile *WorkerProfile) ([]string, error) {
+	selectorQueues, err := matchedSelectorQueues(ctx, rdb, profile)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{WorkerQueueKey(worker), sharedQueueKey}, selectorQueues...), nil
+}
+
+// peekQueues 从每个队列的 offsets 处预读一页任务，按优先级排序；full 为读满一页、后面可能还有任务的队列
+func peekQueues(ctx context.Context, rdb *redis.Client, queues []string, offsets map[string]int64) ([]queuedTask, map[string]bool, error) {
+	pipe := rdb.Pipeline()
+	cmds := make([]*redis.ZSliceCmd, len(queues))
+	for i, key := range queues {
+		start := offsets[key]
+		cmds[i] = pipe.ZRangeWithScores(ctx, key, start, start+claimPeekSize-1)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, nil, err
+	}
+
+	var candidates []queuedTask
+	full := make(map[string]bool)
+	for i, cmd := range cmds {
+		if len(cmd.Val()) == claimPeekSize {
+			full[queues[i]] = true
+		}
+		for _, z := range cmd.Val() {
+			member, ok := z.Member.(string)
+			if !ok {
+				continue
+			}
+			c := queuedTask{member: member, score: z.Score, queue: queues[i]}
+			if err := json.Unmarshal([]byte(member), &c.task); err != nil {
+				continue
+			}
+			candidates = append(candidates, c)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].score < candidates[j].score })
+	return candidates, full, nil
+}
+
+// QueueLength 队列中的任务数，指定了多个Worker的任务按队列分别计数
+func QueueLength(ctx context.Context, rdb *redis.Client) (int64, error) {
+	keys, err := rdb.SMembers(ctx, workerQueuesKey).Result()
+	if err != nil {
+		return 0, err
+	}
+	selectorKeys, err := rdb.HKeys(ctx, selectorQueuesKey).Result()
+	if err != nil {
+		return 0, err
+	}
+	keys = append(append(keys, selectorKeys...), sharedQueueKey)
+	pipe := rdb.Pipeline()
+	cmds := make([]*redis.IntCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = pipe.ZCard(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, cmd := range cmds {
+		total += cmd.Val()
+	}
+	return total, nil
+}
+
+// MigrateQueue 将旧版本放入共享队列、指定了Worker的任务移到专属队列，返回移动的任务数
+func MigrateQueue(ctx context.Context, rdb *redis.Client) (int, error) {
+	members, err := rdb.ZRangeWithScores(ctx, sharedQueueKey, 0, -1).Result()
+	if err != nil {
+		return 0, err
+	}
+	moved := 0
+	for _, z := range members {
+		member, ok := z.Member.(string)
+		if !ok {
+			continue
+		}
+		var task TaskInfo
+		if err := json.Unmarshal([]byte(member), &task); err != nil || len(task.Workers) == 0 {
+			continue
+		}
+		keys := append([]string{sharedQueueKey}, QueueKeys(&task)...)
+		n, err := migrateScript.Run(ctx, rdb, keys, member, z.Score, workerQueuesKey).Int()
+		if err != nil {
+			return moved, err
+		}
+		moved += n
+	}
+	return moved, nil
+}
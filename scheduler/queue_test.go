@@ -0,0 +1,129 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 领取任务的基准测试，需要本地Redis：
+//
+//	CSCAN_BENCH_REDIS=localhost:6379 go test ./scheduler -run ^$ -bench Claim
+//
+// 测试使用 15 号库并会清空该库
+const (
+	benchQueueSize = 20000
+	benchWorkers   = 50
+)
+
+func benchRedis(b *testing.B) *redis.Client {
+	addr := os.Getenv("CSCAN_BENCH_REDIS")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+	rdb := redis.NewClient(&redis.Options{Addr: addr, DB: 15})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		b.Skipf("redis %s not available: %v", addr, err)
+	}
+	rdb.FlushDB(context.Background())
+	b.Cleanup(func() {
+		rdb.FlushDB(context.Background())
+		rdb.Close()
+	})
+	return rdb
+}
+
+// benchTask 每10个任务中有1个指定了Worker
+func benchTask(i int) *TaskInfo {
+	task := &TaskInfo{
+		TaskId:      fmt.Sprintf("bench-%d", i),
+		MainTaskId:  "bench",
+		WorkspaceId: "default",
+		TaskName:    "scan",
+		Config:      `{"target":"10.0.0.0/24","portscan":{"enable":true,"ports":"top1000"}}`,
+	}
+	if i%10 == 0 {
+		task.Workers = []string{fmt.Sprintf("worker-%d", i%benchWorkers), fmt.Sprintf("worker-%d", (i+1)%benchWorkers)}
+	}
+	return task
+}
+
+func fillQueue(push func(*TaskInfo)) {
+	for i := 0; i < benchQueueSize; i++ {
+		push(benchTask(i))
+	}
+}
+
+// BenchmarkClaimNext 专属队列 + 共享队列 + Lua原子领取
+func BenchmarkClaimNext(b *testing.B) {
+	rdb := benchRedis(b)
+	ctx := context.Background()
+	sched := NewScheduler(rdb)
+	leases := NewTaskLeases(rdb)
+	fillQueue(func(task *TaskInfo) { sched.PushTask(ctx, task) })
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		worker := fmt.Sprintf("worker-%d", i%benchWorkers)
+		task, err := leases.ClaimNext(ctx, worker, nil, time.Minute)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if task == nil {
+			b.Fatal("queue drained")
+		}
+		// 放回队列保持队列长度不变
+		b.StopTimer()
+		leases.Release(ctx, task.TaskId, worker)
+		task.Retry = 0
+		sched.PushTask(ctx, task)
+		b.StartTimer()
+	}
+}
+
+// BenchmarkClaimLegacyScan 旧实现：读取整个队列逐个解析查找可执行的任务
+func BenchmarkClaimLegacyScan(b *testing.B) {
+	rdb := benchRedis(b)
+	ctx := context.Background()
+	fillQueue(func(task *TaskInfo) {
+		data, _ := json.Marshal(task)
+		rdb.ZAdd(ctx, sharedQueueKey, redis.Z{Score: float64(time.Now().UnixNano()), Member: data})
+	})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		worker := fmt.Sprintf("worker-%d", i%benchWorkers)
+		member, task := legacyClaim(ctx, rdb, worker)
+		if task == nil {
+			b.Fatal("queue drained")
+		}
+		b.StopTimer()
+		rdb.ZAdd(ctx, sharedQueueKey, redis.Z{Score: float64(time.Now().UnixNano()), Member: member})
+		b.StartTimer()
+	}
+}
+
+func legacyClaim(ctx context.Context, rdb *redis.Client, worker string) (string, *TaskInfo) {
+	results, err := rdb.ZRange(ctx, sharedQueueKey, 0, -1).Result()
+	if err != nil {
+		return "", nil
+	}
+	for _, member := range results {
+		var task TaskInfo
+		if err := json.Unmarshal([]byte(member), &task); err != nil || !task.AllowWorker(worker) {
+			continue
+		}
+		if removed, err := rdb.ZRem(ctx, sharedQueueKey, member).Result(); err != nil || removed == 0 {
+			continue
+		}
+		return member, &task
+	}
+	return "", nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -32,6 +33,9 @@ type TaskInfo struct {
 	Priority    int      `json:"priority"`
 	CreateTime  string   `json:"createTime"`
 	Workers     []string `json:"workers,omitempty"` // 指定执行任务的 Worker 列表，为空表示任意 Worker
+	Retry       int      `json:"retry,omitempty"`   // 租约到期后重新入队的次数
+	// 对 Worker 标签和能力的要求，为空时由 PushTask 根据任务配置生成
+	Selector *WorkerSelector `json:"selector,omitempty"`
 }
 
 // Scheduler 任务调度器
@@ -91,6 +95,10 @@ func (s *Scheduler) PushTask(ctx context.Context, task *TaskInfo) error {
 		task.TaskId = uuid.New().String()
 	}
 	task.CreateTime = time.Now().Local().Format("2006-01-02 15:04:05")
+	// 指定了Worker的任务由指定的Worker执行，不再按选择器匹配
+	if task.Selector == nil && len(task.Workers) == 0 {
+		task.Selector = SelectorFromConfig(task.Config)
+	}
 
 	data, err := json.Marshal(task)
 	if err != nil {
@@ -99,10 +107,31 @@ func (s *Scheduler) PushTask(ctx context.Context, task *TaskInfo) error {
 
 	// 使用优先级队列，分数越小优先级越高
 	score := float64(time.Now().Unix()) - float64(task.Priority*1000)
-	return s.rdb.ZAdd(ctx, s.queueKey, redis.Z{
-		Score:  score,
-		Member: data,
-	}).Err()
+	pipe := s.rdb.TxPipeline()
+	enqueue(ctx, pipe, task, data, score)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// AllowWorker 任务是否可以由该 Worker 执行，Worker 名称不区分大小写
+func (t *TaskInfo) AllowWorker(worker string) bool {
+	if len(t.Workers) == 0 {
+		return true
+	}
+	for _, w := range t.Workers {
+		if strings.EqualFold(w, worker) {
+			return true
+		}
+	}
+	return false
+}
+
+// Claimable 任务是否可以由该 Worker 领取：指定了 Worker 的任务只按名称匹配，其他任务需满足选择器
+func (t *TaskInfo) Claimable(worker string, profile *WorkerProfile) bool {
+	if len(t.Workers) > 0 {
+		return t.AllowWorker(worker)
+	}
+	return t.Selector.Match(profile)
 }
 
 // PopTask 从队列获取任务
@@ -134,7 +163,7 @@ func (s *Scheduler) CompleteTask(ctx context.Context, taskId string) error {
 
 // GetQueueLength 获取队列长度
 func (s *Scheduler) GetQueueLength(ctx context.Context) (int64, error) {
-	return s.rdb.ZCard(ctx, s.queueKey).Result()
+	return QueueLength(ctx, s.rdb)
 }
 
 // GetProcessingCount 获取处理中任务数
@@ -148,7 +177,12 @@ type TaskConfig struct {
 	PortIdentify *PortIdentifyConfig `json:"portidentify,omitempty"` // 端口识别（Nmap服务识别）
 	DomainScan   *DomainScanConfig   `json:"domainscan,omitempty"`
 	Fingerprint  *FingerprintConfig  `json:"fingerprint,omitempty"`
+	Crawl        *CrawlConfig        `json:"crawl,omitempty"`      // Web爬虫
+	DirScan      *DirScanConfig      `json:"dirscan,omitempty"`    // 目录扫描
+	SecretScan   *SecretScanConfig   `json:"secretscan,omitempty"` // 敏感信息检测
 	PocScan      *PocScanConfig      `json:"pocscan,omitempty"`
+	// 执行任务的 Worker 需具备的标签和能力，扫描功能需要的能力会自动补充
+	WorkerSelector *WorkerSelector `json:"workerSelector,omitempty"`
 }
 
 type PortScanConfig struct {
@@ -160,6 +194,7 @@ type PortScanConfig struct {
 	PortThreshold     int    `json:"portThreshold"`     // 开放端口数量阈值，超过则过滤该主机
 	ScanType          string `json:"scanType"`          // s=SYN, c=CONNECT，默认 c
 	SkipHostDiscovery bool   `json:"skipHostDiscovery"` // 跳过主机发现 (-Pn)
+	ExcludeCdn        bool   `json:"excludeCdn"`        // 不扫描CDN/WAF节点
 }
 
 // PortIdentifyConfig 端口识别配置（Nmap服务识别）
@@ -183,6 +218,15 @@ type DomainScanConfig struct {
 	RemoveWildcard     bool     `json:"removeWildcard"`     // 移除泛解析域名
 	ResolveDNS         bool     `json:"resolveDNS"`         // 是否解析DNS
 	Concurrent         int      `json:"concurrent"`         // DNS解析并发数
+	CdnCheck           bool     `json:"cdnCheck"`           // 多解析器CDN/WAF检测
+	Resolvers          []string `json:"resolvers"`          // CDN检测和DNS记录收集使用的DNS服务器
+	DNSRecords         bool     `json:"dnsRecords"`         // 收集完整DNS记录，检查区域传送和SPF/DMARC
+	Bruteforce         bool     `json:"bruteforce"`         // 字典爆破子域名，泛解析结果自动过滤
+	BruteWordlistIds   []string `json:"bruteWordlistIds"`   // 子域名字典ID，多个字典合并去重，为空时使用内置字典
+	BruteRateLimit     int      `json:"bruteRateLimit"`     // 爆破每秒DNS查询数，默认200
+	Permutation        bool     `json:"permutation"`        // 对已发现的子域名生成变形再解析
+	Takeover           bool     `json:"takeover"`           // 子域名接管检测，结果保存为漏洞
+	Monitor            bool     `json:"monitor"`            // 监控模式：只将新增或解析变化的子域名传给端口扫描
 }
 
 type FingerprintConfig struct {
@@ -196,6 +240,53 @@ type FingerprintConfig struct {
 	Timeout       int    `json:"timeout"`       // 总超时时间(秒)，默认300秒
 	TargetTimeout int    `json:"targetTimeout"` // 单个目标超时时间(秒)，默认30秒
 	Concurrency   int    `json:"concurrency"`   // 指纹识别并发数，默认10
+	Jarm          bool   `json:"jarm"`          // 计算TLS服务端JARM指纹
+	// 内置截图参数，字段与 scanner.FingerprintOptions 对应
+	ScreenshotWidth    int    `json:"screenshotWidth,omitempty"`    // 视口宽度，默认1920
+	ScreenshotHeight   int    `json:"screenshotHeight,omitempty"`   // 视口高度，默认1080
+	ScreenshotFullPage bool   `json:"screenshotFullPage,omitempty"` // 截取整个页面，默认只截取首屏
+	ScreenshotFormat   string `json:"screenshotFormat,omitempty"`   // jpeg/webp/png，默认jpeg
+	ScreenshotQuality  int    `json:"screenshotQuality,omitempty"`  // jpeg/webp压缩质量(1-100)，默认80
+	ScreenshotTimeout  int    `json:"screenshotTimeout,omitempty"`  // 单个页面截图超时(秒)，默认30秒
+	ScreenshotTabs     int    `json:"screenshotTabs,omitempty"`     // Worker同时打开的标签页上限，默认4
+	ScreenshotRecycle  int    `json:"screenshotRecycle,omitempty"`  // 浏览器处理多少个页面后重启，默认200
+}
+
+// CrawlConfig Web爬虫配置，在指纹识别之后对HTTP资产进行爬取
+type CrawlConfig struct {
+	Enable        bool     `json:"enable"`
+	MaxDepth      int      `json:"maxDepth"`      // 最大爬取深度，默认2
+	MaxPages      int      `json:"maxPages"`      // 每个站点最多请求的页面数，默认200
+	Scope         string   `json:"scope"`         // 爬取范围: host(同主机端口，默认), domain(同主域名)
+	RateLimit     int      `json:"rateLimit"`     // 每个站点每秒请求数，默认10
+	Concurrency   int      `json:"concurrency"`   // 同时爬取的站点数，默认5
+	Headless      bool     `json:"headless"`      // 使用无头浏览器渲染页面，可发现XHR/fetch请求
+	Timeout       int      `json:"timeout"`       // 总超时时间(秒)，默认1800秒
+	TargetTimeout int      `json:"targetTimeout"` // 单个站点超时时间(秒)，默认300秒
+	ExcludeExt    []string `json:"excludeExt"`    // 额外排除的文件扩展名
+}
+
+// SecretScanConfig 敏感信息检测配置，检测资产的响应头、响应体以及爬虫发现的JS文件
+type SecretScanConfig struct {
+	Enable     bool     `json:"enable"`
+	Categories []string `json:"categories"` // 只使用指定分类的规则，为空时使用全部已启用规则
+	ScanJS     bool     `json:"scanJs"`     // 下载并检测爬虫发现的JS文件，需要启用爬虫
+	MaxJSFiles int      `json:"maxJsFiles"` // 最多检测的JS文件数，默认500
+	Timeout    int      `json:"timeout"`    // 总超时时间(秒)，默认600秒
+}
+
+// DirScanConfig 目录扫描配置，使用字典对HTTP资产进行目录和文件爆破
+type DirScanConfig struct {
+	Enable        bool     `json:"enable"`
+	WordlistIds   []string `json:"wordlistIds"`   // 使用的目录字典ID，多个字典合并去重
+	Extensions    []string `json:"extensions"`    // 替换字典中 %EXT% 占位符的扩展名，如 php,jsp
+	Concurrency   int      `json:"concurrency"`   // 同时扫描的站点数，默认5
+	Threads       int      `json:"threads"`       // 每个站点的并发请求数，默认10
+	RateLimit     int      `json:"rateLimit"`     // 每个站点每秒请求数，默认50
+	ExcludeStatus []int    `json:"excludeStatus"` // 排除的状态码，默认404
+	MaxResults    int      `json:"maxResults"`    // 单个站点结果数上限，超过时认为存在无法识别的泛解析页面并停止，默认200
+	Timeout       int      `json:"timeout"`       // 总超时时间(秒)，默认1800秒
+	TargetTimeout int      `json:"targetTimeout"` // 单个站点超时时间(秒)，默认600秒
 }
 
 type PocScanConfig struct {
@@ -217,6 +308,7 @@ type PocScanConfig struct {
 	NucleiTemplateIds []string            `json:"nucleiTemplateIds"` // Nuclei模板ID列表（新）
 	CustomPocIds      []string            `json:"customPocIds"`      // 自定义POC ID列表（新）
 	TagMappings       map[string][]string `json:"tagMappings"`       // 应用名称到Nuclei标签的映射
+	CrawlTargets      bool                `json:"crawlTargets"`      // 将爬虫发现的带参数URL和接口作为额外扫描目标
 }
 
 // ParseTaskConfig 解析任务配置
@@ -0,0 +1,291 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Worker能力，Worker启动时自动检测并在心跳中上报
+const (
+	CapNmap    = "nmap"    // 端口识别
+	CapMasscan = "masscan" // Masscan端口扫描
+	CapHttpx   = "httpx"   // httpx指纹识别
+	CapChrome  = "chrome"  // 截图和无头浏览器爬虫
+	CapSynScan = "synscan" // 具有原始套接字权限，可进行SYN扫描
+)
+
+const (
+	// 按选择器划分的队列，只指定了选择器的任务放在这里
+	selectorQueueKeyPrefix = "cscan:task:queue:selector:"
+	// 已创建的选择器队列，字段为队列名，值为选择器
+	selectorQueuesKey = "cscan:task:selectors"
+	// Worker心跳上报的标签和能力
+	workerProfileKeyPrefix = "cscan:worker:profile:"
+	workerProfileTTL       = 5 * time.Minute
+)
+
+// WorkerSelector 任务对Worker的要求，Worker需具备全部标签和能力
+type WorkerSelector struct {
+	Labels       map[string]string `json:"labels,omitempty"`       // 标签，如 region: cn、zone: dmz
+	Capabilities []string          `json:"capabilities,omitempty"` // 能力，如 masscan、chrome
+}
+
+// WorkerProfile Worker的标签和能力
+type WorkerProfile struct {
+	Labels       map[string]string `json:"labels,omitempty"`
+	Capabilities []string          `json:"capabilities,omitempty"`
+}
+
+// IsEmpty 选择器是否没有任何要求
+func (s *WorkerSelector) IsEmpty() bool {
+	return s == nil || (len(s.Labels) == 0 && len(s.Capabilities) == 0)
+}
+
+// Match Worker是否满足选择器，profile为nil表示Worker没有上报标签和能力
+func (s *WorkerSelector) Match(profile *WorkerProfile) bool {
+	if s.IsEmpty() {
+		return true
+	}
+	if profile == nil {
+		return false
+	}
+	for k, v := range s.Labels {
+		if actual, ok := profile.Labels[k]; !ok || actual != v {
+			return false
+		}
+	}
+	for _, c := range s.Capabilities {
+		if !profile.HasCapability(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasCapability Worker是否具备该能力，不区分大小写
+func (p *WorkerProfile) HasCapability(capability string) bool {
+	return containsFold(p.Capabilities, capability)
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// String 选择器的规范形式，标签和能力分别排序，用作队列名
+func (s *WorkerSelector) String() string {
+	parts := make([]string, 0, len(s.Labels)+len(s.Capabilities))
+	for k, v := range s.Labels {
+		parts = append(parts, k+"="+v)
+	}
+	sort.Strings(parts)
+	caps := make([]string, 0, len(s.Capabilities))
+	for _, c := range s.Capabilities {
+		caps = append(caps, "cap:"+strings.ToLower(c))
+	}
+	sort.Strings(caps)
+	return strings.Join(append(parts, caps...), ",")
+}
+
+// SelectorQueueKey 选择器队列
+func SelectorQueueKey(s *WorkerSelector) string {
+	return selectorQueueKeyPrefix + s.String()
+}
+
+// RequiredCapabilities 根据扫描配置推断任务需要的Worker能力
+func RequiredCapabilities(config *TaskConfig) []string {
+	var caps []string
+	add := func(c string) {
+		for _, existing := range caps {
+			if existing == c {
+				return
+			}
+		}
+		caps = append(caps, c)
+	}
+
+	if pc := config.PortScan; pc != nil && pc.Enable {
+		switch {
+		case pc.Tool == "masscan":
+			add(CapMasscan)
+			add(CapSynScan)
+		case pc.ScanType == "s":
+			add(CapSynScan)
+		}
+	}
+	if config.PortIdentify != nil && config.PortIdentify.Enable {
+		add(CapNmap)
+	}
+	if fc := config.Fingerprint; fc != nil && fc.Enable {
+		if fc.Tool == "httpx" || (fc.Tool == "" && fc.Httpx) {
+			add(CapHttpx)
+		}
+		if fc.Screenshot {
+			add(CapChrome)
+		}
+	}
+	if config.Crawl != nil && config.Crawl.Enable && config.Crawl.Headless {
+		add(CapChrome)
+	}
+	return caps
+}
+
+// SelectorFromConfig 从任务配置生成选择器：配置中的 workerSelector 加上扫描功能需要的能力
+func SelectorFromConfig(configStr string) *WorkerSelector {
+	config, err := ParseTaskConfig(configStr)
+	if err != nil {
+		return nil
+	}
+	selector := &WorkerSelector{}
+	if config.WorkerSelector != nil {
+		selector.Labels = config.WorkerSelector.Labels
+		selector.Capabilities = append(selector.Capabilities, config.WorkerSelector.Capabilities...)
+	}
+	for _, c := range RequiredCapabilities(config) {
+		if !containsFold(selector.Capabilities, c) {
+			selector.Capabilities = append(selector.Capabilities, c)
+		}
+	}
+	if selector.IsEmpty() {
+		return nil
+	}
+	return selector
+}
+
+// SaveWorkerProfile 保存Worker心跳上报的标签和能力
+func SaveWorkerProfile(ctx context.Context, rdb *redis.Client, worker string, profile *WorkerProfile) error {
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return err
+	}
+	return rdb.Set(ctx, workerProfileKeyPrefix+worker, data, workerProfileTTL).Err()
+}
+
+// LoadWorkerProfile 读取Worker的标签和能力，未上报时返回nil
+func LoadWorkerProfile(ctx context.Context, rdb *redis.Client, worker string) (*WorkerProfile, error) {
+	data, err := rdb.Get(ctx, workerProfileKeyPrefix+worker).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var profile WorkerProfile
+	if err := json.Unmarshal([]byte(data), &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// matchedSelectorQueues Worker满足的选择器队列
+func matchedSelectorQueues(ctx context.Context, rdb *redis.Client, profile *WorkerProfile) ([]string, error) {
+	if profile == nil {
+		return nil, nil
+	}
+	selectors, err := rdb.HGetAll(ctx, selectorQueuesKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	var queues []string
+	for key, data := range selectors {
+		var s WorkerSelector
+		if json.Unmarshal([]byte(data), &s) != nil {
+			continue
+		}
+		if s.Match(profile) {
+			queues = append(queues, key)
+		}
+	}
+	sort.Strings(queues)
+	return queues, nil
+}
+
+// QueueStateUnschedulable 排队中但没有满足要求的Worker，无法被领取
+const QueueStateUnschedulable = "unschedulable"
+
+// UnschedulableTask 排队中但没有满足选择器的在线Worker的任务
+type UnschedulableTask struct {
+	TaskId   string `json:"taskId"`
+	Selector string `json:"selector"`
+}
+
+// Unschedulable 返回主任务中没有满足选择器的在线Worker、无法被领取的排队任务，
+// 在线Worker以心跳上报的标签和能力为准
+func Unschedulable(ctx context.Context, rdb *redis.Client, taskId string) ([]UnschedulableTask, error) {
+	selectors, err := rdb.HGetAll(ctx, selectorQueuesKey).Result()
+	if err != nil || len(selectors) == 0 {
+		return nil, err
+	}
+	profiles, err := onlineWorkerProfiles(ctx, rdb)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []UnschedulableTask
+	for key, data := range selectors {
+		var s WorkerSelector
+		if json.Unmarshal([]byte(data), &s) != nil || matchAny(&s, profiles) {
+			continue
+		}
+		members, err := rdb.ZRange(ctx, key, 0, -1).Result()
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			var task TaskInfo
+			if json.Unmarshal([]byte(member), &task) != nil {
+				continue
+			}
+			if task.TaskId == taskId || strings.HasPrefix(task.TaskId, taskId+"-") {
+				tasks = append(tasks, UnschedulableTask{TaskId: task.TaskId, Selector: s.String()})
+			}
+		}
+	}
+	return tasks, nil
+}
+
+// onlineWorkerProfiles 心跳未过期的Worker上报的标签和能力
+func onlineWorkerProfiles(ctx context.Context, rdb *redis.Client) ([]*WorkerProfile, error) {
+	var keys []string
+	iter := rdb.Scan(ctx, 0, workerProfileKeyPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil || len(keys) == 0 {
+		return nil, err
+	}
+	values, err := rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	profiles := make([]*WorkerProfile, 0, len(values))
+	for _, v := range values {
+		data, ok := v.(string)
+		if !ok {
+			continue
+		}
+		var profile WorkerProfile
+		if json.Unmarshal([]byte(data), &profile) == nil {
+			profiles = append(profiles, &profile)
+		}
+	}
+	return profiles, nil
+}
+
+func matchAny(s *WorkerSelector, profiles []*WorkerProfile) bool {
+	for _, p := range profiles {
+		if s.Match(p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"cscan/pkg/rpcauth"
+	"cscan/rpc/task/pb"
+
+	"github.com/zeromicro/go-zero/zrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultCredentialFile 默认的凭证保存路径
+const DefaultCredentialFile = "worker.cred"
+
+// credentialFile 本地保存的Worker凭证，由注册令牌换取
+type credentialFile struct {
+	WorkerId   string `json:"workerId"`
+	WorkerName string `json:"workerName"`
+	Credential string `json:"credential"`
+	Server     string `json:"server"`
+	EnrollTime string `json:"enrollTime"`
+}
+
+func loadCredentialFile(path string) (*credentialFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var cred credentialFile
+	if err := json.Unmarshal(content, &cred); err != nil {
+		return nil, fmt.Errorf("parse credential file: %v", err)
+	}
+	if cred.Credential == "" {
+		return nil, fmt.Errorf("credential file %s has no credential", path)
+	}
+	return &cred, nil
+}
+
+// saveCredentialFile 凭证只允许当前用户读写，先写临时文件再重命名避免写入一半
+func saveCredentialFile(path string, cred *credentialFile) error {
+	content, err := json.MarshalIndent(cred, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return err
+		}
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// renameCredentialFile Worker被重命名后更新本地凭证中的名称，重启后沿用新名称
+func renameCredentialFile(path, name string) error {
+	if path == "" {
+		path = DefaultCredentialFile
+	}
+	cred, err := loadCredentialFile(path)
+	if err != nil || cred == nil {
+		return err
+	}
+	cred.WorkerName = name
+	return saveCredentialFile(path, cred)
+}
+
+// rpcClientOptions 任务服务连接的TLS和调用凭证，凭证在注册完成后设置
+func rpcClientOptions(config WorkerConfig) ([]zrpc.ClientOption, *rpcauth.TokenCredentials, error) {
+	var opts []zrpc.ClientOption
+	if config.UseTLS {
+		creds, err := rpcauth.ClientCredentials(config.TLS)
+		if err != nil {
+			return nil, nil, err
+		}
+		opts = append(opts, zrpc.WithTransportCredentials(creds))
+	}
+	tokenCreds := rpcauth.NewTokenCredentials("", config.UseTLS)
+	opts = append(opts, zrpc.WithDialOption(grpc.WithPerRPCCredentials(tokenCreds)))
+	return opts, tokenCreds, nil
+}
+
+// setupCredential 加载本地凭证，没有凭证或凭证已失效时使用注册令牌重新注册
+func setupCredential(ctx context.Context, client pb.TaskServiceClient, tokenCreds *rpcauth.TokenCredentials, config *WorkerConfig) error {
+	path := config.CredentialFile
+	if path == "" {
+		path = DefaultCredentialFile
+	}
+
+	cred, err := loadCredentialFile(path)
+	if err != nil {
+		return err
+	}
+	if cred != nil {
+		tokenCreds.SetToken(cred.Credential)
+		if cred.WorkerName != "" && cred.WorkerName != config.Name {
+			fmt.Printf("[Worker] Using enrolled name '%s' from %s\n", cred.WorkerName, path)
+			config.Name = cred.WorkerName
+		}
+		config.Enrolled = true
+		return nil
+	}
+
+	if config.EnrollToken == "" {
+		fmt.Println("[Worker] No credential found and no enroll token specified (-enroll flag), connecting without credential")
+		return nil
+	}
+	return enroll(ctx, client, tokenCreds, config, path)
+}
+
+// enroll 使用注册令牌换取凭证并保存到本地
+func enroll(ctx context.Context, client pb.TaskServiceClient, tokenCreds *rpcauth.TokenCredentials, config *WorkerConfig, path string) error {
+	if !config.UseTLS {
+		fmt.Println("[Worker] WARNING: enrolling without TLS, the credential is sent in plain text")
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	resp, err := client.EnrollWorker(reqCtx, &pb.EnrollWorkerReq{
+		Token:      config.EnrollToken,
+		WorkerName: config.Name,
+		Ip:         config.IP,
+	})
+	if err != nil {
+		return fmt.Errorf("enroll worker failed: %v", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("enroll worker failed: %s", resp.Message)
+	}
+
+	cred := &credentialFile{
+		WorkerId:   resp.WorkerId,
+		WorkerName: resp.WorkerName,
+		Credential: resp.Credential,
+		Server:     config.ServerAddr,
+		EnrollTime: time.Now().Format("2006-01-02 15:04:05"),
+	}
+	if err := saveCredentialFile(path, cred); err != nil {
+		return fmt.Errorf("save credential file failed: %v", err)
+	}
+	tokenCreds.SetToken(cred.Credential)
+	config.Name = cred.WorkerName
+	config.Enrolled = true
+	fmt.Printf("[Worker] Enrolled as '%s', credential saved to %s\n", cred.WorkerName, path)
+	return nil
+}
+
+// workerOnline 向任务服务确认凭证有效并获取最终使用的名称，凭证失效且有注册令牌时重新注册
+func workerOnline(ctx context.Context, client pb.TaskServiceClient, tokenCreds *rpcauth.TokenCredentials, config *WorkerConfig) error {
+	call := func() (*pb.WorkerOnlineResp, error) {
+		reqCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		return client.WorkerOnline(reqCtx, &pb.WorkerOnlineReq{WorkerName: config.Name, Ip: config.IP})
+	}
+
+	resp, err := call()
+	if status.Code(err) == codes.Unauthenticated && config.EnrollToken != "" {
+		fmt.Printf("[Worker] Credential rejected (%v), enrolling again\n", status.Convert(err).Message())
+		path := config.CredentialFile
+		if path == "" {
+			path = DefaultCredentialFile
+		}
+		if err := enroll(ctx, client, tokenCreds, config, path); err != nil {
+			return err
+		}
+		resp, err = call()
+	}
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			return fmt.Errorf("worker credential rejected by server: %s", status.Convert(err).Message())
+		}
+		// 任务服务暂时不可用时继续启动，使用本地配置的名称
+		fmt.Printf("[Worker] Worker online check failed: %v, using name '%s'\n", err, config.Name)
+		return nil
+	}
+	if !resp.Success {
+		return fmt.Errorf("worker online failed: %s", resp.Message)
+	}
+	if resp.WorkerName != "" && resp.WorkerName != config.Name {
+		fmt.Printf("[Worker] Using name '%s' assigned by server (was '%s')\n", resp.WorkerName, config.Name)
+		config.Name = resp.WorkerName
+	}
+	return nil
+}
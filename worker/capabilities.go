@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"cscan/scanner"
+	"cscan/scheduler"
+)
+
+// ParseLabels 解析命令行标签，格式为 key=value,key2=value2
+func ParseLabels(s string) (map[string]string, error) {
+	labels := make(map[string]string)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(item, "=")
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid label %q, expected key=value", item)
+		}
+		labels[k] = v
+	}
+	return labels, nil
+}
+
+// detectCapabilities 检测本机具备的扫描能力，心跳时上报给任务服务用于任务路由
+func detectCapabilities() []string {
+	var caps []string
+	if scanner.CheckNmapInstalled() {
+		caps = append(caps, scheduler.CapNmap)
+	}
+	if scanner.CheckMasscanInstalled() {
+		caps = append(caps, scheduler.CapMasscan)
+	}
+	if scanner.CheckHttpxInstalled() {
+		caps = append(caps, scheduler.CapHttpx)
+	}
+	if scanner.CheckChromeInstalled() {
+		caps = append(caps, scheduler.CapChrome)
+	}
+	// SYN扫描需要原始套接字，Linux/macOS下以root运行
+	if runtime.GOOS != "windows" && os.Geteuid() == 0 {
+		caps = append(caps, scheduler.CapSynScan)
+	}
+	return caps
+}
@@ -0,0 +1,46 @@
+package worker
+
+import "time"
+
+// lostTaskStopTTL 租约丢失的任务在本地按停止处理的时间，覆盖任务剩余的执行时间
+const lostTaskStopTTL = time.Hour
+
+// holdTask 记录已领取的任务，心跳时上报给任务服务续约
+func (w *Worker) holdTask(taskId string) {
+	w.mu.Lock()
+	w.heldTasks[taskId] = struct{}{}
+	w.mu.Unlock()
+}
+
+// unholdTask 任务结束或被跳过后不再续约
+func (w *Worker) unholdTask(taskId string) {
+	w.mu.Lock()
+	delete(w.heldTasks, taskId)
+	w.mu.Unlock()
+}
+
+// heldTaskIds 已领取未结束的任务
+func (w *Worker) heldTaskIds() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	taskIds := make([]string, 0, len(w.heldTasks))
+	for taskId := range w.heldTasks {
+		taskIds = append(taskIds, taskId)
+	}
+	return taskIds
+}
+
+// abandonTasks 停止租约已被回收的任务：任务已重新入队，继续执行只会产生重复结果
+func (w *Worker) abandonTasks(taskIds []string) {
+	expire := time.Now().Add(lostTaskStopTTL)
+	w.ctrlMu.Lock()
+	for _, taskId := range taskIds {
+		w.ctrlCache[taskId] = taskControlEntry{ctrl: "STOP", expire: expire}
+	}
+	w.ctrlMu.Unlock()
+
+	for _, taskId := range taskIds {
+		w.taskLog(taskId, LevelWarn, "Task %s lease was reclaimed by server, stopping", taskId)
+		w.unholdTask(taskId)
+	}
+}
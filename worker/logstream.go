@@ -0,0 +1,128 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"cscan/rpc/task/pb"
+)
+
+const (
+	// logBufferSize 待上报日志的缓冲条数，任务服务不可用时超出部分直接丢弃（控制台仍有输出）
+	logBufferSize = 2000
+	// logRetryInterval 日志流断开后的重连间隔
+	logRetryInterval = 5 * time.Second
+	// logFlushTimeout 关闭时上报剩余日志的最长等待时间
+	logFlushTimeout = 3 * time.Second
+)
+
+// LogStreamer 通过任务服务的日志流上报日志，断开后自动重连
+type LogStreamer struct {
+	client    pb.TaskServiceClient
+	entries   chan *pb.WorkerLogEntry
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewLogStreamer 创建日志上报器并开始上报
+func NewLogStreamer(client pb.TaskServiceClient) *LogStreamer {
+	s := &LogStreamer{
+		client:  client,
+		entries: make(chan *pb.WorkerLogEntry, logBufferSize),
+		done:    make(chan struct{}),
+	}
+	s.wg.Add(1)
+	go s.run()
+	return s
+}
+
+// Push 加入待上报队列，不阻塞调用方
+func (s *LogStreamer) Push(entry *pb.WorkerLogEntry) {
+	select {
+	case <-s.done:
+		return
+	default:
+	}
+	select {
+	case s.entries <- entry:
+	default:
+	}
+}
+
+// Close 停止上报，尽量发送完已缓冲的日志
+func (s *LogStreamer) Close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+	finished := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-time.After(logFlushTimeout + time.Second):
+	}
+}
+
+func (s *LogStreamer) run() {
+	defer s.wg.Done()
+	lastErr := ""
+	for {
+		if err := s.stream(); err != nil && err.Error() != lastErr {
+			// 直接写控制台，避免日志上报失败的日志再次进入上报队列；相同错误只输出一次
+			lastErr = err.Error()
+			fmt.Printf("[Worker] Log stream disconnected: %v, retrying every %v\n", err, logRetryInterval)
+		}
+		select {
+		case <-s.done:
+			return
+		case <-time.After(logRetryInterval):
+		}
+	}
+}
+
+// stream 建立一条日志流并持续发送，出错或关闭时返回
+func (s *LogStreamer) stream() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := s.client.PushWorkerLogs(ctx)
+	if err != nil {
+		return err
+	}
+	for {
+		select {
+		case entry := <-s.entries:
+			if err := stream.Send(entry); err != nil {
+				_, err = stream.CloseAndRecv()
+				return err
+			}
+		case <-s.done:
+			return s.flush(ctx, stream, cancel)
+		}
+	}
+}
+
+// flush 发送剩余日志后关闭日志流，超时后放弃
+func (s *LogStreamer) flush(ctx context.Context, stream pb.TaskService_PushWorkerLogsClient, cancel context.CancelFunc) error {
+	timer := time.AfterFunc(logFlushTimeout, cancel)
+	defer timer.Stop()
+	for {
+		select {
+		case entry := <-s.entries:
+			if err := stream.Send(entry); err != nil {
+				return nil
+			}
+		default:
+			_, err := stream.CloseAndRecv()
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package worker
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,7 +8,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/redis/go-redis/v9"
+	"cscan/rpc/task/pb"
 )
 
 // 日志级别常量
@@ -20,25 +19,6 @@ const (
 	LevelError = "ERROR"
 )
 
-// Redis Key 常量
-const (
-	WorkerLogStream     = "cscan:worker:logs"
-	WorkerLogChannel    = "cscan:worker:logs:realtime"
-	TaskLogStreamPrefix = "cscan:task:logs:"
-	TaskLogChannelPrefix = "cscan:task:logs:realtime:"
-	DefaultMaxLogLen    = 10000
-	TaskMaxLogLen       = 5000
-)
-
-// LogEntry 日志条目（统一结构）
-type LogEntry struct {
-	Timestamp  string `json:"timestamp"`
-	Level      string `json:"level"`
-	WorkerName string `json:"workerName"`
-	TaskId     string `json:"taskId,omitempty"`
-	Message    string `json:"message"`
-}
-
 // Logger 统一日志接口
 type Logger interface {
 	Debug(format string, args ...interface{})
@@ -49,65 +29,39 @@ type Logger interface {
 
 // LogPublisher 日志发布器（核心组件）
 type LogPublisher struct {
-	client     *redis.Client
+	streamer   *LogStreamer
 	workerName string
 }
 
 // NewLogPublisher 创建日志发布器
-func NewLogPublisher(client *redis.Client, workerName string) *LogPublisher {
+func NewLogPublisher(streamer *LogStreamer, workerName string) *LogPublisher {
 	return &LogPublisher{
-		client:     client,
+		streamer:   streamer,
 		workerName: workerName,
 	}
 }
 
-// publish 发布日志到 Redis（内部方法）
+// publish 通过任务服务发布日志（内部方法）
 func (p *LogPublisher) publish(taskId, level, message string) {
-	if p.client == nil {
-		// Redis连接失败时，至少输出到标准输出，确保日志不丢失
-		fmt.Printf("[%s] [%s] [%s] %s: %s\n", 
-			time.Now().Local().Format("2006-01-02 15:04:05"), 
-			level, 
-			p.workerName, 
-			taskId, 
+	if p.streamer == nil {
+		// 没有日志通道时，至少输出到标准输出，确保日志不丢失
+		fmt.Printf("[%s] [%s] [%s] %s: %s\n",
+			time.Now().Local().Format("2006-01-02 15:04:05"),
+			level,
+			p.workerName,
+			taskId,
 			message)
 		return
 	}
 
-	entry := LogEntry{
+	// 服务端写入全局日志流，有 taskId 时同时写入任务专属日志
+	p.streamer.Push(&pb.WorkerLogEntry{
 		Timestamp:  time.Now().Local().Format("2006-01-02 15:04:05"),
 		Level:      level,
 		WorkerName: p.workerName,
 		TaskId:     taskId,
 		Message:    message,
-	}
-
-	data, _ := json.Marshal(entry)
-	ctx := context.Background()
-
-	// 1. 发布到全局 Pub/Sub（Worker 日志实时推送）
-	p.client.Publish(ctx, WorkerLogChannel, string(data))
-
-	// 2. 保存到全局 Stream（Worker 日志历史查询）
-	p.client.XAdd(ctx, &redis.XAddArgs{
-		Stream: WorkerLogStream,
-		MaxLen: DefaultMaxLogLen,
-		Approx: true,
-		Values: map[string]interface{}{"data": string(data)},
 	})
-
-	// 3. 如果有 taskId，同时写入任务专属日志
-	if taskId != "" {
-		// 任务专属 Stream
-		p.client.XAdd(ctx, &redis.XAddArgs{
-			Stream: TaskLogStreamPrefix + taskId,
-			MaxLen: TaskMaxLogLen,
-			Approx: true,
-			Values: map[string]interface{}{"data": string(data)},
-		})
-		// 任务专属 Pub/Sub
-		p.client.Publish(ctx, TaskLogChannelPrefix+taskId, string(data))
-	}
 }
 
 // PublishWorkerLog 发布 Worker 级别日志
@@ -126,22 +80,22 @@ type WorkerLogger struct {
 }
 
 // NewWorkerLogger 创建 Worker 日志记录器
-func NewWorkerLogger(client *redis.Client, workerName string) *WorkerLogger {
+func NewWorkerLogger(streamer *LogStreamer, workerName string) *WorkerLogger {
 	return &WorkerLogger{
-		publisher: NewLogPublisher(client, workerName),
+		publisher: NewLogPublisher(streamer, workerName),
 	}
 }
 
-// log 内部日志方法，同时输出到控制台和 Redis
-// 注意：直接写控制台，不通过 logx，避免被 RedisLogWriter 重复拦截
+// log 内部日志方法，同时输出到控制台和任务服务
+// 注意：直接写控制台，不通过 logx，避免被 StreamLogWriter 重复拦截
 func (l *WorkerLogger) log(level, format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
 	timestamp := time.Now().Local().Format("2006-01-02 15:04:05")
-	
+
 	// 直接输出到控制台（不通过 logx，避免重复）
 	fmt.Printf("%s [%s] %s\n", timestamp, level, msg)
-	
-	// 发布到 Redis
+
+	// 发布到任务服务
 	if l.publisher != nil {
 		l.publisher.PublishWorkerLog(level, msg)
 	}
@@ -170,23 +124,23 @@ type TaskLogger struct {
 }
 
 // NewTaskLogger 创建任务日志记录器
-func NewTaskLogger(client *redis.Client, workerName, taskId string) *TaskLogger {
+func NewTaskLogger(streamer *LogStreamer, workerName, taskId string) *TaskLogger {
 	return &TaskLogger{
-		publisher: NewLogPublisher(client, workerName),
+		publisher: NewLogPublisher(streamer, workerName),
 		taskId:    taskId,
 	}
 }
 
-// log 内部日志方法，同时输出到控制台和 Redis
-// 注意：直接写控制台，不通过 logx，避免被 RedisLogWriter 重复拦截
+// log 内部日志方法，同时输出到控制台和任务服务
+// 注意：直接写控制台，不通过 logx，避免被 StreamLogWriter 重复拦截
 func (l *TaskLogger) log(level, format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
 	timestamp := time.Now().Local().Format("2006-01-02 15:04:05")
-	
+
 	// 直接输出到控制台（不通过 logx，避免重复）
 	fmt.Printf("%s [%s] [Task:%s] %s\n", timestamp, level, l.taskId, msg)
-	
-	// 发布到 Redis（包含 taskId，会同时写入全局和任务专属日志）
+
+	// 发布到任务服务（包含 taskId，会同时写入全局和任务专属日志）
 	if l.publisher != nil {
 		l.publisher.PublishTaskLog(l.taskId, level, msg)
 	}
@@ -208,17 +162,17 @@ func (l *TaskLogger) Error(format string, args ...interface{}) {
 	l.log(LevelError, format, args...)
 }
 
-// RedisLogWriter 将 logx 日志写入 Redis 的 Writer
-// 用于拦截 logx 的输出，同时写入控制台和 Redis
-type RedisLogWriter struct {
+// StreamLogWriter 将 logx 日志通过任务服务上报的 Writer
+// 用于拦截 logx 的输出，同时写入控制台和任务服务
+type StreamLogWriter struct {
 	publisher *LogPublisher
 	stdout    io.Writer
 }
 
-// NewRedisLogWriter 创建 Redis 日志写入器
-func NewRedisLogWriter(client *redis.Client, workerName string) *RedisLogWriter {
-	return &RedisLogWriter{
-		publisher: NewLogPublisher(client, workerName),
+// NewStreamLogWriter 创建日志上报写入器
+func NewStreamLogWriter(streamer *LogStreamer, workerName string) *StreamLogWriter {
+	return &StreamLogWriter{
+		publisher: NewLogPublisher(streamer, workerName),
 		stdout:    os.Stdout,
 	}
 }
@@ -231,11 +185,11 @@ type logxEntry struct {
 }
 
 // Write 实现 io.Writer 接口
-func (w *RedisLogWriter) Write(p []byte) (n int, err error) {
+func (w *StreamLogWriter) Write(p []byte) (n int, err error) {
 	// 先写入控制台
 	w.stdout.Write(p)
 
-	if w.publisher == nil || w.publisher.client == nil {
+	if w.publisher == nil || w.publisher.streamer == nil {
 		return len(p), nil
 	}
 
@@ -274,26 +228,12 @@ func (w *RedisLogWriter) Write(p []byte) (n int, err error) {
 		message = msg
 	}
 
-	// 构建日志条目并发布
-	entry := LogEntry{
+	// 只写入全局日志流
+	w.publisher.streamer.Push(&pb.WorkerLogEntry{
 		Timestamp:  timestamp,
 		Level:      level,
 		WorkerName: w.publisher.workerName,
 		Message:    message,
-	}
-
-	data, _ := json.Marshal(entry)
-	ctx := context.Background()
-
-	// 发布到 Pub/Sub
-	w.publisher.client.Publish(ctx, WorkerLogChannel, string(data))
-
-	// 保存到 Stream
-	w.publisher.client.XAdd(ctx, &redis.XAddArgs{
-		Stream: WorkerLogStream,
-		MaxLen: DefaultMaxLogLen,
-		Approx: true,
-		Values: map[string]interface{}{"data": string(data)},
 	})
 
 	return len(p), nil
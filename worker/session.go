@@ -0,0 +1,247 @@
+package worker
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"cscan/rpc/task/pb"
+	"cscan/scanner"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+const (
+	// sessionRetryInterval 会话断开后的重连间隔
+	sessionRetryInterval = 5 * time.Second
+	// sessionCloseTimeout 停止时等待离线状态送达的最长时间
+	sessionCloseTimeout = 2 * time.Second
+)
+
+// runSession 与任务服务保持会话：上报状态，接收控制命令和状态查询，断开后自动重连
+func (w *Worker) runSession() {
+	defer w.wg.Done()
+
+	for {
+		if err := w.serveSession(); err != nil {
+			select {
+			case <-w.stopChan:
+				return
+			default:
+			}
+			w.logger.Warn("Worker session disconnected: %v, reconnecting in %v", err, sessionRetryInterval)
+		}
+		select {
+		case <-w.stopChan:
+			return
+		case <-time.After(sessionRetryInterval):
+		}
+	}
+}
+
+// serveSession 建立一次会话并处理服务端下发的命令，会话结束时返回
+func (w *Worker) serveSession() error {
+	// 不使用 w.ctx，停止时需要先发送离线状态再断开
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-w.stopChan:
+		case <-ctx.Done():
+			return
+		}
+		select {
+		case <-time.After(sessionCloseTimeout):
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	stream, err := w.rpcClient.WorkerSession(ctx)
+	if err != nil {
+		return err
+	}
+	w.sessionMu.Lock()
+	w.session = stream
+	w.sessionMu.Unlock()
+	defer func() {
+		w.sessionMu.Lock()
+		if w.session == stream {
+			w.session = nil
+		}
+		w.sessionMu.Unlock()
+	}()
+
+	w.logger.Info("Worker %s connected to task service session", w.config.Name)
+	// 连接后立即上报一次状态
+	w.reportStatus()
+
+	for {
+		cmd, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		w.handleControlCommand(cmd)
+	}
+}
+
+// closeSession 上报离线状态并结束会话，让 Worker 立即从列表中消失
+func (w *Worker) closeSession() {
+	w.sessionMu.Lock()
+	defer w.sessionMu.Unlock()
+	if w.session == nil {
+		return
+	}
+	w.session.Send(&pb.WorkerStatusReport{
+		WorkerName: w.config.Name,
+		Offline:    true,
+	})
+	w.session.CloseSend()
+	w.session = nil
+}
+
+// reportStatus 立即通过会话上报状态
+func (w *Worker) reportStatus() {
+	// 快速获取CPU使用率
+	cpuPercent, _ := cpu.Percent(0, false)
+	memInfo, _ := mem.VirtualMemory()
+
+	cpuLoad := 0.0
+	if len(cpuPercent) > 0 {
+		cpuLoad = cpuPercent[0]
+	}
+	memUsed := 0.0
+	if memInfo != nil {
+		memUsed = memInfo.UsedPercent
+	}
+
+	// 确保数值
+	if cpuLoad < 0 || cpuLoad > 100 {
+		cpuLoad = 0.0
+	}
+	if memUsed < 0 || memUsed > 100 {
+		memUsed = 0.0
+	}
+
+	w.mu.Lock()
+	taskStarted := w.taskStarted
+	taskExecuted := w.taskExecuted
+	isThrottled := w.isThrottled
+	cpuOverloadCount := w.cpuOverloadCount
+	w.mu.Unlock()
+
+	// 计算健康状态
+	healthStatus := "healthy"
+	if isThrottled {
+		healthStatus = "throttled"
+	} else if cpuLoad >= CPULoadThreshold {
+		healthStatus = "overloaded"
+	} else if cpuLoad >= CPULoadRecovery {
+		healthStatus = "warning"
+	}
+
+	report := &pb.WorkerStatusReport{
+		WorkerName:         w.config.Name,
+		Ip:                 w.config.IP,
+		CpuLoad:            cpuLoad,
+		MemUsed:            memUsed,
+		TaskStartedNumber:  int32(taskStarted),
+		TaskExecutedNumber: int32(taskExecuted),
+		HealthStatus:       healthStatus,
+		IsThrottled:        isThrottled,
+		CpuOverloadCount:   int32(cpuOverloadCount),
+		Concurrency:        int32(w.config.Concurrency),
+		RunningTasks:       int32(len(w.taskChan)),
+		// 工具安装状态
+		Tools: map[string]bool{
+			"nmap":    scanner.CheckNmapInstalled(),
+			"masscan": scanner.CheckMasscanInstalled(),
+		},
+		Labels:       w.config.Labels,
+		Capabilities: w.capabilities,
+	}
+
+	w.sessionMu.Lock()
+	defer w.sessionMu.Unlock()
+	if w.session == nil {
+		return
+	}
+	if err := w.session.Send(report); err != nil {
+		w.logger.Debug("report status failed: %v", err)
+	}
+}
+
+// handleControlCommand 处理服务端下发的控制命令
+func (w *Worker) handleControlCommand(cmd *pb.WorkerCommand) {
+	// 检查是否是发给当前Worker的命令
+	if cmd.WorkerName != "" && cmd.WorkerName != w.config.Name {
+		return
+	}
+
+	switch cmd.Action {
+	case "query":
+		w.reportStatus()
+	case "stop":
+		w.logger.Info("Received stop command, shutting down worker %s", w.config.Name)
+		// 触发停止
+		go func() {
+			w.Stop()
+			// 退出进程
+			os.Exit(0)
+		}()
+	case "restart":
+		w.logger.Info("Received restart command, restarting worker %s", w.config.Name)
+		go func() {
+			// 快速停止当前 Worker（跳过当前任务，不等待完成）
+			w.logger.Info("Stopping current worker immediately (skipping current tasks)...")
+			w.StopImmediate()
+			w.logger.Info("Worker stopped, restarting in same process...")
+
+			// 在同一进程内重新初始化 Worker
+			time.Sleep(1 * time.Second) // 短暂等待确保资源释放
+
+			// 创建新的 Worker 实例
+			newWorker, err := NewWorker(w.config)
+			if err != nil {
+				w.logger.Error("Failed to create new worker: %v", err)
+				os.Exit(1)
+			}
+
+			// 启动新 Worker
+			newWorker.Start()
+			newWorker.logger.Info("Worker restarted successfully in same process")
+
+			// 阻塞等待信号（保持进程运行）
+			select {}
+		}()
+	case "rename":
+		if cmd.NewName != "" {
+			w.logger.Info("Received rename command, renaming worker from %s to %s", w.config.Name, cmd.NewName)
+			w.config.Name = cmd.NewName
+			if w.config.Enrolled {
+				if err := renameCredentialFile(w.config.CredentialFile, cmd.NewName); err != nil {
+					w.logger.Warn("Update credential file failed: %v", err)
+				}
+			}
+			// 立即上报新状态
+			w.reportStatus()
+		}
+	case "setConcurrency":
+		if cmd.Concurrency >= 1 && cmd.Concurrency <= 100 {
+			oldConcurrency := w.config.Concurrency
+			w.config.Concurrency = int(cmd.Concurrency)
+			w.logger.Info("Received setConcurrency command, changed concurrency from %d to %d (note: restart required to add more workers)", oldConcurrency, cmd.Concurrency)
+			// 立即上报新状态
+			w.reportStatus()
+		} else {
+			w.logger.Warn("Invalid concurrency value: %d, must be between 1 and 100", cmd.Concurrency)
+		}
+	default:
+		w.logger.Warn("Unknown control command: %s", cmd.Action)
+	}
+}
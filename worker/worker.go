@@ -7,18 +7,22 @@ import (
 	"net"
 	"os"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"cscan/model"
+	"cscan/pkg/cdn"
+	"cscan/pkg/dnsrecord"
 	"cscan/pkg/mapping"
+	"cscan/pkg/rpcauth"
+	"cscan/pkg/secret"
 	"cscan/rpc/task/pb"
 	"cscan/scanner"
 	"cscan/scheduler"
 
-	"github.com/redis/go-redis/v9"
 	"github.com/shirou/gopsutil/v3/cpu"
 	"github.com/shirou/gopsutil/v3/mem"
 	"github.com/zeromicro/go-zero/core/logx"
@@ -32,25 +36,32 @@ type WorkerConfig struct {
 	Name        string `json:"name"`
 	IP          string `json:"ip"`
 	ServerAddr  string `json:"serverAddr"`
-	RedisAddr   string `json:"redisAddr"`
-	RedisPass   string `json:"redisPass"`
 	Concurrency int    `json:"concurrency"`
 	Timeout     int    `json:"timeout"`
+
+	// 任务服务认证
+	EnrollToken    string          `json:"-"`              // 注册令牌，没有本地凭证时用于换取凭证
+	CredentialFile string          `json:"credentialFile"` // 凭证保存路径，默认 worker.cred
+	UseTLS         bool            `json:"useTls"`
+	TLS            rpcauth.TLSConf `json:"tls"`
+	Enrolled       bool            `json:"enrolled"` // 已使用凭证连接
+
+	// 标签，心跳时上报，任务可按标签选择Worker，如 region=cn、zone=dmz
+	Labels map[string]string `json:"labels"`
 }
 
 // Worker 工作节点
 type Worker struct {
-	ctx         context.Context
-	cancel      context.CancelFunc
-	config      WorkerConfig
-	rpcClient   pb.TaskServiceClient
-	redisClient *redis.Client
-	scanners    map[string]scanner.Scanner
-	taskChan    chan *scheduler.TaskInfo
-	resultChan  chan *scanner.ScanResult
-	stopChan    chan struct{}
-	wg          sync.WaitGroup
-	mu          sync.Mutex
+	ctx        context.Context
+	cancel     context.CancelFunc
+	config     WorkerConfig
+	rpcClient  pb.TaskServiceClient
+	scanners   map[string]scanner.Scanner
+	taskChan   chan *scheduler.TaskInfo
+	resultChan chan *scanner.ScanResult
+	stopChan   chan struct{}
+	wg         sync.WaitGroup
+	mu         sync.Mutex
 
 	taskStarted  int
 	taskExecuted int
@@ -63,7 +74,22 @@ type Worker struct {
 	throttleUntil    time.Time // 限流结束时间
 
 	// 日志组件
+	logs   *LogStreamer
 	logger *WorkerLogger
+
+	// 与任务服务的会话，用于上报状态和接收控制命令
+	sessionMu sync.Mutex
+	session   pb.TaskService_WorkerSessionClient
+
+	// 任务控制信号的本地缓存
+	ctrlMu    sync.Mutex
+	ctrlCache map[string]taskControlEntry
+
+	// 已领取未结束的任务，心跳时上报用于续约
+	heldTasks map[string]struct{}
+
+	// 启动时检测的扫描能力，心跳时上报
+	capabilities []string
 }
 
 // getMainTaskId 从 taskId 中提取主任务ID
@@ -94,7 +120,7 @@ func (w *Worker) taskLog(taskId, level, format string, args ...interface{}) {
 	// 获取主任务ID，确保子任务日志也能在主任务中查看
 	mainTaskId := getMainTaskId(taskId)
 
-	logger := NewTaskLogger(w.redisClient, w.config.Name, mainTaskId)
+	logger := NewTaskLogger(w.logs, w.config.Name, mainTaskId)
 
 	// 如果是子任务，在日志消息前加上子任务标识
 	if mainTaskId != taskId {
@@ -165,70 +191,55 @@ func NewWorker(config WorkerConfig) (*Worker, error) {
 		config.IP = GetLocalIP()
 	}
 
+	// 任务服务的TLS和调用凭证
+	clientOpts, tokenCreds, err := rpcClientOptions(config)
+	if err != nil {
+		return nil, fmt.Errorf("load tls config failed: %v", err)
+	}
+
 	// 创建RPC客户端，消息大小限制到100MB
 	client, err := zrpc.NewClient(zrpc.RpcClientConf{
 		Target: config.ServerAddr,
-	}, zrpc.WithDialOption(grpc.WithDefaultCallOptions(
+	}, append(clientOpts, zrpc.WithDialOption(grpc.WithDefaultCallOptions(
 		grpc.MaxCallRecvMsgSize(100*1024*1024), // 100MB
 		grpc.MaxCallSendMsgSize(100*1024*1024), // 100MB
-	)))
+	)))...)
 	if err != nil {
 		return nil, fmt.Errorf("connect to server failed: %v", err)
 	}
+	rpcClient := pb.NewTaskServiceClient(client.Conn())
 
-	// 创建Redis客户端（用于日志推送）
-	var redisClient *redis.Client
-	if config.RedisAddr != "" {
-		redisClient = redis.NewClient(&redis.Options{
-			Addr:     config.RedisAddr,
-			Password: config.RedisPass,
-			DB:       0,
-		})
-
-		// 测试Redis连接，增加重试机制
-		ctx := context.Background()
-		maxRetries := 3
-		for i := 0; i < maxRetries; i++ {
-			if err := redisClient.Ping(ctx).Err(); err != nil {
-				if i == maxRetries-1 {
-					fmt.Printf("[Worker] Redis connection failed after %d retries: %v, logs will be output to console\n", maxRetries, err)
-					// 不设置为nil，让日志发布器处理连接失败的情况
-				} else {
-					fmt.Printf("[Worker] Redis connection attempt %d failed: %v, retrying...\n", i+1, err)
-					time.Sleep(time.Duration(i+1) * time.Second)
-				}
-			} else {
-				fmt.Printf("[Worker] Redis connected successfully at %s, logs will be streamed\n", config.RedisAddr)
-
-				// 检查并确保 worker 名称唯一
-				config.Name = ensureUniqueWorkerName(ctx, redisClient, config.Name)
-
-				// 设置logx的输出Writer，将所有日志同时发送到Redis
-				logWriter := NewRedisLogWriter(redisClient, config.Name)
-				logx.SetWriter(logx.NewWriter(logWriter))
-				// 写入一条测试日志确认日志系统工作
-				NewLogPublisher(redisClient, config.Name).PublishWorkerLog(LevelInfo, "Worker日志系统已启动,Redis连接成功")
-				break
-			}
-		}
-	} else {
-		fmt.Println("[Worker] Redis address not specified (-r flag), logs will be output to console only")
+	// 加载或注册Worker凭证，再向任务服务确认凭证和名称
+	if err := setupCredential(context.Background(), rpcClient, tokenCreds, &config); err != nil {
+		return nil, err
 	}
+	if err := workerOnline(context.Background(), rpcClient, tokenCreds, &config); err != nil {
+		return nil, err
+	}
+
+	// 日志通过任务服务的日志流上报，设置logx的输出Writer，将所有日志同时上报
+	logs := NewLogStreamer(rpcClient)
+	logx.SetWriter(logx.NewWriter(NewStreamLogWriter(logs, config.Name)))
+	NewLogPublisher(logs, config.Name).PublishWorkerLog(LevelInfo, "Worker日志系统已启动,日志通过任务服务上报")
 
 	// 创建可取消的Context
 	ctx, cancel := context.WithCancel(context.Background())
 
 	w := &Worker{
-		ctx:         ctx,
-		cancel:      cancel,
-		config:      config,
-		rpcClient:   pb.NewTaskServiceClient(client.Conn()),
-		redisClient: redisClient,
-		scanners:    make(map[string]scanner.Scanner),
-		taskChan:    make(chan *scheduler.TaskInfo, config.Concurrency),
-		resultChan:  make(chan *scanner.ScanResult, 100),
-		stopChan:    make(chan struct{}),
-		logger:      NewWorkerLogger(redisClient, config.Name),
+		ctx:        ctx,
+		cancel:     cancel,
+		config:     config,
+		rpcClient:  rpcClient,
+		scanners:   make(map[string]scanner.Scanner),
+		taskChan:   make(chan *scheduler.TaskInfo, config.Concurrency),
+		resultChan: make(chan *scanner.ScanResult, 100),
+		stopChan:   make(chan struct{}),
+		logs:       logs,
+		logger:     NewWorkerLogger(logs, config.Name),
+		ctrlCache:  make(map[string]taskControlEntry),
+		heldTasks:  make(map[string]struct{}),
+
+		capabilities: detectCapabilities(),
 	}
 
 	// 注册扫描器
@@ -247,10 +258,25 @@ func (w *Worker) registerScanners() {
 	w.scanners["nmap"] = scanner.NewNmapScanner()
 	w.scanners["naabu"] = scanner.NewNaabuScanner()
 	w.scanners["subfinder"] = scanner.NewSubfinderScanner()
-	w.scanners["fingerprint"] = scanner.NewFingerprintScanner()
+	fingerprintScanner := scanner.NewFingerprintScanner()
+	w.scanners["fingerprint"] = fingerprintScanner
+	// 爬虫无头模式与指纹截图共享同一个浏览器
+	w.scanners["crawler"] = scanner.NewCrawlerScanner(fingerprintScanner.Browser())
+	w.scanners["dirscan"] = scanner.NewDirScanner()
+	w.scanners["secret"] = scanner.NewSecretScanner()
 	w.scanners["nuclei"] = scanner.NewNucleiScanner()
 }
 
+// Name Worker名称，注册后以凭证绑定的名称为准
+func (w *Worker) Name() string {
+	return w.config.Name
+}
+
+// Capabilities Worker具备的扫描能力
+func (w *Worker) Capabilities() []string {
+	return w.capabilities
+}
+
 // Start 启动Worker
 func (w *Worker) Start() {
 	w.isRunning = true
@@ -273,15 +299,9 @@ func (w *Worker) Start() {
 	w.wg.Add(1)
 	go w.keepAlive()
 
-	// 启动状态查询订阅协程
-	if w.redisClient != nil {
-		w.wg.Add(1)
-		go w.subscribeStatusQuery()
-
-		// 启动控制命令订阅协程
-		w.wg.Add(1)
-		go w.subscribeControlCommand()
-	}
+	// 启动会话协程，上报状态并接收控制命令
+	w.wg.Add(1)
+	go w.runSession()
 
 	w.logger.Info("Worker %s started with %d workers", w.config.Name, w.config.Concurrency)
 }
@@ -349,6 +369,7 @@ func (w *Worker) pullTask() bool {
 			TaskName:    "scan",
 			Config:      resp.Config,
 		}
+		w.holdTask(task.TaskId)
 		w.taskChan <- task
 		return true
 	}
@@ -359,34 +380,37 @@ func (w *Worker) pullTask() bool {
 func (w *Worker) Stop() {
 	w.isRunning = false
 
-	// 主动清理 Redis 中的状态数据，让 Worker 立即从列表中消失
-	if w.redisClient != nil {
-		ctx := context.Background()
-		key := fmt.Sprintf("worker:%s", w.config.Name)
-		w.redisClient.Del(ctx, key)
-	}
+	// 通知任务服务下线，让 Worker 立即从列表中消失
+	w.closeSession()
 
 	w.cancel() // 通知所有 goroutine 停止
 	close(w.stopChan)
 	w.wg.Wait()
+	w.closeScanners()
 	w.logger.Info("Worker %s stopped", w.config.Name)
+	w.logs.Close()
 }
 
 // StopImmediate 立即停止Worker（跳过当前任务，不等待完成）
 func (w *Worker) StopImmediate() {
 	w.isRunning = false
 
-	// 主动清理 Redis 中的状态数据
-	if w.redisClient != nil {
-		ctx := context.Background()
-		key := fmt.Sprintf("worker:%s", w.config.Name)
-		w.redisClient.Del(ctx, key)
-	}
+	// 通知任务服务下线
+	w.closeSession()
 
 	w.cancel() // 通知所有 goroutine 停止
 	close(w.stopChan)
+	w.closeScanners()
 	// 不等待 wg.Wait()，立即返回，跳过当前正在执行的任务
 	w.logger.Info("Worker %s stopped immediately (tasks skipped)", w.config.Name)
+	w.logs.Close()
+}
+
+// closeScanners 释放扫描器持有的资源（如截图浏览器）
+func (w *Worker) closeScanners() {
+	if s, ok := w.scanners["fingerprint"].(*scanner.FingerprintScanner); ok {
+		s.Close()
+	}
 }
 
 // SubmitTask 提交任务
@@ -407,42 +431,52 @@ func (w *Worker) processTask() {
 			ctx := context.Background()
 			if ctrl := w.checkTaskControl(ctx, task.TaskId); ctrl == "STOP" {
 				w.taskLog(task.TaskId, LevelInfo, "Task %s skipped because it was stopped while waiting in queue", task.TaskId)
+				w.unholdTask(task.TaskId)
 				continue
 			}
 			w.executeTask(task)
+			w.unholdTask(task.TaskId)
 		}
 	}
 }
 
+// taskControlCacheTTL 任务控制信号的本地缓存时间，避免每次检查都请求任务服务
+const taskControlCacheTTL = time.Second
+
+type taskControlEntry struct {
+	ctrl   string
+	expire time.Time
+}
+
 // checkTaskControl 检查任务控制信号
 // 返回: "PAUSE" - 暂停, "STOP" - 停止, "" - 继续执行
-// 对于子任务，会同时检查主任务的控制信号
+// 对于子任务，服务端会同时检查主任务的控制信号
 func (w *Worker) checkTaskControl(ctx context.Context, taskId string) string {
-	if w.redisClient == nil {
-		return ""
+	now := time.Now()
+	w.ctrlMu.Lock()
+	entry, ok := w.ctrlCache[taskId]
+	w.ctrlMu.Unlock()
+	if ok && now.Before(entry.expire) {
+		return entry.ctrl
 	}
 
-	// 使用独立的 context 查询 Redis，避免因任务 context 被取消而查询失败
-	queryCtx := context.Background()
-
-	// 先检查当前任务的控制信号
-	ctrlKey := "cscan:task:ctrl:" + taskId
-	ctrl, err := w.redisClient.Get(queryCtx, ctrlKey).Result()
-	if err == nil && ctrl != "" {
-		return ctrl
+	// 使用独立的 context 查询，避免因任务 context 被取消而查询失败
+	queryCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := w.rpcClient.GetTaskControl(queryCtx, &pb.GetTaskControlReq{TaskId: taskId})
+	if err != nil {
+		return ""
 	}
 
-	// 如果是子任务，还需要检查主任务的控制信号
-	mainTaskId := getMainTaskId(taskId)
-	if mainTaskId != taskId {
-		mainCtrlKey := "cscan:task:ctrl:" + mainTaskId
-		ctrl, err = w.redisClient.Get(queryCtx, mainCtrlKey).Result()
-		if err == nil && ctrl != "" {
-			return ctrl
+	w.ctrlMu.Lock()
+	for id, e := range w.ctrlCache {
+		if now.After(e.expire) {
+			delete(w.ctrlCache, id)
 		}
 	}
-
-	return ""
+	w.ctrlCache[taskId] = taskControlEntry{ctrl: resp.Control, expire: now.Add(taskControlCacheTTL)}
+	w.ctrlMu.Unlock()
+	return resp.Control
 }
 
 // saveTaskProgress 保存任务进度（用于暂停后继续扫描)
@@ -466,6 +500,7 @@ func (w *Worker) saveTaskProgress(ctx context.Context, task *scheduler.TaskInfo,
 	w.rpcClient.UpdateTask(ctx, &pb.UpdateTaskReq{
 		TaskId: task.TaskId,
 		State:  "PAUSED",
+		Worker: w.config.Name,
 		Result: string(stateJson),
 	})
 	w.taskLog(task.TaskId, LevelInfo, "Task %s progress saved: completedPhases=%v, assets=%d", task.TaskId, phases, len(assets))
@@ -574,6 +609,15 @@ func (w *Worker) executeTask(task *scheduler.TaskInfo) {
 	if config.Fingerprint != nil && config.Fingerprint.Enable {
 		enabledPhases = append(enabledPhases, "Fingerprint")
 	}
+	if config.Crawl != nil && config.Crawl.Enable {
+		enabledPhases = append(enabledPhases, "Crawl")
+	}
+	if config.SecretScan != nil && config.SecretScan.Enable {
+		enabledPhases = append(enabledPhases, "Secret Scan")
+	}
+	if config.DirScan != nil && config.DirScan.Enable {
+		enabledPhases = append(enabledPhases, "Dir Scan")
+	}
 	if config.PocScan != nil && config.PocScan.Enable {
 		enabledPhases = append(enabledPhases, "POC Scan")
 	}
@@ -620,6 +664,9 @@ func (w *Worker) executeTask(task *scheduler.TaskInfo) {
 		// 检查是否有其他阶段需要资产
 		needAssets := (config.PortIdentify != nil && config.PortIdentify.Enable) ||
 			(config.Fingerprint != nil && config.Fingerprint.Enable) ||
+			(config.Crawl != nil && config.Crawl.Enable) ||
+			(config.SecretScan != nil && config.SecretScan.Enable) ||
+			(config.DirScan != nil && config.DirScan.Enable) ||
 			(config.PocScan != nil && config.PocScan.Enable)
 
 		if needAssets {
@@ -631,6 +678,13 @@ func (w *Worker) executeTask(task *scheduler.TaskInfo) {
 		}
 	}
 
+	// 子域名的CDN/云检测结果，用于标记端口扫描资产
+	cdnHosts := make(map[string]*scanner.Asset)
+	// 监控模式下本次新发现的子域名数量，-1表示未开启监控
+	newSubdomains := -1
+	// 监控模式下待确认的新增和解析变化的子域名，任务成功完成后才确认为已知
+	var pendingSubdomains []string
+
 	// 执行子域名扫描（在端口扫描之前）
 	if config.DomainScan != nil && config.DomainScan.Enable && !completedPhases["domainscan"] {
 		// 检查控制信号
@@ -682,6 +736,31 @@ func (w *Worker) executeTask(task *scheduler.TaskInfo) {
 			ResolveDNS:         config.DomainScan.ResolveDNS,
 			Concurrent:         w.config.Concurrency * 10, // DNS解析并发数为Worker并发数的10倍
 			ProviderConfig:     providerConfig,
+			CdnCheck:           config.DomainScan.CdnCheck,
+			Resolvers:          config.DomainScan.Resolvers,
+			DNSRecords:         config.DomainScan.DNSRecords,
+			// 兼容旧配置：未开启爆破时始终使用被动数据源
+			SkipPassive:    !config.DomainScan.Subfinder && config.DomainScan.Bruteforce,
+			Bruteforce:     config.DomainScan.Bruteforce,
+			BruteRateLimit: config.DomainScan.BruteRateLimit,
+			Permutation:    config.DomainScan.Permutation,
+			Takeover:       config.DomainScan.Takeover,
+		}
+
+		// 子域名爆破字典
+		if config.DomainScan.Bruteforce && len(config.DomainScan.BruteWordlistIds) > 0 {
+			wordResp, err := w.rpcClient.GetWordlists(ctx, &pb.GetWordlistsReq{
+				Ids:  config.DomainScan.BruteWordlistIds,
+				Type: model.WordlistTypeSubdomain,
+			})
+			if err != nil {
+				w.taskLog(task.TaskId, LevelError, "DNS brute: load wordlists failed: %v", err)
+			} else if !wordResp.Success {
+				w.taskLog(task.TaskId, LevelError, "DNS brute: load wordlists failed: %s", wordResp.Message)
+			} else {
+				subfinderOpts.Wordlist = wordResp.Words
+				w.taskLog(task.TaskId, LevelInfo, "DNS brute: loaded %d words", len(wordResp.Words))
+			}
 		}
 
 		// 设置默认值
@@ -703,19 +782,64 @@ func (w *Worker) executeTask(task *scheduler.TaskInfo) {
 				TaskLogger:  domainTaskLogger,
 			})
 
+			if result != nil && len(result.DNSRecords) > 0 {
+				w.saveDnsRecordResult(ctx, task.WorkspaceId, task.MainTaskId, task.TaskId, result.DNSRecords)
+			}
+			if result != nil && len(result.Vulnerabilities) > 0 {
+				w.taskLog(task.TaskId, LevelInfo, "Saving %d subdomain takeover findings", len(result.Vulnerabilities))
+				w.saveVulResult(ctx, task.WorkspaceId, task.MainTaskId, task.TaskId, result.Vulnerabilities)
+			}
+
 			if err != nil {
 				w.taskLog(task.TaskId, LevelError, "Domain scan error: %v", err)
 			} else if result != nil && len(result.Assets) > 0 {
 				// 保存子域名扫描结果到数据库
 				w.taskLog(task.TaskId, LevelInfo, "Saving %d subdomains to database", len(result.Assets))
-				w.saveAssetResult(ctx, task.WorkspaceId, task.MainTaskId, orgId, result.Assets)
+				w.saveAssetResult(ctx, task.WorkspaceId, task.MainTaskId, task.TaskId, orgId, result.Assets)
+
+				// 监控模式：与已知子域名比对，只扫描新增或解析变化的子域名
+				var monitored map[string]bool
+				if config.DomainScan.Monitor {
+					newSet, changedSet, err := w.syncSubdomains(ctx, task.WorkspaceId, task.MainTaskId, task.TaskId, result.Assets)
+					if err != nil {
+						w.taskLog(task.TaskId, LevelError, "Monitor: sync subdomains failed, scanning all: %v", err)
+					} else {
+						monitored = make(map[string]bool, len(newSet)+len(changedSet))
+						for domain := range newSet {
+							monitored[domain] = true
+						}
+						for domain := range changedSet {
+							monitored[domain] = true
+						}
+						for domain := range monitored {
+							pendingSubdomains = append(pendingSubdomains, domain)
+						}
+						newSubdomains = len(newSet)
+						w.taskLog(task.TaskId, LevelInfo, "Monitor: %d new, %d changed, %d unchanged skipped",
+							len(newSet), len(changedSet), len(result.Assets)-len(monitored))
+					}
+				}
 
 				// 将发现的子域名添加到目标列表
+				excludeCdn := config.PortScan != nil && config.PortScan.ExcludeCdn
 				var newTargets []string
+				var skippedCdn int
 				for _, asset := range result.Assets {
-					if asset.Host != "" {
-						newTargets = append(newTargets, asset.Host)
+					if asset.Host == "" {
+						continue
 					}
+					cdnHosts[asset.Host] = asset
+					if monitored != nil && !monitored[strings.ToLower(asset.Host)] {
+						continue
+					}
+					if excludeCdn && asset.IsCDN {
+						skippedCdn++
+						continue
+					}
+					newTargets = append(newTargets, asset.Host)
+				}
+				if skippedCdn > 0 {
+					w.taskLog(task.TaskId, LevelInfo, "Skipped %d CDN/WAF subdomains for port scan", skippedCdn)
 				}
 				if len(newTargets) > 0 {
 					// 更新目标（将子域名添加到原始目标）
@@ -769,6 +893,16 @@ func (w *Worker) executeTask(task *scheduler.TaskInfo) {
 			w.taskLog(task.TaskId, level, format, args...)
 		}
 
+		// 排除位于CDN/WAF网段的IP目标
+		portTarget := target
+		if config.PortScan != nil && config.PortScan.ExcludeCdn {
+			var skipped int
+			portTarget, skipped = filterCdnTargets(target)
+			if skipped > 0 {
+				w.taskLog(task.TaskId, LevelInfo, "Skipped %d CDN/WAF IP targets for port scan", skipped)
+			}
+		}
+
 		// 创建进度回调
 		onProgress := func(progress int, message string) {
 			w.updateTaskProgress(ctx, task.TaskId, progress, message)
@@ -780,7 +914,7 @@ func (w *Worker) executeTask(task *scheduler.TaskInfo) {
 			w.taskLog(task.TaskId, LevelInfo, "Port scan: Masscan")
 			masscanScanner := w.scanners["masscan"]
 			masscanResult, err := masscanScanner.Scan(portCtx, &scanner.ScanConfig{
-				Target:     target,
+				Target:     portTarget,
 				Options:    config.PortScan,
 				TaskLogger: taskLogger,
 				OnProgress: onProgress,
@@ -804,7 +938,7 @@ func (w *Worker) executeTask(task *scheduler.TaskInfo) {
 			w.taskLog(task.TaskId, LevelInfo, "Port scan: Naabu")
 			naabuScanner := w.scanners["naabu"]
 			naabuResult, err := naabuScanner.Scan(portCtx, &scanner.ScanConfig{
-				Target:     target,
+				Target:     portTarget,
 				Options:    config.PortScan,
 				TaskLogger: taskLogger,
 				OnProgress: onProgress,
@@ -841,12 +975,20 @@ func (w *Worker) executeTask(task *scheduler.TaskInfo) {
 		if len(openPorts) > 0 {
 			for _, asset := range openPorts {
 				asset.IsHTTP = scanner.IsHTTPService(asset.Service, asset.Port)
+				// 继承子域名的CDN/云检测结果，IP资产按网段匹配
+				if info, ok := cdnHosts[asset.Host]; ok {
+					asset.IsCDN = info.IsCDN
+					asset.IsCloud = info.IsCloud
+					asset.CName = info.CName
+				} else {
+					scanner.MarkCdn(asset)
+				}
 			}
 			allAssets = append(allAssets, openPorts...)
 			w.taskLog(task.TaskId, LevelInfo, "Port scan completed: %d assets", len(allAssets))
 
 			// 端口扫描完成后立即保存结果
-			w.saveAssetResult(ctx, task.WorkspaceId, task.MainTaskId, orgId, allAssets)
+			w.saveAssetResult(ctx, task.WorkspaceId, task.MainTaskId, task.TaskId, orgId, allAssets)
 		} else {
 			w.taskLog(task.TaskId, LevelInfo, "No open ports found")
 		}
@@ -884,7 +1026,7 @@ func (w *Worker) executeTask(task *scheduler.TaskInfo) {
 		if len(identifiedAssets) > 0 {
 			allAssets = identifiedAssets
 			// 端口识别完成后保存更新结果
-			w.saveAssetResult(ctx, task.WorkspaceId, task.MainTaskId, orgId, allAssets)
+			w.saveAssetResult(ctx, task.WorkspaceId, task.MainTaskId, task.TaskId, orgId, allAssets)
 		}
 		completedPhases["portidentify"] = true
 	}
@@ -983,11 +1125,12 @@ func (w *Worker) executeTask(task *scheduler.TaskInfo) {
 						originalAsset.Server = fpAsset.Server
 						originalAsset.IconHash = fpAsset.IconHash
 						originalAsset.Screenshot = fpAsset.Screenshot
+						originalAsset.ScreenshotHash = fpAsset.ScreenshotHash
 					}
 				}
 
 				// 指纹识别完成后保存更新结果
-				w.saveAssetResult(ctx, task.WorkspaceId, task.MainTaskId, orgId, allAssets)
+				w.saveAssetResult(ctx, task.WorkspaceId, task.MainTaskId, task.TaskId, orgId, allAssets)
 			}
 		}
 		completedPhases["fingerprint"] = true
@@ -1003,6 +1146,208 @@ func (w *Worker) executeTask(task *scheduler.TaskInfo) {
 		return
 	}
 
+	// 执行Web爬虫，发现的URL保存到服务端，带参数的URL和接口可作为POC扫描的额外目标，JS文件可用于敏感信息检测
+	// 暂停后恢复时爬虫阶段已完成，额外目标和JS文件不会恢复
+	var crawlTargets []string
+	var crawlJSFiles []*scanner.CrawledUrl
+	if config.Crawl != nil && config.Crawl.Enable && len(allAssets) > 0 && !completedPhases["crawl"] {
+		// 更新当前阶段
+		w.updateTaskProgressWithPhase(ctx, task.TaskId, 70, "Web爬虫中", "Web爬虫")
+
+		if s, ok := w.scanners["crawler"]; ok {
+			crawlTimeout := config.Crawl.Timeout
+			if crawlTimeout <= 0 {
+				crawlTimeout = 1800 // 默认30分钟总超时
+			}
+			crawlCtx, crawlCancel := context.WithTimeout(ctx, time.Duration(crawlTimeout)*time.Second)
+
+			// 创建任务日志回调
+			crawlTaskLogger := func(level, format string, args ...interface{}) {
+				w.taskLog(task.TaskId, level, format, args...)
+			}
+
+			result, err := s.Scan(crawlCtx, &scanner.ScanConfig{
+				Assets:      allAssets,
+				WorkspaceId: task.WorkspaceId,
+				MainTaskId:  task.MainTaskId,
+				Options: &scanner.CrawlerOptions{
+					MaxDepth:      config.Crawl.MaxDepth,
+					MaxPages:      config.Crawl.MaxPages,
+					Scope:         config.Crawl.Scope,
+					RateLimit:     config.Crawl.RateLimit,
+					Concurrency:   config.Crawl.Concurrency,
+					Headless:      config.Crawl.Headless,
+					Timeout:       crawlTimeout,
+					TargetTimeout: config.Crawl.TargetTimeout,
+					ExcludeExt:    config.Crawl.ExcludeExt,
+				},
+				TaskLogger: crawlTaskLogger,
+			})
+			crawlCancel()
+
+			// 检查是否被取消
+			if ctx.Err() != nil || w.checkTaskControl(ctx, task.TaskId) == "STOP" {
+				w.taskLog(task.TaskId, LevelInfo, "Task stopped")
+				return
+			}
+
+			if err != nil {
+				w.taskLog(task.TaskId, LevelError, "Crawl failed: %v", err)
+			}
+			if result != nil && len(result.Urls) > 0 {
+				w.saveUrlResult(ctx, task.WorkspaceId, task.MainTaskId, task.TaskId, result.Urls)
+				if config.PocScan != nil && config.PocScan.CrawlTargets {
+					crawlTargets = selectCrawlTargets(result.Urls, maxCrawlPocTargets)
+				}
+				if config.SecretScan != nil && config.SecretScan.ScanJS {
+					crawlJSFiles = scanner.SelectJSFiles(result.Urls)
+				}
+			}
+		}
+		completedPhases["crawl"] = true
+
+		// 检查控制信号
+		if ctrl := w.checkTaskControl(ctx, task.TaskId); ctrl == "STOP" {
+			w.taskLog(task.TaskId, LevelInfo, "Task stopped")
+			return
+		} else if ctrl == "PAUSE" {
+			w.taskLog(task.TaskId, LevelInfo, "Task paused, saving progress...")
+			w.saveTaskProgress(ctx, task, completedPhases, allAssets)
+			return
+		}
+	}
+
+	// 执行敏感信息检测，规则从服务端获取
+	if config.SecretScan != nil && config.SecretScan.Enable && len(allAssets) > 0 && !completedPhases["secretscan"] {
+		// 更新当前阶段
+		w.updateTaskProgressWithPhase(ctx, task.TaskId, 72, "敏感信息检测中", "敏感信息检测")
+
+		rules := w.loadSecretRules(ctx, task.TaskId, config.SecretScan.Categories)
+		if s, ok := w.scanners["secret"]; ok && len(rules) > 0 {
+			// 创建任务日志回调
+			secretTaskLogger := func(level, format string, args ...interface{}) {
+				w.taskLog(task.TaskId, level, format, args...)
+			}
+
+			result, err := s.Scan(ctx, &scanner.ScanConfig{
+				Assets:      allAssets,
+				WorkspaceId: task.WorkspaceId,
+				MainTaskId:  task.MainTaskId,
+				Options: &scanner.SecretScanOptions{
+					Rules:      rules,
+					JSFiles:    crawlJSFiles,
+					MaxJSFiles: config.SecretScan.MaxJSFiles,
+					Timeout:    config.SecretScan.Timeout,
+				},
+				TaskLogger: secretTaskLogger,
+			})
+
+			// 检查是否被取消
+			if ctx.Err() != nil || w.checkTaskControl(ctx, task.TaskId) == "STOP" {
+				w.taskLog(task.TaskId, LevelInfo, "Task stopped")
+				return
+			}
+
+			if err != nil {
+				w.taskLog(task.TaskId, LevelError, "SecretScan failed: %v", err)
+			}
+			if result != nil && len(result.Secrets) > 0 {
+				w.saveSecretResult(ctx, task.WorkspaceId, task.MainTaskId, task.TaskId, result.Secrets)
+			}
+		} else if len(rules) == 0 {
+			w.taskLog(task.TaskId, LevelWarn, "SecretScan: no enabled rule, skipping")
+		}
+		completedPhases["secretscan"] = true
+
+		// 检查控制信号
+		if ctrl := w.checkTaskControl(ctx, task.TaskId); ctrl == "STOP" {
+			w.taskLog(task.TaskId, LevelInfo, "Task stopped")
+			return
+		} else if ctrl == "PAUSE" {
+			w.taskLog(task.TaskId, LevelInfo, "Task paused, saving progress...")
+			w.saveTaskProgress(ctx, task, completedPhases, allAssets)
+			return
+		}
+	}
+
+	// 执行目录扫描，字典从服务端获取
+	if config.DirScan != nil && config.DirScan.Enable && len(allAssets) > 0 && !completedPhases["dirscan"] {
+		// 更新当前阶段
+		w.updateTaskProgressWithPhase(ctx, task.TaskId, 75, "目录扫描中", "目录扫描")
+
+		var words []string
+		wordResp, err := w.rpcClient.GetWordlists(ctx, &pb.GetWordlistsReq{
+			Ids:  config.DirScan.WordlistIds,
+			Type: model.WordlistTypeDir,
+		})
+		if err != nil {
+			w.taskLog(task.TaskId, LevelError, "DirScan: load wordlists failed: %v", err)
+		} else if !wordResp.Success {
+			w.taskLog(task.TaskId, LevelError, "DirScan: load wordlists failed: %s", wordResp.Message)
+		} else {
+			words = wordResp.Words
+		}
+
+		if s, ok := w.scanners["dirscan"]; ok && len(words) > 0 {
+			w.taskLog(task.TaskId, LevelInfo, "DirScan: loaded %d words", len(words))
+			dirScanTimeout := config.DirScan.Timeout
+			if dirScanTimeout <= 0 {
+				dirScanTimeout = 1800 // 默认30分钟总超时
+			}
+			dirScanCtx, dirScanCancel := context.WithTimeout(ctx, time.Duration(dirScanTimeout)*time.Second)
+
+			// 创建任务日志回调
+			dirScanTaskLogger := func(level, format string, args ...interface{}) {
+				w.taskLog(task.TaskId, level, format, args...)
+			}
+
+			result, err := s.Scan(dirScanCtx, &scanner.ScanConfig{
+				Assets:      allAssets,
+				WorkspaceId: task.WorkspaceId,
+				MainTaskId:  task.MainTaskId,
+				Options: &scanner.DirScanOptions{
+					Words:         words,
+					Extensions:    config.DirScan.Extensions,
+					Concurrency:   config.DirScan.Concurrency,
+					Threads:       config.DirScan.Threads,
+					RateLimit:     config.DirScan.RateLimit,
+					ExcludeStatus: config.DirScan.ExcludeStatus,
+					MaxResults:    config.DirScan.MaxResults,
+					Timeout:       dirScanTimeout,
+					TargetTimeout: config.DirScan.TargetTimeout,
+				},
+				TaskLogger: dirScanTaskLogger,
+			})
+			dirScanCancel()
+
+			// 检查是否被取消
+			if ctx.Err() != nil || w.checkTaskControl(ctx, task.TaskId) == "STOP" {
+				w.taskLog(task.TaskId, LevelInfo, "Task stopped")
+				return
+			}
+
+			if err != nil {
+				w.taskLog(task.TaskId, LevelError, "DirScan failed: %v", err)
+			}
+			if result != nil && len(result.DirEntries) > 0 {
+				w.saveDirScanResult(ctx, task.WorkspaceId, task.MainTaskId, task.TaskId, result.DirEntries)
+			}
+		} else if len(words) == 0 {
+			w.taskLog(task.TaskId, LevelWarn, "DirScan: no enabled wordlist, skipping")
+		}
+		completedPhases["dirscan"] = true
+
+		// 检查控制信号
+		if ctrl := w.checkTaskControl(ctx, task.TaskId); ctrl == "STOP" {
+			w.taskLog(task.TaskId, LevelInfo, "Task stopped")
+			return
+		} else if ctrl == "PAUSE" {
+			w.taskLog(task.TaskId, LevelInfo, "Task paused, saving progress...")
+			w.saveTaskProgress(ctx, task, completedPhases, allAssets)
+			return
+		}
+	}
+
 	// 执行POC扫描 (使用Nuclei引擎)
 	if config.PocScan != nil && config.PocScan.Enable && len(allAssets) > 0 && !completedPhases["pocscan"] {
 		// 在POC扫描开始前检查停止信号
@@ -1090,11 +1435,11 @@ func (w *Worker) executeTask(task *scheduler.TaskInfo) {
 							return
 						case <-vulBuffer.flushChan:
 							vulBuffer.Flush(pocCtx, func(vuls []*scanner.Vulnerability) {
-								w.saveVulResult(ctx, task.WorkspaceId, task.MainTaskId, vuls)
+								w.saveVulResult(ctx, task.WorkspaceId, task.MainTaskId, task.TaskId, vuls)
 							})
 						case <-ticker.C:
 							vulBuffer.Flush(pocCtx, func(vuls []*scanner.Vulnerability) {
-								w.saveVulResult(ctx, task.WorkspaceId, task.MainTaskId, vuls)
+								w.saveVulResult(ctx, task.WorkspaceId, task.MainTaskId, task.TaskId, vuls)
 							})
 						}
 					}
@@ -1116,6 +1461,7 @@ func (w *Worker) executeTask(task *scheduler.TaskInfo) {
 					CustomPocOnly:   config.PocScan.CustomPocOnly,
 					CustomTemplates: templates,
 					TagMappings:     config.PocScan.TagMappings,
+					ExtraTargets:    crawlTargets,
 					// 设置回调函数，发现漏洞时添加到缓冲区
 					OnVulnerabilityFound: func(vul *scanner.Vulnerability) {
 						vulCount++
@@ -1145,7 +1491,7 @@ func (w *Worker) executeTask(task *scheduler.TaskInfo) {
 
 				// 扫描完成后，刷新剩余的漏洞
 				vulBuffer.Flush(ctx, func(vuls []*scanner.Vulnerability) {
-					w.saveVulResult(ctx, task.WorkspaceId, task.MainTaskId, vuls)
+					w.saveVulResult(ctx, task.WorkspaceId, task.MainTaskId, task.TaskId, vuls)
 				})
 
 				// 检查是否超时
@@ -1172,9 +1518,22 @@ func (w *Worker) executeTask(task *scheduler.TaskInfo) {
 		}
 	}
 
+	// 后续阶段都已完成，确认待确认的子域名；任务中断时不确认，下次运行仍会扫描
+	if len(pendingSubdomains) > 0 {
+		confirmed, err := w.confirmSubdomains(ctx, task.WorkspaceId, task.MainTaskId, task.TaskId, pendingSubdomains)
+		if err != nil {
+			w.taskLog(task.TaskId, LevelWarn, "Monitor: confirm subdomains failed, they will be rescanned next run: %v", err)
+		} else {
+			newSubdomains = confirmed
+		}
+	}
+
 	// 更新任务状态为完成
 	duration := time.Since(startTime).Seconds()
 	result := fmt.Sprintf("Assets:%d Vuls:%d Duration:%.0fs", len(allAssets), len(allVuls), duration)
+	if newSubdomains >= 0 {
+		result += fmt.Sprintf(" NewSubdomains:%d", newSubdomains)
+	}
 	w.updateTaskStatus(ctx, task.TaskId, scheduler.TaskStatusSuccess, result)
 	w.taskLog(task.TaskId, LevelInfo, "Completed: %s", result)
 
@@ -1185,7 +1544,7 @@ func (w *Worker) executeTask(task *scheduler.TaskInfo) {
 
 // updateTaskStatus 更新任务状态
 func (w *Worker) updateTaskStatus(ctx context.Context, taskId, status, result string) {
-	// 如果任务完成（SUCCESS/FAILURE），同时更新Redis中的进度
+	// 如果任务完成（SUCCESS/FAILURE），同时更新任务进度
 	if status == scheduler.TaskStatusSuccess || status == scheduler.TaskStatusFailure {
 		progress := 100
 		if status == scheduler.TaskStatusFailure {
@@ -1205,44 +1564,28 @@ func (w *Worker) updateTaskStatus(ctx context.Context, taskId, status, result st
 	}
 }
 
-// updateTaskProgress 更新任务进度（通过Redis）
+// updateTaskProgress 更新任务进度（通过任务服务）
 func (w *Worker) updateTaskProgress(ctx context.Context, taskId string, progress int, message string) {
 	w.updateTaskProgressWithPhase(ctx, taskId, progress, message, "")
 }
 
-// updateTaskProgressWithPhase 更新任务进度和当前阶段（通过Redis）
+// updateTaskProgressWithPhase 更新任务进度和当前阶段（通过任务服务）
 func (w *Worker) updateTaskProgressWithPhase(ctx context.Context, taskId string, progress int, message string, currentPhase string) {
-	if w.redisClient == nil {
-		return
-	}
-
-	// 获取主任务ID
-	mainTaskId := getMainTaskId(taskId)
-
-	// 更新子任务进度到Redis（每个子任务独立的key）
-	subKey := fmt.Sprintf("cscan:task:progress:sub:%s", taskId)
-	subData := map[string]interface{}{
-		"taskId":       taskId,
-		"progress":     progress,
-		"message":      message,
-		"currentPhase": currentPhase,
-		"updateTime":   time.Now().Local().Format("2006-01-02 15:04:05"),
-	}
-	subJsonData, _ := json.Marshal(subData)
-	w.redisClient.Set(ctx, subKey, subJsonData, 30*time.Minute)
-
-	// 同时更新主任务的当前阶段（用于显示）
-	mainKey := fmt.Sprintf("cscan:task:progress:%s", mainTaskId)
-	mainData := map[string]interface{}{
-		"currentPhase": currentPhase,
-		"updateTime":   time.Now().Local().Format("2006-01-02 15:04:05"),
+	reqCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := w.rpcClient.UpdateTaskProgress(reqCtx, &pb.UpdateTaskProgressReq{
+		TaskId:       taskId,
+		Progress:     int32(progress),
+		Message:      message,
+		CurrentPhase: currentPhase,
+	})
+	if err != nil {
+		w.logger.Debug("update task progress failed: %v", err)
 	}
-	mainJsonData, _ := json.Marshal(mainData)
-	w.redisClient.Set(ctx, mainKey, mainJsonData, 30*time.Minute)
 }
 
 // saveAssetResult 保存资产结果
-func (w *Worker) saveAssetResult(ctx context.Context, workspaceId, mainTaskId, orgId string, assets []*scanner.Asset) {
+func (w *Worker) saveAssetResult(ctx context.Context, workspaceId, mainTaskId, taskId, orgId string, assets []*scanner.Asset) {
 	if len(assets) == 0 {
 		return
 	}
@@ -1268,26 +1611,34 @@ func (w *Worker) saveAssetResult(ctx context.Context, workspaceId, mainTaskId, o
 
 		for _, asset := range batchAssets {
 			pbAsset := &pb.AssetDocument{
-				Authority:  asset.Authority,
-				Host:       asset.Host,
-				Port:       int32(asset.Port),
-				Category:   asset.Category,
-				Service:    asset.Service,
-				Title:      asset.Title,
-				App:        asset.App,
-				HttpStatus: asset.HttpStatus,
-				HttpHeader: asset.HttpHeader,
-				HttpBody:   asset.HttpBody,
-				IconHash:   asset.IconHash,
-				IconData:   asset.IconData,
-				Screenshot: asset.Screenshot,
-				Server:     asset.Server,
-				Banner:     asset.Banner,
-				IsHttp:     asset.IsHTTP,
-				Cname:      asset.CName,
-				IsCdn:      asset.IsCDN,
-				IsCloud:    asset.IsCloud,
-				Source:     asset.Source,
+				Authority:      asset.Authority,
+				Host:           asset.Host,
+				Port:           int32(asset.Port),
+				Category:       asset.Category,
+				Service:        asset.Service,
+				Title:          asset.Title,
+				App:            asset.App,
+				HttpStatus:     asset.HttpStatus,
+				HttpHeader:     asset.HttpHeader,
+				HttpBody:       asset.HttpBody,
+				IconHash:       asset.IconHash,
+				IconData:       asset.IconData,
+				Screenshot:     asset.Screenshot,
+				ScreenshotHash: asset.ScreenshotHash,
+				Server:         asset.Server,
+				Banner:         asset.Banner,
+				IsHttp:         asset.IsHTTP,
+				Cname:          asset.CName,
+				IsCdn:          asset.IsCDN,
+				IsCloud:        asset.IsCloud,
+				Source:         asset.Source,
+			}
+
+			// TLS证书信息以JSON传输
+			if asset.TLS != nil {
+				if data, err := json.Marshal(asset.TLS); err == nil {
+					pbAsset.Cert = string(data)
+				}
 			}
 
 			// 添加IPv4信息
@@ -1314,6 +1665,7 @@ func (w *Worker) saveAssetResult(ctx context.Context, workspaceId, mainTaskId, o
 		resp, err := w.rpcClient.SaveTaskResult(batchCtx, &pb.SaveTaskResultReq{
 			WorkspaceId: workspaceId,
 			MainTaskId:  mainTaskId,
+			TaskId:      taskId,
 			Assets:      pbAssets,
 			OrgId:       orgId,
 		})
@@ -1332,7 +1684,7 @@ func (w *Worker) saveAssetResult(ctx context.Context, workspaceId, mainTaskId, o
 }
 
 // saveVulResult 保存漏洞结果（支持去重与聚合）
-func (w *Worker) saveVulResult(ctx context.Context, workspaceId, mainTaskId string, vuls []*scanner.Vulnerability) {
+func (w *Worker) saveVulResult(ctx context.Context, workspaceId, mainTaskId, taskId string, vuls []*scanner.Vulnerability) {
 	if len(vuls) == 0 {
 		return
 	}
@@ -1407,6 +1759,7 @@ func (w *Worker) saveVulResult(ctx context.Context, workspaceId, mainTaskId stri
 	_, err := w.rpcClient.SaveVulResult(ctx, &pb.SaveVulResultReq{
 		WorkspaceId: workspaceId,
 		MainTaskId:  mainTaskId,
+		TaskId:      taskId,
 		Vuls:        pbVuls,
 	})
 	if err != nil {
@@ -1414,6 +1767,303 @@ func (w *Worker) saveVulResult(ctx context.Context, workspaceId, mainTaskId stri
 	}
 }
 
+// saveUrlResult 分批保存爬虫发现的URL
+func (w *Worker) saveUrlResult(ctx context.Context, workspaceId, mainTaskId, taskId string, urls []*scanner.CrawledUrl) {
+	const batchSize = 500
+	var total, newCount int32
+	for start := 0; start < len(urls); start += batchSize {
+		end := min(start+batchSize, len(urls))
+		pbUrls := make([]*pb.UrlDocument, 0, end-start)
+		for _, u := range urls[start:end] {
+			pbUrls = append(pbUrls, &pb.UrlDocument{
+				Authority:   u.Authority,
+				Host:        u.Host,
+				Port:        int32(u.Port),
+				Url:         u.Url,
+				Endpoint:    u.Endpoint,
+				Method:      u.Method,
+				Path:        u.Path,
+				Params:      u.Params,
+				Source:      u.Source,
+				StatusCode:  int32(u.StatusCode),
+				ContentType: u.ContentType,
+				Length:      int32(u.Length),
+				Title:       u.Title,
+				Referer:     u.Referer,
+			})
+		}
+
+		resp, err := w.rpcClient.SaveUrlResult(ctx, &pb.SaveUrlResultReq{
+			WorkspaceId: workspaceId,
+			MainTaskId:  mainTaskId,
+			TaskId:      taskId,
+			Urls:        pbUrls,
+		})
+		if err != nil {
+			w.taskLog(mainTaskId, LevelError, "save url result failed: %v", err)
+			continue
+		}
+		if !resp.Success {
+			w.taskLog(mainTaskId, LevelError, "save url result failed: %s", resp.Message)
+			continue
+		}
+		total += resp.Total
+		newCount += resp.NewCount
+	}
+	w.taskLog(mainTaskId, LevelInfo, "Crawl: saved %d urls, %d new", total, newCount)
+}
+
+// saveDirScanResult 分批保存目录扫描结果
+func (w *Worker) saveDirScanResult(ctx context.Context, workspaceId, mainTaskId, taskId string, entries []*scanner.DirEntry) {
+	const batchSize = 500
+	var total, newCount int32
+	for start := 0; start < len(entries); start += batchSize {
+		end := min(start+batchSize, len(entries))
+		docs := make([]*pb.DirScanDocument, 0, end-start)
+		for _, e := range entries[start:end] {
+			docs = append(docs, &pb.DirScanDocument{
+				Authority:   e.Authority,
+				Host:        e.Host,
+				Port:        int32(e.Port),
+				Url:         e.Url,
+				Path:        e.Path,
+				Status:      int32(e.Status),
+				Length:      int32(e.Length),
+				Title:       e.Title,
+				ContentType: e.ContentType,
+				Location:    e.Location,
+			})
+		}
+
+		resp, err := w.rpcClient.SaveDirScanResult(ctx, &pb.SaveDirScanResultReq{
+			WorkspaceId: workspaceId,
+			MainTaskId:  mainTaskId,
+			TaskId:      taskId,
+			Results:     docs,
+		})
+		if err != nil {
+			w.taskLog(mainTaskId, LevelError, "save dirscan result failed: %v", err)
+			continue
+		}
+		if !resp.Success {
+			w.taskLog(mainTaskId, LevelError, "save dirscan result failed: %s", resp.Message)
+			continue
+		}
+		total += resp.Total
+		newCount += resp.NewCount
+	}
+	w.taskLog(mainTaskId, LevelInfo, "DirScan: saved %d paths, %d new", total, newCount)
+}
+
+// loadSecretRules 从服务端获取已启用的敏感信息检测规则，categories不为空时只保留指定分类
+func (w *Worker) loadSecretRules(ctx context.Context, taskId string, categories []string) []secret.Rule {
+	resp, err := w.rpcClient.GetSecretRules(ctx, &pb.GetSecretRulesReq{EnabledOnly: true})
+	if err != nil {
+		w.taskLog(taskId, LevelError, "SecretScan: load rules failed: %v", err)
+		return nil
+	}
+	if !resp.Success {
+		w.taskLog(taskId, LevelError, "SecretScan: load rules failed: %s", resp.Message)
+		return nil
+	}
+
+	var rules []secret.Rule
+	for _, r := range resp.Rules {
+		if len(categories) > 0 && !slices.Contains(categories, r.Category) {
+			continue
+		}
+		rules = append(rules, secret.Rule{
+			Name:       r.Name,
+			Category:   r.Category,
+			Severity:   r.Severity,
+			Pattern:    r.Pattern,
+			MinEntropy: r.MinEntropy,
+			Validator:  r.Validator,
+		})
+	}
+	return rules
+}
+
+// saveSecretResult 分批保存敏感信息检测结果
+func (w *Worker) saveSecretResult(ctx context.Context, workspaceId, mainTaskId, taskId string, findings []*scanner.SecretFinding) {
+	const batchSize = 500
+	var total, newCount int32
+	for start := 0; start < len(findings); start += batchSize {
+		end := min(start+batchSize, len(findings))
+		docs := make([]*pb.SecretDocument, 0, end-start)
+		for _, f := range findings[start:end] {
+			docs = append(docs, &pb.SecretDocument{
+				Authority: f.Authority,
+				Host:      f.Host,
+				Port:      int32(f.Port),
+				Url:       f.Url,
+				Location:  f.Location,
+				Rule:      f.Rule,
+				Category:  f.Category,
+				Severity:  f.Severity,
+				Evidence:  f.Evidence,
+				Hash:      f.Hash,
+			})
+		}
+
+		resp, err := w.rpcClient.SaveSecretResult(ctx, &pb.SaveSecretResultReq{
+			WorkspaceId: workspaceId,
+			MainTaskId:  mainTaskId,
+			TaskId:      taskId,
+			Findings:    docs,
+		})
+		if err != nil {
+			w.taskLog(mainTaskId, LevelError, "save secret result failed: %v", err)
+			continue
+		}
+		if !resp.Success {
+			w.taskLog(mainTaskId, LevelError, "save secret result failed: %s", resp.Message)
+			continue
+		}
+		total += resp.Total
+		newCount += resp.NewCount
+	}
+	w.taskLog(mainTaskId, LevelInfo, "SecretScan: saved %d findings, %d new", total, newCount)
+}
+
+// saveDnsRecordResult 分批保存域名DNS记录
+func (w *Worker) saveDnsRecordResult(ctx context.Context, workspaceId, mainTaskId, taskId string, results []*dnsrecord.Result) {
+	const batchSize = 500
+	var total, newCount int32
+	for start := 0; start < len(results); start += batchSize {
+		end := min(start+batchSize, len(results))
+		docs := make([]*pb.DnsRecordDocument, 0, end-start)
+		for _, r := range results[start:end] {
+			doc := &pb.DnsRecordDocument{
+				Domain:       r.Domain,
+				Zone:         r.Zone,
+				Spf:          r.SPF,
+				Dmarc:        r.DMARC,
+				ZoneTransfer: r.ZoneTransfer,
+			}
+			for _, rec := range r.Records {
+				doc.Records = append(doc.Records, &pb.DnsRecordItem{Type: rec.Type, Name: rec.Name, Value: rec.Value, Ttl: rec.TTL})
+			}
+			for _, issue := range r.Issues {
+				doc.Issues = append(doc.Issues, &pb.DnsIssueItem{Type: issue.Type, Severity: issue.Severity, Detail: issue.Detail})
+			}
+			docs = append(docs, doc)
+		}
+
+		resp, err := w.rpcClient.SaveDnsRecordResult(ctx, &pb.SaveDnsRecordResultReq{
+			WorkspaceId: workspaceId,
+			MainTaskId:  mainTaskId,
+			TaskId:      taskId,
+			Domains:     docs,
+		})
+		if err != nil {
+			w.taskLog(mainTaskId, LevelError, "save dns record result failed: %v", err)
+			continue
+		}
+		if !resp.Success {
+			w.taskLog(mainTaskId, LevelError, "save dns record result failed: %s", resp.Message)
+			continue
+		}
+		total += resp.Total
+		newCount += resp.NewCount
+	}
+	w.taskLog(mainTaskId, LevelInfo, "DNS records: saved %d domains, %d new", total, newCount)
+}
+
+// syncSubdomains 分批同步子域名监控集合，返回新增和解析变化的子域名
+func (w *Worker) syncSubdomains(ctx context.Context, workspaceId, mainTaskId, taskId string, assets []*scanner.Asset) (map[string]bool, map[string]bool, error) {
+	const batchSize = 500
+	docs := make([]*pb.SubdomainDocument, 0, len(assets))
+	for _, asset := range assets {
+		if asset.Host == "" || asset.Category != "domain" {
+			continue
+		}
+		doc := &pb.SubdomainDocument{Domain: strings.ToLower(asset.Host), Cname: asset.CName}
+		for _, ip := range asset.IPV4 {
+			doc.Ips = append(doc.Ips, ip.IP)
+		}
+		for _, ip := range asset.IPV6 {
+			doc.Ips = append(doc.Ips, ip.IP)
+		}
+		docs = append(docs, doc)
+	}
+
+	newSet := make(map[string]bool)
+	changedSet := make(map[string]bool)
+	for start := 0; start < len(docs); start += batchSize {
+		end := min(start+batchSize, len(docs))
+		resp, err := w.rpcClient.SyncSubdomains(ctx, &pb.SyncSubdomainsReq{
+			WorkspaceId: workspaceId,
+			MainTaskId:  mainTaskId,
+			TaskId:      taskId,
+			Subdomains:  docs[start:end],
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if !resp.Success {
+			return nil, nil, fmt.Errorf("%s", resp.Message)
+		}
+		for _, domain := range resp.NewDomains {
+			newSet[domain] = true
+		}
+		for _, domain := range resp.ChangedDomains {
+			changedSet[domain] = true
+		}
+	}
+	return newSet, changedSet, nil
+}
+
+// confirmSubdomains 分批确认扫描完成的子域名，新增子域名由服务端记录到主任务，返回确认的新增数量
+func (w *Worker) confirmSubdomains(ctx context.Context, workspaceId, mainTaskId, taskId string, domains []string) (int, error) {
+	const batchSize = 500
+	confirmed := 0
+	for start := 0; start < len(domains); start += batchSize {
+		end := min(start+batchSize, len(domains))
+		docs := make([]*pb.SubdomainDocument, 0, end-start)
+		for _, domain := range domains[start:end] {
+			docs = append(docs, &pb.SubdomainDocument{Domain: domain})
+		}
+		resp, err := w.rpcClient.SyncSubdomains(ctx, &pb.SyncSubdomainsReq{
+			WorkspaceId: workspaceId,
+			MainTaskId:  mainTaskId,
+			TaskId:      taskId,
+			Subdomains:  docs,
+			Confirm:     true,
+		})
+		if err != nil {
+			return confirmed, err
+		}
+		if !resp.Success {
+			return confirmed, fmt.Errorf("%s", resp.Message)
+		}
+		confirmed += len(resp.NewDomains)
+	}
+	return confirmed, nil
+}
+
+// maxCrawlPocTargets 爬虫结果作为POC额外目标的数量上限
+const maxCrawlPocTargets = 500
+
+// selectCrawlTargets 从爬虫结果中选择适合POC扫描的URL：带参数的GET请求和从JS/XHR中发现的接口
+func selectCrawlTargets(urls []*scanner.CrawledUrl, limit int) []string {
+	targets := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, u := range urls {
+		if len(targets) >= limit {
+			break
+		}
+		if u.Method != "GET" || seen[u.Url] || strings.HasSuffix(strings.ToLower(u.Path), ".js") {
+			continue
+		}
+		if strings.Contains(u.Url, "?") || u.Source == "js" || u.Source == "xhr" {
+			seen[u.Url] = true
+			targets = append(targets, u.Url)
+		}
+	}
+	return targets
+}
+
 // reportResult 上报结果
 func (w *Worker) reportResult() {
 	defer w.wg.Done()
@@ -1431,8 +2081,8 @@ func (w *Worker) reportResult() {
 // handleResult 处理结果
 func (w *Worker) handleResult(result *scanner.ScanResult) {
 	ctx := context.Background()
-	w.saveAssetResult(ctx, result.WorkspaceId, result.MainTaskId, "", result.Assets)
-	w.saveVulResult(ctx, result.WorkspaceId, result.MainTaskId, result.Vulnerabilities)
+	w.saveAssetResult(ctx, result.WorkspaceId, result.MainTaskId, "", "", result.Assets)
+	w.saveVulResult(ctx, result.WorkspaceId, result.MainTaskId, "", result.Vulnerabilities)
 }
 
 // keepAlive 心跳
@@ -1562,6 +2212,9 @@ func (w *Worker) sendHeartbeat() {
 		TaskExecutedNumber: int32(w.taskExecuted),
 		IsDaemon:           false,
 		Ip:                 w.config.IP,
+		TaskIds:            w.heldTaskIds(),
+		Labels:             w.config.Labels,
+		Capabilities:       w.capabilities,
 	})
 
 	// 调试：打印心跳发送的 IP
@@ -1573,6 +2226,11 @@ func (w *Worker) sendHeartbeat() {
 		return
 	}
 
+	// 租约已被回收的任务已重新分配给其他Worker，停止执行
+	if len(resp.LostTaskIds) > 0 {
+		w.abandonTasks(resp.LostTaskIds)
+	}
+
 	// 处理控制指令
 	if resp.ManualStopFlag {
 		w.logger.Info("received stop signal, stopping worker...")
@@ -1585,103 +2243,6 @@ func (w *Worker) sendHeartbeat() {
 	}
 }
 
-// subscribeStatusQuery 订阅状态查询
-func (w *Worker) subscribeStatusQuery() {
-	defer w.wg.Done()
-
-	ctx := context.Background()
-	pubsub := w.redisClient.Subscribe(ctx, "cscan:worker:query")
-	defer pubsub.Close()
-
-	ch := pubsub.Channel()
-	w.logger.Info("Worker %s subscribed to status query channel", w.config.Name)
-
-	for {
-		select {
-		case <-w.stopChan:
-			return
-		case msg := <-ch:
-			if msg != nil {
-				// 收到查询请求，立即上报
-				w.reportStatusToRedis()
-			}
-		}
-	}
-}
-
-// reportStatusToRedis 立即上报状态到Redis
-func (w *Worker) reportStatusToRedis() {
-	if w.redisClient == nil {
-		return
-	}
-
-	ctx := context.Background()
-
-	// 快速获取CPU使用率
-	cpuPercent, _ := cpu.Percent(0, false)
-	memInfo, _ := mem.VirtualMemory()
-
-	cpuLoad := 0.0
-	if len(cpuPercent) > 0 {
-		cpuLoad = cpuPercent[0]
-	}
-	memUsed := 0.0
-	if memInfo != nil {
-		memUsed = memInfo.UsedPercent
-	}
-
-	// 确保数值
-	if cpuLoad < 0 || cpuLoad > 100 {
-		cpuLoad = 0.0
-	}
-	if memUsed < 0 || memUsed > 100 {
-		memUsed = 0.0
-	}
-
-	w.mu.Lock()
-	taskStarted := w.taskStarted
-	taskExecuted := w.taskExecuted
-	isThrottled := w.isThrottled
-	cpuOverloadCount := w.cpuOverloadCount
-	w.mu.Unlock()
-
-	// 计算健康状态
-	healthStatus := "healthy"
-	if isThrottled {
-		healthStatus = "throttled"
-	} else if cpuLoad >= CPULoadThreshold {
-		healthStatus = "overloaded"
-	} else if cpuLoad >= CPULoadRecovery {
-		healthStatus = "warning"
-	}
-
-	// 保存状态到Redis
-	key := fmt.Sprintf("worker:%s", w.config.Name)
-	status := map[string]interface{}{
-		"workerName":         w.config.Name,
-		"ip":                 w.config.IP,
-		"cpuLoad":            cpuLoad,
-		"memUsed":            memUsed,
-		"taskStartedNumber":  taskStarted,
-		"taskExecutedNumber": taskExecuted,
-		"isDaemon":           false,
-		"healthStatus":       healthStatus,
-		"isThrottled":        isThrottled,
-		"cpuOverloadCount":   cpuOverloadCount,
-		"concurrency":        w.config.Concurrency,
-		"runningTasks":       len(w.taskChan),
-		"updateTime":         time.Now().Local().Format("2006-01-02 15:04:05"),
-		// 工具安装状态
-		"tools": map[string]bool{
-			"nmap":    scanner.CheckNmapInstalled(),
-			"masscan": scanner.CheckMasscanInstalled(),
-		},
-	}
-
-	data, _ := json.Marshal(status)
-	w.redisClient.Set(ctx, key, data, 2*time.Minute) // 2分钟过期，离线Worker会更快从列表消失
-}
-
 // GetWorkerName 获取Worker名称
 func GetWorkerName() string {
 	hostname, _ := os.Hostname()
@@ -1700,38 +2261,6 @@ func randomSuffix(length int) string {
 	return string(b)
 }
 
-// ensureUniqueWorkerName 确保 worker 名称唯一，如果存在同名则自动生成新名称
-func ensureUniqueWorkerName(ctx context.Context, redisClient *redis.Client, name string) string {
-	if redisClient == nil {
-		return name
-	}
-
-	// 检查是否存在同名 worker（通过心跳 key 判断）
-	key := "cscan:worker:heartbeat:" + name
-	exists, err := redisClient.Exists(ctx, key).Result()
-	if err != nil || exists == 0 {
-		// 不存在同名 worker，直接使用
-		return name
-	}
-
-	// 存在同名 worker，生成新名称
-	baseName := name
-	for i := 1; i <= 100; i++ {
-		newName := fmt.Sprintf("%s-%s", baseName, randomSuffix(4))
-		key = "cscan:worker:heartbeat:" + newName
-		exists, err = redisClient.Exists(ctx, key).Result()
-		if err != nil || exists == 0 {
-			fmt.Printf("[Worker] Name conflict detected, renamed from '%s' to '%s'\n", baseName, newName)
-			return newName
-		}
-	}
-
-	// 极端情况：100次都冲突，使用时间戳
-	newName := fmt.Sprintf("%s-%d", baseName, time.Now().UnixNano())
-	fmt.Printf("[Worker] Name conflict detected, renamed from '%s' to '%s'\n", baseName, newName)
-	return newName
-}
-
 // GetLocalIP 获取本机IP地址
 func GetLocalIP() string {
 	// 1. 优先使用环境变量 WORKER_IP（适用于 Docker 等容器环境）
@@ -1930,6 +2459,28 @@ func (w *Worker) loadCustomFingerprints(ctx context.Context, fpScanner *scanner.
 	w.logger.Info("Loaded %d fingerprints (builtin + custom) into fingerprint scanner", len(fingerprints))
 }
 
+// filterCdnTargets 从目标中移除位于已知CDN/WAF网段的IP，返回过滤后的目标和移除数量
+func filterCdnTargets(target string) (string, int) {
+	detector := cdn.Default()
+	lines := strings.Split(target, "\n")
+	kept := make([]string, 0, len(lines))
+	skipped := 0
+	for _, line := range lines {
+		host := strings.TrimSpace(line)
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if net.ParseIP(host) != nil {
+			if p := detector.MatchIP(host); p != nil && p.Type != cdn.TypeCloud {
+				skipped++
+				continue
+			}
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n"), skipped
+}
+
 // filterByPortThreshold 根据端口阈值过滤资产
 // 如果某个主机开放的端口数量超过阈值，则过滤掉该主机的所有资产（可能是防火墙或蜜罐）
 // 返回值: 过滤后的资产列表, 是否有主机超过阈值
@@ -2144,7 +2695,7 @@ func (w *Worker) executePocValidateTask(ctx context.Context, task *scheduler.Tas
 			w.taskLog(task.TaskId, LevelInfo, "[%s] Vulnerability found! Matched URL: %s", task.TaskId, vul.Url)
 		}
 		// 保存漏洞到数据库
-		w.saveVulResult(ctx, workspaceId, task.TaskId, result.Vulnerabilities)
+		w.saveVulResult(ctx, workspaceId, task.TaskId, task.TaskId, result.Vulnerabilities)
 	} else {
 		// 没有发现漏洞，添加一个未匹配的结果
 		resultPocName := pocName
@@ -2163,7 +2714,7 @@ func (w *Worker) executePocValidateTask(ctx context.Context, task *scheduler.Tas
 		w.taskLog(task.TaskId, LevelInfo, "[%s] No vulnerability found", task.TaskId)
 	}
 
-	// 保存结果到Redis
+	// 保存验证结果
 	w.savePocValidationResult(ctx, task.TaskId, batchId, validationResults, "")
 
 	// 更新任务状态
@@ -2282,7 +2833,7 @@ func (w *Worker) executePocBatchValidateTask(ctx context.Context, task *schedule
 
 	// 保存漏洞到数据库
 	if vulCount > 0 {
-		w.saveVulResult(ctx, workspaceId, task.TaskId, vuls)
+		w.saveVulResult(ctx, workspaceId, task.TaskId, task.TaskId, vuls)
 		w.taskLog(task.TaskId, LevelInfo, "[%s] Saved %d vulnerabilities to database", task.TaskId, vulCount)
 	}
 
@@ -2304,7 +2855,7 @@ func (w *Worker) executePocBatchValidateTask(ctx context.Context, task *schedule
 		}
 	}
 
-	// 保存结果到Redis
+	// 保存验证结果
 	w.savePocValidationResult(ctx, task.TaskId, "", validationResults, "")
 
 	// 更新任务状态
@@ -2330,56 +2881,36 @@ type PocValidationResult struct {
 	Tags       []string `json:"tags"`
 }
 
-// savePocValidationResult 保存POC验证结果到Redis
+// savePocValidationResult 通过任务服务保存POC验证结果
 func (w *Worker) savePocValidationResult(ctx context.Context, taskId, batchId string, results []*PocValidationResult, errorMsg string) {
-	if w.redisClient == nil {
-		w.logger.Error("Redis client not available, cannot save POC validation result")
-		return
-	}
-
-	// 构建结果数据
-	resultData := map[string]interface{}{
-		"taskId":     taskId,
-		"batchId":    batchId,
-		"status":     "SUCCESS",
-		"results":    results,
-		"updateTime": time.Now().Local().Format("2006-01-02 15:04:05"),
-	}
-
-	if errorMsg != "" {
-		resultData["status"] = "FAILURE"
-		resultData["error"] = errorMsg
-	}
-
-	resultJson, err := json.Marshal(resultData)
-	if err != nil {
-		w.taskLog(taskId, LevelError, "Failed to marshal POC validation result: %v", err)
-		return
+	pbResults := make([]*pb.PocValidationResult, 0, len(results))
+	for _, r := range results {
+		pbResults = append(pbResults, &pb.PocValidationResult{
+			PocId:      r.PocId,
+			PocName:    r.PocName,
+			TemplateId: r.TemplateId,
+			Severity:   r.Severity,
+			Matched:    r.Matched,
+			MatchedUrl: r.MatchedUrl,
+			Details:    r.Details,
+			Output:     r.Output,
+			PocType:    r.PocType,
+			Tags:       r.Tags,
+		})
 	}
 
-	// 保存到Redis
-	resultKey := fmt.Sprintf("cscan:task:result:%s", taskId)
-	err = w.redisClient.Set(ctx, resultKey, resultJson, 24*time.Hour).Err()
+	resp, err := w.rpcClient.SavePocValidationResult(ctx, &pb.SavePocValidationResultReq{
+		TaskId:  taskId,
+		BatchId: batchId,
+		Results: pbResults,
+		Error:   errorMsg,
+	})
 	if err != nil {
-		w.taskLog(taskId, LevelError, "Failed to save POC validation result to Redis: %v", err)
+		w.taskLog(taskId, LevelError, "Failed to save POC validation result: %v", err)
 		return
 	}
-
-	// 更新任务信息状态
-	taskInfoKey := fmt.Sprintf("cscan:task:info:%s", taskId)
-	taskInfoData, err := w.redisClient.Get(ctx, taskInfoKey).Result()
-	if err == nil && taskInfoData != "" {
-		var taskInfo map[string]string
-		if json.Unmarshal([]byte(taskInfoData), &taskInfo) == nil {
-			if errorMsg != "" {
-				taskInfo["status"] = "FAILURE"
-			} else {
-				taskInfo["status"] = "SUCCESS"
-			}
-			taskInfo["updateTime"] = time.Now().Local().Format("2006-01-02 15:04:05")
-			updatedInfo, _ := json.Marshal(taskInfo)
-			w.redisClient.Set(ctx, taskInfoKey, updatedInfo, 24*time.Hour)
-		}
+	if !resp.Success {
+		w.taskLog(taskId, LevelError, "Failed to save POC validation result: %s", resp.Message)
 	}
 }
 
@@ -2712,101 +3243,3 @@ func (w *Worker) loadHttpServiceMappings() {
 	scanner.SetHttpServiceChecker(checker)
 	w.logger.Info("Loaded %d HTTP service mappings from database", len(resp.Mappings))
 }
-
-// subscribeControlCommand 订阅控制命令
-func (w *Worker) subscribeControlCommand() {
-	defer w.wg.Done()
-
-	ctx := context.Background()
-	pubsub := w.redisClient.Subscribe(ctx, "cscan:worker:control")
-	defer pubsub.Close()
-
-	ch := pubsub.Channel()
-	w.logger.Info("Worker %s subscribed to control command channel", w.config.Name)
-
-	for {
-		select {
-		case <-w.stopChan:
-			return
-		case msg := <-ch:
-			if msg != nil {
-				w.handleControlCommand(msg.Payload)
-			}
-		}
-	}
-}
-
-// handleControlCommand 处理控制命令
-func (w *Worker) handleControlCommand(payload string) {
-	var cmd struct {
-		Action      string `json:"action"`
-		WorkerName  string `json:"workerName"`
-		NewName     string `json:"newName"`
-		Concurrency int    `json:"concurrency"`
-	}
-
-	if err := json.Unmarshal([]byte(payload), &cmd); err != nil {
-		w.logger.Error("Failed to parse control command: %v", err)
-		return
-	}
-
-	// 检查是否是发给当前Worker的命令
-	if cmd.WorkerName != "" && cmd.WorkerName != w.config.Name {
-		return
-	}
-
-	switch cmd.Action {
-	case "stop":
-		w.logger.Info("Received stop command, shutting down worker %s", w.config.Name)
-		// 触发停止
-		go func() {
-			w.Stop()
-			// 退出进程
-			os.Exit(0)
-		}()
-	case "restart":
-		w.logger.Info("Received restart command, restarting worker %s", w.config.Name)
-		go func() {
-			// 快速停止当前 Worker（跳过当前任务，不等待完成）
-			w.logger.Info("Stopping current worker immediately (skipping current tasks)...")
-			w.StopImmediate()
-			w.logger.Info("Worker stopped, restarting in same process...")
-
-			// 在同一进程内重新初始化 Worker
-			time.Sleep(1 * time.Second) // 短暂等待确保资源释放
-
-			// 创建新的 Worker 实例
-			newWorker, err := NewWorker(w.config)
-			if err != nil {
-				w.logger.Error("Failed to create new worker: %v", err)
-				os.Exit(1)
-			}
-
-			// 启动新 Worker
-			newWorker.Start()
-			w.logger.Info("Worker restarted successfully in same process")
-
-			// 阻塞等待信号（保持进程运行）
-			select {}
-		}()
-	case "rename":
-		if cmd.NewName != "" {
-			w.logger.Info("Received rename command, renaming worker from %s to %s", w.config.Name, cmd.NewName)
-			w.config.Name = cmd.NewName
-			// 立即上报新状态
-			w.reportStatusToRedis()
-		}
-	case "setConcurrency":
-		if cmd.Concurrency >= 1 && cmd.Concurrency <= 100 {
-			oldConcurrency := w.config.Concurrency
-			w.config.Concurrency = cmd.Concurrency
-			w.logger.Info("Received setConcurrency command, changed concurrency from %d to %d (note: restart required to add more workers)", oldConcurrency, cmd.Concurrency)
-			// 立即上报新状态
-			w.reportStatusToRedis()
-		} else {
-			w.logger.Warn("Invalid concurrency value: %d, must be between 1 and 100", cmd.Concurrency)
-		}
-	default:
-		w.logger.Warn("Unknown control command: %s", cmd.Action)
-	}
-}